@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/formancehq/go-libs/auth"
 	"github.com/formancehq/go-libs/otlp/otlpmetrics"
 	"github.com/formancehq/go-libs/otlp/otlptraces"
 	"github.com/formancehq/go-libs/publish"
 	"github.com/formancehq/go-libs/service"
+	"github.com/formancehq/ledger/internal/bus"
 	"github.com/formancehq/ledger/internal/engine"
 	driver "github.com/formancehq/ledger/internal/storage/driver"
 	"github.com/spf13/cobra"
@@ -14,15 +18,56 @@ import (
 
 const ServiceName = "ledger"
 
+// cloudEventsFromFlags returns the mode and the protocol binding of the CloudEvents envelope of the published events,
+// the mode being empty if the events are published without envelope.
+func cloudEventsFromFlags(cmd *cobra.Command) (string, string, error) {
+	mode, _ := cmd.Flags().GetString(publisherCloudEventsModeFlag)
+	if mode == "" {
+		return "", "", nil
+	}
+	if !slices.Contains(bus.CloudEventsModes, mode) {
+		return "", "", fmt.Errorf("unknown CloudEvents mode '%s', expected one of %v", mode, bus.CloudEventsModes)
+	}
+
+	httpEnabled, _ := cmd.Flags().GetBool(publish.PublisherHttpEnabledFlag)
+	kafkaEnabled, _ := cmd.Flags().GetBool(publish.PublisherKafkaEnabledFlag)
+	switch {
+	case httpEnabled:
+		return mode, bus.CloudEventsBindingHTTP, nil
+	case kafkaEnabled:
+		return mode, bus.CloudEventsBindingKafka, nil
+	default:
+		return "", "", fmt.Errorf("CloudEvents are only supported by the http and kafka publishers")
+	}
+}
+
+// cloudEventsModule sends the content type and the attributes of the CloudEvents as headers with the http publisher.
+func cloudEventsModule(binding string) fx.Option {
+	if binding != bus.CloudEventsBindingHTTP {
+		return fx.Options()
+	}
+	return fx.Decorate(bus.NewCloudEventsHTTPMarshalMessageFunc)
+}
+
 func resolveOptions(cmd *cobra.Command, userOptions ...fx.Option) []fx.Option {
 	options := make([]fx.Option, 0)
 	options = append(options, fx.NopLogger)
 
 	numscriptCacheMaxCountFlag, _ := cmd.Flags().GetInt(NumscriptCacheMaxCountFlag)
 	ledgerBatchSizeFlag, _ := cmd.Flags().GetInt(ledgerBatchSizeFlag)
+	schedulerIntervalFlag, _ := cmd.Flags().GetDuration(schedulerIntervalFlag)
+	outboxRelayIntervalFlag, _ := cmd.Flags().GetDuration(outboxRelayIntervalFlag)
+	lockStrategyFlag, _ := cmd.Flags().GetString(lockStrategyFlag)
+	writerLockTimeoutFlag, _ := cmd.Flags().GetDuration(writerLockTimeoutFlag)
+	writerTenureFlag, _ := cmd.Flags().GetDuration(writerTenureFlag)
+	idempotencyKeysRetentionFlag, _ := cmd.Flags().GetDuration(idempotencyKeysRetentionFlag)
+	idempotencyKeysPurgeIntervalFlag, _ := cmd.Flags().GetDuration(idempotencyKeysPurgeIntervalFlag)
+	eventsVersionFlag, _ := cmd.Flags().GetString(eventsVersionFlag)
+	cloudEventsMode, cloudEventsBinding, _ := cloudEventsFromFlags(cmd)
 
 	options = append(options,
 		publish.FXModuleFromFlags(cmd, service.IsDebug(cmd)),
+		cloudEventsModule(cloudEventsBinding),
 		otlptraces.FXModuleFromFlags(cmd),
 		otlpmetrics.FXModuleFromFlags(cmd),
 		auth.FXModuleFromFlags(cmd),
@@ -31,7 +76,17 @@ func resolveOptions(cmd *cobra.Command, userOptions ...fx.Option) []fx.Option {
 			NumscriptCache: engine.NumscriptCacheConfiguration{
 				MaxCount: numscriptCacheMaxCountFlag,
 			},
-			LedgerBatchSize: ledgerBatchSizeFlag,
+			LedgerBatchSize:              ledgerBatchSizeFlag,
+			SchedulerInterval:            schedulerIntervalFlag,
+			OutboxRelayInterval:          outboxRelayIntervalFlag,
+			EventsVersion:                eventsVersionFlag,
+			CloudEventsMode:              cloudEventsMode,
+			CloudEventsBinding:           cloudEventsBinding,
+			LockStrategy:                 lockStrategyFlag,
+			WriterLockTimeout:            writerLockTimeoutFlag,
+			WriterTenure:                 writerTenureFlag,
+			IdempotencyKeysRetention:     idempotencyKeysRetentionFlag,
+			IdempotencyKeysPurgeInterval: idempotencyKeysPurgeIntervalFlag,
 		}),
 	)
 
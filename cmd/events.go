@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/publish"
+	"github.com/formancehq/go-libs/service"
+	"github.com/formancehq/ledger/internal/bus"
+	"github.com/formancehq/ledger/internal/engine"
+	storage "github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+const (
+	eventsFromLogFlag = "from-log"
+	eventsToLogFlag   = "to-log"
+	eventsTopicFlag   = "topic"
+)
+
+func NewEvents() *cobra.Command {
+	return &cobra.Command{
+		Use:   "events",
+		Short: "Manage the events published by the ledgers",
+	}
+}
+
+func NewEventsReplay() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <ledger>",
+		Short: "Publish again the events of the logs of a ledger",
+		Long: "Publish again the events of the logs of a ledger, in the order of the logs, with the configured publisher.\n" +
+			"The replayed messages have the same payloads as the live ones, in the version given by --events-version, and are marked with a 'ledger-replay' metadata.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := replayQueryFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			cloudEventsMode, cloudEventsBinding, err := cloudEventsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			var (
+				driver    *storage.Driver
+				publisher message.Publisher
+			)
+			app := fx.New(
+				fx.NopLogger,
+				fx.Supply(fx.Annotate(logging.FromContext(cmd.Context()), fx.As(new(logging.Logger)))),
+				storage.FXModuleFromFlags(cmd),
+				publish.FXModuleFromFlags(cmd, service.IsDebug(cmd)),
+				cloudEventsModule(cloudEventsBinding),
+				fx.Populate(&driver, &publisher),
+			)
+			if err := app.Start(cmd.Context()); err != nil {
+				return err
+			}
+			defer func() {
+				_ = app.Stop(context.Background())
+			}()
+
+			if cloudEventsMode != "" {
+				publisher = bus.NewCloudEventsPublisher(publisher, cloudEventsMode, cloudEventsBinding)
+			}
+
+			name := args[0]
+
+			ledgerConfiguration, err := driver.GetSystemStore().GetLedger(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+
+			store, err := driver.GetLedgerStore(cmd.Context(), name, storage.LedgerState{
+				LedgerConfiguration: storage.LedgerConfiguration{
+					Bucket:   ledgerConfiguration.Bucket,
+					Metadata: ledgerConfiguration.Metadata,
+				},
+				State: ledgerConfiguration.State,
+			})
+			if err != nil {
+				return err
+			}
+
+			report, err := engine.ReplayEvents(cmd.Context(), store, publisher, q, func(_ context.Context, report engine.ReplayReport) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%d logs replayed (last log id: %s)\n", report.ReplayedLogs, report.LastLogID)
+			})
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Events of %d logs replayed\n", report.ReplayedLogs)
+
+			return nil
+		},
+	}
+	cmd.Flags().String(eventsFromLogFlag, "", "Id of the first log to replay, the replay starts from the first log of the ledger if empty")
+	cmd.Flags().String(eventsToLogFlag, "", "Id of the last log to replay, the replay ends with the last log of the ledger if empty")
+	cmd.Flags().String(eventsTopicFlag, "", "Topic to publish the events to, instead of the topics of their types")
+	return cmd
+}
+
+func replayQueryFromFlags(cmd *cobra.Command) (engine.ReplayQuery, error) {
+	q := engine.ReplayQuery{}
+	q.Topic, _ = cmd.Flags().GetString(eventsTopicFlag)
+	q.Version, _ = cmd.Flags().GetString(eventsVersionFlag)
+
+	for flag, id := range map[string]**big.Int{
+		eventsFromLogFlag: &q.FromLog,
+		eventsToLogFlag:   &q.ToLog,
+	} {
+		value, _ := cmd.Flags().GetString(flag)
+		if value == "" {
+			continue
+		}
+		parsed, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return q, errors.Errorf("invalid --%s '%s'", flag, value)
+		}
+		*id = parsed
+	}
+
+	return q, q.Validate()
+}
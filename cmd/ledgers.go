@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/formancehq/go-libs/bun/bunconnect"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/spf13/cobra"
+)
+
+const ledgerPurgeFlag = "purge"
+
+func NewLedgers() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ledgers",
+		Short: "Manage the lifecycle of ledgers",
+	}
+}
+
+func NewLedgerDelete() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <ledger>",
+		Short: "Archive a ledger, or purge its rows from its bucket",
+		Long: "Archive a ledger, or purge its rows from its bucket.\n" +
+			"Running servers which have already loaded the ledger keep serving it until they are restarted.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectionOptions, err := bunconnect.ConnectionOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			driver := driver.New(*connectionOptions)
+			if err := driver.Initialize(cmd.Context()); err != nil {
+				return err
+			}
+			defer func() {
+				_ = driver.Close()
+			}()
+
+			purge, _ := cmd.Flags().GetBool(ledgerPurgeFlag)
+			if err := driver.DeleteLedger(cmd.Context(), args[0], purge); err != nil {
+				return err
+			}
+
+			if purge {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Ledger %s purged\n", args[0])
+			} else {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Ledger %s archived\n", args[0])
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().Bool(ledgerPurgeFlag, false, "Delete the rows of the ledger from its bucket, instead of archiving it")
+	return cmd
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/formancehq/go-libs/bun/bunmigrate"
 	"github.com/formancehq/go-libs/service"
 	"github.com/uptrace/bun"
@@ -12,12 +14,16 @@ import (
 	"github.com/formancehq/go-libs/otlp/otlpmetrics"
 	"github.com/formancehq/go-libs/otlp/otlptraces"
 	"github.com/formancehq/go-libs/publish"
+	"github.com/formancehq/ledger/internal/bus"
 	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/formancehq/ledger/pkg/events"
 	"github.com/spf13/cobra"
 )
 
 const (
-	BindFlag = "bind"
+	BindFlag                     = "bind"
+	eventsVersionFlag            = "events-version"
+	publisherCloudEventsModeFlag = "publisher-cloudevents-mode"
 )
 
 var (
@@ -40,9 +46,25 @@ func NewRootCommand() *cobra.Command {
 	buckets := NewBucket()
 	buckets.AddCommand(NewBucketUpgrade())
 
+	verify := NewVerify()
+
+	snapshots := NewSnapshots()
+	snapshots.AddCommand(NewSnapshotCreate())
+	snapshots.AddCommand(NewSnapshotRestore())
+
+	ledgers := NewLedgers()
+	ledgers.AddCommand(NewLedgerDelete())
+
+	eventsCommand := NewEvents()
+	eventsCommand.AddCommand(NewEventsReplay())
+
 	root.AddCommand(serve)
 	root.AddCommand(buckets)
 	root.AddCommand(version)
+	root.AddCommand(verify)
+	root.AddCommand(snapshots)
+	root.AddCommand(ledgers)
+	root.AddCommand(eventsCommand)
 	root.AddCommand(bunmigrate.NewDefaultCommand(func(cmd *cobra.Command, args []string, db *bun.DB) error {
 		return upgradeAll(cmd, args)
 	}))
@@ -58,6 +80,8 @@ func NewRootCommand() *cobra.Command {
 	publish.AddFlags(ServiceName, root.PersistentFlags(), func(cd *publish.ConfigDefault) {
 		cd.PublisherCircuitBreakerSchema = systemstore.Schema
 	})
+	root.PersistentFlags().String(eventsVersionFlag, events.EventVersion, fmt.Sprintf("Version of the published events, one of %v", events.EventVersions))
+	root.PersistentFlags().String(publisherCloudEventsModeFlag, "", fmt.Sprintf("Publish the events in a CloudEvents envelope, in mode %v, with the http or kafka publisher", bus.CloudEventsModes))
 	bunconnect.AddFlags(root.PersistentFlags())
 	iam.AddFlags(root.PersistentFlags())
 
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/go-chi/chi/v5"
 
@@ -10,6 +12,9 @@ import (
 	"github.com/formancehq/ledger/internal/storage/driver"
 
 	"github.com/formancehq/ledger/internal/api"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/webhooks"
+	"github.com/formancehq/ledger/pkg/events"
 
 	"github.com/formancehq/go-libs/ballast"
 	"github.com/formancehq/go-libs/httpserver"
@@ -20,11 +25,23 @@ import (
 )
 
 const (
-	BallastSizeInBytesFlag     = "ballast-size"
-	NumscriptCacheMaxCountFlag = "numscript-cache-max-count"
-	ledgerBatchSizeFlag        = "ledger-batch-size"
-	ReadOnlyFlag               = "read-only"
-	AutoUpgradeFlag            = "auto-upgrade"
+	BallastSizeInBytesFlag           = "ballast-size"
+	NumscriptCacheMaxCountFlag       = "numscript-cache-max-count"
+	ledgerBatchSizeFlag              = "ledger-batch-size"
+	schedulerIntervalFlag            = "scheduler-interval"
+	outboxRelayIntervalFlag          = "outbox-relay-interval"
+	lockStrategyFlag                 = "lock-strategy"
+	writerLockTimeoutFlag            = "writer-lock-timeout"
+	writerTenureFlag                 = "writer-tenure"
+	idempotencyKeysRetentionFlag     = "idempotency-keys-retention"
+	idempotencyKeysPurgeIntervalFlag = "idempotency-keys-purge-interval"
+	ReadOnlyFlag                     = "read-only"
+	AutoUpgradeFlag                  = "auto-upgrade"
+	webhooksPollIntervalFlag         = "webhooks-poll-interval"
+	webhooksMaxAttemptsFlag          = "webhooks-max-attempts"
+	webhooksMinBackoffFlag           = "webhooks-min-backoff"
+	webhooksMaxBackoffFlag           = "webhooks-max-backoff"
+	webhooksTimeoutFlag              = "webhooks-timeout"
 )
 
 func NewServe() *cobra.Command {
@@ -35,6 +52,25 @@ func NewServe() *cobra.Command {
 			autoUpgrade, _ := cmd.Flags().GetBool(AutoUpgradeFlag)
 			ballastSize, _ := cmd.Flags().GetUint(BallastSizeInBytesFlag)
 			bind, _ := cmd.Flags().GetString(BindFlag)
+			webhooksPollInterval, _ := cmd.Flags().GetDuration(webhooksPollIntervalFlag)
+			webhooksMaxAttempts, _ := cmd.Flags().GetInt(webhooksMaxAttemptsFlag)
+			webhooksMinBackoff, _ := cmd.Flags().GetDuration(webhooksMinBackoffFlag)
+			webhooksMaxBackoff, _ := cmd.Flags().GetDuration(webhooksMaxBackoffFlag)
+			webhooksTimeout, _ := cmd.Flags().GetDuration(webhooksTimeoutFlag)
+
+			lockStrategy, _ := cmd.Flags().GetString(lockStrategyFlag)
+			if lockStrategy != engine.LockStrategyMemory && lockStrategy != engine.LockStrategyPostgres {
+				return fmt.Errorf("unknown lock strategy '%s', expected '%s' or '%s'",
+					lockStrategy, engine.LockStrategyMemory, engine.LockStrategyPostgres)
+			}
+
+			eventsVersion, _ := cmd.Flags().GetString(eventsVersionFlag)
+			if !slices.Contains(events.EventVersions, eventsVersion) {
+				return fmt.Errorf("unknown events version '%s', expected one of %v", eventsVersion, events.EventVersions)
+			}
+			if _, _, err := cloudEventsFromFlags(cmd); err != nil {
+				return err
+			}
 
 			return service.New(cmd.OutOrStdout(), resolveOptions(
 				cmd,
@@ -44,6 +80,13 @@ func NewServe() *cobra.Command {
 					ReadOnly: readOnly,
 					Debug:    service.IsDebug(cmd),
 				}),
+				webhooks.Module(webhooks.Configuration{
+					PollInterval: webhooksPollInterval,
+					MaxAttempts:  webhooksMaxAttempts,
+					MinBackoff:   webhooksMinBackoff,
+					MaxBackoff:   webhooksMaxBackoff,
+					Timeout:      webhooksTimeout,
+				}),
 				fx.Invoke(func(lc fx.Lifecycle, driver *driver.Driver) {
 					if autoUpgrade {
 						lc.Append(fx.Hook{
@@ -71,8 +114,20 @@ func NewServe() *cobra.Command {
 	cmd.Flags().Uint(BallastSizeInBytesFlag, 0, "Ballast size in bytes, default to 0")
 	cmd.Flags().Int(NumscriptCacheMaxCountFlag, 1024, "Numscript cache max count")
 	cmd.Flags().Int(ledgerBatchSizeFlag, 50, "ledger batch size")
+	cmd.Flags().Duration(schedulerIntervalFlag, time.Second, "Interval between checks for due scheduled transactions")
+	cmd.Flags().Duration(outboxRelayIntervalFlag, time.Second, "Interval between retries of the events whose publication failed")
+	cmd.Flags().String(lockStrategyFlag, engine.LockStrategyMemory, fmt.Sprintf("Lock strategy, use '%s' to run several instances writing on the same ledgers", engine.LockStrategyPostgres))
+	cmd.Flags().Duration(writerLockTimeoutFlag, 10*time.Second, fmt.Sprintf("Maximum wait for the writer lock of a ledger with the '%s' lock strategy, the writes fail once elapsed. It should exceed the writer tenure", engine.LockStrategyPostgres))
+	cmd.Flags().Duration(writerTenureFlag, 5*time.Second, fmt.Sprintf("Duration an instance keeps the writer lock of a ledger with the '%s' lock strategy, before handing it over", engine.LockStrategyPostgres))
+	cmd.Flags().Duration(idempotencyKeysRetentionFlag, 0, "Duration after which idempotency keys expire and can be reused, keys never expire if zero")
+	cmd.Flags().Duration(idempotencyKeysPurgeIntervalFlag, time.Hour, "Interval between purges of expired idempotency keys")
 	cmd.Flags().Bool(ReadOnlyFlag, false, "Read only mode")
 	cmd.Flags().Bool(AutoUpgradeFlag, false, "Automatically upgrade all schemas")
+	cmd.Flags().Duration(webhooksPollIntervalFlag, webhooks.DefaultConfiguration.PollInterval, "Interval between checks for webhook deliveries to retry")
+	cmd.Flags().Int(webhooksMaxAttemptsFlag, webhooks.DefaultConfiguration.MaxAttempts, "Number of attempts before a webhook delivery is moved to the dead letters")
+	cmd.Flags().Duration(webhooksMinBackoffFlag, webhooks.DefaultConfiguration.MinBackoff, "Delay before the first retry of a webhook delivery")
+	cmd.Flags().Duration(webhooksMaxBackoffFlag, webhooks.DefaultConfiguration.MaxBackoff, "Maximum delay between retries of a webhook delivery")
+	cmd.Flags().Duration(webhooksTimeoutFlag, webhooks.DefaultConfiguration.Timeout, "Timeout of webhook deliveries")
 	return cmd
 }
 
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/formancehq/go-libs/bun/bunconnect"
+	"github.com/formancehq/ledger/internal/engine"
+	storage "github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/spf13/cobra"
+)
+
+const (
+	snapshotOutputFlag = "output"
+	snapshotBucketFlag = "bucket"
+	snapshotLogIDFlag  = "log-id"
+)
+
+func NewSnapshots() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snapshots",
+		Short: "Take and restore point-in-time snapshots of ledgers",
+	}
+}
+
+func NewSnapshotCreate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "create <ledger>",
+		Short:        "Capture the accounts, metadata and volumes of a ledger at a given log, or at its last log",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectionOptions, err := bunconnect.ConnectionOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			driver := storage.New(*connectionOptions)
+			if err := driver.Initialize(cmd.Context()); err != nil {
+				return err
+			}
+			defer func() {
+				_ = driver.Close()
+			}()
+
+			name := args[0]
+
+			ledgerConfiguration, err := driver.GetSystemStore().GetLedger(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+
+			store, err := driver.GetLedgerStore(cmd.Context(), name, storage.LedgerState{
+				LedgerConfiguration: storage.LedgerConfiguration{
+					Bucket:   ledgerConfiguration.Bucket,
+					Metadata: ledgerConfiguration.Metadata,
+				},
+				State: ledgerConfiguration.State,
+			})
+			if err != nil {
+				return err
+			}
+
+			var output io.Writer = cmd.OutOrStdout()
+			if path, _ := cmd.Flags().GetString(snapshotOutputFlag); path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = f.Close()
+				}()
+				output = f
+			}
+
+			var logID *big.Int
+			if flag, _ := cmd.Flags().GetString(snapshotLogIDFlag); flag != "" {
+				id, ok := new(big.Int).SetString(flag, 10)
+				if !ok || id.Sign() < 0 {
+					return fmt.Errorf("invalid log id '%s'", flag)
+				}
+				logID = id
+			}
+
+			header, err := engine.WriteSnapshot(cmd.Context(), store, logID, output)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Snapshot of ledger %s taken at log %s (hash: %s)\n",
+				name, header.LastLogID, base64.StdEncoding.EncodeToString(header.LastLogHash))
+
+			return nil
+		},
+	}
+	cmd.Flags().StringP(snapshotOutputFlag, "o", "", "File to write the snapshot to, the standard output if not specified")
+	cmd.Flags().String(snapshotLogIDFlag, "", "Id of the log to take the snapshot at, the last log if not specified")
+	return cmd
+}
+
+func NewSnapshotRestore() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "restore <ledger> <file>",
+		Short:        "Create a new ledger from a snapshot",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectionOptions, err := bunconnect.ConnectionOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			driver := storage.New(*connectionOptions)
+			if err := driver.Initialize(cmd.Context()); err != nil {
+				return err
+			}
+			defer func() {
+				_ = driver.Close()
+			}()
+
+			f, err := os.Open(args[1])
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+
+			bucket, _ := cmd.Flags().GetString(snapshotBucketFlag)
+
+			header, err := engine.RestoreSnapshot(cmd.Context(), driver, args[0], storage.LedgerConfiguration{
+				Bucket: bucket,
+			}, f)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Ledger %s restored from ledger %s at log %s\n",
+				args[0], header.Ledger, header.LastLogID)
+
+			return nil
+		},
+	}
+	cmd.Flags().String(snapshotBucketFlag, "", "Bucket of the new ledger, the default bucket if not specified")
+	return cmd
+}
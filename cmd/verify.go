@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/formancehq/go-libs/bun/bunconnect"
+	"github.com/formancehq/ledger/internal/engine"
+	storage "github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewVerify() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "verify",
+		Short:        "Verify the hash chain of the logs of a ledger",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectionOptions, err := bunconnect.ConnectionOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			driver := storage.New(*connectionOptions)
+			if err := driver.Initialize(cmd.Context()); err != nil {
+				return err
+			}
+			defer func() {
+				_ = driver.Close()
+			}()
+
+			name := args[0]
+
+			ledgerConfiguration, err := driver.GetSystemStore().GetLedger(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+
+			store, err := driver.GetLedgerStore(cmd.Context(), name, storage.LedgerState{
+				LedgerConfiguration: storage.LedgerConfiguration{
+					Bucket:   ledgerConfiguration.Bucket,
+					Metadata: ledgerConfiguration.Metadata,
+				},
+				State: ledgerConfiguration.State,
+			})
+			if err != nil {
+				return err
+			}
+
+			report, err := engine.VerifyLogs(cmd.Context(), store, func(_ context.Context, report engine.VerifyReport) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%d logs verified (last log id: %s)\n", report.CheckedLogs, report.LastLogID)
+			})
+			if err != nil {
+				return err
+			}
+
+			if !report.Valid {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Chain broken at log %s: expected hash %s, stored hash %s\n",
+					report.BrokenLink.ID,
+					base64.StdEncoding.EncodeToString(report.BrokenLink.Expected),
+					base64.StdEncoding.EncodeToString(report.BrokenLink.Stored),
+				)
+				return errors.New("log chain is broken")
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Chain valid, %d logs verified\n", report.CheckedLogs)
+
+			return nil
+		},
+	}
+	return cmd
+}
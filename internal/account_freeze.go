@@ -0,0 +1,86 @@
+package ledger
+
+import (
+	"slices"
+
+	"github.com/formancehq/go-libs/time"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	FreezeDirectionDebit  = "DEBIT"
+	FreezeDirectionCredit = "CREDIT"
+	FreezeDirectionBoth   = "BOTH"
+
+	AccountFreezeEventTypeFreeze   = "FREEZE"
+	AccountFreezeEventTypeUnfreeze = "UNFREEZE"
+)
+
+var freezeDirections = []string{FreezeDirectionDebit, FreezeDirectionCredit, FreezeDirectionBoth}
+
+func ValidateFreezeDirection(direction string) error {
+	if !slices.Contains(freezeDirections, direction) {
+		return errors.Errorf("invalid freeze direction '%s', expected one of %v", direction, freezeDirections)
+	}
+	return nil
+}
+
+// AccountFreeze blocks the postings moving funds out of (debit) or into (credit) an account.
+type AccountFreeze struct {
+	Address   string    `json:"address"`
+	Direction string    `json:"direction"`
+	Reason    string    `json:"reason,omitempty"`
+	FrozenAt  time.Time `json:"frozenAt"`
+}
+
+func (f AccountFreeze) BlocksDebits() bool {
+	return f.Direction == FreezeDirectionDebit || f.Direction == FreezeDirectionBoth
+}
+
+func (f AccountFreeze) BlocksCredits() bool {
+	return f.Direction == FreezeDirectionCredit || f.Direction == FreezeDirectionBoth
+}
+
+// AccountFreezes indexes the freezes of a ledger by account address.
+type AccountFreezes map[string]AccountFreeze
+
+// AccountFreezeEvent records a freeze or an unfreeze of an account, to keep an audit trail of the freezes.
+type AccountFreezeEvent struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Address   string    `json:"address"`
+	Direction string    `json:"direction,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Date      time.Time `json:"date"`
+}
+
+func (e AccountFreezeEvent) Freeze() AccountFreeze {
+	return AccountFreeze{
+		Address:   e.Address,
+		Direction: e.Direction,
+		Reason:    e.Reason,
+		FrozenAt:  e.Date,
+	}
+}
+
+func NewAccountFreezeEvent(address, direction, reason string) AccountFreezeEvent {
+	return AccountFreezeEvent{
+		ID:        uuid.NewString(),
+		Type:      AccountFreezeEventTypeFreeze,
+		Address:   address,
+		Direction: direction,
+		Reason:    reason,
+		Date:      time.Now(),
+	}
+}
+
+func NewAccountUnfreezeEvent(address, reason string) AccountFreezeEvent {
+	return AccountFreezeEvent{
+		ID:      uuid.NewString(),
+		Type:    AccountFreezeEventTypeUnfreeze,
+		Address: address,
+		Reason:  reason,
+		Date:    time.Now(),
+	}
+}
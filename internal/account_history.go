@@ -0,0 +1,45 @@
+package ledger
+
+import (
+	"math/big"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+)
+
+const (
+	AccountHistoryEntryTypeMove     = "MOVE"
+	AccountHistoryEntryTypeMetadata = "METADATA"
+)
+
+// AccountHistoryEntry is a change of an account: a move of funds from or to the account,
+// or a revision of its metadata.
+type AccountHistoryEntry struct {
+	// ID orders the entries of the history of an account as they have been committed
+	ID       *big.Int                 `json:"id"`
+	Type     string                   `json:"type"`
+	Date     time.Time                `json:"date"`
+	Move     *AccountMove             `json:"move,omitempty"`
+	Metadata *AccountMetadataRevision `json:"metadata,omitempty"`
+}
+
+// AccountMove is the part of a transaction moving funds from or to an account.
+type AccountMove struct {
+	TransactionID     *big.Int  `json:"transactionId"`
+	Asset             string    `json:"asset"`
+	Amount            *big.Int  `json:"amount"`
+	IsSource          bool      `json:"isSource"`
+	EffectiveDate     time.Time `json:"effectiveDate"`
+	PreCommitVolumes  Volumes   `json:"preCommitVolumes"`
+	PostCommitVolumes Volumes   `json:"postCommitVolumes"`
+	// Reverts is the id of the transaction reverted by the transaction of the move, if any
+	Reverts *big.Int `json:"reverts,omitempty"`
+	// Reverted is true if the transaction of the move has been reverted since
+	Reverted bool `json:"reverted"`
+}
+
+// AccountMetadataRevision holds the metadata of an account after one of its changes.
+type AccountMetadataRevision struct {
+	Revision *big.Int          `json:"revision"`
+	Metadata metadata.Metadata `json:"metadata"`
+}
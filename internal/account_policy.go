@@ -0,0 +1,134 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/formancehq/go-libs/time"
+	"github.com/pkg/errors"
+)
+
+const policyWildcard = "*"
+
+var policySegmentRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// AccountPolicy constrains the movements of the accounts matching its pattern, whatever the script used.
+// A pattern is an account address where segments can be replaced by a wildcard matching any segment,
+// like `users:*:wallet`.
+type AccountPolicy struct {
+	Pattern string `json:"pattern"`
+	// MinimumBalances is the lowest balance, by asset, the accounts can be left with by a debit.
+	// A debit must leave the account with a positive balance for the assets not listed.
+	MinimumBalances map[string]*big.Int `json:"minimumBalances,omitempty"`
+	// AllowUnboundedOverdraft disables the checks of the balances
+	AllowUnboundedOverdraft bool `json:"allowUnboundedOverdraft"`
+	// AllowedAssets restricts the assets the accounts can receive or send, if not empty
+	AllowedAssets []string  `json:"allowedAssets,omitempty"`
+	Frozen        bool      `json:"frozen"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func (p AccountPolicy) Validate() error {
+	if err := ValidateAccountPolicyPattern(p.Pattern); err != nil {
+		return err
+	}
+	for asset, minimum := range p.MinimumBalances {
+		if minimum == nil {
+			return fmt.Errorf("missing minimum balance for asset %s", asset)
+		}
+	}
+	return nil
+}
+
+// Match returns whether address matches the pattern of the policy.
+func (p AccountPolicy) Match(address string) bool {
+	patternSegments := strings.Split(p.Pattern, ":")
+	addressSegments := strings.Split(address, ":")
+	if len(patternSegments) != len(addressSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if segment != policyWildcard && segment != addressSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MinimumBalance returns the lowest balance a debit can leave the accounts with for asset,
+// or nil if the balance is not constrained.
+func (p AccountPolicy) MinimumBalance(asset string) *big.Int {
+	if p.AllowUnboundedOverdraft {
+		return nil
+	}
+	if minimum, ok := p.MinimumBalances[asset]; ok {
+		return minimum
+	}
+	return Zero
+}
+
+func (p AccountPolicy) IsAssetAllowed(asset string) bool {
+	return len(p.AllowedAssets) == 0 || slices.Contains(p.AllowedAssets, asset)
+}
+
+// wildcards returns the number of wildcards of the pattern, the fewer wildcards, the more specific the policy.
+func (p AccountPolicy) wildcards() int {
+	ret := 0
+	for _, segment := range strings.Split(p.Pattern, ":") {
+		if segment == policyWildcard {
+			ret++
+		}
+	}
+	return ret
+}
+
+func NewAccountPolicy(pattern string) AccountPolicy {
+	return AccountPolicy{
+		Pattern:   pattern,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func ValidateAccountPolicyPattern(pattern string) error {
+	for _, segment := range strings.Split(pattern, ":") {
+		if segment != policyWildcard && !policySegmentRegexp.MatchString(segment) {
+			return errors.Errorf("invalid account policy pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+type AccountPolicies []AccountPolicy
+
+// Find returns the policy applying to address, if any.
+// When several patterns match, the most specific one applies: the one with the fewest wildcards,
+// then the one whose first wildcard comes last.
+// The world account is never constrained by policies.
+func (policies AccountPolicies) Find(address string) *AccountPolicy {
+	if address == WORLD {
+		return nil
+	}
+
+	var ret *AccountPolicy
+	for i := range policies {
+		policy := &policies[i]
+		if !policy.Match(address) {
+			continue
+		}
+		if ret == nil || policy.moreSpecificThan(*ret) {
+			ret = policy
+		}
+	}
+	return ret
+}
+
+func (p AccountPolicy) moreSpecificThan(other AccountPolicy) bool {
+	if p.wildcards() != other.wildcards() {
+		return p.wildcards() < other.wildcards()
+	}
+	// Patterns with the same number of wildcards matching the same address only differ by the position of their wildcards
+	return strings.Index(p.Pattern, policyWildcard) > strings.Index(other.Pattern, policyWildcard)
+}
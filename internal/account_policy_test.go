@@ -0,0 +1,41 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPoliciesFind(t *testing.T) {
+	policies := AccountPolicies{
+		NewAccountPolicy("*"),
+		NewAccountPolicy("users:*:wallet"),
+		NewAccountPolicy("*:1:wallet"),
+		NewAccountPolicy("users:vip:wallet"),
+	}
+
+	for address, expectedPattern := range map[string]string{
+		"bank":             "*",
+		"users:1:wallet":   "users:*:wallet",
+		"orders:1:wallet":  "*:1:wallet",
+		"users:vip:wallet": "users:vip:wallet",
+		"users:1":          "",
+		"world":            "",
+	} {
+		policy := policies.Find(address)
+		if expectedPattern == "" {
+			require.Nil(t, policy, address)
+			continue
+		}
+		require.NotNil(t, policy, address)
+		require.Equal(t, expectedPattern, policy.Pattern, address)
+	}
+}
+
+func TestValidateAccountPolicyPattern(t *testing.T) {
+	require.NoError(t, ValidateAccountPolicyPattern("users:*:wallet"))
+	require.NoError(t, ValidateAccountPolicyPattern("bank"))
+	require.Error(t, ValidateAccountPolicyPattern("users::wallet"))
+	require.Error(t, ValidateAccountPolicyPattern("users:**"))
+	require.Error(t, ValidateAccountPolicyPattern(""))
+}
@@ -2,12 +2,14 @@ package backend
 
 import (
 	"context"
+	"io"
 	"math/big"
 
 	"github.com/formancehq/go-libs/bun/bunpaginate"
 
 	"github.com/formancehq/go-libs/metadata"
 	"github.com/formancehq/go-libs/migrations"
+	"github.com/formancehq/go-libs/time"
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/engine"
 	"github.com/formancehq/ledger/internal/engine/command"
@@ -26,6 +28,7 @@ type Ledger interface {
 	GetMigrationsInfo(ctx context.Context) ([]migrations.Info, error)
 	Stats(ctx context.Context) (engine.Stats, error)
 	GetLogs(ctx context.Context, query ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error)
+	StreamLogs(ctx context.Context, after *big.Int, fn engine.StreamLogsFn) error
 	CountTransactions(ctx context.Context, query ledgerstore.GetTransactionsQuery) (int, error)
 	GetTransactions(ctx context.Context, query ledgerstore.GetTransactionsQuery) (*bunpaginate.Cursor[ledger.ExpandedTransaction], error)
 	GetTransactionWithVolumes(ctx context.Context, query ledgerstore.GetTransactionQuery) (*ledger.ExpandedTransaction, error)
@@ -34,8 +37,49 @@ type Ledger interface {
 	RevertTransaction(ctx context.Context, parameters command.Parameters, id *big.Int, force, atEffectiveDate bool) (*ledger.Transaction, error)
 	SaveMeta(ctx context.Context, parameters command.Parameters, targetType string, targetID any, m metadata.Metadata) error
 	DeleteMetadata(ctx context.Context, parameters command.Parameters, targetType string, targetID any, key string) error
-	Import(ctx context.Context, stream chan *ledger.ChainedLog) error
-	Export(ctx context.Context, w engine.ExportWriter) error
+	ExecuteAtomicBulk(ctx context.Context, elements []command.BulkElement) ([]command.BulkElementResult, error)
+	ImportLogs(ctx context.Context, r io.Reader, progress engine.ImportProgressFn) (*engine.ImportReport, error)
+	StartImport(ctx context.Context, r io.ReadCloser) (*ledger.ImportJob, error)
+	GetImportJob(ctx context.Context, id string) (*ledger.ImportJob, error)
+	GetImportJobs(ctx context.Context, query ledgerstore.GetImportJobsQuery) (*bunpaginate.Cursor[ledger.ImportJob], error)
+	Export(ctx context.Context, q ledgerstore.GetLogsQuery, w engine.ExportWriter) (*engine.ExportManifest, error)
+	Verify(ctx context.Context, progress engine.VerifyProgressFn) (*engine.VerifyReport, error)
+	ReplayEvents(ctx context.Context, q engine.ReplayQuery, progress engine.ReplayProgressFn) (*engine.ReplayReport, error)
+
+	CreateScheduledTransaction(ctx context.Context, data ledger.RunScript, executeAt time.Time) (*ledger.ScheduledTransaction, error)
+	GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error)
+	GetScheduledTransactions(ctx context.Context, query ledgerstore.GetScheduledTransactionsQuery) (*bunpaginate.Cursor[ledger.ScheduledTransaction], error)
+
+	CreateHold(ctx context.Context, parameters command.Parameters, destination string, data ledger.RunScript) (*ledger.Hold, *ledger.Transaction, error)
+	ConfirmHold(ctx context.Context, parameters command.Parameters, id string, amount *big.Int, final bool) (*ledger.Hold, *ledger.Transaction, error)
+	VoidHold(ctx context.Context, parameters command.Parameters, id string) (*ledger.Hold, *ledger.Transaction, error)
+	GetHold(ctx context.Context, id string) (*ledger.Hold, error)
+
+	ClosePeriod(ctx context.Context, closingDate time.Time, withBalances bool) (*ledger.ClosedPeriod, error)
+	GetClosedPeriod(ctx context.Context, id string) (*ledger.ClosedPeriod, error)
+	GetClosedPeriods(ctx context.Context, query ledgerstore.GetClosedPeriodsQuery) (*bunpaginate.Cursor[ledger.ClosedPeriod], error)
+
+	SaveAccountPolicy(ctx context.Context, policy ledger.AccountPolicy) error
+	DeleteAccountPolicy(ctx context.Context, pattern string) error
+	GetAccountPolicy(ctx context.Context, pattern string) (*ledger.AccountPolicy, error)
+	GetAccountPolicies(ctx context.Context, query ledgerstore.GetAccountPoliciesQuery) (*bunpaginate.Cursor[ledger.AccountPolicy], error)
+	FreezeAccount(ctx context.Context, address, direction, reason string) (*ledger.AccountFreeze, error)
+	UnfreezeAccount(ctx context.Context, address, reason string) error
+	GetAccountFreeze(ctx context.Context, address string) (*ledger.AccountFreeze, error)
+	GetAccountFreezes(ctx context.Context, query ledgerstore.GetAccountFreezesQuery) (*bunpaginate.Cursor[ledger.AccountFreeze], error)
+	GetAccountFreezeEvents(ctx context.Context, query ledgerstore.GetAccountFreezeEventsQuery) (*bunpaginate.Cursor[ledger.AccountFreezeEvent], error)
+	GetAccountHistory(ctx context.Context, query ledgerstore.GetAccountHistoryQuery) (*bunpaginate.Cursor[ledger.AccountHistoryEntry], error)
+	StreamAccountHistory(ctx context.Context, filters ledgerstore.AccountHistoryFilters, after *big.Int, fn engine.StreamAccountHistoryFn) error
+	SaveChart(ctx context.Context, chart ledger.Chart) error
+	DeleteChart(ctx context.Context) error
+	GetChart(ctx context.Context) (*ledger.Chart, error)
+
+	CreateWebhook(ctx context.Context, webhook systemstore.Webhook) (*systemstore.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook systemstore.Webhook) (*systemstore.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhook(ctx context.Context, id string) (*systemstore.Webhook, error)
+	ListWebhooks(ctx context.Context, query systemstore.ListWebhooksQuery) (*bunpaginate.Cursor[systemstore.Webhook], error)
+	ListWebhookAttempts(ctx context.Context, id string, query systemstore.ListWebhookAttemptsQuery) (*bunpaginate.Cursor[systemstore.WebhookAttempt], error)
 
 	IsDatabaseUpToDate(ctx context.Context) (bool, error)
 
@@ -50,6 +94,8 @@ type Backend interface {
 	UpdateLedgerMetadata(ctx context.Context, name string, m map[string]string) error
 	GetVersion() string
 	DeleteLedgerMetadata(ctx context.Context, param string, key string) error
+	UpdateLedgerState(ctx context.Context, name string, state string) error
+	DeleteLedger(ctx context.Context, name string, purge bool) error
 }
 
 type DefaultBackend struct {
@@ -66,6 +112,14 @@ func (d DefaultBackend) UpdateLedgerMetadata(ctx context.Context, name string, m
 	return d.storageDriver.GetSystemStore().UpdateLedgerMetadata(ctx, name, m)
 }
 
+func (d DefaultBackend) UpdateLedgerState(ctx context.Context, name string, state string) error {
+	return d.resolver.UpdateLedgerState(ctx, name, state)
+}
+
+func (d DefaultBackend) DeleteLedger(ctx context.Context, name string, purge bool) error {
+	return d.resolver.DeleteLedger(ctx, name, purge)
+}
+
 func (d DefaultBackend) GetLedger(ctx context.Context, name string) (*systemstore.Ledger, error) {
 	return d.storageDriver.GetSystemStore().GetLedger(ctx, name)
 }
@@ -11,6 +11,7 @@ package backend
 
 import (
 	context "context"
+	io "io"
 	big "math/big"
 	reflect "reflect"
 
@@ -23,6 +24,7 @@ import (
 	bunpaginate "github.com/formancehq/go-libs/bun/bunpaginate"
 	metadata "github.com/formancehq/go-libs/metadata"
 	migrations "github.com/formancehq/go-libs/migrations"
+	time "github.com/formancehq/go-libs/time"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -49,6 +51,37 @@ func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
 	return m.recorder
 }
 
+// ClosePeriod mocks base method.
+func (m *MockLedger) ClosePeriod(ctx context.Context, closingDate time.Time, withBalances bool) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePeriod", ctx, closingDate, withBalances)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePeriod indicates an expected call of ClosePeriod.
+func (mr *MockLedgerMockRecorder) ClosePeriod(ctx, closingDate, withBalances any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeriod", reflect.TypeOf((*MockLedger)(nil).ClosePeriod), ctx, closingDate, withBalances)
+}
+
+// ConfirmHold mocks base method.
+func (m *MockLedger) ConfirmHold(ctx context.Context, parameters command.Parameters, id string, amount *big.Int, final bool) (*ledger.Hold, *ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmHold", ctx, parameters, id, amount, final)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(*ledger.Transaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ConfirmHold indicates an expected call of ConfirmHold.
+func (mr *MockLedgerMockRecorder) ConfirmHold(ctx, parameters, id, amount, final any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmHold", reflect.TypeOf((*MockLedger)(nil).ConfirmHold), ctx, parameters, id, amount, final)
+}
+
 // CountAccounts mocks base method.
 func (m *MockLedger) CountAccounts(ctx context.Context, query ledgerstore.GetAccountsQuery) (int, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +112,37 @@ func (mr *MockLedgerMockRecorder) CountTransactions(ctx, query any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactions", reflect.TypeOf((*MockLedger)(nil).CountTransactions), ctx, query)
 }
 
+// CreateHold mocks base method.
+func (m *MockLedger) CreateHold(ctx context.Context, parameters command.Parameters, destination string, data ledger.RunScript) (*ledger.Hold, *ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, parameters, destination, data)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(*ledger.Transaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockLedgerMockRecorder) CreateHold(ctx, parameters, destination, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockLedger)(nil).CreateHold), ctx, parameters, destination, data)
+}
+
+// CreateScheduledTransaction mocks base method.
+func (m *MockLedger) CreateScheduledTransaction(ctx context.Context, data ledger.RunScript, executeAt time.Time) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransaction", ctx, data, executeAt)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransaction indicates an expected call of CreateScheduledTransaction.
+func (mr *MockLedgerMockRecorder) CreateScheduledTransaction(ctx, data, executeAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransaction", reflect.TypeOf((*MockLedger)(nil).CreateScheduledTransaction), ctx, data, executeAt)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockLedger) CreateTransaction(ctx context.Context, parameters command.Parameters, data ledger.RunScript) (*ledger.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +158,49 @@ func (mr *MockLedgerMockRecorder) CreateTransaction(ctx, parameters, data any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockLedger)(nil).CreateTransaction), ctx, parameters, data)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockLedger) CreateWebhook(ctx context.Context, webhook systemstore.Webhook) (*systemstore.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(*systemstore.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockLedgerMockRecorder) CreateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockLedger)(nil).CreateWebhook), ctx, webhook)
+}
+
+// DeleteAccountPolicy mocks base method.
+func (m *MockLedger) DeleteAccountPolicy(ctx context.Context, pattern string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountPolicy", ctx, pattern)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountPolicy indicates an expected call of DeleteAccountPolicy.
+func (mr *MockLedgerMockRecorder) DeleteAccountPolicy(ctx, pattern any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountPolicy", reflect.TypeOf((*MockLedger)(nil).DeleteAccountPolicy), ctx, pattern)
+}
+
+// DeleteChart mocks base method.
+func (m *MockLedger) DeleteChart(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChart", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChart indicates an expected call of DeleteChart.
+func (mr *MockLedgerMockRecorder) DeleteChart(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChart", reflect.TypeOf((*MockLedger)(nil).DeleteChart), ctx)
+}
+
 // DeleteMetadata mocks base method.
 func (m *MockLedger) DeleteMetadata(ctx context.Context, parameters command.Parameters, targetType string, targetID any, key string) error {
 	m.ctrl.T.Helper()
@@ -108,18 +215,153 @@ func (mr *MockLedgerMockRecorder) DeleteMetadata(ctx, parameters, targetType, ta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetadata", reflect.TypeOf((*MockLedger)(nil).DeleteMetadata), ctx, parameters, targetType, targetID, key)
 }
 
-// Export mocks base method.
-func (m *MockLedger) Export(ctx context.Context, w engine.ExportWriter) error {
+// DeleteWebhook mocks base method.
+func (m *MockLedger) DeleteWebhook(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Export", ctx, w)
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockLedgerMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockLedger)(nil).DeleteWebhook), ctx, id)
+}
+
+// ExecuteAtomicBulk mocks base method.
+func (m *MockLedger) ExecuteAtomicBulk(ctx context.Context, elements []command.BulkElement) ([]command.BulkElementResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteAtomicBulk", ctx, elements)
+	ret0, _ := ret[0].([]command.BulkElementResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteAtomicBulk indicates an expected call of ExecuteAtomicBulk.
+func (mr *MockLedgerMockRecorder) ExecuteAtomicBulk(ctx, elements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteAtomicBulk", reflect.TypeOf((*MockLedger)(nil).ExecuteAtomicBulk), ctx, elements)
+}
+
+// Export mocks base method.
+func (m *MockLedger) Export(ctx context.Context, q ledgerstore.GetLogsQuery, w engine.ExportWriter) (*engine.ExportManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, q, w)
+	ret0, _ := ret[0].(*engine.ExportManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Export indicates an expected call of Export.
-func (mr *MockLedgerMockRecorder) Export(ctx, w any) *gomock.Call {
+func (mr *MockLedgerMockRecorder) Export(ctx, q, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockLedger)(nil).Export), ctx, q, w)
+}
+
+// FreezeAccount mocks base method.
+func (m *MockLedger) FreezeAccount(ctx context.Context, address, direction, reason string) (*ledger.AccountFreeze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeAccount", ctx, address, direction, reason)
+	ret0, _ := ret[0].(*ledger.AccountFreeze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeAccount indicates an expected call of FreezeAccount.
+func (mr *MockLedgerMockRecorder) FreezeAccount(ctx, address, direction, reason any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockLedger)(nil).Export), ctx, w)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeAccount", reflect.TypeOf((*MockLedger)(nil).FreezeAccount), ctx, address, direction, reason)
+}
+
+// GetAccountFreeze mocks base method.
+func (m *MockLedger) GetAccountFreeze(ctx context.Context, address string) (*ledger.AccountFreeze, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFreeze", ctx, address)
+	ret0, _ := ret[0].(*ledger.AccountFreeze)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFreeze indicates an expected call of GetAccountFreeze.
+func (mr *MockLedgerMockRecorder) GetAccountFreeze(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFreeze", reflect.TypeOf((*MockLedger)(nil).GetAccountFreeze), ctx, address)
+}
+
+// GetAccountFreezeEvents mocks base method.
+func (m *MockLedger) GetAccountFreezeEvents(ctx context.Context, query ledgerstore.GetAccountFreezeEventsQuery) (*bunpaginate.Cursor[ledger.AccountFreezeEvent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFreezeEvents", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.AccountFreezeEvent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFreezeEvents indicates an expected call of GetAccountFreezeEvents.
+func (mr *MockLedgerMockRecorder) GetAccountFreezeEvents(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFreezeEvents", reflect.TypeOf((*MockLedger)(nil).GetAccountFreezeEvents), ctx, query)
+}
+
+// GetAccountFreezes mocks base method.
+func (m *MockLedger) GetAccountFreezes(ctx context.Context, query ledgerstore.GetAccountFreezesQuery) (*bunpaginate.Cursor[ledger.AccountFreeze], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFreezes", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.AccountFreeze])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFreezes indicates an expected call of GetAccountFreezes.
+func (mr *MockLedgerMockRecorder) GetAccountFreezes(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFreezes", reflect.TypeOf((*MockLedger)(nil).GetAccountFreezes), ctx, query)
+}
+
+// GetAccountHistory mocks base method.
+func (m *MockLedger) GetAccountHistory(ctx context.Context, query ledgerstore.GetAccountHistoryQuery) (*bunpaginate.Cursor[ledger.AccountHistoryEntry], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHistory", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.AccountHistoryEntry])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHistory indicates an expected call of GetAccountHistory.
+func (mr *MockLedgerMockRecorder) GetAccountHistory(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHistory", reflect.TypeOf((*MockLedger)(nil).GetAccountHistory), ctx, query)
+}
+
+// GetAccountPolicies mocks base method.
+func (m *MockLedger) GetAccountPolicies(ctx context.Context, query ledgerstore.GetAccountPoliciesQuery) (*bunpaginate.Cursor[ledger.AccountPolicy], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountPolicies", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.AccountPolicy])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountPolicies indicates an expected call of GetAccountPolicies.
+func (mr *MockLedgerMockRecorder) GetAccountPolicies(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountPolicies", reflect.TypeOf((*MockLedger)(nil).GetAccountPolicies), ctx, query)
+}
+
+// GetAccountPolicy mocks base method.
+func (m *MockLedger) GetAccountPolicy(ctx context.Context, pattern string) (*ledger.AccountPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountPolicy", ctx, pattern)
+	ret0, _ := ret[0].(*ledger.AccountPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountPolicy indicates an expected call of GetAccountPolicy.
+func (mr *MockLedgerMockRecorder) GetAccountPolicy(ctx, pattern any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountPolicy", reflect.TypeOf((*MockLedger)(nil).GetAccountPolicy), ctx, pattern)
 }
 
 // GetAccountWithVolumes mocks base method.
@@ -167,6 +409,96 @@ func (mr *MockLedgerMockRecorder) GetAggregatedBalances(ctx, q any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedBalances", reflect.TypeOf((*MockLedger)(nil).GetAggregatedBalances), ctx, q)
 }
 
+// GetChart mocks base method.
+func (m *MockLedger) GetChart(ctx context.Context) (*ledger.Chart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChart", ctx)
+	ret0, _ := ret[0].(*ledger.Chart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChart indicates an expected call of GetChart.
+func (mr *MockLedgerMockRecorder) GetChart(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChart", reflect.TypeOf((*MockLedger)(nil).GetChart), ctx)
+}
+
+// GetClosedPeriod mocks base method.
+func (m *MockLedger) GetClosedPeriod(ctx context.Context, id string) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedPeriod", ctx, id)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedPeriod indicates an expected call of GetClosedPeriod.
+func (mr *MockLedgerMockRecorder) GetClosedPeriod(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedPeriod", reflect.TypeOf((*MockLedger)(nil).GetClosedPeriod), ctx, id)
+}
+
+// GetClosedPeriods mocks base method.
+func (m *MockLedger) GetClosedPeriods(ctx context.Context, query ledgerstore.GetClosedPeriodsQuery) (*bunpaginate.Cursor[ledger.ClosedPeriod], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedPeriods", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.ClosedPeriod])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedPeriods indicates an expected call of GetClosedPeriods.
+func (mr *MockLedgerMockRecorder) GetClosedPeriods(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedPeriods", reflect.TypeOf((*MockLedger)(nil).GetClosedPeriods), ctx, query)
+}
+
+// GetHold mocks base method.
+func (m *MockLedger) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockLedgerMockRecorder) GetHold(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockLedger)(nil).GetHold), ctx, id)
+}
+
+// GetImportJob mocks base method.
+func (m *MockLedger) GetImportJob(ctx context.Context, id string) (*ledger.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(*ledger.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockLedgerMockRecorder) GetImportJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockLedger)(nil).GetImportJob), ctx, id)
+}
+
+// GetImportJobs mocks base method.
+func (m *MockLedger) GetImportJobs(ctx context.Context, query ledgerstore.GetImportJobsQuery) (*bunpaginate.Cursor[ledger.ImportJob], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJobs", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.ImportJob])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJobs indicates an expected call of GetImportJobs.
+func (mr *MockLedgerMockRecorder) GetImportJobs(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJobs", reflect.TypeOf((*MockLedger)(nil).GetImportJobs), ctx, query)
+}
+
 // GetLogs mocks base method.
 func (m *MockLedger) GetLogs(ctx context.Context, query ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
 	m.ctrl.T.Helper()
@@ -197,6 +529,36 @@ func (mr *MockLedgerMockRecorder) GetMigrationsInfo(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationsInfo", reflect.TypeOf((*MockLedger)(nil).GetMigrationsInfo), ctx)
 }
 
+// GetScheduledTransaction mocks base method.
+func (m *MockLedger) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransaction indicates an expected call of GetScheduledTransaction.
+func (mr *MockLedgerMockRecorder) GetScheduledTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransaction", reflect.TypeOf((*MockLedger)(nil).GetScheduledTransaction), ctx, id)
+}
+
+// GetScheduledTransactions mocks base method.
+func (m *MockLedger) GetScheduledTransactions(ctx context.Context, query ledgerstore.GetScheduledTransactionsQuery) (*bunpaginate.Cursor[ledger.ScheduledTransaction], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransactions", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[ledger.ScheduledTransaction])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransactions indicates an expected call of GetScheduledTransactions.
+func (mr *MockLedgerMockRecorder) GetScheduledTransactions(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransactions", reflect.TypeOf((*MockLedger)(nil).GetScheduledTransactions), ctx, query)
+}
+
 // GetTransactionWithVolumes mocks base method.
 func (m *MockLedger) GetTransactionWithVolumes(ctx context.Context, query ledgerstore.GetTransactionQuery) (*ledger.ExpandedTransaction, error) {
 	m.ctrl.T.Helper()
@@ -242,18 +604,34 @@ func (mr *MockLedgerMockRecorder) GetVolumesWithBalances(ctx, q any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolumesWithBalances", reflect.TypeOf((*MockLedger)(nil).GetVolumesWithBalances), ctx, q)
 }
 
-// Import mocks base method.
-func (m *MockLedger) Import(ctx context.Context, stream chan *ledger.ChainedLog) error {
+// GetWebhook mocks base method.
+func (m *MockLedger) GetWebhook(ctx context.Context, id string) (*systemstore.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Import", ctx, stream)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*systemstore.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockLedgerMockRecorder) GetWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockLedger)(nil).GetWebhook), ctx, id)
+}
+
+// ImportLogs mocks base method.
+func (m *MockLedger) ImportLogs(ctx context.Context, r io.Reader, progress engine.ImportProgressFn) (*engine.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLogs", ctx, r, progress)
+	ret0, _ := ret[0].(*engine.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Import indicates an expected call of Import.
-func (mr *MockLedgerMockRecorder) Import(ctx, stream any) *gomock.Call {
+// ImportLogs indicates an expected call of ImportLogs.
+func (mr *MockLedgerMockRecorder) ImportLogs(ctx, r, progress any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockLedger)(nil).Import), ctx, stream)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLogs", reflect.TypeOf((*MockLedger)(nil).ImportLogs), ctx, r, progress)
 }
 
 // IsDatabaseUpToDate mocks base method.
@@ -271,6 +649,51 @@ func (mr *MockLedgerMockRecorder) IsDatabaseUpToDate(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDatabaseUpToDate", reflect.TypeOf((*MockLedger)(nil).IsDatabaseUpToDate), ctx)
 }
 
+// ListWebhookAttempts mocks base method.
+func (m *MockLedger) ListWebhookAttempts(ctx context.Context, id string, query systemstore.ListWebhookAttemptsQuery) (*bunpaginate.Cursor[systemstore.WebhookAttempt], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookAttempts", ctx, id, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[systemstore.WebhookAttempt])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookAttempts indicates an expected call of ListWebhookAttempts.
+func (mr *MockLedgerMockRecorder) ListWebhookAttempts(ctx, id, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookAttempts", reflect.TypeOf((*MockLedger)(nil).ListWebhookAttempts), ctx, id, query)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockLedger) ListWebhooks(ctx context.Context, query systemstore.ListWebhooksQuery) (*bunpaginate.Cursor[systemstore.Webhook], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, query)
+	ret0, _ := ret[0].(*bunpaginate.Cursor[systemstore.Webhook])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockLedgerMockRecorder) ListWebhooks(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockLedger)(nil).ListWebhooks), ctx, query)
+}
+
+// ReplayEvents mocks base method.
+func (m *MockLedger) ReplayEvents(ctx context.Context, q engine.ReplayQuery, progress engine.ReplayProgressFn) (*engine.ReplayReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayEvents", ctx, q, progress)
+	ret0, _ := ret[0].(*engine.ReplayReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayEvents indicates an expected call of ReplayEvents.
+func (mr *MockLedgerMockRecorder) ReplayEvents(ctx, q, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayEvents", reflect.TypeOf((*MockLedger)(nil).ReplayEvents), ctx, q, progress)
+}
+
 // RevertTransaction mocks base method.
 func (m *MockLedger) RevertTransaction(ctx context.Context, parameters command.Parameters, id *big.Int, force, atEffectiveDate bool) (*ledger.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +709,34 @@ func (mr *MockLedgerMockRecorder) RevertTransaction(ctx, parameters, id, force,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTransaction", reflect.TypeOf((*MockLedger)(nil).RevertTransaction), ctx, parameters, id, force, atEffectiveDate)
 }
 
+// SaveAccountPolicy mocks base method.
+func (m *MockLedger) SaveAccountPolicy(ctx context.Context, policy ledger.AccountPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAccountPolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAccountPolicy indicates an expected call of SaveAccountPolicy.
+func (mr *MockLedgerMockRecorder) SaveAccountPolicy(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccountPolicy", reflect.TypeOf((*MockLedger)(nil).SaveAccountPolicy), ctx, policy)
+}
+
+// SaveChart mocks base method.
+func (m *MockLedger) SaveChart(ctx context.Context, chart ledger.Chart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChart", ctx, chart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChart indicates an expected call of SaveChart.
+func (mr *MockLedgerMockRecorder) SaveChart(ctx, chart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChart", reflect.TypeOf((*MockLedger)(nil).SaveChart), ctx, chart)
+}
+
 // SaveMeta mocks base method.
 func (m_2 *MockLedger) SaveMeta(ctx context.Context, parameters command.Parameters, targetType string, targetID any, m metadata.Metadata) error {
 	m_2.ctrl.T.Helper()
@@ -300,6 +751,21 @@ func (mr *MockLedgerMockRecorder) SaveMeta(ctx, parameters, targetType, targetID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMeta", reflect.TypeOf((*MockLedger)(nil).SaveMeta), ctx, parameters, targetType, targetID, m)
 }
 
+// StartImport mocks base method.
+func (m *MockLedger) StartImport(ctx context.Context, r io.ReadCloser) (*ledger.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", ctx, r)
+	ret0, _ := ret[0].(*ledger.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockLedgerMockRecorder) StartImport(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockLedger)(nil).StartImport), ctx, r)
+}
+
 // Stats mocks base method.
 func (m *MockLedger) Stats(ctx context.Context) (engine.Stats, error) {
 	m.ctrl.T.Helper()
@@ -315,6 +781,94 @@ func (mr *MockLedgerMockRecorder) Stats(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockLedger)(nil).Stats), ctx)
 }
 
+// StreamAccountHistory mocks base method.
+func (m *MockLedger) StreamAccountHistory(ctx context.Context, filters ledgerstore.AccountHistoryFilters, after *big.Int, fn engine.StreamAccountHistoryFn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamAccountHistory", ctx, filters, after, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamAccountHistory indicates an expected call of StreamAccountHistory.
+func (mr *MockLedgerMockRecorder) StreamAccountHistory(ctx, filters, after, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamAccountHistory", reflect.TypeOf((*MockLedger)(nil).StreamAccountHistory), ctx, filters, after, fn)
+}
+
+// StreamLogs mocks base method.
+func (m *MockLedger) StreamLogs(ctx context.Context, after *big.Int, fn engine.StreamLogsFn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamLogs", ctx, after, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamLogs indicates an expected call of StreamLogs.
+func (mr *MockLedgerMockRecorder) StreamLogs(ctx, after, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLogs", reflect.TypeOf((*MockLedger)(nil).StreamLogs), ctx, after, fn)
+}
+
+// UnfreezeAccount mocks base method.
+func (m *MockLedger) UnfreezeAccount(ctx context.Context, address, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfreezeAccount", ctx, address, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfreezeAccount indicates an expected call of UnfreezeAccount.
+func (mr *MockLedgerMockRecorder) UnfreezeAccount(ctx, address, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfreezeAccount", reflect.TypeOf((*MockLedger)(nil).UnfreezeAccount), ctx, address, reason)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockLedger) UpdateWebhook(ctx context.Context, webhook systemstore.Webhook) (*systemstore.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(*systemstore.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockLedgerMockRecorder) UpdateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockLedger)(nil).UpdateWebhook), ctx, webhook)
+}
+
+// Verify mocks base method.
+func (m *MockLedger) Verify(ctx context.Context, progress engine.VerifyProgressFn) (*engine.VerifyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, progress)
+	ret0, _ := ret[0].(*engine.VerifyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockLedgerMockRecorder) Verify(ctx, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockLedger)(nil).Verify), ctx, progress)
+}
+
+// VoidHold mocks base method.
+func (m *MockLedger) VoidHold(ctx context.Context, parameters command.Parameters, id string) (*ledger.Hold, *ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidHold", ctx, parameters, id)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(*ledger.Transaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VoidHold indicates an expected call of VoidHold.
+func (mr *MockLedgerMockRecorder) VoidHold(ctx, parameters, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidHold", reflect.TypeOf((*MockLedger)(nil).VoidHold), ctx, parameters, id)
+}
+
 // MockBackend is a mock of Backend interface.
 type MockBackend struct {
 	ctrl     *gomock.Controller
@@ -352,6 +906,20 @@ func (mr *MockBackendMockRecorder) CreateLedger(ctx, name, configuration any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLedger", reflect.TypeOf((*MockBackend)(nil).CreateLedger), ctx, name, configuration)
 }
 
+// DeleteLedger mocks base method.
+func (m *MockBackend) DeleteLedger(ctx context.Context, name string, purge bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLedger", ctx, name, purge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLedger indicates an expected call of DeleteLedger.
+func (mr *MockBackendMockRecorder) DeleteLedger(ctx, name, purge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLedger", reflect.TypeOf((*MockBackend)(nil).DeleteLedger), ctx, name, purge)
+}
+
 // DeleteLedgerMetadata mocks base method.
 func (m *MockBackend) DeleteLedgerMetadata(ctx context.Context, param, key string) error {
 	m.ctrl.T.Helper()
@@ -438,3 +1006,17 @@ func (mr *MockBackendMockRecorder) UpdateLedgerMetadata(ctx, name, m any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLedgerMetadata", reflect.TypeOf((*MockBackend)(nil).UpdateLedgerMetadata), ctx, name, m)
 }
+
+// UpdateLedgerState mocks base method.
+func (m *MockBackend) UpdateLedgerState(ctx context.Context, name, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLedgerState", ctx, name, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLedgerState indicates an expected call of UpdateLedgerState.
+func (mr *MockBackendMockRecorder) UpdateLedgerState(ctx, name, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLedgerState", reflect.TypeOf((*MockBackend)(nil).UpdateLedgerState), ctx, name, state)
+}
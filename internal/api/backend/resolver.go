@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/ledger/internal/engine"
 	"github.com/formancehq/ledger/internal/opentelemetry/tracer"
 )
 
@@ -26,6 +27,7 @@ var (
 
 const (
 	ErrOutdatedSchema = "OUTDATED_SCHEMA"
+	ErrLedgerArchived = "LEDGER_ARCHIVED"
 )
 
 func init() {
@@ -78,6 +80,8 @@ func LedgerMiddleware(
 				switch {
 				case sqlutils.IsNotFoundError(err):
 					sharedapi.WriteErrorResponse(w, http.StatusNotFound, "LEDGER_NOT_FOUND", err)
+				case errors.Is(err, engine.ErrLedgerArchived):
+					sharedapi.WriteErrorResponse(w, http.StatusNotFound, ErrLedgerArchived, err)
 				default:
 					sharedapi.InternalServerError(w, r, err)
 				}
@@ -34,6 +34,98 @@ type Element struct {
 	Data           json.RawMessage `json:"data"`
 }
 
+// toCommand parses the data of the element.
+func (element Element) toCommand(i int) (*command.BulkElement, error) {
+	ret := &command.BulkElement{
+		IdempotencyKey: element.IdempotencyKey,
+	}
+
+	switch element.Action {
+	case ActionCreateTransaction:
+		req := &ledger.TransactionRequest{}
+		if err := json.Unmarshal(element.Data, req); err != nil {
+			return nil, fmt.Errorf("error parsing element %d: %s", i, err)
+		}
+		ret.CreateTransaction = req.ToRunScript()
+	case ActionAddMetadata:
+		type addMetadataRequest struct {
+			TargetType string            `json:"targetType"`
+			TargetID   json.RawMessage   `json:"targetId"`
+			Metadata   metadata.Metadata `json:"metadata"`
+		}
+		req := &addMetadataRequest{}
+		if err := json.Unmarshal(element.Data, req); err != nil {
+			return nil, fmt.Errorf("error parsing element %d: %s", i, err)
+		}
+
+		targetID, err := parseTargetID(req.TargetType, req.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.SaveMetadata = &command.SaveMetadataRequest{
+			TargetType: req.TargetType,
+			TargetID:   targetID,
+			Metadata:   req.Metadata,
+		}
+	case ActionRevertTransaction:
+		type revertTransactionRequest struct {
+			ID              *big.Int `json:"id"`
+			Force           bool     `json:"force"`
+			AtEffectiveDate bool     `json:"atEffectiveDate"`
+		}
+		req := &revertTransactionRequest{}
+		if err := json.Unmarshal(element.Data, req); err != nil {
+			return nil, fmt.Errorf("error parsing element %d: %s", i, err)
+		}
+
+		ret.RevertTransaction = &command.RevertTransactionRequest{
+			ID:              req.ID,
+			Force:           req.Force,
+			AtEffectiveDate: req.AtEffectiveDate,
+		}
+	case ActionDeleteMetadata:
+		type deleteMetadataRequest struct {
+			TargetType string          `json:"targetType"`
+			TargetID   json.RawMessage `json:"targetId"`
+			Key        string          `json:"key"`
+		}
+		req := &deleteMetadataRequest{}
+		if err := json.Unmarshal(element.Data, req); err != nil {
+			return nil, fmt.Errorf("error parsing element %d: %s", i, err)
+		}
+
+		targetID, err := parseTargetID(req.TargetType, req.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.DeleteMetadata = &command.DeleteMetadataRequest{
+			TargetType: req.TargetType,
+			TargetID:   targetID,
+			Key:        req.Key,
+		}
+	default:
+		return nil, nil
+	}
+
+	return ret, nil
+}
+
+func parseTargetID(targetType string, data json.RawMessage) (any, error) {
+	var targetID any
+	switch targetType {
+	case ledger.MetaTargetTypeAccount:
+		targetID = ""
+	case ledger.MetaTargetTypeTransaction:
+		targetID = big.NewInt(0)
+	}
+	if err := json.Unmarshal(data, &targetID); err != nil {
+		return nil, err
+	}
+	return targetID, nil
+}
+
 type Result struct {
 	ErrorCode        string `json:"errorCode,omitempty"`
 	ErrorDescription string `json:"errorDescription,omitempty"`
@@ -42,168 +134,185 @@ type Result struct {
 	ResponseType     string `json:"responseType"` // Added for sdk generation (discriminator in oneOf)
 }
 
-func ProcessBulk(ctx context.Context, l backend.Ledger, bulk Bulk, continueOnFailure bool) ([]Result, bool, error) {
+func bulkErrorCode(action string, err error) string {
+	if command.IsErrIdempotencyKeyConflict(err) {
+		return ErrIdempotencyKeyConflict
+	}
+	if command.IsErrLedgerReadOnly(err) {
+		return ErrLedgerReadOnly
+	}
+	if command.IsErrNotLeader(err) {
+		return ErrNotLeader
+	}
+	if command.IsPeriodError(err, command.ErrPeriodCodeClosed) {
+		return ErrPeriodClosed
+	}
+	if machine.IsAccountPolicyError(err) {
+		return ErrAccountPolicy
+	}
+	if command.IsErrAccountFrozen(err) {
+		return ErrAccountFrozen
+	}
+	if command.IsErrChartViolation(err) {
+		return ErrChartViolation
+	}
+	if command.IsErrHoldProtected(err) {
+		return ErrHoldProtected
+	}
+
+	switch action {
+	case ActionCreateTransaction:
+		switch {
+		case machine.IsInsufficientFundError(err):
+			return ErrInsufficientFund
+		case machine.IsAssertionFailedError(err):
+			return ErrAssertionFailed
+		case engine.IsCommandError(err):
+			return ErrValidation
+		}
+	case ActionAddMetadata:
+		switch {
+		case command.IsSaveMetaError(err, command.ErrSaveMetaCodeTransactionNotFound):
+			return sharedapi.ErrorCodeNotFound
+		}
+	case ActionRevertTransaction:
+		switch {
+		case engine.IsCommandError(err):
+			return ErrValidation
+		}
+	case ActionDeleteMetadata:
+		switch {
+		case command.IsDeleteMetaError(err, command.ErrSaveMetaCodeTransactionNotFound):
+			return sharedapi.ErrorCodeNotFound
+		}
+	}
+	return sharedapi.ErrorInternal
+}
+
+func errorResult(action string, err error) Result {
+	return Result{
+		ErrorCode:        bulkErrorCode(action, err),
+		ErrorDescription: err.Error(),
+		ResponseType:     "ERROR",
+	}
+}
+
+// processElement executes a single element of a bulk and returns its result.
+func processElement(ctx context.Context, l backend.Ledger, action string, req command.BulkElement) (Result, error) {
+	parameters := command.Parameters{
+		DryRun:         false,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+
+	var (
+		data any
+		err  error
+	)
+	switch {
+	case req.CreateTransaction != nil:
+		var tx *ledger.Transaction
+		tx, err = l.CreateTransaction(ctx, parameters, *req.CreateTransaction)
+		data = tx
+	case req.SaveMetadata != nil:
+		err = l.SaveMeta(ctx, parameters, req.SaveMetadata.TargetType, req.SaveMetadata.TargetID, req.SaveMetadata.Metadata)
+	case req.RevertTransaction != nil:
+		var tx *ledger.Transaction
+		tx, err = l.RevertTransaction(ctx, parameters, req.RevertTransaction.ID, req.RevertTransaction.Force, req.RevertTransaction.AtEffectiveDate)
+		data = tx
+	case req.DeleteMetadata != nil:
+		err = l.DeleteMetadata(ctx, parameters, req.DeleteMetadata.TargetType, req.DeleteMetadata.TargetID, req.DeleteMetadata.Key)
+	}
+	if err != nil {
+		return errorResult(action, err), err
+	}
+
+	return Result{
+		Data:         data,
+		ResponseType: action,
+	}, nil
+}
+
+func ProcessBulk(ctx context.Context, l backend.Ledger, bulk Bulk, continueOnFailure, atomic bool) ([]Result, bool, error) {
 
 	ctx, span := tracer.Start(ctx, "Bulk")
 	defer span.End()
 
+	if atomic {
+		return processAtomicBulk(ctx, l, bulk)
+	}
+
 	ret := make([]Result, 0, len(bulk))
 
 	errorsInBulk := false
-	var bulkError = func(action, code string, err error) {
-		ret = append(ret, Result{
-			ErrorCode:        code,
-			ErrorDescription: err.Error(),
-			ResponseType:     "ERROR",
-		})
-		errorsInBulk = true
-	}
-
 	for i, element := range bulk {
-		parameters := command.Parameters{
-			DryRun:         false,
-			IdempotencyKey: element.IdempotencyKey,
+		req, err := element.toCommand(i)
+		if err != nil {
+			return nil, errorsInBulk, err
+		}
+		if req == nil {
+			continue
 		}
 
-		switch element.Action {
-		case ActionCreateTransaction:
-			req := &ledger.TransactionRequest{}
-			if err := json.Unmarshal(element.Data, req); err != nil {
-				return nil, errorsInBulk, fmt.Errorf("error parsing element %d: %s", i, err)
-			}
-			rs := req.ToRunScript()
-
-			tx, err := l.CreateTransaction(ctx, parameters, *rs)
-			if err != nil {
-				var code string
-				switch {
-				case machine.IsInsufficientFundError(err):
-					code = ErrInsufficientFund
-				case engine.IsCommandError(err):
-					code = ErrValidation
-				default:
-					code = sharedapi.ErrorInternal
-				}
-				bulkError(element.Action, code, err)
-				if !continueOnFailure {
-					return ret, errorsInBulk, nil
-				}
-			} else {
-				ret = append(ret, Result{
-					Data:         tx,
-					ResponseType: element.Action,
-				})
-			}
-		case ActionAddMetadata:
-			type addMetadataRequest struct {
-				TargetType string            `json:"targetType"`
-				TargetID   json.RawMessage   `json:"targetId"`
-				Metadata   metadata.Metadata `json:"metadata"`
-			}
-			req := &addMetadataRequest{}
-			if err := json.Unmarshal(element.Data, req); err != nil {
-				return nil, errorsInBulk, fmt.Errorf("error parsing element %d: %s", i, err)
-			}
-
-			var targetID any
-			switch req.TargetType {
-			case ledger.MetaTargetTypeAccount:
-				targetID = ""
-			case ledger.MetaTargetTypeTransaction:
-				targetID = big.NewInt(0)
-			}
-			if err := json.Unmarshal(req.TargetID, &targetID); err != nil {
-				return nil, errorsInBulk, err
-			}
-
-			if err := l.SaveMeta(ctx, parameters, req.TargetType, targetID, req.Metadata); err != nil {
-				var code string
-				switch {
-				case command.IsSaveMetaError(err, command.ErrSaveMetaCodeTransactionNotFound):
-					code = sharedapi.ErrorCodeNotFound
-				default:
-					code = sharedapi.ErrorInternal
-				}
-				bulkError(element.Action, code, err)
-				if !continueOnFailure {
-					return ret, errorsInBulk, nil
-				}
-			} else {
-				ret = append(ret, Result{
-					ResponseType: element.Action,
-				})
-			}
-		case ActionRevertTransaction:
-			type revertTransactionRequest struct {
-				ID              *big.Int `json:"id"`
-				Force           bool     `json:"force"`
-				AtEffectiveDate bool     `json:"atEffectiveDate"`
-			}
-			req := &revertTransactionRequest{}
-			if err := json.Unmarshal(element.Data, req); err != nil {
-				return nil, errorsInBulk, fmt.Errorf("error parsing element %d: %s", i, err)
+		result, err := processElement(ctx, l, element.Action, *req)
+		ret = append(ret, result)
+		if err != nil {
+			errorsInBulk = true
+			if !continueOnFailure {
+				return ret, errorsInBulk, nil
 			}
+		}
+	}
+	return ret, errorsInBulk, nil
+}
 
-			tx, err := l.RevertTransaction(ctx, parameters, req.ID, req.Force, req.AtEffectiveDate)
-			if err != nil {
-				var code string
-				switch {
-				case engine.IsCommandError(err):
-					code = ErrValidation
-				default:
-					code = sharedapi.ErrorInternal
-				}
-				bulkError(element.Action, code, err)
-				if !continueOnFailure {
-					return ret, errorsInBulk, nil
-				}
-			} else {
-				ret = append(ret, Result{
-					Data:         tx,
-					ResponseType: element.Action,
-				})
-			}
-		case ActionDeleteMetadata:
-			type deleteMetadataRequest struct {
-				TargetType string          `json:"targetType"`
-				TargetID   json.RawMessage `json:"targetId"`
-				Key        string          `json:"key"`
-			}
-			req := &deleteMetadataRequest{}
-			if err := json.Unmarshal(element.Data, req); err != nil {
-				return nil, errorsInBulk, fmt.Errorf("error parsing element %d: %s", i, err)
-			}
+// processAtomicBulk executes all the elements of the bulk at once.
+// When an element fails, nothing is committed: failing elements report their error,
+// while the others are returned without data.
+func processAtomicBulk(ctx context.Context, l backend.Ledger, bulk Bulk) ([]Result, bool, error) {
+	actions := make([]string, 0, len(bulk))
+	elements := make([]command.BulkElement, 0, len(bulk))
+	for i, element := range bulk {
+		req, err := element.toCommand(i)
+		if err != nil {
+			return nil, false, err
+		}
+		if req == nil {
+			continue
+		}
+		actions = append(actions, element.Action)
+		elements = append(elements, *req)
+	}
 
-			var targetID any
-			switch req.TargetType {
-			case ledger.MetaTargetTypeAccount:
-				targetID = ""
-			case ledger.MetaTargetTypeTransaction:
-				targetID = big.NewInt(0)
-			}
-			if err := json.Unmarshal(req.TargetID, &targetID); err != nil {
-				return nil, errorsInBulk, err
-			}
+	results, err := l.ExecuteAtomicBulk(ctx, elements)
+	if err != nil {
+		return nil, false, err
+	}
 
-			err := l.DeleteMetadata(ctx, parameters, req.TargetType, targetID, req.Key)
-			if err != nil {
-				var code string
-				switch {
-				case command.IsDeleteMetaError(err, command.ErrSaveMetaCodeTransactionNotFound):
-					code = sharedapi.ErrorCodeNotFound
-				default:
-					code = sharedapi.ErrorInternal
-				}
-				bulkError(element.Action, code, err)
-				if !continueOnFailure {
-					return ret, errorsInBulk, nil
-				}
-			} else {
-				ret = append(ret, Result{
-					ResponseType: element.Action,
-				})
+	ret := make([]Result, 0, len(results))
+	errorsInBulk := false
+	for i, result := range results {
+		switch {
+		case result.Err != nil:
+			ret = append(ret, errorResult(actions[i], result.Err))
+			errorsInBulk = true
+		case result.Log == nil:
+			ret = append(ret, Result{
+				ResponseType: actions[i],
+			})
+		default:
+			var data any
+			switch payload := result.Log.Data.(type) {
+			case ledger.NewTransactionLogPayload:
+				data = payload.Transaction
+			case ledger.RevertedTransactionLogPayload:
+				data = payload.RevertTransaction
 			}
+			ret = append(ret, Result{
+				Data:         data,
+				ResponseType: actions[i],
+			})
 		}
 	}
+
 	return ret, errorsInBulk, nil
 }
@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/opentelemetry/tracer"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultBulkStreamParallelism = 1
+	MaxBulkStreamParallelism     = 100
+)
+
+// StreamBulk reads the elements of a bulk as NDJSON from r and writes their results to w,
+// as NDJSON too and in the same order.
+//
+// Up to parallelism elements are executed concurrently, allowing the commander
+// to commit their logs within the same batches. Elements are no longer read
+// while parallelism results are waiting to be written, so a slow reader
+// slows down the processing instead of making results pile up in memory.
+// With a parallelism of 1, elements are executed strictly in order.
+//
+// If an element fails and continueOnFailure is not set, no more element is read,
+// but elements already being executed complete and report their results.
+func StreamBulk(ctx context.Context, l backend.Ledger, r io.Reader, w io.Writer, flush func(),
+	continueOnFailure bool, parallelism int) error {
+
+	ctx, span := tracer.Start(ctx, "StreamBulk")
+	defer span.End()
+
+	if parallelism < 1 {
+		parallelism = DefaultBulkStreamParallelism
+	}
+
+	var (
+		pending  = make(chan chan Result, parallelism)
+		slots    = make(chan struct{}, parallelism)
+		failed   = atomic.Bool{}
+		wg       sync.WaitGroup
+		writeErr error
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		enc := json.NewEncoder(w)
+		for next := range pending {
+			result := <-next
+			if writeErr != nil {
+				// Keep draining to release the reader
+				continue
+			}
+			if err := enc.Encode(result); err != nil {
+				writeErr = err
+				failed.Store(true)
+				continue
+			}
+			if len(pending) == 0 {
+				flush()
+			}
+		}
+	}()
+
+	dec := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		slots <- struct{}{}
+		if failed.Load() {
+			break
+		}
+
+		element := Element{}
+		if err := dec.Decode(&element); err != nil {
+			if !errors.Is(err, io.EOF) {
+				next := make(chan Result, 1)
+				next <- Result{
+					ErrorCode:        ErrValidation,
+					ErrorDescription: errors.Wrapf(err, "error parsing element %d", i).Error(),
+					ResponseType:     "ERROR",
+				}
+				pending <- next
+			}
+			break
+		}
+
+		req, err := element.toCommand(i)
+		if err != nil {
+			next := make(chan Result, 1)
+			next <- Result{
+				ErrorCode:        ErrValidation,
+				ErrorDescription: err.Error(),
+				ResponseType:     "ERROR",
+			}
+			pending <- next
+			break
+		}
+		if req == nil {
+			<-slots
+			continue
+		}
+
+		next := make(chan Result, 1)
+		pending <- next
+
+		go func() {
+			defer func() {
+				<-slots
+			}()
+
+			result, err := processElement(ctx, l, element.Action, *req)
+			if err != nil && !continueOnFailure {
+				failed.Store(true)
+			}
+			next <- result
+		}()
+	}
+
+	close(pending)
+	wg.Wait()
+
+	return writeErr
+}
@@ -1,23 +1,147 @@
 package v2
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/api/backend"
 	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
 )
 
+const (
+	exportCompressionGzip = "gzip"
+	exportCompressionZstd = "zstd"
+)
+
+func getExportQuery(r *http.Request) (ledgerstore.GetLogsQuery, error) {
+	query := ledgerstore.GetLogsQuery{}
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		if err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query); err != nil {
+			return query, fmt.Errorf("invalid '%s' query param", QueryKeyCursor)
+		}
+		return query, nil
+	}
+
+	qb, err := getQueryBuilder(r)
+	if err != nil {
+		return query, err
+	}
+
+	return engine.NewExportQuery(ledgerstore.PaginatedQueryOptions[any]{
+		QueryBuilder: qb,
+	}), nil
+}
+
+// exportLogsWriter encodes the exported logs and, when the manifest is requested, a checkpoint after each page,
+// flushed to the client so an interrupted export can be resumed from the last received checkpoint.
+type exportLogsWriter struct {
+	enc         *json.Encoder
+	checkpoints bool
+	flush       func() error
+}
+
+func (w exportLogsWriter) Write(_ context.Context, log *ledger.ChainedLog) error {
+	return w.enc.Encode(log)
+}
+
+func (w exportLogsWriter) Checkpoint(_ context.Context, manifest engine.ExportManifest) error {
+	if !w.checkpoints {
+		return nil
+	}
+	if err := w.enc.Encode(engine.ExportCheckpointDocument{
+		Checkpoint: &manifest,
+	}); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+var _ engine.ExportCheckpointer = exportLogsWriter{}
+
 func exportLogs(w http.ResponseWriter, r *http.Request) {
-	enc := json.NewEncoder(w)
-	w.Header().Set("Content-Type", "application/octet-stream")
-	if err := backend.LedgerFromContext(r.Context()).Export(r.Context(), engine.ExportWriterFn(func(ctx context.Context, log *ledger.ChainedLog) error {
-		return enc.Encode(log)
-	})); err != nil {
-		api.InternalServerError(w, r, err)
+	query, err := getExportQuery(r)
+	if err != nil {
+		api.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	var (
+		out   io.Writer = w
+		flush           = func() error { return nil }
+		// partialFlush sends the data written so far to the client, without terminating the stream
+		partialFlush = rc.Flush
+	)
+	switch compression := r.URL.Query().Get("compression"); compression {
+	case "":
+		w.Header().Set("Content-Type", "application/octet-stream")
+	case exportCompressionGzip:
+		gz := gzip.NewWriter(w)
+		out, flush = gz, gz.Close
+		partialFlush = func() error {
+			if err := gz.Flush(); err != nil {
+				return err
+			}
+			return rc.Flush()
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+	case exportCompressionZstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			api.InternalServerError(w, r, err)
+			return
+		}
+		out, flush = zw, zw.Close
+		partialFlush = func() error {
+			if err := zw.Flush(); err != nil {
+				return err
+			}
+			return rc.Flush()
+		}
+		w.Header().Set("Content-Type", "application/zstd")
+	default:
+		api.BadRequest(w, ErrValidation, fmt.Errorf("unknown compression '%s'", compression))
+		return
+	}
+
+	enc := json.NewEncoder(out)
+	manifest, err := backend.LedgerFromContext(r.Context()).Export(r.Context(), query, exportLogsWriter{
+		enc:         enc,
+		checkpoints: api.QueryParamBool(r, "manifest"),
+		flush:       partialFlush,
+	})
+	if err != nil {
+		switch {
+		case ledgerstore.IsErrInvalidQuery(err):
+			api.BadRequest(w, ErrValidation, err)
+		default:
+			// The compressed stream is not terminated, so a partial export cannot be mistaken for a complete one
+			api.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	if api.QueryParamBool(r, "manifest") {
+		if err := enc.Encode(engine.ExportManifestDocument{
+			Manifest: manifest,
+		}); err != nil {
+			api.InternalServerError(w, r, err)
+			return
+		}
+	}
+
+	if err := flush(); err != nil {
+		api.InternalServerError(w, r, errors.Wrap(err, "flushing export"))
 		return
 	}
 }
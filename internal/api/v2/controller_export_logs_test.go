@@ -0,0 +1,163 @@
+package v2_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/query"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportLogs(t *testing.T) {
+	t.Parallel()
+
+	logs := ledger.ChainLogs(
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))),
+			map[string]metadata.Metadata{},
+		),
+		ledger.NewSetMetadataOnAccountLog(time.Now(), "bank", metadata.Metadata{"foo": "bar"}),
+	)
+	manifest := &engine.ExportManifest{
+		Count:      len(logs),
+		FirstLogID: logs[0].ID,
+		LastLogID:  logs[1].ID,
+		LastHash:   logs[1].Hash,
+		Cursor:     "xxx",
+	}
+
+	type testCase struct {
+		name              string
+		queryParams       url.Values
+		body              string
+		expectQuery       ledgerstore.GetLogsQuery
+		expectBackendCall bool
+		expectStatusCode  int
+		expectErrorCode   string
+		expectGzip        bool
+		expectManifest    bool
+	}
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			expectQuery:       engine.NewExportQuery(ledgerstore.PaginatedQueryOptions[any]{}),
+			expectBackendCall: true,
+		},
+		{
+			name:              "with filters, compression and manifest",
+			body:              `{"$gte": {"id": 10}}`,
+			queryParams:       url.Values{"compression": []string{"gzip"}, "manifest": []string{"true"}},
+			expectQuery:       engine.NewExportQuery(ledgerstore.PaginatedQueryOptions[any]{QueryBuilder: query.Gte("id", float64(10))}),
+			expectBackendCall: true,
+			expectGzip:        true,
+			expectManifest:    true,
+		},
+		{
+			name: "from cursor",
+			queryParams: url.Values{"cursor": []string{bunpaginate.EncodeCursor(
+				engine.NewExportQuery(ledgerstore.PaginatedQueryOptions[any]{}),
+			)}},
+			expectQuery:       engine.NewExportQuery(ledgerstore.PaginatedQueryOptions[any]{}),
+			expectBackendCall: true,
+		},
+		{
+			name:             "with unknown compression",
+			queryParams:      url.Values{"compression": []string{"lz4"}},
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.expectStatusCode == 0 {
+				tc.expectStatusCode = http.StatusOK
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if tc.expectBackendCall {
+				mockLedger.EXPECT().
+					Export(gomock.Any(), tc.expectQuery, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, q ledgerstore.GetLogsQuery, w engine.ExportWriter) (*engine.ExportManifest, error) {
+						for _, log := range logs {
+							require.NoError(t, w.Write(ctx, log))
+						}
+						require.NoError(t, w.(engine.ExportCheckpointer).Checkpoint(ctx, *manifest))
+						return manifest, nil
+					})
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/logs/export", bytes.NewBufferString(tc.body))
+			req = req.WithContext(logging.TestingContext())
+			req.URL.RawQuery = tc.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			if tc.expectStatusCode >= 300 {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, tc.expectErrorCode, err.ErrorCode)
+				return
+			}
+
+			body := io.Reader(rec.Body)
+			if tc.expectGzip {
+				require.Equal(t, "application/gzip", rec.Header().Get("Content-Type"))
+				gz, err := gzip.NewReader(body)
+				require.NoError(t, err)
+				body = gz
+			}
+
+			scanner := bufio.NewScanner(body)
+			for _, log := range logs {
+				require.True(t, scanner.Scan())
+				exported := &ledger.ChainedLog{}
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), exported))
+				require.Equal(t, log.ID, exported.ID)
+				require.Equal(t, log.Hash, exported.Hash)
+			}
+			if tc.expectManifest {
+				require.True(t, scanner.Scan())
+				checkpoint := engine.ExportCheckpointDocument{}
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &checkpoint))
+				require.Equal(t, manifest, checkpoint.Checkpoint)
+
+				require.True(t, scanner.Scan())
+				document := struct {
+					Manifest engine.ExportManifest `json:"manifest"`
+				}{}
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &document))
+				require.Equal(t, *manifest, document.Manifest)
+			}
+			require.False(t, scanner.Scan())
+		})
+	}
+}
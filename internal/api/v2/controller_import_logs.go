@@ -1,65 +1,137 @@
 package v2
 
 import (
-	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
 
-	"github.com/formancehq/ledger/internal/engine"
+	"github.com/go-chi/chi/v5"
 
 	"github.com/formancehq/go-libs/api"
-	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
 	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
 	"github.com/pkg/errors"
 )
 
-func importLogs(w http.ResponseWriter, r *http.Request) {
+// spooledFile is removed once the import reading it is terminated
+type spooledFile struct {
+	*os.File
+}
 
-	stream := make(chan *ledger.ChainedLog)
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- backend.LedgerFromContext(r.Context()).Import(r.Context(), stream)
+func (f spooledFile) Close() error {
+	defer func() {
+		_ = os.Remove(f.Name())
 	}()
-	dec := json.NewDecoder(r.Body)
-	handleError := func(err error) {
+	return f.File.Close()
+}
+
+// spool copies the request body to a temporary file, so that an async import does not depend on the connection
+func spool(r io.Reader) (io.ReadCloser, error) {
+	f, err := os.CreateTemp("", "ledger-import-*")
+	if err != nil {
+		return nil, err
+	}
+	ret := spooledFile{File: f}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = ret.Close()
+		return nil, errors.Wrap(err, "reading logs")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = ret.Close()
+		return nil, err
+	}
+	return ret, nil
+}
+
+func importLogs(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	if api.QueryParamBool(r, "async") {
+		f, err := spool(r.Body)
+		if err != nil {
+			api.InternalServerError(w, r, err)
+			return
+		}
+
+		job, err := l.StartImport(r.Context(), f)
+		if err != nil {
+			api.InternalServerError(w, r, err)
+			return
+		}
+
+		api.Accepted(w, job)
+		return
+	}
+
+	if _, err := l.ImportLogs(r.Context(), r.Body, nil); err != nil {
 		switch {
 		case errors.Is(err, engine.ImportError{}):
-			api.WriteErrorResponse(w, http.StatusBadRequest, "IMPORT", err)
+			api.WriteErrorResponse(w, http.StatusBadRequest, ErrImport, err)
+		case errors.Is(err, engine.ErrImportInProgress):
+			api.WriteErrorResponse(w, http.StatusConflict, ErrImportInProgress, err)
+		case command.IsErrLedgerReadOnly(err):
+			api.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			api.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
 		default:
 			api.InternalServerError(w, r, err)
 		}
+		return
 	}
-	for {
-		l := &ledger.ChainedLog{}
-		if err := dec.Decode(l); err != nil {
-			if errors.Is(err, io.EOF) {
-				close(stream)
-				break
-			} else {
-				api.InternalServerError(w, r, err)
-				return
-			}
-		}
-		select {
-		case stream <- l:
-		case <-r.Context().Done():
-			api.InternalServerError(w, r, r.Context().Err())
-			return
-		case err := <-errChan:
-			handleError(err)
+
+	api.NoContent(w)
+}
+
+func getImportJobs(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetImportJobsQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			api.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
 			return
 		}
-	}
-	select {
-	case err := <-errChan:
+	} else {
+		pageSize, err := bunpaginate.GetPageSize(r)
 		if err != nil {
-			handleError(err)
+			api.BadRequest(w, ErrValidation, err)
 			return
 		}
-	case <-r.Context().Done():
-		api.InternalServerError(w, r, r.Context().Err())
+
+		query = ledgerstore.NewGetImportJobsQuery(ledgerstore.PaginatedQueryOptions[any]{
+			PageSize: pageSize,
+		})
+	}
+
+	cursor, err := l.GetImportJobs(r.Context(), query)
+	if err != nil {
+		api.InternalServerError(w, r, err)
 		return
 	}
 
-	api.NoContent(w)
+	api.RenderCursor(w, *cursor)
+}
+
+func getImportJob(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	job, err := l.GetImportJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			api.NotFound(w, err)
+		default:
+			api.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, job)
 }
@@ -0,0 +1,155 @@
+package v2_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/logging"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportLogs(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"id": 0}`
+
+	type testCase struct {
+		name             string
+		queryParams      url.Values
+		returnErr        error
+		expectAsync      bool
+		expectStatusCode int
+		expectErrorCode  string
+	}
+	testCases := []testCase{
+		{
+			name:             "nominal",
+			expectStatusCode: http.StatusNoContent,
+		},
+		{
+			name:             "with import in progress",
+			returnErr:        engine.ErrImportInProgress,
+			expectStatusCode: http.StatusConflict,
+			expectErrorCode:  v2.ErrImportInProgress,
+		},
+		{
+			name:             "with unexpected error",
+			returnErr:        errors.New("unexpected"),
+			expectStatusCode: http.StatusInternalServerError,
+			expectErrorCode:  sharedapi.ErrorInternal,
+		},
+		{
+			name:             "async",
+			queryParams:      url.Values{"async": []string{"true"}},
+			expectAsync:      true,
+			expectStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend, mockLedger := newTestingBackend(t, true)
+			job := ledger.NewImportJob()
+			if tc.expectAsync {
+				mockLedger.EXPECT().
+					StartImport(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, r io.ReadCloser) (*ledger.ImportJob, error) {
+						// The body is fully read before the job is started
+						data, err := io.ReadAll(r)
+						require.NoError(t, err)
+						require.Equal(t, body, string(data))
+						require.NoError(t, r.Close())
+						return &job, nil
+					})
+			} else {
+				mockLedger.EXPECT().
+					ImportLogs(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&engine.ImportReport{}, tc.returnErr)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/logs/import", bytes.NewBufferString(body))
+			req = req.WithContext(logging.TestingContext())
+			req.URL.RawQuery = tc.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			switch {
+			case tc.expectStatusCode >= 300:
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, tc.expectErrorCode, err.ErrorCode)
+			case tc.expectAsync:
+				ret, ok := sharedapi.DecodeSingleResponse[ledger.ImportJob](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, job.ID, ret.ID)
+				require.Equal(t, ledger.ImportJobStateRunning, ret.State)
+			}
+		})
+	}
+}
+
+func TestGetImportJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := ledger.NewImportJob()
+		mockLedger.EXPECT().
+			GetImportJob(gomock.Any(), expected.ID).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/logs/imports/"+expected.ID, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		job, ok := sharedapi.DecodeSingleResponse[ledger.ImportJob](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.ID, job.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetImportJob(gomock.Any(), "unknown").
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/logs/imports/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
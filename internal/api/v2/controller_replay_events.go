@@ -0,0 +1,35 @@
+package v2
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/pkg/errors"
+)
+
+func replayEvents(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	// The body is optional, all the logs are replayed without it
+	q := engine.ReplayQuery{}
+	if err := json.NewDecoder(r.Body).Decode(&q); err != nil && !errors.Is(err, io.EOF) {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid replay format"))
+		return
+	}
+	if err := q.Validate(); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	report, err := l.ReplayEvents(r.Context(), q, nil)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, report)
+}
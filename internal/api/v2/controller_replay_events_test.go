@@ -0,0 +1,100 @@
+package v2_test
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplayEvents(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		body               string
+		expectBackendCall  bool
+		expectQuery        engine.ReplayQuery
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			body:              `{"fromLog": 10, "toLog": 20, "topic": "backfill"}`,
+			expectBackendCall: true,
+			expectQuery: engine.ReplayQuery{
+				FromLog: big.NewInt(10),
+				ToLog:   big.NewInt(20),
+				Topic:   "backfill",
+			},
+		},
+		{
+			name:              "without body",
+			expectBackendCall: true,
+		},
+		{
+			name:               "invalid range",
+			body:               `{"fromLog": 20, "toLog": 10}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "invalid body",
+			body:               `{"fromLog": "first"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusOK
+			}
+
+			expectedReport := engine.ReplayReport{
+				ReplayedLogs: 11,
+				LastLogID:    big.NewInt(20),
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				mockLedger.EXPECT().
+					ReplayEvents(gomock.Any(), testCase.expectQuery, gomock.Any()).
+					Return(&expectedReport, nil)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/logs/_replay", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				report, ok := sharedapi.DecodeSingleResponse[engine.ReplayReport](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, expectedReport, report)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/logging"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	lastEventIDHeader      = "Last-Event-ID"
+)
+
+// streamLogs streams the logs of a ledger as server-sent events, each event having the id of its log.
+// The stream starts after the log given by the Last-Event-ID header when resuming,
+// else from the log given by the 'from' query param, else with the next log inserted.
+func streamLogs(w http.ResponseWriter, r *http.Request) {
+	var after *big.Int
+	switch {
+	case r.Header.Get(lastEventIDHeader) != "":
+		id, ok := new(big.Int).SetString(r.Header.Get(lastEventIDHeader), 10)
+		if !ok {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' header", lastEventIDHeader))
+			return
+		}
+		after = id
+	case r.URL.Query().Get("from") != "":
+		id, ok := new(big.Int).SetString(r.URL.Query().Get("from"), 10)
+		if !ok || id.Sign() < 0 {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid 'from' query param"))
+			return
+		}
+		after = id.Sub(id, big.NewInt(1))
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	err := backend.LedgerFromContext(r.Context()).StreamLogs(r.Context(), after, func(log ledger.ChainedLog) error {
+		data, err := json.Marshal(log)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", log.ID, log.Type, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err != nil && r.Context().Err() == nil {
+		logging.FromContext(r.Context()).Errorf("streaming logs: %s", err)
+	}
+}
@@ -0,0 +1,131 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStreamLogs(t *testing.T) {
+	t.Parallel()
+
+	logs := ledger.ChainLogs(
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))),
+			map[string]metadata.Metadata{},
+		),
+		ledger.NewSetMetadataOnAccountLog(time.Now(), "bank", metadata.Metadata{"foo": "bar"}),
+	)
+
+	type testCase struct {
+		name              string
+		queryParams       url.Values
+		lastEventID       string
+		expectBackendCall bool
+		expectAfter       *big.Int
+		expectStatusCode  int
+		expectErrorCode   string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			expectBackendCall: true,
+		},
+		{
+			name:              "from log",
+			queryParams:       url.Values{"from": []string{"10"}},
+			expectBackendCall: true,
+			expectAfter:       big.NewInt(9),
+		},
+		{
+			name:              "resume",
+			queryParams:       url.Values{"from": []string{"10"}},
+			lastEventID:       "15",
+			expectBackendCall: true,
+			expectAfter:       big.NewInt(15),
+		},
+		{
+			name:             "invalid from",
+			queryParams:      url.Values{"from": []string{"first"}},
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:             "invalid last event id",
+			lastEventID:      "last",
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectStatusCode == 0 {
+				testCase.expectStatusCode = http.StatusOK
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				mockLedger.EXPECT().
+					StreamLogs(gomock.Any(), testCase.expectAfter, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, after *big.Int, fn engine.StreamLogsFn) error {
+						for _, log := range logs {
+							if err := fn(*log); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodGet, "/xxx/logs/stream", nil)
+			req.URL.RawQuery = testCase.queryParams.Encode()
+			if testCase.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", testCase.lastEventID)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectStatusCode, rec.Code)
+			if testCase.expectStatusCode < 300 && testCase.expectStatusCode >= 200 {
+				require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+				expected := ""
+				for _, log := range logs {
+					expected += fmt.Sprintf("id: %s\nevent: %s\ndata: ", log.ID, log.Type)
+					data, err := json.Marshal(log)
+					require.NoError(t, err)
+					expected += string(data) + "\n\n"
+				}
+				require.Equal(t, expected, rec.Body.String())
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
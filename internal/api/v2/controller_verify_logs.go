@@ -0,0 +1,18 @@
+package v2
+
+import (
+	"net/http"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/ledger/internal/api/backend"
+)
+
+func verifyLogs(w http.ResponseWriter, r *http.Request) {
+	report, err := backend.LedgerFromContext(r.Context()).Verify(r.Context(), nil)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, report)
+}
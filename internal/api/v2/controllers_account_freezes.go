@@ -0,0 +1,207 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/pkg/core/accounts"
+	"github.com/pkg/errors"
+)
+
+type freezeAccountRequest struct {
+	Direction string `json:"direction"`
+	Reason    string `json:"reason"`
+}
+
+type unfreezeAccountRequest struct {
+	Reason string `json:"reason"`
+}
+
+func getAccountAddress(r *http.Request) (string, error) {
+	address, err := url.PathUnescape(chi.URLParam(r, "address"))
+	if err != nil {
+		return "", err
+	}
+	if !accounts.ValidateAddress(address) {
+		return "", errors.New("invalid account address format")
+	}
+	return address, nil
+}
+
+func freezeAccount(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	address, err := getAccountAddress(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	payload := freezeAccountRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid freeze format"))
+		return
+	}
+	payload.Direction = strings.ToUpper(payload.Direction)
+	if err := ledger.ValidateFreezeDirection(payload.Direction); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	freeze, err := l.FreezeAccount(r.Context(), address, payload.Direction, payload.Reason)
+	if err != nil {
+		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, freeze)
+}
+
+func unfreezeAccount(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	address, err := getAccountAddress(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	// The body is optional
+	payload := unfreezeAccountRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid unfreeze format"))
+		return
+	}
+
+	if err := l.UnfreezeAccount(r.Context(), address, payload.Reason); err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.NoContent(w)
+}
+
+func getAccountFreeze(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	address, err := getAccountAddress(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	freeze, err := l.GetAccountFreeze(r.Context(), address)
+	if err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, freeze)
+}
+
+func getAccountFreezes(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetAccountFreezesQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
+			return
+		}
+	} else {
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		query = ledgerstore.NewGetAccountFreezesQuery(ledgerstore.PaginatedQueryOptions[any]{
+			PageSize: pageSize,
+		})
+	}
+
+	cursor, err := l.GetAccountFreezes(r.Context(), query)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
+
+func getAccountFreezeEvents(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetAccountFreezeEventsQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
+			return
+		}
+	} else {
+		address, err := getAccountAddress(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		query = ledgerstore.NewGetAccountFreezeEventsQuery(ledgerstore.PaginatedQueryOptions[ledgerstore.AccountFreezeEventsFilter]{
+			PageSize: pageSize,
+			Options: ledgerstore.AccountFreezeEventsFilter{
+				Address: address,
+			},
+		})
+	}
+
+	cursor, err := l.GetAccountFreezeEvents(r.Context(), query)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
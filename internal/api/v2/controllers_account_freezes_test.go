@@ -0,0 +1,280 @@
+package v2_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFreezeAccount(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		address            string
+		body               string
+		expectBackendCall  bool
+		expectDirection    string
+		expectReason       string
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			address:           "users:1",
+			body:              `{"direction": "DEBIT", "reason": "fraud"}`,
+			expectBackendCall: true,
+			expectDirection:   ledger.FreezeDirectionDebit,
+			expectReason:      "fraud",
+		},
+		{
+			name:              "lowercase direction",
+			address:           "users:1",
+			body:              `{"direction": "both"}`,
+			expectBackendCall: true,
+			expectDirection:   ledger.FreezeDirectionBoth,
+		},
+		{
+			name:               "invalid direction",
+			address:            "users:1",
+			body:               `{"direction": "sideways"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "invalid address",
+			address:            "users:1!",
+			body:               `{"direction": "DEBIT"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "ledger read-only",
+			address:            "users:1",
+			body:               `{"direction": "CREDIT"}`,
+			expectBackendCall:  true,
+			expectDirection:    ledger.FreezeDirectionCredit,
+			returnErr:          engine.NewCommandError(command.NewErrLedgerReadOnly()),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrLedgerReadOnly,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusOK
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				freeze := ledger.NewAccountFreezeEvent(testCase.address, testCase.expectDirection, testCase.expectReason).Freeze()
+				mockLedger.EXPECT().
+					FreezeAccount(gomock.Any(), testCase.address, testCase.expectDirection, testCase.expectReason).
+					Return(&freeze, testCase.returnErr)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/accounts/"+testCase.address+"/freeze", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				freeze, ok := sharedapi.DecodeSingleResponse[ledger.AccountFreeze](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, testCase.expectDirection, freeze.Direction)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestUnfreezeAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			UnfreezeAccount(gomock.Any(), "users:1", "resolved").
+			Return(nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodPost, "/xxx/accounts/users:1/unfreeze", bytes.NewBufferString(`{"reason": "resolved"}`))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("without body", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			UnfreezeAccount(gomock.Any(), "users:1", "").
+			Return(nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodPost, "/xxx/accounts/users:1/unfreeze", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("not frozen", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			UnfreezeAccount(gomock.Any(), "users:1", "").
+			Return(sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodPost, "/xxx/accounts/users:1/unfreeze", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetAccountFreeze(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionBoth, "").Freeze()
+		mockLedger.EXPECT().
+			GetAccountFreeze(gomock.Any(), "users:1").
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/accounts/users:1/freeze", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		freeze, ok := sharedapi.DecodeSingleResponse[ledger.AccountFreeze](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.Direction, freeze.Direction)
+	})
+
+	t.Run("not frozen", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetAccountFreeze(gomock.Any(), "users:1").
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/accounts/users:1/freeze", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetAccountFreezes(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[ledger.AccountFreeze]{
+		Data: []ledger.AccountFreeze{
+			ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionDebit, "").Freeze(),
+		},
+	}
+	mockLedger.EXPECT().
+		GetAccountFreezes(gomock.Any(), ledgerstore.NewGetAccountFreezesQuery(
+			ledgerstore.NewPaginatedQueryOptions[any](nil),
+		)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/freezes", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[ledger.AccountFreeze](t, rec.Body)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, "users:1", cursor.Data[0].Address)
+}
+
+func TestGetAccountFreezeEvents(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[ledger.AccountFreezeEvent]{
+		Data: []ledger.AccountFreezeEvent{
+			ledger.NewAccountUnfreezeEvent("users:1", ""),
+			ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionDebit, ""),
+		},
+	}
+	mockLedger.EXPECT().
+		GetAccountFreezeEvents(gomock.Any(), ledgerstore.NewGetAccountFreezeEventsQuery(
+			ledgerstore.NewPaginatedQueryOptions(ledgerstore.AccountFreezeEventsFilter{
+				Address: "users:1",
+			}),
+		)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/accounts/users:1/freeze/events", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[ledger.AccountFreezeEvent](t, rec.Body)
+	require.Len(t, cursor.Data, 2)
+	require.Equal(t, ledger.AccountFreezeEventTypeUnfreeze, cursor.Data[0].Type)
+}
@@ -0,0 +1,123 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+)
+
+func getAccountHistoryFilters(r *http.Request) (*ledgerstore.AccountHistoryFilters, error) {
+	address, err := getAccountAddress(r)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := &ledgerstore.AccountHistoryFilters{
+		Address: address,
+		Asset:   r.URL.Query().Get("asset"),
+	}
+	for param, date := range map[string]**time.Time{
+		"startTime": &filters.StartTime,
+		"endTime":   &filters.EndTime,
+	} {
+		if r.URL.Query().Get(param) == "" {
+			continue
+		}
+		parsed, err := time.ParseTime(r.URL.Query().Get(param))
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' query param", param)
+		}
+		*date = &parsed
+	}
+
+	return filters, nil
+}
+
+func getAccountHistory(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetAccountHistoryQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
+			return
+		}
+	} else {
+		filters, err := getAccountHistoryFilters(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		query = ledgerstore.NewGetAccountHistoryQuery(ledgerstore.PaginatedQueryOptions[ledgerstore.AccountHistoryFilters]{
+			PageSize: pageSize,
+			Options:  *filters,
+		})
+	}
+
+	cursor, err := l.GetAccountHistory(r.Context(), query)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
+
+// streamAccountHistory streams the history of an account as server-sent events, each event having the id of its entry.
+// The stream starts after the entry given by the Last-Event-ID header when resuming, else with the next entry added.
+func streamAccountHistory(w http.ResponseWriter, r *http.Request) {
+	filters, err := getAccountHistoryFilters(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	var after *big.Int
+	if r.Header.Get(lastEventIDHeader) != "" {
+		id, ok := new(big.Int).SetString(r.Header.Get(lastEventIDHeader), 10)
+		if !ok {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' header", lastEventIDHeader))
+			return
+		}
+		after = id
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	err = backend.LedgerFromContext(r.Context()).StreamAccountHistory(r.Context(), *filters, after, func(entry ledger.AccountHistoryEntry) error {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", entry.ID, entry.Type, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err != nil && r.Context().Err() == nil {
+		logging.FromContext(r.Context()).Errorf("streaming account history: %s", err)
+	}
+}
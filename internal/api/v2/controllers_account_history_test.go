@@ -0,0 +1,258 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/pointer"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccountHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	type testCase struct {
+		name              string
+		address           string
+		queryParams       url.Values
+		expectQuery       ledgerstore.GetAccountHistoryQuery
+		expectBackendCall bool
+		expectStatusCode  int
+		expectErrorCode   string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			address:           "users:1",
+			expectBackendCall: true,
+			expectQuery: ledgerstore.NewGetAccountHistoryQuery(ledgerstore.NewPaginatedQueryOptions(ledgerstore.AccountHistoryFilters{
+				Address: "users:1",
+			})),
+		},
+		{
+			name:    "with filters",
+			address: "users:1",
+			queryParams: url.Values{
+				"asset":     []string{"USD"},
+				"startTime": []string{now.Format(time.DateFormat)},
+				"endTime":   []string{now.Add(time.Hour).Format(time.DateFormat)},
+			},
+			expectBackendCall: true,
+			expectQuery: ledgerstore.NewGetAccountHistoryQuery(ledgerstore.NewPaginatedQueryOptions(ledgerstore.AccountHistoryFilters{
+				Address:   "users:1",
+				Asset:     "USD",
+				StartTime: pointer.For(now),
+				EndTime:   pointer.For(now.Add(time.Hour)),
+			})),
+		},
+		{
+			name:    "using cursor",
+			address: "users:1",
+			queryParams: url.Values{
+				"cursor": []string{bunpaginate.EncodeCursor(ledgerstore.NewGetAccountHistoryQuery(ledgerstore.NewPaginatedQueryOptions(ledgerstore.AccountHistoryFilters{
+					Address: "users:2",
+				})))},
+			},
+			expectBackendCall: true,
+			expectQuery: ledgerstore.NewGetAccountHistoryQuery(ledgerstore.NewPaginatedQueryOptions(ledgerstore.AccountHistoryFilters{
+				Address: "users:2",
+			})),
+		},
+		{
+			name:             "invalid start time",
+			address:          "users:1",
+			queryParams:      url.Values{"startTime": []string{"yesterday"}},
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:             "invalid address",
+			address:          "users:1:",
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectStatusCode == 0 {
+				testCase.expectStatusCode = http.StatusOK
+			}
+
+			expectedCursor := bunpaginate.Cursor[ledger.AccountHistoryEntry]{
+				Data: []ledger.AccountHistoryEntry{
+					{
+						ID:   big.NewInt(11),
+						Type: ledger.AccountHistoryEntryTypeMetadata,
+						Date: now,
+						Metadata: &ledger.AccountMetadataRevision{
+							Revision: big.NewInt(2),
+							Metadata: metadata.Metadata{"category": "gold"},
+						},
+					},
+				},
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				mockLedger.EXPECT().
+					GetAccountHistory(gomock.Any(), testCase.expectQuery).
+					Return(&expectedCursor, nil)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/xxx/accounts/%s/history", testCase.address), nil)
+			req.URL.RawQuery = testCase.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectStatusCode, rec.Code)
+			if testCase.expectStatusCode < 300 && testCase.expectStatusCode >= 200 {
+				cursor := sharedapi.DecodeCursorResponse[ledger.AccountHistoryEntry](t, rec.Body)
+				require.Equal(t, expectedCursor.Data, cursor.Data)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestStreamAccountHistory(t *testing.T) {
+	t.Parallel()
+
+	entries := []ledger.AccountHistoryEntry{
+		{
+			ID:   big.NewInt(10),
+			Type: ledger.AccountHistoryEntryTypeMove,
+			Date: time.Now(),
+			Move: &ledger.AccountMove{
+				TransactionID:     big.NewInt(0),
+				Asset:             "USD",
+				Amount:            big.NewInt(100),
+				EffectiveDate:     time.Now(),
+				PreCommitVolumes:  ledger.Volumes{Input: big.NewInt(0), Output: big.NewInt(0)},
+				PostCommitVolumes: ledger.Volumes{Input: big.NewInt(100), Output: big.NewInt(0)},
+			},
+		},
+		{
+			ID:   big.NewInt(21),
+			Type: ledger.AccountHistoryEntryTypeMetadata,
+			Date: time.Now(),
+			Metadata: &ledger.AccountMetadataRevision{
+				Revision: big.NewInt(2),
+				Metadata: metadata.Metadata{"category": "gold"},
+			},
+		},
+	}
+
+	type testCase struct {
+		name              string
+		lastEventID       string
+		expectBackendCall bool
+		expectAfter       *big.Int
+		expectStatusCode  int
+		expectErrorCode   string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			expectBackendCall: true,
+		},
+		{
+			name:              "resume",
+			lastEventID:       "5",
+			expectBackendCall: true,
+			expectAfter:       big.NewInt(5),
+		},
+		{
+			name:             "invalid last event id",
+			lastEventID:      "last",
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectStatusCode == 0 {
+				testCase.expectStatusCode = http.StatusOK
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				mockLedger.EXPECT().
+					StreamAccountHistory(gomock.Any(), ledgerstore.AccountHistoryFilters{
+						Address: "users:1",
+						Asset:   "USD",
+					}, testCase.expectAfter, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, filters ledgerstore.AccountHistoryFilters, after *big.Int, fn engine.StreamAccountHistoryFn) error {
+						for _, entry := range entries {
+							if err := fn(entry); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodGet, "/xxx/accounts/users:1/history/stream?asset=USD", nil)
+			if testCase.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", testCase.lastEventID)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectStatusCode, rec.Code)
+			if testCase.expectStatusCode < 300 && testCase.expectStatusCode >= 200 {
+				require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+				expected := ""
+				for _, entry := range entries {
+					expected += fmt.Sprintf("id: %s\nevent: %s\ndata: ", entry.ID, entry.Type)
+					data, err := json.Marshal(entry)
+					require.NoError(t, err)
+					expected += string(data) + "\n\n"
+				}
+				require.Equal(t, expected, rec.Body.String())
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
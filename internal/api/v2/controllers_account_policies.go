@@ -0,0 +1,156 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+)
+
+type accountPolicyRequest struct {
+	MinimumBalances         map[string]*big.Int `json:"minimumBalances"`
+	AllowUnboundedOverdraft bool                `json:"allowUnboundedOverdraft"`
+	AllowedAssets           []string            `json:"allowedAssets"`
+	Frozen                  bool                `json:"frozen"`
+}
+
+func getPolicyPattern(r *http.Request) (string, error) {
+	pattern, err := url.PathUnescape(chi.URLParam(r, "pattern"))
+	if err != nil {
+		return "", err
+	}
+	return pattern, ledger.ValidateAccountPolicyPattern(pattern)
+}
+
+func putAccountPolicy(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	pattern, err := getPolicyPattern(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	payload := accountPolicyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid account policy format"))
+		return
+	}
+
+	policy := ledger.NewAccountPolicy(pattern)
+	policy.MinimumBalances = payload.MinimumBalances
+	policy.AllowUnboundedOverdraft = payload.AllowUnboundedOverdraft
+	policy.AllowedAssets = payload.AllowedAssets
+	policy.Frozen = payload.Frozen
+	if err := policy.Validate(); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	if err := l.SaveAccountPolicy(r.Context(), policy); err != nil {
+		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, policy)
+}
+
+func deleteAccountPolicy(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	pattern, err := getPolicyPattern(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	if err := l.DeleteAccountPolicy(r.Context(), pattern); err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.NoContent(w)
+}
+
+func getAccountPolicies(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetAccountPoliciesQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
+			return
+		}
+	} else {
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		query = ledgerstore.NewGetAccountPoliciesQuery(ledgerstore.PaginatedQueryOptions[any]{
+			PageSize: pageSize,
+		})
+	}
+
+	cursor, err := l.GetAccountPolicies(r.Context(), query)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
+
+func getAccountPolicy(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	pattern, err := getPolicyPattern(r)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	policy, err := l.GetAccountPolicy(r.Context(), pattern)
+	if err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, policy)
+}
@@ -0,0 +1,243 @@
+package v2_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPutAccountPolicy(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		pattern            string
+		body               string
+		expectBackendCall  bool
+		expectPolicy       ledger.AccountPolicy
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "nominal",
+			pattern: "users:*:wallet",
+			body:    `{"minimumBalances": {"USD": -100}, "allowedAssets": ["USD"]}`,
+			expectPolicy: ledger.AccountPolicy{
+				Pattern:         "users:*:wallet",
+				MinimumBalances: map[string]*big.Int{"USD": big.NewInt(-100)},
+				AllowedAssets:   []string{"USD"},
+			},
+			expectBackendCall: true,
+		},
+		{
+			name:    "frozen",
+			pattern: "blocked:*",
+			body:    `{"frozen": true}`,
+			expectPolicy: ledger.AccountPolicy{
+				Pattern: "blocked:*",
+				Frozen:  true,
+			},
+			expectBackendCall: true,
+		},
+		{
+			name:               "invalid pattern",
+			pattern:            "users:1!",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "invalid minimum balance",
+			pattern:            "users:*",
+			body:               `{"minimumBalances": {"USD": null}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:    "ledger read-only",
+			pattern: "users:*",
+			body:    `{}`,
+			expectPolicy: ledger.AccountPolicy{
+				Pattern: "users:*",
+			},
+			expectBackendCall:  true,
+			returnErr:          engine.NewCommandError(command.NewErrLedgerReadOnly()),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrLedgerReadOnly,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusOK
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				mockLedger.EXPECT().
+					SaveAccountPolicy(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, policy ledger.AccountPolicy) error {
+						policy.UpdatedAt = testCase.expectPolicy.UpdatedAt
+						require.Equal(t, testCase.expectPolicy, policy)
+						return testCase.returnErr
+					})
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPut, "/xxx/policies/"+url.PathEscape(testCase.pattern), bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				policy, ok := sharedapi.DecodeSingleResponse[ledger.AccountPolicy](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, testCase.expectPolicy.Pattern, policy.Pattern)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestDeleteAccountPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			DeleteAccountPolicy(gomock.Any(), "users:*").
+			Return(nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodDelete, "/xxx/policies/users:*", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			DeleteAccountPolicy(gomock.Any(), "users:*").
+			Return(sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodDelete, "/xxx/policies/users:*", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetAccountPolicies(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[ledger.AccountPolicy]{
+		Data: []ledger.AccountPolicy{
+			ledger.NewAccountPolicy("users:*"),
+		},
+	}
+	mockLedger.EXPECT().
+		GetAccountPolicies(gomock.Any(), ledgerstore.NewGetAccountPoliciesQuery(
+			ledgerstore.NewPaginatedQueryOptions[any](nil),
+		)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/policies", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[ledger.AccountPolicy](t, rec.Body)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, "users:*", cursor.Data[0].Pattern)
+}
+
+func TestGetAccountPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := ledger.NewAccountPolicy("users:*")
+		mockLedger.EXPECT().
+			GetAccountPolicy(gomock.Any(), expected.Pattern).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/policies/users:*", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		policy, ok := sharedapi.DecodeSingleResponse[ledger.AccountPolicy](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.Pattern, policy.Pattern)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetAccountPolicy(gomock.Any(), "unknown").
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/policies/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
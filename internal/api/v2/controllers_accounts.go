@@ -20,6 +20,7 @@ import (
 	"github.com/formancehq/go-libs/metadata"
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine/command"
 	"github.com/formancehq/ledger/internal/storage/ledgerstore"
 	"github.com/pkg/errors"
 )
@@ -136,7 +137,18 @@ func postAccountMetadata(w http.ResponseWriter, r *http.Request) {
 
 	err = l.SaveMeta(r.Context(), getCommandParameters(r), ledger.MetaTargetTypeAccount, chi.URLParam(r, "address"), m)
 	if err != nil {
-		sharedapi.InternalServerError(w, r, err)
+		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		case command.IsErrHoldProtected(err):
+			sharedapi.BadRequest(w, ErrHoldProtected, err)
+		case command.IsErrIdempotencyKeyConflict(err):
+			sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -158,7 +170,18 @@ func deleteAccountMetadata(w http.ResponseWriter, r *http.Request) {
 			param,
 			chi.URLParam(r, "key"),
 		); err != nil {
-		sharedapi.InternalServerError(w, r, err)
+		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		case command.IsErrHoldProtected(err):
+			sharedapi.BadRequest(w, ErrHoldProtected, err)
+		case command.IsErrIdempotencyKeyConflict(err):
+			sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
 		return
 	}
 
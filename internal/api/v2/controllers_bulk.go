@@ -2,15 +2,27 @@ package v2
 
 import (
 	"encoding/json"
+	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
 
 	"github.com/formancehq/go-libs/contextutil"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/pkg/errors"
 
 	sharedapi "github.com/formancehq/go-libs/api"
 	"github.com/formancehq/ledger/internal/api/backend"
 )
 
+const ndjsonContentType = "application/x-ndjson"
+
 func bulkHandler(w http.ResponseWriter, r *http.Request) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == ndjsonContentType {
+		streamBulkHandler(w, r)
+		return
+	}
+
 	b := Bulk{}
 	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
 		sharedapi.BadRequest(w, ErrValidation, err)
@@ -20,7 +32,10 @@ func bulkHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	ctx, _ := contextutil.Detached(r.Context())
-	ret, errorsInBulk, err := ProcessBulk(ctx, backend.LedgerFromContext(r.Context()), b, sharedapi.QueryParamBool(r, "continueOnFailure"))
+	ret, errorsInBulk, err := ProcessBulk(ctx, backend.LedgerFromContext(r.Context()), b,
+		sharedapi.QueryParamBool(r, "continueOnFailure"),
+		sharedapi.QueryParamBool(r, "atomic"),
+	)
 	if err != nil || errorsInBulk {
 		w.WriteHeader(http.StatusBadRequest)
 	}
@@ -31,3 +46,35 @@ func bulkHandler(w http.ResponseWriter, r *http.Request) {
 		panic(err)
 	}
 }
+
+func streamBulkHandler(w http.ResponseWriter, r *http.Request) {
+	if sharedapi.QueryParamBool(r, "atomic") {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("atomic mode is not supported on streamed bulks"))
+		return
+	}
+
+	parallelism := DefaultBulkStreamParallelism
+	if parallelismStr := r.URL.Query().Get("parallelism"); parallelismStr != "" {
+		var err error
+		parallelism, err = strconv.Atoi(parallelismStr)
+		if err != nil || parallelism < 1 || parallelism > MaxBulkStreamParallelism {
+			sharedapi.BadRequest(w, ErrValidation,
+				fmt.Errorf("parallelism must be between 1 and %d", MaxBulkStreamParallelism))
+			return
+		}
+	}
+
+	// Results are written while the request body is still being read
+	rc := http.NewResponseController(w)
+	_ = rc.EnableFullDuplex()
+
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.WriteHeader(http.StatusOK)
+
+	ctx, _ := contextutil.Detached(r.Context())
+	if err := StreamBulk(ctx, backend.LedgerFromContext(r.Context()), r.Body, w, func() {
+		_ = rc.Flush()
+	}, sharedapi.QueryParamBool(r, "continueOnFailure"), parallelism); err != nil {
+		logging.FromContext(r.Context()).Errorf("streaming bulk results: %s", err)
+	}
+}
@@ -2,6 +2,7 @@ package v2_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -301,6 +302,123 @@ func TestBulk(t *testing.T) {
 			}},
 			expectError: true,
 		},
+		{
+			name: "atomic",
+			body: `[
+				{
+					"action": "ADD_METADATA",
+					"data": {
+						"targetId": "world",
+						"targetType": "ACCOUNT",
+						"metadata": {
+							"foo": "bar"
+						}
+					}
+				},
+				{
+					"action": "DELETE_METADATA",
+					"data": {
+						"targetId": "world",
+						"targetType": "ACCOUNT",
+						"key": "foo2"
+					}
+				}
+			]`,
+			queryParams: map[string][]string{
+				"atomic": {"true"},
+			},
+			expectations: func(mockLedger *backend.MockLedger) {
+				mockLedger.EXPECT().
+					ExecuteAtomicBulk(gomock.Any(), []command.BulkElement{{
+						SaveMetadata: &command.SaveMetadataRequest{
+							TargetType: ledger.MetaTargetTypeAccount,
+							TargetID:   "world",
+							Metadata: metadata.Metadata{
+								"foo": "bar",
+							},
+						},
+					}, {
+						DeleteMetadata: &command.DeleteMetadataRequest{
+							TargetType: ledger.MetaTargetTypeAccount,
+							TargetID:   "world",
+							Key:        "foo2",
+						},
+					}}).
+					Return([]command.BulkElementResult{{
+						Log: ledger.NewSetMetadataOnAccountLog(now, "world", metadata.Metadata{"foo": "bar"}).ChainLog(nil),
+					}, {
+						Log: ledger.NewDeleteMetadataLog(now, ledger.DeleteMetadataLogPayload{
+							TargetType: ledger.MetaTargetTypeAccount,
+							TargetID:   "world",
+							Key:        "foo2",
+						}).ChainLog(nil),
+					}}, nil)
+			},
+			expectResults: []v2.Result{{
+				ResponseType: v2.ActionAddMetadata,
+			}, {
+				ResponseType: v2.ActionDeleteMetadata,
+			}},
+		},
+		{
+			name: "atomic with error",
+			body: `[
+				{
+					"action": "ADD_METADATA",
+					"data": {
+						"targetId": "world",
+						"targetType": "ACCOUNT",
+						"metadata": {
+							"foo": "bar"
+						}
+					}
+				},
+				{
+					"action": "ADD_METADATA",
+					"data": {
+						"targetId": "world",
+						"targetType": "ACCOUNT",
+						"metadata": {
+							"foo2": "bar2"
+						}
+					}
+				}
+			]`,
+			queryParams: map[string][]string{
+				"atomic": {"true"},
+			},
+			expectations: func(mockLedger *backend.MockLedger) {
+				mockLedger.EXPECT().
+					ExecuteAtomicBulk(gomock.Any(), []command.BulkElement{{
+						SaveMetadata: &command.SaveMetadataRequest{
+							TargetType: ledger.MetaTargetTypeAccount,
+							TargetID:   "world",
+							Metadata: metadata.Metadata{
+								"foo": "bar",
+							},
+						},
+					}, {
+						SaveMetadata: &command.SaveMetadataRequest{
+							TargetType: ledger.MetaTargetTypeAccount,
+							TargetID:   "world",
+							Metadata: metadata.Metadata{
+								"foo2": "bar2",
+							},
+						},
+					}}).
+					Return([]command.BulkElementResult{{}, {
+						Err: errors.New("unexpected error"),
+					}}, nil)
+			},
+			expectResults: []v2.Result{{
+				ResponseType: v2.ActionAddMetadata,
+			}, {
+				ResponseType:     "ERROR",
+				ErrorCode:        "INTERNAL",
+				ErrorDescription: "unexpected error",
+			}},
+			expectError: true,
+		},
 	}
 	for _, testCase := range testCases {
 		testCase := testCase
@@ -330,3 +448,177 @@ func TestBulk(t *testing.T) {
 		})
 	}
 }
+
+func TestBulkStream(t *testing.T) {
+	t.Parallel()
+
+	type bulkStreamTestCase struct {
+		name             string
+		queryParams      url.Values
+		body             string
+		expectations     func(mockLedger *backend.MockLedger)
+		expectStatusCode int
+		expectResults    []v2.Result
+	}
+
+	testCases := []bulkStreamTestCase{
+		{
+			name: "nominal",
+			body: `{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo": "bar"}}}
+{"action": "DELETE_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "key": "foo"}}
+`,
+			expectations: func(mockLedger *backend.MockLedger) {
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo": "bar",
+					}).
+					Return(nil)
+				mockLedger.EXPECT().
+					DeleteMetadata(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", "foo").
+					Return(nil)
+			},
+			expectResults: []v2.Result{{
+				ResponseType: v2.ActionAddMetadata,
+			}, {
+				ResponseType: v2.ActionDeleteMetadata,
+			}},
+		},
+		{
+			name: "error in the middle",
+			body: `{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo": "bar"}}}
+{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo2": "bar2"}}}
+{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo3": "bar3"}}}
+`,
+			expectations: func(mockLedger *backend.MockLedger) {
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo": "bar",
+					}).
+					Return(nil)
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo2": "bar2",
+					}).
+					Return(errors.New("unexpected error"))
+			},
+			expectResults: []v2.Result{{
+				ResponseType: v2.ActionAddMetadata,
+			}, {
+				ResponseType:     "ERROR",
+				ErrorCode:        "INTERNAL",
+				ErrorDescription: "unexpected error",
+			}},
+		},
+		{
+			name: "error in the middle with continue on failure and parallelism",
+			body: `{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo": "bar"}}}
+{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo2": "bar2"}}}
+{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo3": "bar3"}}}
+`,
+			queryParams: map[string][]string{
+				"continueOnFailure": {"true"},
+				"parallelism":       {"3"},
+			},
+			expectations: func(mockLedger *backend.MockLedger) {
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo": "bar",
+					}).
+					Return(nil)
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo2": "bar2",
+					}).
+					Return(errors.New("unexpected error"))
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo3": "bar3",
+					}).
+					Return(nil)
+			},
+			expectResults: []v2.Result{{
+				ResponseType: v2.ActionAddMetadata,
+			}, {
+				ResponseType:     "ERROR",
+				ErrorCode:        "INTERNAL",
+				ErrorDescription: "unexpected error",
+			}, {
+				ResponseType: v2.ActionAddMetadata,
+			}},
+		},
+		{
+			name: "malformed element",
+			body: `{"action": "ADD_METADATA", "data": {"targetId": "world", "targetType": "ACCOUNT", "metadata": {"foo": "bar"}}}
+{"action": "ADD_METADATA",
+`,
+			expectations: func(mockLedger *backend.MockLedger) {
+				mockLedger.EXPECT().
+					SaveMeta(gomock.Any(), command.Parameters{}, ledger.MetaTargetTypeAccount, "world", metadata.Metadata{
+						"foo": "bar",
+					}).
+					Return(nil)
+			},
+			expectResults: []v2.Result{{
+				ResponseType: v2.ActionAddMetadata,
+			}, {
+				ResponseType:     "ERROR",
+				ErrorCode:        v2.ErrValidation,
+				ErrorDescription: "error parsing element 1: unexpected EOF",
+			}},
+		},
+		{
+			name: "invalid parallelism",
+			queryParams: map[string][]string{
+				"parallelism": {"0"},
+			},
+			expectStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "atomic not supported",
+			queryParams: map[string][]string{
+				"atomic": {"true"},
+			},
+			expectStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			backend, mock := newTestingBackend(t, true)
+			if testCase.expectations != nil {
+				testCase.expectations(mock)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/_bulk", bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/x-ndjson")
+			rec := httptest.NewRecorder()
+			if testCase.queryParams != nil {
+				req.URL.RawQuery = testCase.queryParams.Encode()
+			}
+
+			router.ServeHTTP(rec, req)
+
+			if testCase.expectStatusCode == 0 {
+				testCase.expectStatusCode = http.StatusOK
+			}
+			require.Equal(t, testCase.expectStatusCode, rec.Code)
+			if testCase.expectStatusCode != http.StatusOK {
+				return
+			}
+			require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+			ret := make([]v2.Result, 0)
+			dec := json.NewDecoder(rec.Body)
+			for dec.More() {
+				result := v2.Result{}
+				require.NoError(t, dec.Decode(&result))
+				ret = append(ret, result)
+			}
+			require.Equal(t, testCase.expectResults, ret)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine/command"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+)
+
+type chartRequest struct {
+	Mode     string                `json:"mode"`
+	Accounts []ledger.ChartAccount `json:"accounts"`
+}
+
+func putChart(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	payload := chartRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid chart of accounts format"))
+		return
+	}
+
+	chart := ledger.NewChart(payload.Mode, payload.Accounts...)
+	if err := chart.Validate(); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	if err := l.SaveChart(r.Context(), chart); err != nil {
+		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, chart)
+}
+
+func deleteChart(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	if err := l.DeleteChart(r.Context()); err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.NoContent(w)
+}
+
+func getChart(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	chart, err := l.GetChart(r.Context())
+	if err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, chart)
+}
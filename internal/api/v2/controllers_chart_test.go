@@ -0,0 +1,204 @@
+package v2_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/metadata"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPutChart(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		body               string
+		expectBackendCall  bool
+		expectChart        ledger.Chart
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "nominal",
+			body: `{"mode": "STRICT", "accounts": [{"template": "users:{id}:wallet", "type": "LIABILITY", "allowedAssets": ["USD/2"], "defaultMetadata": {"kind": "wallet"}}]}`,
+			expectChart: ledger.Chart{
+				Mode: ledger.ChartModeStrict,
+				Accounts: []ledger.ChartAccount{{
+					Template:        "users:{id}:wallet",
+					Type:            ledger.AccountTypeLiability,
+					AllowedAssets:   []string{"USD/2"},
+					DefaultMetadata: metadata.Metadata{"kind": "wallet"},
+				}},
+			},
+			expectBackendCall: true,
+		},
+		{
+			name:               "invalid mode",
+			body:               `{"mode": "LAX", "accounts": []}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "invalid type",
+			body:               `{"mode": "WARN", "accounts": [{"template": "bank", "type": "CASH"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name: "ledger read-only",
+			body: `{"mode": "WARN"}`,
+			expectChart: ledger.Chart{
+				Mode: ledger.ChartModeWarn,
+			},
+			expectBackendCall:  true,
+			returnErr:          engine.NewCommandError(command.NewErrLedgerReadOnly()),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrLedgerReadOnly,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusOK
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				mockLedger.EXPECT().
+					SaveChart(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, chart ledger.Chart) error {
+						chart.UpdatedAt = testCase.expectChart.UpdatedAt
+						require.Equal(t, testCase.expectChart, chart)
+						return testCase.returnErr
+					})
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPut, "/xxx/chart", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				chart, ok := sharedapi.DecodeSingleResponse[ledger.Chart](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, testCase.expectChart.Accounts, chart.Accounts)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestDeleteChart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			DeleteChart(gomock.Any()).
+			Return(nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodDelete, "/xxx/chart", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			DeleteChart(gomock.Any()).
+			Return(sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodDelete, "/xxx/chart", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetChart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := ledger.NewChart(ledger.ChartModeWarn, ledger.ChartAccount{
+			Template: "bank",
+			Type:     ledger.AccountTypeAsset,
+		})
+		mockLedger.EXPECT().
+			GetChart(gomock.Any()).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/chart", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		chart, ok := sharedapi.DecodeSingleResponse[ledger.Chart](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.Mode, chart.Mode)
+		require.Equal(t, expected.Accounts, chart.Accounts)
+	})
+
+	t.Run("no chart", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetChart(gomock.Any()).
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/chart", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/ledger/internal/bus"
+)
+
+// getEventSchemas returns the JSON schemas of all the events published by the ledger, by version and type.
+func getEventSchemas(w http.ResponseWriter, r *http.Request) {
+	sharedapi.Ok(w, bus.EventSchemas())
+}
+
+// getEventSchema returns the raw JSON schema of an event, so it can be referenced by consumers.
+func getEventSchema(w http.ResponseWriter, r *http.Request) {
+	schema, err := bus.EventSchema(chi.URLParam(r, "version"), chi.URLParam(r, "type"))
+	if err != nil {
+		sharedapi.NotFound(w, err)
+		return
+	}
+
+	sharedapi.RawOk(w, schema)
+}
@@ -0,0 +1,116 @@
+package v2_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEventSchemas(t *testing.T) {
+	t.Parallel()
+
+	backend, _ := newTestingBackend(t, false)
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/_events/schemas", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	schemas, ok := sharedapi.DecodeSingleResponse[map[string]map[string]any](t, rec.Body)
+	require.True(t, ok)
+	require.Len(t, schemas, len(events.EventVersions))
+	for _, version := range events.EventVersions {
+		require.Len(t, schemas[version], len(events.EventTypes))
+	}
+}
+
+func TestGetEventSchema(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		version          string
+		eventType        string
+		expectStatusCode int
+		expectPayload    []string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "v2",
+			version:       events.EventVersionV2,
+			eventType:     events.EventTypeCommittedTransactions,
+			expectPayload: []string{"ledger", "transactions", "accountMetadata"},
+		},
+		{
+			name:          "v3",
+			version:       events.EventVersionV3,
+			eventType:     events.EventTypeCommittedTransactions,
+			expectPayload: []string{"ledger", "transactions", "accountMetadata", "postCommitVolumes"},
+		},
+		{
+			name:          "unchanged in v3",
+			version:       events.EventVersionV3,
+			eventType:     events.EventTypeSavedMetadata,
+			expectPayload: []string{"ledger", "targetType", "targetId", "metadata"},
+		},
+		{
+			name:             "unknown version",
+			version:          "v1",
+			eventType:        events.EventTypeCommittedTransactions,
+			expectStatusCode: http.StatusNotFound,
+		},
+		{
+			name:             "unknown type",
+			version:          events.EventVersionV2,
+			eventType:        "UNKNOWN",
+			expectStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectStatusCode == 0 {
+				testCase.expectStatusCode = http.StatusOK
+			}
+
+			backend, _ := newTestingBackend(t, false)
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodGet, "/_events/schemas/"+testCase.version+"/"+testCase.eventType, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectStatusCode, rec.Code)
+			if testCase.expectStatusCode == http.StatusOK {
+				schema := struct {
+					Properties struct {
+						Version struct {
+							Const string `json:"const"`
+						} `json:"version"`
+						Payload struct {
+							Required []string `json:"required"`
+						} `json:"payload"`
+					} `json:"properties"`
+				}{}
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&schema))
+				require.Equal(t, testCase.version, schema.Properties.Version.Const)
+				require.Equal(t, testCase.expectPayload, schema.Properties.Payload.Required)
+			}
+		})
+	}
+}
@@ -44,7 +44,12 @@ func getLogs(w http.ResponseWriter, r *http.Request) {
 
 	cursor, err := l.GetLogs(r.Context(), query)
 	if err != nil {
-		sharedapi.InternalServerError(w, r, err)
+		switch {
+		case ledgerstore.IsErrInvalidQuery(err):
+			sharedapi.BadRequest(w, ErrValidation, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
 		return
 	}
 
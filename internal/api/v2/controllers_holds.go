@@ -0,0 +1,191 @@
+package v2
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/contextutil"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/pkg/errors"
+)
+
+type holdRequest struct {
+	ledger.TransactionRequest
+	Destination string `json:"destination"`
+}
+
+type confirmHoldRequest struct {
+	Amount *big.Int `json:"amount"`
+	Final  bool     `json:"final"`
+}
+
+type holdResponse struct {
+	ledger.Hold
+	Transaction *ledger.Transaction `json:"transaction"`
+}
+
+func writeHoldError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case command.IsErrLedgerReadOnly(err):
+		sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+	case command.IsErrNotLeader(err):
+		sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		return
+	case command.IsPeriodError(err, command.ErrPeriodCodeClosed):
+		sharedapi.BadRequest(w, ErrPeriodClosed, err)
+		return
+	case command.IsErrAccountFrozen(err):
+		sharedapi.BadRequest(w, ErrAccountFrozen, err)
+		return
+	case command.IsErrHoldProtected(err):
+		sharedapi.BadRequest(w, ErrHoldProtected, err)
+		return
+	case command.IsErrChartViolation(err):
+		sharedapi.BadRequest(w, ErrChartViolation, err)
+		return
+	case command.IsErrIdempotencyKeyConflict(err):
+		sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
+		return
+	case engine.IsCommandError(err):
+		switch {
+		case command.IsErrMachine(err):
+			switch {
+			case machine.IsInsufficientFundError(err):
+				sharedapi.BadRequest(w, ErrInsufficientFund, err)
+				return
+			case machine.IsAccountPolicyError(err):
+				sharedapi.BadRequest(w, ErrAccountPolicy, err)
+				return
+			case machine.IsMetadataOverride(err):
+				sharedapi.BadRequest(w, ErrMetadataOverride, err)
+				return
+			case machine.IsAssertionFailedError(err):
+				sharedapi.BadRequest(w, ErrAssertionFailed, err)
+				return
+			}
+		case command.IsHoldError(err, command.ErrHoldCodeNotFound):
+			sharedapi.NotFound(w, err)
+			return
+		case command.IsHoldError(err, command.ErrHoldCodeInvalid):
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		case command.IsHoldError(err, command.ErrHoldCodeClosed):
+			sharedapi.BadRequest(w, ErrHoldClosed, err)
+			return
+		case command.IsHoldError(err, command.ErrHoldCodeOccurring):
+			sharedapi.BadRequest(w, ErrHoldOccurring, err)
+			return
+		case command.IsHoldError(err, command.ErrHoldCodeInsufficientFunds):
+			sharedapi.BadRequest(w, ErrInsufficientFund, err)
+			return
+		case command.IsInvalidTransactionError(err, command.ErrInvalidTransactionCodeConflict):
+			sharedapi.BadRequest(w, ErrConflict, err)
+			return
+		case command.IsInvalidTransactionError(err, command.ErrInvalidTransactionCodeNoPostings):
+			sharedapi.BadRequest(w, ErrNoPostings, err)
+			return
+		case command.IsInvalidTransactionError(err, command.ErrInvalidTransactionCodeNoScript):
+			sharedapi.BadRequest(w, ErrNoScript, err)
+			return
+		case command.IsInvalidTransactionError(err, command.ErrInvalidTransactionCodeCompilationFailed):
+			sharedapi.BadRequestWithDetails(w, ErrCompilationFailed, err, backend.EncodeLink(errors.Cause(err).Error()))
+			return
+		}
+	}
+	sharedapi.InternalServerError(w, r, err)
+}
+
+func postHold(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	payload := holdRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid hold format"))
+		return
+	}
+
+	if payload.Destination == "" {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("missing destination"))
+		return
+	}
+
+	if len(payload.Postings) > 0 {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("holds can only be created using numscript"))
+		return
+	}
+
+	ctx, _ := contextutil.Detached(r.Context())
+
+	hold, tx, err := l.CreateHold(ctx, getCommandParameters(r), payload.Destination, *payload.ToRunScript())
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	sharedapi.Created(w, holdResponse{
+		Hold:        *hold,
+		Transaction: tx,
+	})
+}
+
+func confirmHold(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	payload := confirmHoldRequest{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			sharedapi.BadRequest(w, ErrValidation, errors.New("invalid confirmation format"))
+			return
+		}
+	}
+
+	ctx, _ := contextutil.Detached(r.Context())
+
+	hold, tx, err := l.ConfirmHold(ctx, getCommandParameters(r), chi.URLParam(r, "id"), payload.Amount, payload.Final)
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, holdResponse{
+		Hold:        *hold,
+		Transaction: tx,
+	})
+}
+
+func voidHold(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	ctx, _ := contextutil.Detached(r.Context())
+
+	hold, tx, err := l.VoidHold(ctx, getCommandParameters(r), chi.URLParam(r, "id"))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, holdResponse{
+		Hold:        *hold,
+		Transaction: tx,
+	})
+}
+
+func getHold(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	hold, err := l.GetHold(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, hold)
+}
@@ -0,0 +1,315 @@
+package v2_test
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/formancehq/go-libs/auth"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestingHold(state string, remaining int64) ledger.Hold {
+	return ledger.Hold{
+		ID:          "hold1",
+		Account:     ledger.HoldAccount("hold1"),
+		Destination: "merchant",
+		Asset:       "USD",
+		Amount:      big.NewInt(100),
+		Remaining:   big.NewInt(remaining),
+		Sources: []ledger.HoldSource{{
+			Account: "alice",
+			Amount:  big.NewInt(100),
+		}},
+		State: state,
+	}
+}
+
+func TestPostHold(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		body               string
+		expectedRunScript  ledger.RunScript
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "nominal",
+			body: `{
+				"destination": "merchant",
+				"script": {
+					"plain": "vars {\naccount $hold\n}\nsend [USD 100] (source = @alice destination = $hold)"
+				}
+			}`,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: "vars {\naccount $hold\n}\nsend [USD 100] (source = @alice destination = $hold)",
+					Vars:  map[string]string{},
+				},
+			},
+		},
+		{
+			name:               "missing destination",
+			body:               `{"script": {"plain": "fail"}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name: "using postings",
+			body: `{
+				"destination": "merchant",
+				"postings": [{"source": "world", "destination": "bank", "asset": "USD", "amount": 100}]
+			}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name: "nothing held",
+			body: `{
+				"destination": "merchant",
+				"script": {
+					"plain": "vars {\naccount $hold\n}\nsend [USD 100] (source = @alice destination = @bob)"
+				}
+			}`,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: "vars {\naccount $hold\n}\nsend [USD 100] (source = @alice destination = @bob)",
+					Vars:  map[string]string{},
+				},
+			},
+			returnErr:          engine.NewCommandError(command.NewErrInvalidHold(ledger.ErrHoldNoFunds)),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name: "insufficient funds",
+			body: `{
+				"destination": "merchant",
+				"script": {
+					"plain": "vars {\naccount $hold\n}\nsend [USD 100] (source = @alice destination = $hold)"
+				}
+			}`,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: "vars {\naccount $hold\n}\nsend [USD 100] (source = @alice destination = $hold)",
+					Vars:  map[string]string{},
+				},
+			},
+			returnErr:          engine.NewCommandError(command.NewErrMachine(&machine.ErrInsufficientFund{})),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrInsufficientFund,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusCreated
+			}
+
+			hold := newTestingHold(ledger.HoldStatePending, 100)
+			tx := ledger.NewTransaction().WithPostings(
+				ledger.NewPosting("alice", hold.Account, "USD", big.NewInt(100)),
+			)
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectedRunScript.Plain != "" {
+				expect := mockLedger.EXPECT().
+					CreateHold(gomock.Any(), command.Parameters{}, "merchant", testCase.expectedRunScript)
+				if testCase.returnErr != nil {
+					expect.Return(nil, nil, testCase.returnErr)
+				} else {
+					expect.Return(&hold, tx, nil)
+				}
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/holds", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				ret, ok := sharedapi.DecodeSingleResponse[ledger.Hold](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, hold, ret)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestConfirmHold(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		body               string
+		expectedAmount     *big.Int
+		expectedFinal      bool
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "full amount",
+		},
+		{
+			name:           "partial amount",
+			body:           `{"amount": 30}`,
+			expectedAmount: big.NewInt(30),
+		},
+		{
+			name:           "partial final amount",
+			body:           `{"amount": 30, "final": true}`,
+			expectedAmount: big.NewInt(30),
+			expectedFinal:  true,
+		},
+		{
+			name:               "invalid body",
+			body:               `[]`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "hold closed",
+			returnErr:          engine.NewCommandError(command.NewErrHoldClosed()),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrHoldClosed,
+		},
+		{
+			name:               "amount exceeding the hold",
+			body:               `{"amount": 300}`,
+			expectedAmount:     big.NewInt(300),
+			returnErr:          engine.NewCommandError(command.NewErrHoldInsufficientFunds()),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrInsufficientFund,
+		},
+		{
+			name:               "hold not found",
+			returnErr:          engine.NewCommandError(command.NewErrHoldNotFound()),
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  sharedapi.ErrorCodeNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusOK
+			}
+
+			hold := newTestingHold(ledger.HoldStateConfirmed, 0)
+			tx := ledger.NewTransaction().WithPostings(
+				ledger.NewPosting(hold.Account, "merchant", "USD", big.NewInt(100)),
+			)
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectedErrorCode != v2.ErrValidation {
+				expect := mockLedger.EXPECT().
+					ConfirmHold(gomock.Any(), command.Parameters{}, "hold1", testCase.expectedAmount, testCase.expectedFinal)
+				if testCase.returnErr != nil {
+					expect.Return(nil, nil, testCase.returnErr)
+				} else {
+					expect.Return(&hold, tx, nil)
+				}
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/holds/hold1/confirm", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				ret, ok := sharedapi.DecodeSingleResponse[ledger.Hold](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, hold, ret)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestVoidHold(t *testing.T) {
+	t.Parallel()
+
+	hold := newTestingHold(ledger.HoldStateVoided, 0)
+	tx := ledger.NewTransaction().WithPostings(
+		ledger.NewPosting(hold.Account, "alice", "USD", big.NewInt(100)),
+	)
+
+	backend, mockLedger := newTestingBackend(t, true)
+	mockLedger.EXPECT().
+		VoidHold(gomock.Any(), command.Parameters{}, "hold1").
+		Return(&hold, tx, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodPost, "/xxx/holds/hold1/void", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	ret, ok := sharedapi.DecodeSingleResponse[ledger.Hold](t, rec.Body)
+	require.True(t, ok)
+	require.Equal(t, hold, ret)
+}
+
+func TestGetHold(t *testing.T) {
+	t.Parallel()
+
+	hold := newTestingHold(ledger.HoldStatePending, 100)
+
+	backend, mockLedger := newTestingBackend(t, true)
+	mockLedger.EXPECT().
+		GetHold(gomock.Any(), "hold1").
+		Return(&hold, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/holds/hold1", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	ret, ok := sharedapi.DecodeSingleResponse[ledger.Hold](t, rec.Body)
+	require.True(t, ok)
+	require.Equal(t, hold, ret)
+}
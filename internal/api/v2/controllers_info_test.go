@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -200,3 +201,36 @@ func TestGetLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyLogs(t *testing.T) {
+	t.Parallel()
+
+	backend, mock := newTestingBackend(t, true)
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	expectedReport := engine.VerifyReport{
+		CheckedLogs: 10,
+		LastLogID:   big.NewInt(9),
+		BrokenLink: &engine.BrokenLink{
+			ID:       big.NewInt(10),
+			Expected: []byte("expected"),
+			Stored:   []byte("stored"),
+		},
+	}
+
+	mock.EXPECT().
+		Verify(gomock.Any(), gomock.Any()).
+		Return(&expectedReport, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/logs/_verify", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	report, ok := sharedapi.DecodeSingleResponse[engine.VerifyReport](t, rec.Body)
+	require.True(t, ok)
+
+	require.EqualValues(t, expectedReport, report)
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/formancehq/go-libs/bun/bunpaginate"
 
 	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
 	"github.com/formancehq/ledger/internal/storage/systemstore"
 
 	"github.com/formancehq/ledger/internal/api/backend"
@@ -73,3 +75,48 @@ func deleteLedgerMetadata(b backend.Backend) http.HandlerFunc {
 		sharedapi.NoContent(w)
 	}
 }
+
+type ledgerStateRequest struct {
+	State string `json:"state"`
+}
+
+func updateLedgerState(b backend.Backend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		req := ledgerStateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			sharedapi.BadRequest(w, ErrValidation, errors.New("invalid format"))
+			return
+		}
+
+		if err := b.UpdateLedgerState(r.Context(), chi.URLParam(r, "ledger"), req.State); err != nil {
+			switch {
+			case errors.Is(err, engine.ErrInvalidLedgerState):
+				sharedapi.BadRequest(w, ErrValidation, err)
+			case sqlutils.IsNotFoundError(err):
+				sharedapi.NotFound(w, err)
+			default:
+				sharedapi.InternalServerError(w, r, err)
+			}
+			return
+		}
+
+		sharedapi.NoContent(w)
+	}
+}
+
+func deleteLedger(b backend.Backend) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := b.DeleteLedger(r.Context(), chi.URLParam(r, "ledger"), sharedapi.QueryParamBool(r, "purge")); err != nil {
+			switch {
+			case sqlutils.IsNotFoundError(err):
+				sharedapi.NotFound(w, err)
+			default:
+				sharedapi.InternalServerError(w, r, err)
+			}
+			return
+		}
+
+		sharedapi.NoContent(w)
+	}
+}
@@ -3,6 +3,7 @@ package v2_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	sharedapi "github.com/formancehq/go-libs/api"
@@ -10,6 +11,8 @@ import (
 	"github.com/formancehq/go-libs/logging"
 	v2 "github.com/formancehq/ledger/internal/api/v2"
 	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -57,3 +60,78 @@ func TestDeleteLedgerMetadata(t *testing.T) {
 
 	require.Equal(t, http.StatusNoContent, rec.Code)
 }
+
+func TestUpdateLedgerState(t *testing.T) {
+	ctx := logging.TestingContext()
+
+	name := uuid.NewString()
+	backend, _ := newTestingBackend(t, false)
+	backend.EXPECT().
+		UpdateLedgerState(gomock.Any(), name, systemstore.StateReadOnly).
+		Return(nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodPut, "/"+name+"/state", sharedapi.Buffer(t, map[string]string{
+		"state": systemstore.StateReadOnly,
+	}))
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestDeleteLedger(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		queryParams      url.Values
+		expectPurge      bool
+		returnErr        error
+		expectStatusCode int
+	}
+	testCases := []testCase{
+		{
+			name:             "nominal",
+			expectStatusCode: http.StatusNoContent,
+		},
+		{
+			name:             "with purge",
+			queryParams:      url.Values{"purge": []string{"true"}},
+			expectPurge:      true,
+			expectStatusCode: http.StatusNoContent,
+		},
+		{
+			name:             "not found",
+			returnErr:        sqlutils.ErrNotFound,
+			expectStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			name := uuid.NewString()
+			backend, _ := newTestingBackend(t, false)
+			backend.EXPECT().
+				DeleteLedger(gomock.Any(), name, tc.expectPurge).
+				Return(tc.returnErr)
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodDelete, "/"+name, nil)
+			req = req.WithContext(logging.TestingContext())
+			req.URL.RawQuery = tc.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+)
+
+type closePeriodRequest struct {
+	ClosingDate  time.Time `json:"closingDate"`
+	WithBalances bool      `json:"withBalances"`
+}
+
+func closePeriod(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	payload := closePeriodRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid period format"))
+		return
+	}
+
+	if payload.ClosingDate.IsZero() {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("missing closing date"))
+		return
+	}
+
+	period, err := l.ClosePeriod(r.Context(), payload.ClosingDate, payload.WithBalances)
+	if err != nil {
+		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		case command.IsPeriodError(err, command.ErrPeriodCodeInvalidClosingDate):
+			sharedapi.BadRequest(w, ErrValidation, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Created(w, period)
+}
+
+func getClosedPeriods(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetClosedPeriodsQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
+			return
+		}
+	} else {
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		query = ledgerstore.NewGetClosedPeriodsQuery(ledgerstore.PaginatedQueryOptions[any]{
+			PageSize: pageSize,
+		})
+	}
+
+	cursor, err := l.GetClosedPeriods(r.Context(), query)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
+
+func getClosedPeriod(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	period, err := l.GetClosedPeriod(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, period)
+}
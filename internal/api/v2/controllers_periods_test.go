@@ -0,0 +1,199 @@
+package v2_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/engine"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClosePeriod(t *testing.T) {
+	t.Parallel()
+
+	closingDate := time.Now().Add(-time.Hour).Round(time.Second)
+
+	type testCase struct {
+		name               string
+		body               string
+		expectWithBalances bool
+		expectBackendCall  bool
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "nominal",
+			body:              `{"closingDate": "` + closingDate.Format(time.RFC3339Nano) + `"}`,
+			expectBackendCall: true,
+		},
+		{
+			name:               "with balances",
+			body:               `{"closingDate": "` + closingDate.Format(time.RFC3339Nano) + `", "withBalances": true}`,
+			expectWithBalances: true,
+			expectBackendCall:  true,
+		},
+		{
+			name:               "missing closing date",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "invalid closing date",
+			body:               `{"closingDate": "` + closingDate.Format(time.RFC3339Nano) + `"}`,
+			expectBackendCall:  true,
+			returnErr:          engine.NewCommandError(command.NewErrInvalidClosingDate(errors.New("closing date is in the future"))),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "ledger read-only",
+			body:               `{"closingDate": "` + closingDate.Format(time.RFC3339Nano) + `"}`,
+			expectBackendCall:  true,
+			returnErr:          engine.NewCommandError(command.NewErrLedgerReadOnly()),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrLedgerReadOnly,
+		},
+		{
+			name:               "unexpected error",
+			body:               `{"closingDate": "` + closingDate.Format(time.RFC3339Nano) + `"}`,
+			expectBackendCall:  true,
+			returnErr:          errors.New("unexpected error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorCode:  sharedapi.ErrorInternal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusCreated
+			}
+
+			expected := ledger.NewClosedPeriod(closingDate)
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectBackendCall {
+				call := mockLedger.EXPECT().
+					ClosePeriod(gomock.Any(), closingDate, testCase.expectWithBalances)
+				if testCase.returnErr != nil {
+					call.Return(nil, testCase.returnErr)
+				} else {
+					call.Return(&expected, nil)
+				}
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/periods/close", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				period, ok := sharedapi.DecodeSingleResponse[ledger.ClosedPeriod](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, expected.ID, period.ID)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestGetClosedPeriods(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[ledger.ClosedPeriod]{
+		Data: []ledger.ClosedPeriod{
+			ledger.NewClosedPeriod(time.Now()),
+		},
+	}
+	mockLedger.EXPECT().
+		GetClosedPeriods(gomock.Any(), ledgerstore.NewGetClosedPeriodsQuery(
+			ledgerstore.NewPaginatedQueryOptions[any](nil),
+		)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/periods", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[ledger.ClosedPeriod](t, rec.Body)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, expectedCursor.Data[0].ID, cursor.Data[0].ID)
+}
+
+func TestGetClosedPeriod(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := ledger.NewClosedPeriod(time.Now())
+		mockLedger.EXPECT().
+			GetClosedPeriod(gomock.Any(), expected.ID).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/periods/"+expected.ID, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		period, ok := sharedapi.DecodeSingleResponse[ledger.ClosedPeriod](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.ID, period.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetClosedPeriod(gomock.Any(), "unknown").
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/periods/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
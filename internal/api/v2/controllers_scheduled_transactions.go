@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/backend"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+)
+
+type scheduledTransactionRequest struct {
+	ledger.TransactionRequest
+	ExecuteAt time.Time `json:"executeAt"`
+}
+
+func postScheduledTransaction(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	payload := scheduledTransactionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid scheduled transaction format"))
+		return
+	}
+
+	if payload.ExecuteAt.IsZero() {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("missing execution date"))
+		return
+	}
+
+	if len(payload.Postings) > 0 && payload.Script.Plain != "" {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("cannot pass postings and numscript in the same request"))
+		return
+	}
+
+	if len(payload.Postings) == 0 && payload.Script.Plain == "" {
+		sharedapi.BadRequest(w, ErrNoScript, errors.New("either postings or numscript must be provided"))
+		return
+	}
+
+	scheduled, err := l.CreateScheduledTransaction(r.Context(), *payload.ToRunScript(), payload.ExecuteAt)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.Created(w, scheduled)
+}
+
+func getScheduledTransactions(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query := ledgerstore.GetScheduledTransactionsQuery{}
+
+	if r.URL.Query().Get(QueryKeyCursor) != "" {
+		err := bunpaginate.UnmarshalCursor(r.URL.Query().Get(QueryKeyCursor), &query)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, fmt.Errorf("invalid '%s' query param", QueryKeyCursor))
+			return
+		}
+	} else {
+		var err error
+
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		qb, err := getQueryBuilder(r)
+		if err != nil {
+			sharedapi.BadRequest(w, ErrValidation, err)
+			return
+		}
+
+		query = ledgerstore.NewGetScheduledTransactionsQuery(ledgerstore.PaginatedQueryOptions[any]{
+			QueryBuilder: qb,
+			PageSize:     pageSize,
+		})
+	}
+
+	cursor, err := l.GetScheduledTransactions(r.Context(), query)
+	if err != nil {
+		switch {
+		case ledgerstore.IsErrInvalidQuery(err):
+			sharedapi.BadRequest(w, ErrValidation, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
+
+func getScheduledTransaction(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	scheduled, err := l.GetScheduledTransaction(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case storageerrors.IsNotFoundError(err):
+			sharedapi.NotFound(w, err)
+		default:
+			sharedapi.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	sharedapi.Ok(w, scheduled)
+}
@@ -0,0 +1,190 @@
+package v2_test
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	ledger "github.com/formancehq/ledger/internal"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostScheduledTransaction(t *testing.T) {
+	t.Parallel()
+
+	executeAt := time.Now().Add(time.Hour).Round(time.Second)
+
+	type testCase struct {
+		name               string
+		body               string
+		expectedRunScript  ledger.RunScript
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "using plain numscript",
+			body: `{
+				"executeAt": "` + executeAt.Format(time.RFC3339Nano) + `",
+				"script": {
+					"plain": "send [USD 100] (source = @world destination = @bank)"
+				},
+				"reference": "invoice-1"
+			}`,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: "send [USD 100] (source = @world destination = @bank)",
+					Vars:  map[string]string{},
+				},
+				Reference: "invoice-1",
+			},
+		},
+		{
+			name:               "missing execution date",
+			body:               `{"script": {"plain": "fail"}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "missing script",
+			body:               `{"executeAt": "` + executeAt.Format(time.RFC3339Nano) + `"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrNoScript,
+		},
+		{
+			name:               "invalid body",
+			body:               `[]`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusCreated
+			}
+
+			expected := ledger.NewScheduledTransaction(testCase.expectedRunScript, executeAt)
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				mockLedger.EXPECT().
+					CreateScheduledTransaction(gomock.Any(), testCase.expectedRunScript, executeAt).
+					Return(&expected, nil)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/scheduled-transactions", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				scheduled, ok := sharedapi.DecodeSingleResponse[ledger.ScheduledTransaction](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, expected.ID, scheduled.ID)
+				require.Equal(t, ledger.ScheduledTransactionStatePending, scheduled.State)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestGetScheduledTransactions(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[ledger.ScheduledTransaction]{
+		Data: []ledger.ScheduledTransaction{
+			ledger.NewScheduledTransaction(ledger.RunScript{}, time.Now()).
+				WithSuccess(time.Now(), big.NewInt(0)),
+		},
+	}
+	mockLedger.EXPECT().
+		GetScheduledTransactions(gomock.Any(), ledgerstore.NewGetScheduledTransactionsQuery(
+			ledgerstore.NewPaginatedQueryOptions[any](nil),
+		)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/scheduled-transactions", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[ledger.ScheduledTransaction](t, rec.Body)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, expectedCursor.Data[0].ID, cursor.Data[0].ID)
+	require.Equal(t, ledger.ScheduledTransactionStateSucceeded, cursor.Data[0].State)
+	require.Equal(t, big.NewInt(0), cursor.Data[0].TransactionID)
+}
+
+func TestGetScheduledTransaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := ledger.NewScheduledTransaction(ledger.RunScript{}, time.Now())
+		mockLedger.EXPECT().
+			GetScheduledTransaction(gomock.Any(), expected.ID).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/scheduled-transactions/"+expected.ID, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		scheduled, ok := sharedapi.DecodeSingleResponse[ledger.ScheduledTransaction](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.ID, scheduled.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetScheduledTransaction(gomock.Any(), "unknown").
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/scheduled-transactions/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -107,6 +107,26 @@ func postTransaction(w http.ResponseWriter, r *http.Request) {
 	res, err := l.CreateTransaction(ctx, getCommandParameters(r), *payload.ToRunScript())
 	if err != nil {
 		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+			return
+		case command.IsPeriodError(err, command.ErrPeriodCodeClosed):
+			sharedapi.BadRequest(w, ErrPeriodClosed, err)
+			return
+		case command.IsErrAccountFrozen(err):
+			sharedapi.BadRequest(w, ErrAccountFrozen, err)
+			return
+		case command.IsErrHoldProtected(err):
+			sharedapi.BadRequest(w, ErrHoldProtected, err)
+			return
+		case command.IsErrChartViolation(err):
+			sharedapi.BadRequest(w, ErrChartViolation, err)
+			return
+		case command.IsErrIdempotencyKeyConflict(err):
+			sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
+			return
 		case engine.IsCommandError(err):
 			switch {
 			case command.IsErrMachine(err):
@@ -114,9 +134,15 @@ func postTransaction(w http.ResponseWriter, r *http.Request) {
 				case machine.IsInsufficientFundError(err):
 					sharedapi.BadRequest(w, ErrInsufficientFund, err)
 					return
+				case machine.IsAccountPolicyError(err):
+					sharedapi.BadRequest(w, ErrAccountPolicy, err)
+					return
 				case machine.IsMetadataOverride(err):
 					sharedapi.BadRequest(w, ErrMetadataOverride, err)
 					return
+				case machine.IsAssertionFailedError(err):
+					sharedapi.BadRequest(w, ErrAssertionFailed, err)
+					return
 				}
 			case command.IsInvalidTransactionError(err, command.ErrInvalidTransactionCodeConflict):
 				sharedapi.BadRequest(w, ErrConflict, err)
@@ -192,6 +218,26 @@ func revertTransaction(w http.ResponseWriter, r *http.Request) {
 	)
 	if err != nil {
 		switch {
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+			return
+		case command.IsPeriodError(err, command.ErrPeriodCodeClosed):
+			sharedapi.BadRequest(w, ErrPeriodClosed, err)
+			return
+		case command.IsErrAccountFrozen(err):
+			sharedapi.BadRequest(w, ErrAccountFrozen, err)
+			return
+		case command.IsErrHoldProtected(err):
+			sharedapi.BadRequest(w, ErrHoldProtected, err)
+			return
+		case command.IsErrChartViolation(err):
+			sharedapi.BadRequest(w, ErrChartViolation, err)
+			return
+		case command.IsErrIdempotencyKeyConflict(err):
+			sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
+			return
 		case engine.IsCommandError(err):
 			switch {
 			case command.IsErrMachine(err):
@@ -199,6 +245,9 @@ func revertTransaction(w http.ResponseWriter, r *http.Request) {
 				case machine.IsInsufficientFundError(err):
 					sharedapi.BadRequest(w, ErrInsufficientFund, err)
 					return
+				case machine.IsAccountPolicyError(err):
+					sharedapi.BadRequest(w, ErrAccountPolicy, err)
+					return
 				}
 			case command.IsRevertError(err, command.ErrRevertTransactionCodeNotFound):
 				sharedapi.NotFound(w, err)
@@ -237,6 +286,14 @@ func postTransactionMetadata(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case command.IsSaveMetaError(err, command.ErrSaveMetaCodeTransactionNotFound):
 			sharedapi.NotFound(w, err)
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		case command.IsErrHoldProtected(err):
+			sharedapi.BadRequest(w, ErrHoldProtected, err)
+		case command.IsErrIdempotencyKeyConflict(err):
+			sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
 		default:
 			sharedapi.InternalServerError(w, r, err)
 		}
@@ -261,6 +318,14 @@ func deleteTransactionMetadata(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case command.IsSaveMetaError(err, command.ErrSaveMetaCodeTransactionNotFound):
 			sharedapi.NotFound(w, err)
+		case command.IsErrLedgerReadOnly(err):
+			sharedapi.BadRequest(w, ErrLedgerReadOnly, err)
+		case command.IsErrNotLeader(err):
+			sharedapi.WriteErrorResponse(w, http.StatusServiceUnavailable, ErrNotLeader, err)
+		case command.IsErrHoldProtected(err):
+			sharedapi.BadRequest(w, ErrHoldProtected, err)
+		case command.IsErrIdempotencyKeyConflict(err):
+			sharedapi.WriteErrorResponse(w, http.StatusConflict, ErrIdempotencyKeyConflict, err)
 		default:
 			sharedapi.InternalServerError(w, r, err)
 		}
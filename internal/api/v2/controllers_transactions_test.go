@@ -381,6 +381,138 @@ func TestPostTransactions(t *testing.T) {
 				command.NewErrConflict(),
 			),
 		},
+		{
+			name:             "idempotency key conflict",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  v2.ErrIdempotencyKeyConflict,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrIdempotencyKeyConflict("ik"),
+			),
+		},
+		{
+			name:             "ledger read-only",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrLedgerReadOnly,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrLedgerReadOnly(),
+			),
+		},
+		{
+			name:             "period closed",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrPeriodClosed,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrPeriodClosed(time.Now().Add(-time.Hour), time.Now()),
+			),
+		},
+		{
+			name:             "account policy violation",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrAccountPolicy,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrMachine(machine.NewErrAccountPolicy("account users:1 is frozen by policy users:*")),
+			),
+		},
+		{
+			name:             "account frozen",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrAccountFrozen,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrAccountFrozen("users:1", ledger.FreezeDirectionDebit),
+			),
+		},
+		{
+			name:             "chart of accounts violation",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrChartViolation,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrChartViolation(errors.New("account users:1:walet is not declared in the chart of accounts")),
+			),
+		},
 		{
 			name:             "numscript and metadata override",
 			expectEngineCall: true,
@@ -419,6 +551,28 @@ func TestPostTransactions(t *testing.T) {
 				command.NewErrMachine(&machine.ErrMetadataOverride{}),
 			),
 		},
+		{
+			name:             "numscript and failed assertion",
+			expectEngineCall: true,
+			payload: ledger.TransactionRequest{
+				Script: ledger.ScriptV1{
+					Script: ledger.Script{
+						Plain: `assert balance(@bob, COIN) > [COIN 100]`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrAssertionFailed,
+			expectedRunScript: ledger.RunScript{
+				Script: ledger.Script{
+					Plain: `assert balance(@bob, COIN) > [COIN 100]`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: engine.NewCommandError(
+				command.NewErrMachine(&machine.ErrAssertionFailed{}),
+			),
+		},
 	}
 
 	for _, testCase := range testCases {
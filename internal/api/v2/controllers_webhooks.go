@@ -0,0 +1,194 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/pointer"
+	"github.com/formancehq/ledger/internal/api/backend"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/pkg/errors"
+)
+
+type webhookRequest struct {
+	Endpoint   string   `json:"endpoint"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+	Active     *bool    `json:"active"`
+}
+
+// createdWebhook exposes the signing secret, which is only returned on creation.
+type createdWebhook struct {
+	systemstore.Webhook
+	Secret string `json:"secret"`
+}
+
+func (req webhookRequest) toWebhook(id string) (*systemstore.Webhook, error) {
+	endpoint, err := url.Parse(req.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, errors.New("endpoint must be an absolute http or https url")
+	}
+
+	for _, eventType := range req.EventTypes {
+		if !slices.Contains(events.EventTypes, eventType) {
+			return nil, fmt.Errorf("unknown event type '%s'", eventType)
+		}
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &systemstore.Webhook{
+		ID:         id,
+		Endpoint:   req.Endpoint,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Active:     active,
+	}, nil
+}
+
+func readWebhook(w http.ResponseWriter, r *http.Request, id string) *systemstore.Webhook {
+	req := webhookRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedapi.BadRequest(w, ErrValidation, errors.New("invalid webhook format"))
+		return nil
+	}
+
+	webhook, err := req.toWebhook(id)
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return nil
+	}
+
+	return webhook
+}
+
+func writeWebhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case storageerrors.IsNotFoundError(err):
+		sharedapi.NotFound(w, err)
+	default:
+		sharedapi.InternalServerError(w, r, err)
+	}
+}
+
+func postWebhook(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	webhook := readWebhook(w, r, "")
+	if webhook == nil {
+		return
+	}
+
+	webhook, err := l.CreateWebhook(r.Context(), *webhook)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.Created(w, createdWebhook{
+		Webhook: *webhook,
+		Secret:  webhook.Secret,
+	})
+}
+
+func putWebhook(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	webhook := readWebhook(w, r, chi.URLParam(r, "id"))
+	if webhook == nil {
+		return
+	}
+
+	webhook, err := l.UpdateWebhook(r.Context(), *webhook)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, webhook)
+}
+
+func deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	if err := l.DeleteWebhook(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	sharedapi.NoContent(w)
+}
+
+func getWebhook(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	webhook, err := l.GetWebhook(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	sharedapi.Ok(w, webhook)
+}
+
+func getWebhooks(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query, err := bunpaginate.Extract[systemstore.ListWebhooksQuery](r, func() (*systemstore.ListWebhooksQuery, error) {
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return pointer.For(systemstore.NewListWebhooksQuery(pageSize)), nil
+	})
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	cursor, err := l.ListWebhooks(r.Context(), *query)
+	if err != nil {
+		sharedapi.InternalServerError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
+
+func getWebhookAttempts(w http.ResponseWriter, r *http.Request) {
+	l := backend.LedgerFromContext(r.Context())
+
+	query, err := bunpaginate.Extract[systemstore.ListWebhookAttemptsQuery](r, func() (*systemstore.ListWebhookAttemptsQuery, error) {
+		pageSize, err := bunpaginate.GetPageSize(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return pointer.For(systemstore.NewListWebhookAttemptsQuery(pageSize)), nil
+	})
+	if err != nil {
+		sharedapi.BadRequest(w, ErrValidation, err)
+		return
+	}
+
+	cursor, err := l.ListWebhookAttempts(r.Context(), chi.URLParam(r, "id"), *query)
+	if err != nil {
+		writeWebhookError(w, r, err)
+		return
+	}
+
+	sharedapi.RenderCursor(w, *cursor)
+}
@@ -0,0 +1,293 @@
+package v2_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/formancehq/go-libs/auth"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+
+	sharedapi "github.com/formancehq/go-libs/api"
+	v2 "github.com/formancehq/ledger/internal/api/v2"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostWebhook(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		body               string
+		expectedWebhook    systemstore.Webhook
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "nominal",
+			body: `{
+				"endpoint": "https://example.com/hooks",
+				"eventTypes": ["COMMITTED_TRANSACTIONS"]
+			}`,
+			expectedWebhook: systemstore.Webhook{
+				Endpoint:   "https://example.com/hooks",
+				EventTypes: []string{events.EventTypeCommittedTransactions},
+				Active:     true,
+			},
+		},
+		{
+			name: "inactive with secret",
+			body: `{
+				"endpoint": "http://localhost:8080",
+				"secret": "secret",
+				"active": false
+			}`,
+			expectedWebhook: systemstore.Webhook{
+				Endpoint: "http://localhost:8080",
+				Secret:   "secret",
+			},
+		},
+		{
+			name:               "invalid endpoint",
+			body:               `{"endpoint": "/hooks"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "unknown event type",
+			body:               `{"endpoint": "https://example.com/hooks", "eventTypes": ["UNKNOWN"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+		{
+			name:               "invalid body",
+			body:               `[]`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  v2.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.expectedStatusCode == 0 {
+				testCase.expectedStatusCode = http.StatusCreated
+			}
+
+			backend, mockLedger := newTestingBackend(t, true)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				created := testCase.expectedWebhook
+				created.ID = "webhook0"
+				mockLedger.EXPECT().
+					CreateWebhook(gomock.Any(), testCase.expectedWebhook).
+					Return(&created, nil)
+			}
+
+			router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/webhooks", bytes.NewBufferString(testCase.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.expectedStatusCode, rec.Code)
+			if testCase.expectedStatusCode < 300 && testCase.expectedStatusCode >= 200 {
+				require.Contains(t, rec.Body.String(), `"secret":"`+testCase.expectedWebhook.Secret+`"`)
+				webhook, ok := sharedapi.DecodeSingleResponse[systemstore.Webhook](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, "webhook0", webhook.ID)
+				require.Equal(t, testCase.expectedWebhook.Endpoint, webhook.Endpoint)
+			} else {
+				err := sharedapi.ErrorResponse{}
+				sharedapi.Decode(t, rec.Body, &err)
+				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestPutWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := systemstore.Webhook{
+			ID:       "webhook0",
+			Endpoint: "https://example.com/hooks",
+			Active:   true,
+		}
+		mockLedger.EXPECT().
+			UpdateWebhook(gomock.Any(), expected).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodPut, "/xxx/webhooks/webhook0",
+			bytes.NewBufferString(`{"endpoint": "https://example.com/hooks"}`))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			UpdateWebhook(gomock.Any(), gomock.Any()).
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodPut, "/xxx/webhooks/unknown",
+			bytes.NewBufferString(`{"endpoint": "https://example.com/hooks"}`))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nominal", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+
+		expected := systemstore.Webhook{
+			ID:       "webhook0",
+			Endpoint: "https://example.com/hooks",
+			Secret:   "secret",
+		}
+		mockLedger.EXPECT().
+			GetWebhook(gomock.Any(), expected.ID).
+			Return(&expected, nil)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/webhooks/webhook0", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NotContains(t, rec.Body.String(), "secret")
+		webhook, ok := sharedapi.DecodeSingleResponse[systemstore.Webhook](t, rec.Body)
+		require.True(t, ok)
+		require.Equal(t, expected.ID, webhook.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		backend, mockLedger := newTestingBackend(t, true)
+		mockLedger.EXPECT().
+			GetWebhook(gomock.Any(), "unknown").
+			Return(nil, sqlutils.ErrNotFound)
+
+		router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+		req := httptest.NewRequest(http.MethodGet, "/xxx/webhooks/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetWebhooks(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[systemstore.Webhook]{
+		Data: []systemstore.Webhook{{
+			ID:       "webhook0",
+			Endpoint: "https://example.com/hooks",
+		}},
+	}
+	mockLedger.EXPECT().
+		ListWebhooks(gomock.Any(), systemstore.NewListWebhooksQuery(bunpaginate.QueryDefaultPageSize)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/webhooks", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[systemstore.Webhook](t, rec.Body)
+	require.Equal(t, expectedCursor.Data, cursor.Data)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+	mockLedger.EXPECT().
+		DeleteWebhook(gomock.Any(), "webhook0").
+		Return(nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodDelete, "/xxx/webhooks/webhook0", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestGetWebhookAttempts(t *testing.T) {
+	t.Parallel()
+
+	backend, mockLedger := newTestingBackend(t, true)
+
+	expectedCursor := bunpaginate.Cursor[systemstore.WebhookAttempt]{
+		Data: []systemstore.WebhookAttempt{{
+			ID:        "attempt0",
+			WebhookID: "webhook0",
+			EventType: events.EventTypeCommittedTransactions,
+			Payload:   []byte(`{"type": "COMMITTED_TRANSACTIONS"}`),
+			Status:    systemstore.WebhookAttemptStatusPending,
+			Attempts:  1,
+		}},
+	}
+	mockLedger.EXPECT().
+		ListWebhookAttempts(gomock.Any(), "webhook0", systemstore.NewListWebhookAttemptsQuery(bunpaginate.QueryDefaultPageSize)).
+		Return(&expectedCursor, nil)
+
+	router := v2.NewRouter(backend, nil, metrics.NewNoOpRegistry(), auth.NewNoAuth(), testing.Verbose())
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/webhooks/webhook0/attempts", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := sharedapi.DecodeCursorResponse[systemstore.WebhookAttempt](t, rec.Body)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, "attempt0", cursor.Data[0].ID)
+	require.Equal(t, 1, cursor.Data[0].Attempts)
+}
@@ -10,4 +10,23 @@ const (
 	ErrCompilationFailed = "COMPILATION_FAILED"
 	ErrMetadataOverride  = "METADATA_OVERRIDE"
 	ErrNoScript          = "NO_SCRIPT"
+	ErrAssertionFailed   = "ASSERTION_FAILED"
+	ErrHoldClosed        = "HOLD_CLOSED"
+	ErrHoldOccurring     = "HOLD_OCCURRING"
+	ErrHoldProtected     = "HOLD_PROTECTED"
+
+	ErrIdempotencyKeyConflict = "IDEMPOTENCY_KEY_CONFLICT"
+
+	ErrImport           = "IMPORT"
+	ErrImportInProgress = "IMPORT_IN_PROGRESS"
+
+	ErrLedgerReadOnly = "LEDGER_READ_ONLY"
+	ErrNotLeader      = "NOT_LEADER"
+
+	ErrPeriodClosed = "PERIOD_CLOSED"
+
+	ErrAccountPolicy = "ACCOUNT_POLICY"
+	ErrAccountFrozen = "ACCOUNT_FROZEN"
+
+	ErrChartViolation = "CHART_VIOLATION"
 )
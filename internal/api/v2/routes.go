@@ -46,6 +46,11 @@ func NewRouter(
 		router.Use(service.OTLPMiddleware("ledger", debug))
 
 		router.Get("/", listLedgers(b))
+
+		// EventController
+		router.Get("/_events/schemas", getEventSchemas)
+		router.Get("/_events/schemas/{version}/{type}", getEventSchema)
+
 		router.Route("/{ledger}", func(router chi.Router) {
 			router.Use(func(handler http.Handler) http.Handler {
 				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -57,6 +62,8 @@ func NewRouter(
 			})
 			router.Post("/", createLedger(b))
 			router.Get("/", getLedger(b))
+			router.Delete("/", deleteLedger(b))
+			router.Put("/state", updateLedgerState(b))
 			router.Put("/metadata", updateLedgerMetadata(b))
 			router.Delete("/metadata/{key}", deleteLedgerMetadata(b))
 
@@ -67,8 +74,13 @@ func NewRouter(
 				router.Get("/_info", getLedgerInfo)
 				router.Get("/stats", getStats)
 				router.Get("/logs", getLogs)
+				router.Get("/logs/stream", streamLogs)
 				router.Post("/logs/import", importLogs)
+				router.Get("/logs/imports", getImportJobs)
+				router.Get("/logs/imports/{id}", getImportJob)
 				router.Post("/logs/export", exportLogs)
+				router.Get("/logs/_verify", verifyLogs)
+				router.Post("/logs/_replay", replayEvents)
 
 				// AccountController
 				router.Get("/accounts", getAccounts)
@@ -76,6 +88,26 @@ func NewRouter(
 				router.Get("/accounts/{address}", getAccount)
 				router.Post("/accounts/{address}/metadata", postAccountMetadata)
 				router.Delete("/accounts/{address}/metadata/{key}", deleteAccountMetadata)
+				router.Get("/accounts/{address}/history", getAccountHistory)
+				router.Get("/accounts/{address}/history/stream", streamAccountHistory)
+
+				// AccountFreezeController
+				router.Get("/freezes", getAccountFreezes)
+				router.Get("/accounts/{address}/freeze", getAccountFreeze)
+				router.Post("/accounts/{address}/freeze", freezeAccount)
+				router.Post("/accounts/{address}/unfreeze", unfreezeAccount)
+				router.Get("/accounts/{address}/freeze/events", getAccountFreezeEvents)
+
+				// AccountPolicyController
+				router.Get("/policies", getAccountPolicies)
+				router.Get("/policies/{pattern}", getAccountPolicy)
+				router.Put("/policies/{pattern}", putAccountPolicy)
+				router.Delete("/policies/{pattern}", deleteAccountPolicy)
+
+				// ChartController
+				router.Get("/chart", getChart)
+				router.Put("/chart", putChart)
+				router.Delete("/chart", deleteChart)
 
 				// TransactionController
 				router.Get("/transactions", getTransactions)
@@ -88,6 +120,30 @@ func NewRouter(
 				router.Post("/transactions/{id}/metadata", postTransactionMetadata)
 				router.Delete("/transactions/{id}/metadata/{key}", deleteTransactionMetadata)
 
+				// ScheduledTransactionController
+				router.Get("/scheduled-transactions", getScheduledTransactions)
+				router.Post("/scheduled-transactions", postScheduledTransaction)
+				router.Get("/scheduled-transactions/{id}", getScheduledTransaction)
+
+				// PeriodController
+				router.Post("/periods/close", closePeriod)
+				router.Get("/periods", getClosedPeriods)
+				router.Get("/periods/{id}", getClosedPeriod)
+
+				// HoldController
+				router.Post("/holds", postHold)
+				router.Get("/holds/{id}", getHold)
+				router.Post("/holds/{id}/confirm", confirmHold)
+				router.Post("/holds/{id}/void", voidHold)
+
+				// WebhookController
+				router.Get("/webhooks", getWebhooks)
+				router.Post("/webhooks", postWebhook)
+				router.Get("/webhooks/{id}", getWebhook)
+				router.Put("/webhooks/{id}", putWebhook)
+				router.Delete("/webhooks/{id}", deleteWebhook)
+				router.Get("/webhooks/{id}/attempts", getWebhookAttempts)
+
 				router.Get("/aggregate/balances", getBalancesAggregated)
 
 				router.Get("/volumes", getVolumesWithBalances)
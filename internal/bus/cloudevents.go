@@ -0,0 +1,181 @@
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	wHttp "github.com/ThreeDotsLabs/watermill-http/v2/pkg/http"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+
+	// CloudEventsModeStructured publishes the whole CloudEvent, attributes included, as the body of the messages
+	CloudEventsModeStructured = "structured"
+	// CloudEventsModeBinary publishes the payload of the events as the body of the messages,
+	// and their attributes as headers
+	CloudEventsModeBinary = "binary"
+
+	CloudEventsBindingHTTP  = "http"
+	CloudEventsBindingKafka = "kafka"
+
+	cloudEventsContentType = "application/cloudevents+json; charset=UTF-8"
+	contentTypeMetadataKey = "content-type"
+)
+
+var (
+	CloudEventsModes    = []string{CloudEventsModeStructured, CloudEventsModeBinary}
+	CloudEventsBindings = []string{CloudEventsBindingHTTP, CloudEventsBindingKafka}
+)
+
+// CloudEvent is a CloudEvents 1.0 envelope of an event published by the ledger.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// attributes returns the attributes of the event, keyed by their name.
+func (e CloudEvent) attributes() map[string]string {
+	ret := map[string]string{
+		"specversion": e.SpecVersion,
+		"id":          e.ID,
+		"source":      e.Source,
+		"type":        e.Type,
+		"time":        e.Time.Format(time.RFC3339Nano),
+	}
+	if e.Subject != "" {
+		ret["subject"] = e.Subject
+	}
+	return ret
+}
+
+// NewCloudEvent wraps the event published in msg in a CloudEvent.
+// The type of the CloudEvent is made of the type and the version of the event, like `com.formance.ledger.committed_transactions.v2`,
+// and its subject is the ledger of the event.
+func NewCloudEvent(msg *message.Message) (*CloudEvent, error) {
+	ev := struct {
+		Date    time.Time       `json:"date"`
+		App     string          `json:"app"`
+		Version string          `json:"version"`
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+	}{}
+	if err := json.Unmarshal(msg.Payload, &ev); err != nil {
+		return nil, errors.Wrap(err, "decoding event")
+	}
+	payload := struct {
+		Ledger string `json:"ledger"`
+	}{}
+	if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+		return nil, errors.Wrap(err, "decoding event payload")
+	}
+
+	return &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              msg.UUID,
+		Source:          ev.App,
+		Type:            fmt.Sprintf("com.formance.%s.%s.%s", ev.App, strings.ToLower(ev.Type), ev.Version),
+		Subject:         payload.Ledger,
+		Time:            ev.Date,
+		DataContentType: "application/json",
+		Data:            ev.Payload,
+	}, nil
+}
+
+// cloudEventsPublisher decorates a publisher to publish the events as CloudEvents,
+// using the headers of the protocol binding of the publisher in binary mode.
+type cloudEventsPublisher struct {
+	message.Publisher
+	mode    string
+	binding string
+}
+
+func (p *cloudEventsPublisher) Publish(topic string, messages ...*message.Message) error {
+	ret := make([]*message.Message, 0, len(messages))
+	for _, msg := range messages {
+		ce, err := NewCloudEvent(msg)
+		if err != nil {
+			return errors.Wrapf(err, "encoding message %s as a CloudEvent", msg.UUID)
+		}
+
+		var next *message.Message
+		switch p.mode {
+		case CloudEventsModeBinary:
+			next = message.NewMessage(msg.UUID, message.Payload(ce.Data))
+			next.Metadata = copyMetadata(msg.Metadata)
+			for name, value := range ce.attributes() {
+				next.Metadata.Set(p.headerPrefix()+name, value)
+			}
+			next.Metadata.Set(contentTypeMetadataKey, ce.DataContentType)
+		default:
+			data, err := json.Marshal(ce)
+			if err != nil {
+				return err
+			}
+			next = message.NewMessage(msg.UUID, data)
+			next.Metadata = copyMetadata(msg.Metadata)
+			next.Metadata.Set(contentTypeMetadataKey, cloudEventsContentType)
+		}
+		next.SetContext(msg.Context())
+		ret = append(ret, next)
+	}
+
+	return p.Publisher.Publish(topic, ret...)
+}
+
+func copyMetadata(metadata message.Metadata) message.Metadata {
+	ret := make(message.Metadata, len(metadata))
+	for key, value := range metadata {
+		ret[key] = value
+	}
+	return ret
+}
+
+// headerPrefix returns the prefix of the headers of the attributes of the events in binary mode.
+func (p *cloudEventsPublisher) headerPrefix() string {
+	if p.binding == CloudEventsBindingKafka {
+		return "ce_"
+	}
+	return "ce-"
+}
+
+var _ message.Publisher = (*cloudEventsPublisher)(nil)
+
+func NewCloudEventsPublisher(publisher message.Publisher, mode, binding string) *cloudEventsPublisher {
+	return &cloudEventsPublisher{
+		Publisher: publisher,
+		mode:      mode,
+		binding:   binding,
+	}
+}
+
+// NewCloudEventsHTTPMarshalMessageFunc decorates the marshalling of the messages published over HTTP
+// to send the content type and the attributes of the CloudEvents as headers.
+func NewCloudEventsHTTPMarshalMessageFunc(marshal wHttp.MarshalMessageFunc) wHttp.MarshalMessageFunc {
+	return func(url string, msg *message.Message) (*http.Request, error) {
+		req, err := marshal(url, msg)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range msg.Metadata {
+			switch {
+			case key == contentTypeMetadataKey:
+				req.Header.Set("Content-Type", value)
+			case strings.HasPrefix(key, "ce-"):
+				req.Header.Set(key, value)
+			}
+		}
+		return req, nil
+	}
+}
@@ -0,0 +1,98 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	wHttp "github.com/ThreeDotsLabs/watermill-http/v2/pkg/http"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/publish"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/stretchr/testify/require"
+)
+
+type capturingPublisher struct {
+	messages []*message.Message
+}
+
+func (p *capturingPublisher) Publish(topic string, messages ...*message.Message) error {
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+func (p *capturingPublisher) Close() error {
+	return nil
+}
+
+func TestCloudEventsPublisher(t *testing.T) {
+	tx := ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100)))
+	_, ev := NewLogEvent("default", *ledger.NewTransactionLog(tx, map[string]metadata.Metadata{}).ChainLog(nil), nil)
+	msg := publish.NewMessage(context.Background(), ev)
+
+	expectedData, err := json.Marshal(ev.Payload)
+	require.NoError(t, err)
+	expectedType := "com.formance.ledger.committed_transactions." + events.EventVersion
+
+	t.Run("structured", func(t *testing.T) {
+		underlying := &capturingPublisher{}
+		require.NoError(t, NewCloudEventsPublisher(underlying, CloudEventsModeStructured, CloudEventsBindingHTTP).
+			Publish(events.EventTypeCommittedTransactions, msg))
+		require.Len(t, underlying.messages, 1)
+
+		published := underlying.messages[0]
+		require.Equal(t, msg.UUID, published.UUID)
+		require.Equal(t, cloudEventsContentType, published.Metadata.Get(contentTypeMetadataKey))
+
+		ce := CloudEvent{}
+		require.NoError(t, json.Unmarshal(published.Payload, &ce))
+		require.Equal(t, CloudEventsSpecVersion, ce.SpecVersion)
+		require.Equal(t, msg.UUID, ce.ID)
+		require.Equal(t, events.EventApp, ce.Source)
+		require.Equal(t, expectedType, ce.Type)
+		require.Equal(t, "default", ce.Subject)
+		require.JSONEq(t, string(expectedData), string(ce.Data))
+	})
+
+	for _, tc := range []struct {
+		binding string
+		prefix  string
+	}{
+		{binding: CloudEventsBindingHTTP, prefix: "ce-"},
+		{binding: CloudEventsBindingKafka, prefix: "ce_"},
+	} {
+		tc := tc
+		t.Run("binary "+tc.binding, func(t *testing.T) {
+			underlying := &capturingPublisher{}
+			require.NoError(t, NewCloudEventsPublisher(underlying, CloudEventsModeBinary, tc.binding).
+				Publish(events.EventTypeCommittedTransactions, msg))
+			require.Len(t, underlying.messages, 1)
+
+			published := underlying.messages[0]
+			require.JSONEq(t, string(expectedData), string(published.Payload))
+			require.Equal(t, "application/json", published.Metadata.Get(contentTypeMetadataKey))
+			require.Equal(t, CloudEventsSpecVersion, published.Metadata.Get(tc.prefix+"specversion"))
+			require.Equal(t, msg.UUID, published.Metadata.Get(tc.prefix+"id"))
+			require.Equal(t, events.EventApp, published.Metadata.Get(tc.prefix+"source"))
+			require.Equal(t, expectedType, published.Metadata.Get(tc.prefix+"type"))
+			require.Equal(t, "default", published.Metadata.Get(tc.prefix+"subject"))
+			require.NotEmpty(t, published.Metadata.Get(tc.prefix+"time"))
+		})
+	}
+}
+
+func TestCloudEventsHTTPMarshalMessageFunc(t *testing.T) {
+	msg := message.NewMessage("1", []byte(`{}`))
+	msg.Metadata.Set(contentTypeMetadataKey, "application/json")
+	msg.Metadata.Set("ce-id", "1")
+	msg.Metadata.Set("ce_id", "1")
+
+	req, err := NewCloudEventsHTTPMarshalMessageFunc(wHttp.DefaultMarshalMessageFunc)("http://localhost", msg)
+	require.NoError(t, err)
+	require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	require.Equal(t, "1", req.Header.Get("ce-id"))
+	require.Empty(t, req.Header.Get("ce_id"))
+}
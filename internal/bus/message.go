@@ -8,6 +8,10 @@ import (
 	"github.com/formancehq/ledger/pkg/events"
 )
 
+// ReplayMetadataKey marks the messages of the events replayed from the logs, to distinguish them from the live events.
+// Its value is the id of the replayed log.
+const ReplayMetadataKey = "ledger-replay"
+
 type CommittedTransactions struct {
 	Ledger          string                       `json:"ledger"`
 	Transactions    []ledger.Transaction         `json:"transactions"`
@@ -24,6 +28,22 @@ func newEventCommittedTransactions(txs CommittedTransactions) publish.EventMessa
 	}
 }
 
+// CommittedTransactionsV3 adds the volumes of the accounts touched by the transactions, once committed.
+type CommittedTransactionsV3 struct {
+	CommittedTransactions
+	PostCommitVolumes ledger.AccountsAssetsVolumes `json:"postCommitVolumes"`
+}
+
+func newEventCommittedTransactionsV3(txs CommittedTransactionsV3) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersionV3,
+		Type:    events.EventTypeCommittedTransactions,
+		Payload: txs,
+	}
+}
+
 type SavedMetadata struct {
 	Ledger     string            `json:"ledger"`
 	TargetType string            `json:"targetType"`
@@ -57,6 +77,22 @@ func newEventRevertedTransaction(tx RevertedTransaction) publish.EventMessage {
 	}
 }
 
+// RevertedTransactionV3 adds the volumes of the accounts touched by the revert transaction, once committed.
+type RevertedTransactionV3 struct {
+	RevertedTransaction
+	PostCommitVolumes ledger.AccountsAssetsVolumes `json:"postCommitVolumes"`
+}
+
+func newEventRevertedTransactionV3(tx RevertedTransactionV3) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersionV3,
+		Type:    events.EventTypeRevertedTransaction,
+		Payload: tx,
+	}
+}
+
 type DeletedMetadata struct {
 	Ledger     string `json:"ledger"`
 	TargetType string `json:"targetType"`
@@ -73,3 +109,96 @@ func newEventDeletedMetadata(tx DeletedMetadata) publish.EventMessage {
 		Payload: tx,
 	}
 }
+
+type ExecutedScheduledTransaction struct {
+	Ledger               string                      `json:"ledger"`
+	ScheduledTransaction ledger.ScheduledTransaction `json:"scheduledTransaction"`
+}
+
+func newEventExecutedScheduledTransaction(scheduled ExecutedScheduledTransaction) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    events.EventTypeExecutedScheduledTransaction,
+		Payload: scheduled,
+	}
+}
+
+type CreatedHold struct {
+	Ledger      string             `json:"ledger"`
+	Hold        ledger.Hold        `json:"hold"`
+	Transaction ledger.Transaction `json:"transaction"`
+}
+
+func newEventCreatedHold(hold CreatedHold) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    events.EventTypeCreatedHold,
+		Payload: hold,
+	}
+}
+
+type ConfirmedHold struct {
+	Ledger      string             `json:"ledger"`
+	Hold        ledger.Hold        `json:"hold"`
+	Transaction ledger.Transaction `json:"transaction"`
+}
+
+func newEventConfirmedHold(hold ConfirmedHold) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    events.EventTypeConfirmedHold,
+		Payload: hold,
+	}
+}
+
+type VoidedHold struct {
+	Ledger      string             `json:"ledger"`
+	Hold        ledger.Hold        `json:"hold"`
+	Transaction ledger.Transaction `json:"transaction"`
+}
+
+func newEventVoidedHold(hold VoidedHold) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    events.EventTypeVoidedHold,
+		Payload: hold,
+	}
+}
+
+type FrozenAccount struct {
+	Ledger string                    `json:"ledger"`
+	Event  ledger.AccountFreezeEvent `json:"event"`
+}
+
+func newEventFrozenAccount(frozen FrozenAccount) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    events.EventTypeFrozenAccount,
+		Payload: frozen,
+	}
+}
+
+type UnfrozenAccount struct {
+	Ledger string                    `json:"ledger"`
+	Event  ledger.AccountFreezeEvent `json:"event"`
+}
+
+func newEventUnfrozenAccount(unfrozen UnfrozenAccount) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    events.EventTypeUnfrozenAccount,
+		Payload: unfrozen,
+	}
+}
@@ -16,6 +16,12 @@ type Monitor interface {
 	SavedMetadata(ctx context.Context, targetType, id string, metadata metadata.Metadata)
 	RevertedTransaction(ctx context.Context, reverted, revert *ledger.Transaction)
 	DeletedMetadata(ctx context.Context, targetType string, targetID any, key string)
+	ExecutedScheduledTransaction(ctx context.Context, scheduled ledger.ScheduledTransaction)
+	CreatedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction)
+	ConfirmedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction)
+	VoidedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction)
+	FrozenAccount(ctx context.Context, event ledger.AccountFreezeEvent)
+	UnfrozenAccount(ctx context.Context, event ledger.AccountFreezeEvent)
 }
 
 type noOpMonitor struct{}
@@ -29,6 +35,18 @@ func (n noOpMonitor) SavedMetadata(ctx context.Context, targetType string, id st
 }
 func (n noOpMonitor) RevertedTransaction(ctx context.Context, reverted, revert *ledger.Transaction) {
 }
+func (n noOpMonitor) ExecutedScheduledTransaction(ctx context.Context, scheduled ledger.ScheduledTransaction) {
+}
+func (n noOpMonitor) CreatedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction) {
+}
+func (n noOpMonitor) ConfirmedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction) {
+}
+func (n noOpMonitor) VoidedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction) {
+}
+func (n noOpMonitor) FrozenAccount(ctx context.Context, event ledger.AccountFreezeEvent) {
+}
+func (n noOpMonitor) UnfrozenAccount(ctx context.Context, event ledger.AccountFreezeEvent) {
+}
 
 var _ Monitor = &noOpMonitor{}
 
@@ -89,6 +107,57 @@ func (l *ledgerMonitor) DeletedMetadata(ctx context.Context, targetType string,
 		}))
 }
 
+func (l *ledgerMonitor) ExecutedScheduledTransaction(ctx context.Context, scheduled ledger.ScheduledTransaction) {
+	l.publish(ctx, events.EventTypeExecutedScheduledTransaction,
+		newEventExecutedScheduledTransaction(ExecutedScheduledTransaction{
+			Ledger:               l.ledgerName,
+			ScheduledTransaction: scheduled,
+		}))
+}
+
+func (l *ledgerMonitor) CreatedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction) {
+	l.publish(ctx, events.EventTypeCreatedHold,
+		newEventCreatedHold(CreatedHold{
+			Ledger:      l.ledgerName,
+			Hold:        hold,
+			Transaction: tx,
+		}))
+}
+
+func (l *ledgerMonitor) ConfirmedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction) {
+	l.publish(ctx, events.EventTypeConfirmedHold,
+		newEventConfirmedHold(ConfirmedHold{
+			Ledger:      l.ledgerName,
+			Hold:        hold,
+			Transaction: tx,
+		}))
+}
+
+func (l *ledgerMonitor) VoidedHold(ctx context.Context, hold ledger.Hold, tx ledger.Transaction) {
+	l.publish(ctx, events.EventTypeVoidedHold,
+		newEventVoidedHold(VoidedHold{
+			Ledger:      l.ledgerName,
+			Hold:        hold,
+			Transaction: tx,
+		}))
+}
+
+func (l *ledgerMonitor) FrozenAccount(ctx context.Context, event ledger.AccountFreezeEvent) {
+	l.publish(ctx, events.EventTypeFrozenAccount,
+		newEventFrozenAccount(FrozenAccount{
+			Ledger: l.ledgerName,
+			Event:  event,
+		}))
+}
+
+func (l *ledgerMonitor) UnfrozenAccount(ctx context.Context, event ledger.AccountFreezeEvent) {
+	l.publish(ctx, events.EventTypeUnfrozenAccount,
+		newEventUnfrozenAccount(UnfrozenAccount{
+			Ledger: l.ledgerName,
+			Event:  event,
+		}))
+}
+
 func (l *ledgerMonitor) publish(ctx context.Context, topic string, ev publish.EventMessage) {
 	if err := l.publisher.Publish(topic, publish.NewMessage(ctx, ev)); err != nil {
 		logging.FromContext(ctx).Errorf("publishing message: %s", err)
@@ -0,0 +1,108 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/publish"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/pkg/events"
+)
+
+// NewLogEvent returns the topic and the event published for a log.
+// reverted is the transaction reverted by a RevertedTransaction log, and is ignored for the other logs.
+func NewLogEvent(ledgerName string, log ledger.ChainedLog, reverted *ledger.Transaction) (string, publish.EventMessage) {
+	switch payload := log.Data.(type) {
+	case ledger.NewTransactionLogPayload:
+		return events.EventTypeCommittedTransactions, newEventCommittedTransactions(CommittedTransactions{
+			Ledger:          ledgerName,
+			Transactions:    []ledger.Transaction{*payload.Transaction},
+			AccountMetadata: payload.AccountMetadata,
+		})
+	case ledger.SetMetadataLogPayload:
+		return events.EventTypeSavedMetadata, newEventSavedMetadata(SavedMetadata{
+			Ledger:     ledgerName,
+			TargetType: payload.TargetType,
+			TargetID:   fmt.Sprint(payload.TargetID),
+			Metadata:   payload.Metadata,
+		})
+	case ledger.RevertedTransactionLogPayload:
+		// Keep the same payload as the one published by the ledger monitor
+		return events.EventTypeRevertedTransaction, newEventRevertedTransaction(RevertedTransaction{
+			Ledger:              ledgerName,
+			RevertedTransaction: *payload.RevertTransaction,
+			RevertTransaction:   *reverted,
+		})
+	case ledger.DeleteMetadataLogPayload:
+		return events.EventTypeDeletedMetadata, newEventDeletedMetadata(DeletedMetadata{
+			Ledger:     ledgerName,
+			TargetType: payload.TargetType,
+			TargetID:   payload.TargetID,
+			Key:        payload.Key,
+		})
+	default:
+		panic(fmt.Sprintf("unexpected log type %s", log.Type))
+	}
+}
+
+// NewLogEventV3 returns the topic and the event published for a log in version v3,
+// postCommitVolumes being the volumes of the accounts touched by the transaction of the log, once committed.
+// The events which have no v3 version are returned in their default version.
+func NewLogEventV3(ledgerName string, log ledger.ChainedLog, reverted *ledger.Transaction, postCommitVolumes ledger.AccountsAssetsVolumes) (string, publish.EventMessage) {
+	switch payload := log.Data.(type) {
+	case ledger.NewTransactionLogPayload:
+		return events.EventTypeCommittedTransactions, newEventCommittedTransactionsV3(CommittedTransactionsV3{
+			CommittedTransactions: CommittedTransactions{
+				Ledger:          ledgerName,
+				Transactions:    []ledger.Transaction{*payload.Transaction},
+				AccountMetadata: payload.AccountMetadata,
+			},
+			PostCommitVolumes: postCommitVolumes,
+		})
+	case ledger.RevertedTransactionLogPayload:
+		return events.EventTypeRevertedTransaction, newEventRevertedTransactionV3(RevertedTransactionV3{
+			RevertedTransaction: RevertedTransaction{
+				Ledger:              ledgerName,
+				RevertedTransaction: *payload.RevertTransaction,
+				RevertTransaction:   *reverted,
+			},
+			PostCommitVolumes: postCommitVolumes,
+		})
+	default:
+		return NewLogEvent(ledgerName, log, reverted)
+	}
+}
+
+// outboxMonitor leaves the publication of the events derived from the logs to the outbox relay,
+// and only notifies it that new logs were committed.
+// The other events are published by the underlying monitor.
+type outboxMonitor struct {
+	Monitor
+	notify func()
+}
+
+var _ Monitor = &outboxMonitor{}
+
+func NewOutboxMonitor(monitor Monitor, notify func()) *outboxMonitor {
+	return &outboxMonitor{
+		Monitor: monitor,
+		notify:  notify,
+	}
+}
+
+func (m *outboxMonitor) CommittedTransactions(ctx context.Context, res ledger.Transaction, accountMetadata map[string]metadata.Metadata) {
+	m.notify()
+}
+
+func (m *outboxMonitor) SavedMetadata(ctx context.Context, targetType, id string, metadata metadata.Metadata) {
+	m.notify()
+}
+
+func (m *outboxMonitor) RevertedTransaction(ctx context.Context, reverted, revert *ledger.Transaction) {
+	m.notify()
+}
+
+func (m *outboxMonitor) DeletedMetadata(ctx context.Context, targetType string, targetID any, key string) {
+	m.notify()
+}
@@ -0,0 +1,124 @@
+package bus
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogEvent(t *testing.T) {
+	now := time.Now()
+	tx := ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100)))
+	revert := ledger.NewTransaction().WithIDUint64(1).WithPostings(ledger.NewPosting("bank", "world", "USD", big.NewInt(100)))
+
+	for _, tc := range []struct {
+		name         string
+		log          *ledger.Log
+		reverted     *ledger.Transaction
+		expectedType string
+		expected     any
+	}{
+		{
+			name:         "new transaction",
+			log:          ledger.NewTransactionLog(tx, map[string]metadata.Metadata{}),
+			expectedType: events.EventTypeCommittedTransactions,
+			expected: CommittedTransactions{
+				Ledger:          "default",
+				Transactions:    []ledger.Transaction{*tx},
+				AccountMetadata: map[string]metadata.Metadata{},
+			},
+		},
+		{
+			name: "saved metadata",
+			log: ledger.NewSetMetadataLog(now, ledger.SetMetadataLogPayload{
+				TargetType: ledger.MetaTargetTypeTransaction,
+				TargetID:   big.NewInt(0),
+				Metadata:   metadata.Metadata{"foo": "bar"},
+			}),
+			expectedType: events.EventTypeSavedMetadata,
+			expected: SavedMetadata{
+				Ledger:     "default",
+				TargetType: ledger.MetaTargetTypeTransaction,
+				TargetID:   "0",
+				Metadata:   metadata.Metadata{"foo": "bar"},
+			},
+		},
+		{
+			name:         "reverted transaction",
+			log:          ledger.NewRevertedTransactionLog(now, big.NewInt(0), revert),
+			reverted:     tx,
+			expectedType: events.EventTypeRevertedTransaction,
+			expected: RevertedTransaction{
+				Ledger:              "default",
+				RevertedTransaction: *revert,
+				RevertTransaction:   *tx,
+			},
+		},
+		{
+			name: "deleted metadata",
+			log: ledger.NewDeleteMetadataLog(now, ledger.DeleteMetadataLogPayload{
+				TargetType: ledger.MetaTargetTypeAccount,
+				TargetID:   "bank",
+				Key:        "foo",
+			}),
+			expectedType: events.EventTypeDeletedMetadata,
+			expected: DeletedMetadata{
+				Ledger:     "default",
+				TargetType: ledger.MetaTargetTypeAccount,
+				TargetID:   "bank",
+				Key:        "foo",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			topic, ev := NewLogEvent("default", *tc.log.ChainLog(nil), tc.reverted)
+			require.Equal(t, tc.expectedType, topic)
+			require.Equal(t, tc.expectedType, ev.Type)
+			require.Equal(t, tc.expected, ev.Payload)
+		})
+	}
+}
+
+func TestNewLogEventV3(t *testing.T) {
+	tx := ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100)))
+	postCommitVolumes := ledger.AccountsAssetsVolumes{
+		"world": {"USD": ledger.NewEmptyVolumes().WithOutput(big.NewInt(100))},
+		"bank":  {"USD": ledger.NewEmptyVolumes().WithInput(big.NewInt(100))},
+	}
+
+	topic, ev := NewLogEventV3("default", *ledger.NewTransactionLog(tx, map[string]metadata.Metadata{}).ChainLog(nil), nil, postCommitVolumes)
+	require.Equal(t, events.EventTypeCommittedTransactions, topic)
+	require.Equal(t, events.EventVersionV3, ev.Version)
+	require.Equal(t, CommittedTransactionsV3{
+		CommittedTransactions: CommittedTransactions{
+			Ledger:          "default",
+			Transactions:    []ledger.Transaction{*tx},
+			AccountMetadata: map[string]metadata.Metadata{},
+		},
+		PostCommitVolumes: postCommitVolumes,
+	}, ev.Payload)
+
+	_, ev = NewLogEventV3("default", *ledger.NewSetMetadataOnAccountLog(time.Now(), "bank", metadata.Metadata{"foo": "bar"}).ChainLog(nil), nil, nil)
+	require.Equal(t, events.EventVersion, ev.Version)
+}
+
+func TestOutboxMonitor(t *testing.T) {
+	notifications := 0
+	m := NewOutboxMonitor(NewNoOpMonitor(), func() {
+		notifications++
+	})
+
+	m.CommittedTransactions(context.Background(), ledger.Transaction{}, nil)
+	m.SavedMetadata(context.Background(), ledger.MetaTargetTypeAccount, "bank", metadata.Metadata{})
+	m.RevertedTransaction(context.Background(), &ledger.Transaction{}, &ledger.Transaction{})
+	m.DeletedMetadata(context.Background(), ledger.MetaTargetTypeAccount, "bank", "foo")
+	m.ExecutedScheduledTransaction(context.Background(), ledger.ScheduledTransaction{})
+	require.Equal(t, 4, notifications)
+}
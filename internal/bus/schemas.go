@@ -0,0 +1,126 @@
+package bus
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/invopop/jsonschema"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// eventPayloads lists the payloads of the events published by the ledger, by version and type.
+var eventPayloads = map[string]map[string]any{
+	events.EventVersionV2: {
+		events.EventTypeCommittedTransactions:        CommittedTransactions{},
+		events.EventTypeSavedMetadata:                SavedMetadata{},
+		events.EventTypeRevertedTransaction:          RevertedTransaction{},
+		events.EventTypeDeletedMetadata:              DeletedMetadata{},
+		events.EventTypeExecutedScheduledTransaction: ExecutedScheduledTransaction{},
+		events.EventTypeCreatedHold:                  CreatedHold{},
+		events.EventTypeConfirmedHold:                ConfirmedHold{},
+		events.EventTypeVoidedHold:                   VoidedHold{},
+		events.EventTypeFrozenAccount:                FrozenAccount{},
+		events.EventTypeUnfrozenAccount:              UnfrozenAccount{},
+	},
+	events.EventVersionV3: {
+		events.EventTypeCommittedTransactions: CommittedTransactionsV3{},
+		events.EventTypeRevertedTransaction:   RevertedTransactionV3{},
+	},
+}
+
+var (
+	bigIntType  = reflect.TypeOf(big.Int{})
+	timeType    = reflect.TypeOf(time.Time{})
+	volumesType = reflect.TypeOf(ledger.Volumes{})
+)
+
+// mapSchemaType describes the types whose json representation differs from their go structure.
+func mapSchemaType(t reflect.Type) *jsonschema.Schema {
+	switch t {
+	case bigIntType:
+		return &jsonschema.Schema{Type: "integer"}
+	case timeType:
+		return &jsonschema.Schema{Type: "string", Format: "date-time"}
+	case volumesType:
+		properties := orderedmap.New[string, *jsonschema.Schema]()
+		for _, name := range []string{"input", "output", "balance"} {
+			properties.Set(name, &jsonschema.Schema{Type: "integer"})
+		}
+		return &jsonschema.Schema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"input", "output", "balance"},
+		}
+	default:
+		return nil
+	}
+}
+
+// EventSchema returns the JSON schema of the events of the given type, in the given version.
+// The events which are unchanged in a version are described by the schema of their previous version.
+func EventSchema(version, eventType string) (*jsonschema.Schema, error) {
+	if !slices.Contains(events.EventVersions, version) {
+		return nil, fmt.Errorf("unknown event version %s", version)
+	}
+
+	var payload any
+	for _, v := range events.EventVersions {
+		if p, ok := eventPayloads[v][eventType]; ok {
+			payload = p
+		}
+		if v == version {
+			break
+		}
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("unknown event type %s", eventType)
+	}
+
+	reflector := &jsonschema.Reflector{
+		Mapper:         mapSchemaType,
+		ExpandedStruct: true,
+		Anonymous:      true,
+	}
+	payloadSchema := reflector.Reflect(payload)
+	definitions := payloadSchema.Definitions
+	payloadSchema.Version = ""
+	payloadSchema.Definitions = nil
+
+	properties := orderedmap.New[string, *jsonschema.Schema]()
+	properties.Set("idempotency_key", &jsonschema.Schema{Type: "string"})
+	properties.Set("date", &jsonschema.Schema{Type: "string", Format: "date-time"})
+	properties.Set("app", &jsonschema.Schema{Type: "string", Const: events.EventApp})
+	properties.Set("version", &jsonschema.Schema{Type: "string", Const: version})
+	properties.Set("type", &jsonschema.Schema{Type: "string", Const: eventType})
+	properties.Set("payload", payloadSchema)
+
+	return &jsonschema.Schema{
+		Version:     jsonschema.Version,
+		Title:       fmt.Sprintf("%s %s", eventType, version),
+		Type:        "object",
+		Properties:  properties,
+		Required:    []string{"date", "app", "version", "type", "payload"},
+		Definitions: definitions,
+	}, nil
+}
+
+// EventSchemas returns the JSON schemas of all the events published by the ledger, by version and type.
+func EventSchemas() map[string]map[string]*jsonschema.Schema {
+	ret := make(map[string]map[string]*jsonschema.Schema)
+	for _, version := range events.EventVersions {
+		ret[version] = make(map[string]*jsonschema.Schema)
+		for _, eventType := range events.EventTypes {
+			schema, err := EventSchema(version, eventType)
+			if err != nil {
+				panic(err)
+			}
+			ret[version][eventType] = schema
+		}
+	}
+	return ret
+}
@@ -0,0 +1,162 @@
+package ledger
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	"github.com/pkg/errors"
+)
+
+const (
+	AccountTypeAsset     = "ASSET"
+	AccountTypeLiability = "LIABILITY"
+	AccountTypeEquity    = "EQUITY"
+	AccountTypeRevenue   = "REVENUE"
+	AccountTypeExpense   = "EXPENSE"
+
+	// ChartModeStrict rejects the transactions using accounts not declared in the chart
+	ChartModeStrict = "STRICT"
+	// ChartModeWarn only reports the transactions using accounts not declared in the chart
+	ChartModeWarn = "WARN"
+)
+
+var (
+	accountTypes = []string{AccountTypeAsset, AccountTypeLiability, AccountTypeEquity, AccountTypeRevenue, AccountTypeExpense}
+	chartModes   = []string{ChartModeStrict, ChartModeWarn}
+
+	chartSegmentRegexp  = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	chartVariableRegexp = regexp.MustCompile(`^\{[a-zA-Z0-9_-]+\}$`)
+)
+
+// ChartAccount declares the accounts matching its template, like `users:{id}:wallet`,
+// where a variable between braces matches any segment.
+type ChartAccount struct {
+	Template string `json:"template"`
+	Type     string `json:"type"`
+	// AllowedAssets restricts the assets the accounts can receive or send, if not empty
+	AllowedAssets []string `json:"allowedAssets,omitempty"`
+	// DefaultMetadata is set on the accounts when a transaction uses them, for the keys they don't have yet
+	DefaultMetadata metadata.Metadata `json:"defaultMetadata,omitempty"`
+}
+
+func (a ChartAccount) Validate() error {
+	if err := ValidateChartTemplate(a.Template); err != nil {
+		return err
+	}
+	if !slices.Contains(accountTypes, a.Type) {
+		return errors.Errorf("invalid account type '%s' for template '%s', expected one of %v", a.Type, a.Template, accountTypes)
+	}
+	return nil
+}
+
+// Match returns whether address matches the template of the account.
+func (a ChartAccount) Match(address string) bool {
+	templateSegments := strings.Split(a.Template, ":")
+	addressSegments := strings.Split(address, ":")
+	if len(templateSegments) != len(addressSegments) {
+		return false
+	}
+	for i, segment := range templateSegments {
+		if !chartVariableRegexp.MatchString(segment) && segment != addressSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (a ChartAccount) IsAssetAllowed(asset string) bool {
+	return len(a.AllowedAssets) == 0 || slices.Contains(a.AllowedAssets, asset)
+}
+
+// variables returns the number of variables of the template, the fewer variables, the more specific the account.
+func (a ChartAccount) variables() int {
+	ret := 0
+	for _, segment := range strings.Split(a.Template, ":") {
+		if chartVariableRegexp.MatchString(segment) {
+			ret++
+		}
+	}
+	return ret
+}
+
+func ValidateChartTemplate(template string) error {
+	for _, segment := range strings.Split(template, ":") {
+		if !chartSegmentRegexp.MatchString(segment) && !chartVariableRegexp.MatchString(segment) {
+			return errors.Errorf("invalid account template '%s'", template)
+		}
+	}
+	return nil
+}
+
+// Chart is the chart of accounts of a ledger, declaring the accounts the transactions can use.
+// The world account is always allowed.
+type Chart struct {
+	Mode      string         `json:"mode"`
+	Accounts  []ChartAccount `json:"accounts"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+func (c Chart) Validate() error {
+	if !slices.Contains(chartModes, c.Mode) {
+		return errors.Errorf("invalid chart mode '%s', expected one of %v", c.Mode, chartModes)
+	}
+	templates := make(map[string]struct{}, len(c.Accounts))
+	for _, account := range c.Accounts {
+		if err := account.Validate(); err != nil {
+			return err
+		}
+		if _, ok := templates[account.Template]; ok {
+			return errors.Errorf("account template '%s' declared twice", account.Template)
+		}
+		templates[account.Template] = struct{}{}
+	}
+	return nil
+}
+
+// Find returns the declaration of address, if any.
+// When several templates match, the one with the fewest variables applies.
+func (c Chart) Find(address string) *ChartAccount {
+	var ret *ChartAccount
+	for i := range c.Accounts {
+		account := &c.Accounts[i]
+		if !account.Match(address) {
+			continue
+		}
+		if ret == nil || account.variables() < ret.variables() {
+			ret = account
+		}
+	}
+	return ret
+}
+
+// Check returns an error describing the first posting using an account not declared,
+// or an asset not allowed by the declaration of its accounts.
+func (c Chart) Check(postings Postings) error {
+	for _, posting := range postings {
+		for _, address := range []string{posting.Source, posting.Destination} {
+			if address == WORLD {
+				continue
+			}
+			account := c.Find(address)
+			if account == nil {
+				return fmt.Errorf("account %s is not declared in the chart of accounts", address)
+			}
+			if !account.IsAssetAllowed(posting.Asset) {
+				return fmt.Errorf("asset %s is not allowed on account %s by template %s", posting.Asset, address, account.Template)
+			}
+		}
+	}
+	return nil
+}
+
+func NewChart(mode string, accounts ...ChartAccount) Chart {
+	return Chart{
+		Mode:      mode,
+		Accounts:  accounts,
+		UpdatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,61 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartFind(t *testing.T) {
+	chart := NewChart(ChartModeStrict,
+		ChartAccount{Template: "bank", Type: AccountTypeAsset},
+		ChartAccount{Template: "users:{id}:wallet", Type: AccountTypeLiability},
+		ChartAccount{Template: "users:vip:wallet", Type: AccountTypeLiability},
+	)
+
+	for address, expectedTemplate := range map[string]string{
+		"bank":             "bank",
+		"users:1:wallet":   "users:{id}:wallet",
+		"users:vip:wallet": "users:vip:wallet",
+		"users:1:walet":    "",
+		"users:1":          "",
+	} {
+		account := chart.Find(address)
+		if expectedTemplate == "" {
+			require.Nil(t, account, address)
+			continue
+		}
+		require.NotNil(t, account, address)
+		require.Equal(t, expectedTemplate, account.Template, address)
+	}
+}
+
+func TestChartCheck(t *testing.T) {
+	chart := NewChart(ChartModeStrict,
+		ChartAccount{Template: "bank", Type: AccountTypeAsset},
+		ChartAccount{Template: "users:{id}:wallet", Type: AccountTypeLiability, AllowedAssets: []string{"USD/2"}},
+	)
+
+	require.NoError(t, chart.Check(Postings{
+		NewPosting("world", "users:1:wallet", "USD/2", big.NewInt(100)),
+		NewPosting("users:1:wallet", "bank", "USD/2", big.NewInt(100)),
+	}))
+	require.Error(t, chart.Check(Postings{
+		NewPosting("world", "users:1:walet", "USD/2", big.NewInt(100)),
+	}))
+	require.Error(t, chart.Check(Postings{
+		NewPosting("world", "users:1:wallet", "EUR/2", big.NewInt(100)),
+	}))
+}
+
+func TestChartValidate(t *testing.T) {
+	require.NoError(t, NewChart(ChartModeWarn, ChartAccount{Template: "users:{id}:wallet", Type: AccountTypeLiability}).Validate())
+	require.Error(t, NewChart("LAX").Validate())
+	require.Error(t, NewChart(ChartModeStrict, ChartAccount{Template: "users:{id}:wallet", Type: "CASH"}).Validate())
+	require.Error(t, NewChart(ChartModeStrict, ChartAccount{Template: "users:{id", Type: AccountTypeAsset}).Validate())
+	require.Error(t, NewChart(ChartModeStrict,
+		ChartAccount{Template: "bank", Type: AccountTypeAsset},
+		ChartAccount{Template: "bank", Type: AccountTypeEquity},
+	).Validate())
+}
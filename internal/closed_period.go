@@ -0,0 +1,29 @@
+package ledger
+
+import (
+	"github.com/formancehq/go-libs/time"
+	"github.com/google/uuid"
+)
+
+// ClosedPeriod records the closing of the period of a ledger ending at ClosingDate.
+// Once closed, no transaction can be inserted with a timestamp before the closing date.
+type ClosedPeriod struct {
+	ID          string    `json:"id"`
+	ClosingDate time.Time `json:"closingDate"`
+	ClosedAt    time.Time `json:"closedAt"`
+	// Balances holds the balances of the accounts at the closing date, if they were requested
+	Balances BalancesByAssetsByAccounts `json:"balances,omitempty"`
+}
+
+func (p ClosedPeriod) WithBalances(balances BalancesByAssetsByAccounts) ClosedPeriod {
+	p.Balances = balances
+	return p
+}
+
+func NewClosedPeriod(closingDate time.Time) ClosedPeriod {
+	return ClosedPeriod{
+		ID:          uuid.NewString(),
+		ClosingDate: closingDate,
+		ClosedAt:    time.Now(),
+	}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+)
+
+// loadAccountFreezes applies the account freezes recorded in the storage to the commander.
+func (l *Ledger) loadAccountFreezes(ctx context.Context) error {
+	freezes, err := l.store.GetAllAccountFreezes(ctx)
+	if err != nil {
+		return newStorageError(err, "getting account freezes")
+	}
+	l.commander.SetAccountFreezes(freezes)
+
+	return nil
+}
+
+// FreezeAccount blocks the postings debiting and/or crediting address, replacing its current freeze if any.
+// The freeze is enforced on the transactions created once it returns.
+func (l *Ledger) FreezeAccount(ctx context.Context, address, direction, reason string) (*ledger.AccountFreeze, error) {
+	event := ledger.NewAccountFreezeEvent(address, direction, reason)
+	if err := l.updateAccountFreezes(ctx, func(ctx context.Context) error {
+		return newStorageError(l.store.FreezeAccount(ctx, event), "freezing account")
+	}); err != nil {
+		return nil, err
+	}
+	l.monitor.FrozenAccount(ctx, event)
+
+	freeze := event.Freeze()
+	return &freeze, nil
+}
+
+func (l *Ledger) UnfreezeAccount(ctx context.Context, address, reason string) error {
+	event := ledger.NewAccountUnfreezeEvent(address, reason)
+	if err := l.updateAccountFreezes(ctx, func(ctx context.Context) error {
+		return newStorageError(l.store.UnfreezeAccount(ctx, event), "unfreezing account")
+	}); err != nil {
+		return err
+	}
+	l.monitor.UnfrozenAccount(ctx, event)
+
+	return nil
+}
+
+func (l *Ledger) updateAccountFreezes(ctx context.Context, updateFn func(ctx context.Context) error) error {
+	err := l.commander.UpdateAccountFreezes(ctx, func(ctx context.Context) (ledger.AccountFreezes, error) {
+		if err := updateFn(ctx); err != nil {
+			return nil, err
+		}
+
+		freezes, err := l.store.GetAllAccountFreezes(ctx)
+		return freezes, newStorageError(err, "getting account freezes")
+	})
+	if err != nil {
+		if IsStorageError(err) {
+			return err
+		}
+		return NewCommandError(err)
+	}
+
+	return nil
+}
+
+func (l *Ledger) GetAccountFreeze(ctx context.Context, address string) (*ledger.AccountFreeze, error) {
+	freeze, err := l.store.GetAccountFreeze(ctx, address)
+	return freeze, newStorageError(err, "getting account freeze")
+}
+
+func (l *Ledger) GetAccountFreezes(ctx context.Context, q ledgerstore.GetAccountFreezesQuery) (*bunpaginate.Cursor[ledger.AccountFreeze], error) {
+	freezes, err := l.store.GetAccountFreezes(ctx, q)
+	return freezes, newStorageError(err, "getting account freezes")
+}
+
+func (l *Ledger) GetAccountFreezeEvents(ctx context.Context, q ledgerstore.GetAccountFreezeEventsQuery) (*bunpaginate.Cursor[ledger.AccountFreezeEvent], error) {
+	events, err := l.store.GetAccountFreezeEvents(ctx, q)
+	return events, newStorageError(err, "getting account freeze events")
+}
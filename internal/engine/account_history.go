@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+)
+
+func (l *Ledger) GetAccountHistory(ctx context.Context, q ledgerstore.GetAccountHistoryQuery) (*bunpaginate.Cursor[ledger.AccountHistoryEntry], error) {
+	history, err := l.store.GetAccountHistory(ctx, q)
+	return history, newStorageError(err, "getting account history")
+}
+
+// StreamAccountHistoryFn is called with each entry of a stream of the history of an account, the stream stops if it returns an error.
+type StreamAccountHistoryFn func(entry ledger.AccountHistoryEntry) error
+
+// StreamAccountHistory calls fn with the entries of the history of an account matching filters and following the entry with id after,
+// in ascending order, until ctx is done.
+// If after is nil, only the entries added from now are streamed.
+func (l *Ledger) StreamAccountHistory(ctx context.Context, filters ledgerstore.AccountHistoryFilters, after *big.Int, fn StreamAccountHistoryFn) error {
+	notifications, unsubscribe := l.logsNotifier.subscribe()
+	defer unsubscribe()
+
+	if after == nil {
+		last, err := l.store.GetAccountHistory(ctx, ledgerstore.NewGetAccountHistoryQuery(
+			ledgerstore.NewPaginatedQueryOptions(filters).WithPageSize(1),
+		))
+		if err != nil {
+			return newStorageError(err, "getting last entry of account history")
+		}
+		after = big.NewInt(-1)
+		if len(last.Data) > 0 {
+			after = last.Data[0].ID
+		}
+	}
+
+	poll, stopPolling := l.pollOtherWriters()
+	defer stopPolling()
+
+	for {
+		// The pagination starts from the given id, included
+		q := ledgerstore.NewGetAccountHistoryQuery(ledgerstore.NewPaginatedQueryOptions(filters).WithPageSize(100)).
+			WithOrder(bunpaginate.OrderAsc)
+		q.PaginationID = new(big.Int).Add(after, big.NewInt(1))
+
+		err := bunpaginate.Iterate(
+			ctx,
+			q,
+			func(ctx context.Context, q ledgerstore.GetAccountHistoryQuery) (*bunpaginate.Cursor[ledger.AccountHistoryEntry], error) {
+				return l.store.GetAccountHistory(ctx, q)
+			},
+			func(cursor *bunpaginate.Cursor[ledger.AccountHistoryEntry]) error {
+				for _, entry := range cursor.Data {
+					if err := fn(entry); err != nil {
+						return err
+					}
+					after = entry.ID
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notifications:
+		case <-poll:
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+)
+
+// loadAccountPolicies applies the account policies recorded in the storage to the commander.
+func (l *Ledger) loadAccountPolicies(ctx context.Context) error {
+	policies, err := l.store.GetAllAccountPolicies(ctx)
+	if err != nil {
+		return newStorageError(err, "getting account policies")
+	}
+	l.commander.SetAccountPolicies(policies)
+
+	return nil
+}
+
+// SaveAccountPolicy creates the policy, or replaces the policy having the same pattern.
+// The policy is enforced on the transactions created once it returns.
+func (l *Ledger) SaveAccountPolicy(ctx context.Context, policy ledger.AccountPolicy) error {
+	return l.updateAccountPolicies(ctx, func(ctx context.Context) error {
+		return newStorageError(l.store.SaveAccountPolicy(ctx, policy), "saving account policy")
+	})
+}
+
+func (l *Ledger) DeleteAccountPolicy(ctx context.Context, pattern string) error {
+	return l.updateAccountPolicies(ctx, func(ctx context.Context) error {
+		return newStorageError(l.store.DeleteAccountPolicy(ctx, pattern), "deleting account policy")
+	})
+}
+
+func (l *Ledger) updateAccountPolicies(ctx context.Context, updateFn func(ctx context.Context) error) error {
+	err := l.commander.UpdateAccountPolicies(ctx, func(ctx context.Context) (ledger.AccountPolicies, error) {
+		if err := updateFn(ctx); err != nil {
+			return nil, err
+		}
+
+		policies, err := l.store.GetAllAccountPolicies(ctx)
+		return policies, newStorageError(err, "getting account policies")
+	})
+	if err != nil {
+		if IsStorageError(err) {
+			return err
+		}
+		return NewCommandError(err)
+	}
+
+	return nil
+}
+
+func (l *Ledger) GetAccountPolicy(ctx context.Context, pattern string) (*ledger.AccountPolicy, error) {
+	policy, err := l.store.GetAccountPolicy(ctx, pattern)
+	return policy, newStorageError(err, "getting account policy")
+}
+
+func (l *Ledger) GetAccountPolicies(ctx context.Context, q ledgerstore.GetAccountPoliciesQuery) (*bunpaginate.Cursor[ledger.AccountPolicy], error) {
+	policies, err := l.store.GetAccountPolicies(ctx, q)
+	return policies, newStorageError(err, "getting account policies")
+}
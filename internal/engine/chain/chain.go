@@ -16,27 +16,65 @@ type Chain struct {
 	store    Store
 }
 
-func (chain *Chain) ChainLog(log *ledger.Log) *ledger.ChainedLog {
+// ChainLogs chains logs contiguously, then calls appendFn with them before any other log can be chained,
+// so the logs are appended in the order of the chain.
+func (chain *Chain) ChainLogs(logs []*ledger.Log, appendFn func(logs []*ledger.ChainedLog)) []*ledger.ChainedLog {
 	chain.mu.Lock()
 	defer chain.mu.Unlock()
 
-	chain.lastLog = log.ChainLog(chain.lastLog)
-	return chain.lastLog
+	chainedLogs := make([]*ledger.ChainedLog, 0, len(logs))
+	for _, log := range logs {
+		chain.lastLog = log.ChainLog(chain.lastLog)
+		chainedLogs = append(chainedLogs, chain.lastLog)
+	}
+	appendFn(chainedLogs)
+
+	return chainedLogs
+}
+
+// Reset calls dropFn, then reloads the chain from the store, while no log can be chained.
+// It is used when logs already chained could not be inserted, dropFn then drops the logs chained on top of them.
+func (chain *Chain) Reset(ctx context.Context, dropFn func()) error {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
+	dropFn()
+
+	return chain.init(ctx)
 }
 
 func (chain *Chain) Init(ctx context.Context) error {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
+	return chain.init(ctx)
+}
+
+func (chain *Chain) init(ctx context.Context) error {
+	// A ledger restored from a snapshot continues the chain of the snapshot until it has its own logs and transactions
+	snapshot, err := chain.store.GetRestoredSnapshot(ctx)
+	if err != nil && !storageerrors.IsNotFoundError(err) {
+		return err
+	}
+
 	lastTx, err := chain.store.GetLastTransaction(ctx)
 	if err != nil && !storageerrors.IsNotFoundError(err) {
 		return err
 	}
-	if lastTx != nil {
+	switch {
+	case lastTx != nil:
 		chain.lastTXID = lastTx.ID
+	case snapshot != nil && snapshot.LastTransactionID != nil:
+		chain.lastTXID = snapshot.LastTransactionID
 	}
 
 	chain.lastLog, err = chain.store.GetLastLog(ctx)
 	if err != nil && !storageerrors.IsNotFoundError(err) {
 		return err
 	}
+	if chain.lastLog == nil && snapshot != nil {
+		chain.lastLog = snapshot.LastLog()
+	}
 	return nil
 }
 
@@ -49,6 +87,20 @@ func (chain *Chain) AllocateNewTxID() *big.Int {
 	return chain.lastTXID
 }
 
+// AllocateNewTxIDs allocates n contiguous transaction ids starting from first, which must be the next id.
+// It allocates nothing and returns false if other ids have been allocated since first was predicted.
+func (chain *Chain) AllocateNewTxIDs(first *big.Int, n int) bool {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
+	if chain.predictNextTxID().Cmp(first) != 0 {
+		return false
+	}
+	chain.lastTXID = new(big.Int).Add(first, big.NewInt(int64(n-1)))
+
+	return true
+}
+
 func (chain *Chain) PredictNextTxID() *big.Int {
 	chain.mu.Lock()
 	defer chain.mu.Unlock()
@@ -60,7 +112,11 @@ func (chain *Chain) predictNextTxID() *big.Int {
 	return big.NewInt(0).Add(chain.lastTXID, big.NewInt(1))
 }
 
+// ReplaceLast sets log as the last log of the chain, it is used to append the logs chained by another ledger.
 func (chain *Chain) ReplaceLast(log *ledger.ChainedLog) {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
 	if log.Type == ledger.NewTransactionLogType {
 		chain.lastTXID = log.Data.(ledger.NewTransactionLogPayload).Transaction.ID
 	}
@@ -68,6 +124,9 @@ func (chain *Chain) ReplaceLast(log *ledger.ChainedLog) {
 }
 
 func (chain *Chain) GetLastLog() *ledger.ChainedLog {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
 	return chain.lastLog
 }
 
@@ -9,4 +9,5 @@ import (
 type Store interface {
 	GetLastLog(ctx context.Context) (*ledger.ChainedLog, error)
 	GetLastTransaction(ctx context.Context) (*ledger.ExpandedTransaction, error)
+	GetRestoredSnapshot(ctx context.Context) (*ledger.SnapshotHeader, error)
 }
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"context"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+)
+
+// loadChart applies the chart of accounts recorded in the storage to the commander.
+func (l *Ledger) loadChart(ctx context.Context) error {
+	chart, err := l.getChart(ctx)
+	if err != nil {
+		return err
+	}
+	l.commander.SetChart(chart)
+
+	return nil
+}
+
+// getChart returns the chart of accounts of the ledger, or nil if it has none.
+func (l *Ledger) getChart(ctx context.Context) (*ledger.Chart, error) {
+	chart, err := l.store.GetChart(ctx)
+	if err != nil {
+		if sqlutils.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, newStorageError(err, "getting chart of accounts")
+	}
+	return chart, nil
+}
+
+// SaveChart creates or replaces the chart of accounts of the ledger.
+// The transactions created once it returns are checked against the chart.
+func (l *Ledger) SaveChart(ctx context.Context, chart ledger.Chart) error {
+	return l.updateChart(ctx, func(ctx context.Context) error {
+		return newStorageError(l.store.SaveChart(ctx, chart), "saving chart of accounts")
+	})
+}
+
+// DeleteChart deletes the chart of accounts of the ledger, so that any account can be used again.
+func (l *Ledger) DeleteChart(ctx context.Context) error {
+	return l.updateChart(ctx, func(ctx context.Context) error {
+		return newStorageError(l.store.DeleteChart(ctx), "deleting chart of accounts")
+	})
+}
+
+func (l *Ledger) updateChart(ctx context.Context, updateFn func(ctx context.Context) error) error {
+	err := l.commander.UpdateChart(ctx, func(ctx context.Context) (*ledger.Chart, error) {
+		if err := updateFn(ctx); err != nil {
+			return nil, err
+		}
+
+		return l.getChart(ctx)
+	})
+	if err != nil {
+		if IsStorageError(err) {
+			return err
+		}
+		return NewCommandError(err)
+	}
+
+	return nil
+}
+
+func (l *Ledger) GetChart(ctx context.Context) (*ledger.Chart, error) {
+	chart, err := l.store.GetChart(ctx)
+	return chart, newStorageError(err, "getting chart of accounts")
+}
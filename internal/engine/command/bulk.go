@@ -0,0 +1,539 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/machine/vm"
+	"github.com/formancehq/ledger/internal/opentelemetry/tracer"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+)
+
+type RevertTransactionRequest struct {
+	ID              *big.Int
+	Force           bool
+	AtEffectiveDate bool
+}
+
+type SaveMetadataRequest struct {
+	TargetType string
+	TargetID   any
+	Metadata   metadata.Metadata
+}
+
+type DeleteMetadataRequest struct {
+	TargetType string
+	TargetID   any
+	Key        string
+}
+
+// BulkElement is an operation of an atomic bulk, only one of the requests must be set.
+type BulkElement struct {
+	IdempotencyKey    string
+	CreateTransaction *ledger.RunScript
+	RevertTransaction *RevertTransactionRequest
+	SaveMetadata      *SaveMetadataRequest
+	DeleteMetadata    *DeleteMetadataRequest
+}
+
+// fingerprint hashes the request of the element the same way as the corresponding command,
+// so an idempotency key can be reused indifferently within or outside of a bulk.
+func (element BulkElement) fingerprint() string {
+	switch {
+	case element.CreateTransaction != nil:
+		return fingerprint(actionCreateTransaction, *element.CreateTransaction)
+	case element.RevertTransaction != nil:
+		return fingerprint(actionRevertTransaction, *element.RevertTransaction)
+	case element.SaveMetadata != nil:
+		return fingerprint(actionSaveMetadata, *element.SaveMetadata)
+	case element.DeleteMetadata != nil:
+		return fingerprint(actionDeleteMetadata, *element.DeleteMetadata)
+	default:
+		return ""
+	}
+}
+
+// BulkElementResult is the outcome of an element of an atomic bulk.
+// Log is only set when the bulk is committed.
+type BulkElementResult struct {
+	Log *ledger.ChainedLog
+	Err error
+}
+
+// bulkOperation is an element of an atomic bulk being processed.
+type bulkOperation struct {
+	BulkElement
+	script   ledger.RunScript
+	reverted *ledger.Transaction
+	result   *vm.Result
+	tx       *ledger.Transaction
+	err      error
+}
+
+// ExecuteAtomicBulk executes all the elements of a bulk against a single lock set,
+// then commits their logs as a single batch.
+// Each element sees the effects of the previous ones, so a bulk can for example revert a transaction it creates.
+// If any element fails, nothing is committed and the errors are reported by element.
+func (commander *Commander) ExecuteAtomicBulk(ctx context.Context, elements []BulkElement) ([]BulkElementResult, error) {
+
+	ctx, span := tracer.Start(ctx, "AtomicBulk")
+	defer span.End()
+
+	commander.rules.RLock()
+	defer commander.rules.RUnlock()
+
+	ctx, unlead, err := commander.lead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlead(ctx)
+
+	var (
+		results    = make([]BulkElementResult, len(elements))
+		operations = make([]*bulkOperation, len(elements))
+		references = map[string]struct{}{}
+		iks        = map[string]struct{}{}
+		reverts    = map[string]struct{}{}
+		failed     = false
+	)
+	fail := func(i int, err error) {
+		results[i].Err = err
+		failed = true
+	}
+
+	for i, element := range elements {
+		if ik := element.IdempotencyKey; ik != "" {
+			if _, ok := iks[ik]; ok {
+				fail(i, NewErrIdempotencyKeyDuplicated(ik))
+				continue
+			}
+			iks[ik] = struct{}{}
+
+			if err := commander.referencer.take(referenceIks, ik); err != nil {
+				return nil, err
+			}
+			defer commander.referencer.release(referenceIks, ik)
+
+			log, err := commander.store.ReadLogWithIdempotencyKey(ctx, ik)
+			if err == nil {
+				if err := checkIdempotencyHash(log, element.fingerprint()); err != nil {
+					fail(i, err)
+					continue
+				}
+				results[i].Log = log
+				continue
+			}
+			if !storageerrors.IsNotFoundError(err) {
+				return nil, err
+			}
+		}
+
+		operation := &bulkOperation{
+			BulkElement: element,
+		}
+
+		switch {
+		case element.CreateTransaction != nil:
+			operation.script = *element.CreateTransaction
+			if operation.script.Plain == "" {
+				fail(i, NewErrNoScript())
+				continue
+			}
+			if operation.script.Timestamp.IsZero() {
+				operation.script.Timestamp = time.Now()
+			}
+			if reference := operation.script.Reference; reference != "" {
+				if _, ok := references[reference]; ok {
+					fail(i, NewErrConflict())
+					continue
+				}
+				references[reference] = struct{}{}
+
+				if err := commander.referencer.take(referenceTxReference, reference); err != nil {
+					fail(i, NewErrConflict())
+					continue
+				}
+				defer commander.referencer.release(referenceTxReference, reference)
+
+				if err := commander.checkReference(ctx, reference); err != nil {
+					if IsInvalidTransactionError(err, ErrInvalidTransactionCodeConflict) {
+						fail(i, err)
+						continue
+					}
+					return nil, err
+				}
+			}
+		case element.RevertTransaction != nil:
+			id := element.RevertTransaction.ID
+			if _, ok := reverts[id.String()]; ok {
+				fail(i, NewErrRevertTransactionAlreadyReverted())
+				continue
+			}
+			reverts[id.String()] = struct{}{}
+
+			if err := commander.referencer.take(referenceReverts, id); err != nil {
+				fail(i, NewErrRevertTransactionOccurring())
+				continue
+			}
+			defer commander.referencer.release(referenceReverts, id)
+		case element.SaveMetadata != nil:
+			if err := checkHoldMetadata(element.SaveMetadata.Metadata); err != nil {
+				fail(i, err)
+				continue
+			}
+		case element.DeleteMetadata != nil:
+			if ledger.IsHoldMetadata(element.DeleteMetadata.Key) {
+				fail(i, NewErrHoldMetadataProtected(element.DeleteMetadata.Key))
+				continue
+			}
+		default:
+			return nil, fmt.Errorf("element %d has no operation", i)
+		}
+
+		operations[i] = operation
+	}
+
+	if failed {
+		return results, nil
+	}
+
+	// The accounts to lock are only known once the operations are executed, as they depend on the effects of the previous ones.
+	// The operations are executed again once the accounts are locked, until they do not need other accounts.
+	var (
+		lockAccounts = Accounts{}
+		unlock       Unlock
+		store        *bulkStore
+		allocated    = false
+	)
+	for {
+		unlock, err = commander.lock(ctx, lockAccounts)
+		if err != nil {
+			return nil, err
+		}
+
+		var accounts Accounts
+		store = newBulkStore(commander.store, commander.chain.PredictNextTxID())
+		accounts, failed, err = commander.executeBulk(ctx, operations, store)
+		if err != nil {
+			unlock(ctx)
+			return nil, err
+		}
+		if lockAccounts.contains(accounts) {
+			if failed {
+				break
+			}
+			// The transactions created by the bulk may be referenced by their id in the following elements,
+			// they must then be committed with the ids they have been executed with
+			allocated = commander.chain.AllocateNewTxIDs(store.firstTxID, store.txs)
+			if allocated || !store.referenced {
+				break
+			}
+		}
+
+		unlock(ctx)
+		lockAccounts = lockAccounts.merge(accounts)
+	}
+	defer unlock(ctx)
+
+	if failed {
+		for i, operation := range operations {
+			if operation != nil {
+				results[i].Err = operation.err
+			}
+		}
+		return results, nil
+	}
+
+	logs := make([]*ledger.Log, 0, len(operations))
+	for _, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		if operation.tx != nil && !allocated {
+			operation.tx.ID = commander.chain.AllocateNewTxID()
+		}
+
+		var log *ledger.Log
+		at := time.Now()
+		switch {
+		case operation.CreateTransaction != nil:
+			log = ledger.NewTransactionLog(operation.tx, operation.result.AccountMetadata)
+		case operation.RevertTransaction != nil:
+			log = ledger.NewRevertedTransactionLog(operation.tx.Timestamp, operation.reverted.ID, operation.tx)
+		case operation.SaveMetadata != nil:
+			log = ledger.NewSetMetadataLog(at, ledger.SetMetadataLogPayload{
+				TargetType: operation.SaveMetadata.TargetType,
+				TargetID:   operation.SaveMetadata.TargetID,
+				Metadata:   operation.SaveMetadata.Metadata,
+			})
+		case operation.DeleteMetadata != nil:
+			log = ledger.NewDeleteMetadataLog(at, ledger.DeleteMetadataLogPayload{
+				TargetType: operation.DeleteMetadata.TargetType,
+				TargetID:   operation.DeleteMetadata.TargetID,
+				Key:        operation.DeleteMetadata.Key,
+			})
+		}
+		if operation.IdempotencyKey != "" {
+			log = log.
+				WithIdempotencyKey(operation.IdempotencyKey).
+				WithIdempotencyHash(operation.fingerprint())
+		}
+		logs = append(logs, log)
+	}
+
+	chainedLogs, err := commander.appendLogs(ctx, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		results[i].Log = chainedLogs[0]
+		chainedLogs = chainedLogs[1:]
+
+		switch payload := results[i].Log.Data.(type) {
+		case ledger.NewTransactionLogPayload:
+			commander.monitor.CommittedTransactions(ctx, *payload.Transaction, payload.AccountMetadata)
+		case ledger.RevertedTransactionLogPayload:
+			commander.monitor.RevertedTransaction(ctx, payload.RevertTransaction, operation.reverted)
+		case ledger.SetMetadataLogPayload:
+			commander.monitor.SavedMetadata(ctx, payload.TargetType, fmt.Sprint(payload.TargetID), payload.Metadata)
+		case ledger.DeleteMetadataLogPayload:
+			commander.monitor.DeletedMetadata(ctx, payload.TargetType, payload.TargetID, payload.Key)
+		}
+	}
+
+	return results, nil
+}
+
+// executeBulk executes the operations in order on top of store, so each one sees the effects of the previous ones,
+// and returns the accounts they use.
+// The errors of the operations are reported on them, failed is then set. The other errors are returned.
+func (commander *Commander) executeBulk(ctx context.Context, operations []*bulkOperation, store *bulkStore) (accounts Accounts, failed bool, err error) {
+	fail := func(operation *bulkOperation, err error) {
+		operation.err = err
+		failed = true
+	}
+
+	for _, operation := range operations {
+		if operation == nil {
+			continue
+		}
+		operation.err = nil
+		operation.result = nil
+		operation.tx = nil
+
+		switch {
+		case operation.RevertTransaction != nil:
+			script, reverted, err := commander.revertScript(ctx, store, *operation.RevertTransaction)
+			if err != nil {
+				if IsRevertError(err, ErrRevertTransactionCodeNotFound) ||
+					IsRevertError(err, ErrRevertTransactionCodeAlreadyReverted) {
+					fail(operation, err)
+					continue
+				}
+				return Accounts{}, false, err
+			}
+			operation.script = *script
+			operation.reverted = reverted
+		case operation.SaveMetadata != nil:
+			if err := checkMetadataTarget(ctx, store, operation.SaveMetadata.TargetType, operation.SaveMetadata.TargetID); err != nil {
+				fail(operation, newErrSaveMetadataTransactionNotFound())
+				continue
+			}
+			if operation.SaveMetadata.TargetType == ledger.MetaTargetTypeAccount {
+				if err := store.apply(ctx, nil, map[string]metadata.Metadata{
+					operation.SaveMetadata.TargetID.(string): operation.SaveMetadata.Metadata,
+				}); err != nil {
+					return Accounts{}, false, err
+				}
+			}
+			continue
+		case operation.DeleteMetadata != nil:
+			if err := checkMetadataTarget(ctx, store, operation.DeleteMetadata.TargetType, operation.DeleteMetadata.TargetID); err != nil {
+				fail(operation, newErrDeleteMetadataTransactionNotFound())
+				continue
+			}
+			if operation.DeleteMetadata.TargetType == ledger.MetaTargetTypeAccount {
+				if err := store.deleteMetadata(ctx, operation.DeleteMetadata.TargetID.(string), operation.DeleteMetadata.Key); err != nil {
+					return Accounts{}, false, err
+				}
+			}
+			continue
+		}
+
+		if err := commander.checkPeriod(operation.script.Timestamp); err != nil {
+			fail(operation, err)
+			continue
+		}
+
+		m, lockAccounts, err := commander.prepare(ctx, store, operation.script)
+		if err != nil {
+			fail(operation, err)
+			continue
+		}
+		accounts.Read = append(accounts.Read, lockAccounts.Read...)
+		accounts.Write = append(accounts.Write, lockAccounts.Write...)
+
+		result, err := commander.run(ctx, m, store, operation.script, "")
+		if err != nil {
+			if IsErrMachine(err) || IsErrInvalidTransaction(err) || IsErrAccountFrozen(err) || IsErrChartViolation(err) || IsErrHoldProtected(err) {
+				fail(operation, err)
+				continue
+			}
+			return Accounts{}, false, err
+		}
+		operation.result = result
+		operation.tx = newTransaction(result, operation.script, store.nextTxID())
+		if err := store.apply(ctx, result.Postings, result.AccountMetadata); err != nil {
+			return Accounts{}, false, err
+		}
+		store.transactions[operation.tx.ID.String()] = operation.tx
+	}
+
+	return accounts, failed, nil
+}
+
+func checkMetadataTarget(ctx context.Context, store transactionReader, targetType string, targetID any) error {
+	if targetType != ledger.MetaTargetTypeTransaction {
+		return nil
+	}
+	_, err := store.GetTransaction(ctx, targetID.(*big.Int))
+	return err
+}
+
+// appendLogs chains the logs contiguously and waits for them to be inserted within the same batch.
+func (commander *Commander) appendLogs(ctx context.Context, logs []*ledger.Log) ([]*ledger.ChainedLog, error) {
+	ctx, span := tracer.Start(ctx, "AppendLogs")
+	defer span.End()
+
+	done := make(chan error, 1)
+	chainedLogs := commander.chain.ChainLogs(logs, func(logs []*ledger.ChainedLog) {
+		commander.AppendAll(logs, func(err error) {
+			done <- err
+		})
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		return chainedLogs, nil
+	}
+}
+
+// bulkStore applies the effects of the elements of a bulk already executed
+// on top of the ledger store.
+type bulkStore struct {
+	Store
+	balances     map[string]map[string]*big.Int
+	accounts     map[string]*ledger.Account
+	transactions map[string]*ledger.Transaction
+	// firstTxID is the id of the first transaction created by the bulk, txs the number of transactions it creates
+	firstTxID *big.Int
+	txs       int
+	// referenced is set when a transaction created by the bulk is read by a following element
+	referenced bool
+}
+
+func (s *bulkStore) GetTransaction(ctx context.Context, txID *big.Int) (*ledger.Transaction, error) {
+	if tx, ok := s.transactions[txID.String()]; ok {
+		s.referenced = true
+		return tx, nil
+	}
+	return s.Store.GetTransaction(ctx, txID)
+}
+
+func (s *bulkStore) nextTxID() *big.Int {
+	id := new(big.Int).Add(s.firstTxID, big.NewInt(int64(s.txs)))
+	s.txs++
+	return id
+}
+
+func (s *bulkStore) GetBalance(ctx context.Context, address, asset string) (*big.Int, error) {
+	balance, err := s.Store.GetBalance(ctx, address, asset)
+	if err != nil {
+		return nil, err
+	}
+	if delta, ok := s.balances[address][asset]; ok {
+		return new(big.Int).Add(balance, delta), nil
+	}
+	return balance, nil
+}
+
+func (s *bulkStore) GetAccount(ctx context.Context, address string) (*ledger.Account, error) {
+	if account, ok := s.accounts[address]; ok {
+		return account, nil
+	}
+	return s.Store.GetAccount(ctx, address)
+}
+
+func (s *bulkStore) account(ctx context.Context, address string) (*ledger.Account, error) {
+	if account, ok := s.accounts[address]; ok {
+		return account, nil
+	}
+	account, err := s.Store.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	s.accounts[address] = &ledger.Account{
+		Address:  address,
+		Metadata: metadata.Metadata{}.Merge(account.Metadata),
+	}
+	return s.accounts[address], nil
+}
+
+func (s *bulkStore) addBalance(address, asset string, amount *big.Int) {
+	if _, ok := s.balances[address]; !ok {
+		s.balances[address] = map[string]*big.Int{}
+	}
+	if _, ok := s.balances[address][asset]; !ok {
+		s.balances[address][asset] = new(big.Int)
+	}
+	s.balances[address][asset].Add(s.balances[address][asset], amount)
+}
+
+func (s *bulkStore) apply(ctx context.Context, postings ledger.Postings, accountMetadata map[string]metadata.Metadata) error {
+	for _, posting := range postings {
+		s.addBalance(posting.Source, posting.Asset, new(big.Int).Neg(posting.Amount))
+		s.addBalance(posting.Destination, posting.Asset, posting.Amount)
+	}
+	for address, m := range accountMetadata {
+		account, err := s.account(ctx, address)
+		if err != nil {
+			return err
+		}
+		account.Metadata = account.Metadata.Merge(m)
+	}
+	return nil
+}
+
+func (s *bulkStore) deleteMetadata(ctx context.Context, address, key string) error {
+	account, err := s.account(ctx, address)
+	if err != nil {
+		return err
+	}
+	delete(account.Metadata, key)
+	return nil
+}
+
+func newBulkStore(store Store, firstTxID *big.Int) *bulkStore {
+	return &bulkStore{
+		Store:        store,
+		balances:     map[string]map[string]*big.Int{},
+		accounts:     map[string]*ledger.Account{},
+		transactions: map[string]*ledger.Transaction{},
+		firstTxID:    firstTxID,
+	}
+}
+
+var _ vm.Store = (*bulkStore)(nil)
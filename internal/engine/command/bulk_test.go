@@ -0,0 +1,189 @@
+package command
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/bus"
+	"github.com/formancehq/ledger/internal/engine/chain"
+	"github.com/formancehq/ledger/internal/machine"
+	storageerrors "github.com/formancehq/ledger/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func newBulkTestingCommander(t *testing.T) (*Commander, *storageerrors.InMemoryStore) {
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	t.Cleanup(commander.Close)
+
+	return commander, store
+}
+
+func sendScript(source, destination string, amount int64) *ledger.RunScript {
+	script := ledger.TxToScriptData(ledger.TransactionData{
+		Postings: ledger.Postings{
+			ledger.NewPosting(source, destination, "USD", big.NewInt(amount)),
+		},
+	}, false)
+	return &script
+}
+
+func TestAtomicBulk(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newBulkTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{
+		{CreateTransaction: sendScript("world", "alice", 100)},
+		{CreateTransaction: sendScript("alice", "bob", 60)},
+		{SaveMetadata: &SaveMetadataRequest{
+			TargetType: ledger.MetaTargetTypeAccount,
+			TargetID:   "bob",
+			Metadata:   metadata.Metadata{"foo": "bar"},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		require.NotNil(t, result.Log)
+	}
+	require.Equal(t, big.NewInt(1), results[1].Log.Data.(ledger.NewTransactionLogPayload).Transaction.ID)
+
+	requireBalance(t, store, "alice", 40)
+	requireBalance(t, store, "bob", 60)
+
+	account, err := store.GetAccount(context.Background(), "bob")
+	require.NoError(t, err)
+	require.Equal(t, metadata.Metadata{"foo": "bar"}, account.Metadata)
+}
+
+func TestAtomicBulkWithFailure(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newBulkTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{
+		{CreateTransaction: sendScript("world", "alice", 100)},
+		{CreateTransaction: sendScript("alice", "bob", 150)},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.Nil(t, results[0].Log)
+	require.True(t, machine.IsInsufficientFundError(results[1].Err))
+
+	requireBalance(t, store, "alice", 0)
+	lastLog, err := store.GetLastLog(ctx)
+	require.NoError(t, err)
+	require.Nil(t, lastLog)
+}
+
+func TestAtomicBulkWithIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	commander, _ := newBulkTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	tx, err := commander.CreateTransaction(ctx, Parameters{IdempotencyKey: "ik"}, *sendScript("world", "alice", 100))
+	require.NoError(t, err)
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		IdempotencyKey:    "ik",
+		CreateTransaction: sendScript("world", "alice", 100),
+	}})
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, tx.ID, results[0].Log.Data.(ledger.NewTransactionLogPayload).Transaction.ID)
+
+	results, err = commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		IdempotencyKey:    "ik",
+		CreateTransaction: sendScript("world", "alice", 200),
+	}})
+	require.NoError(t, err)
+	require.True(t, IsErrIdempotencyKeyConflict(results[0].Err))
+}
+
+func TestAtomicBulkWithDuplicatedIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	commander, _ := newBulkTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{
+		{IdempotencyKey: "ik", CreateTransaction: sendScript("world", "alice", 100)},
+		{IdempotencyKey: "ik", CreateTransaction: sendScript("world", "alice", 100)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	require.Nil(t, results[0].Log)
+	require.True(t, IsErrIdempotencyKeyConflict(results[1].Err))
+}
+
+func TestAtomicBulkReadsPreviousElements(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newBulkTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{
+		{CreateTransaction: sendScript("world", "alice", 100)},
+		{SaveMetadata: &SaveMetadataRequest{
+			TargetType: ledger.MetaTargetTypeTransaction,
+			TargetID:   big.NewInt(0),
+			Metadata:   metadata.Metadata{"foo": "bar"},
+		}},
+		{RevertTransaction: &RevertTransactionRequest{
+			ID: big.NewInt(0),
+		}},
+		{SaveMetadata: &SaveMetadataRequest{
+			TargetType: ledger.MetaTargetTypeAccount,
+			TargetID:   "alice",
+			Metadata:   metadata.Metadata{"destination": "bank"},
+		}},
+		// The variables are resolved from the metadata saved by the previous elements
+		{CreateTransaction: &ledger.RunScript{
+			Script: ledger.Script{
+				Plain: `
+vars {
+	account $destination = meta(@alice, "destination")
+}
+send [USD 10] (
+	source = @world
+	destination = $destination
+)`,
+			},
+		}},
+	})
+	require.NoError(t, err)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		require.NotNil(t, result.Log)
+	}
+	require.Equal(t, big.NewInt(0), results[2].Log.Data.(ledger.RevertedTransactionLogPayload).RevertedTransactionID)
+	require.Equal(t, big.NewInt(1), results[2].Log.Data.(ledger.RevertedTransactionLogPayload).RevertTransaction.ID)
+
+	requireBalance(t, store, "alice", 0)
+	requireBalance(t, store, "bank", 10)
+
+	require.Equal(t, big.NewInt(0), results[1].Log.Data.(ledger.SetMetadataLogPayload).TargetID)
+
+	// The transactions created by the bulk are only visible to the following elements
+	results, err = commander.ExecuteAtomicBulk(ctx, []BulkElement{
+		{RevertTransaction: &RevertTransactionRequest{
+			ID: big.NewInt(4),
+		}},
+		{CreateTransaction: sendScript("world", "alice", 100)},
+	})
+	require.NoError(t, err)
+	require.True(t, IsRevertError(results[0].Err, ErrRevertTransactionCodeNotFound))
+}
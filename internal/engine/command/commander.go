@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
+	"github.com/formancehq/ledger/internal/machine"
 	"github.com/formancehq/ledger/internal/machine/vm/program"
 	"github.com/formancehq/ledger/internal/opentelemetry/tracer"
 
@@ -27,8 +29,10 @@ type Parameters struct {
 }
 
 type Chainer interface {
-	ChainLog(log *ledger.Log) *ledger.ChainedLog
+	ChainLogs(logs []*ledger.Log, appendFn func(logs []*ledger.ChainedLog)) []*ledger.ChainedLog
+	Reset(ctx context.Context, dropFn func()) error
 	AllocateNewTxID() *big.Int
+	AllocateNewTxIDs(first *big.Int, n int) bool
 	PredictNextTxID() *big.Int
 }
 
@@ -40,8 +44,20 @@ type Commander struct {
 	running    sync.WaitGroup
 	referencer *Referencer
 
-	monitor bus.Monitor
-	chain   Chainer
+	monitor          bus.Monitor
+	chain            Chainer
+	onBatchProcessed batching.OnBatchProcessed[*ledger.ChainedLog]
+
+	readOnly  atomic.Bool
+	importing atomic.Bool
+
+	// rules is read locked by the writes, and write locked while the rules enforced on them are changed,
+	// so the writes started once a change is recorded all comply with it, or while an import starts
+	rules           sync.RWMutex
+	closingDate     atomic.Pointer[time.Time]
+	accountPolicies atomic.Pointer[ledger.AccountPolicies]
+	accountFreezes  atomic.Pointer[ledger.AccountFreezes]
+	chart           atomic.Pointer[ledger.Chart]
 }
 
 func New(
@@ -53,23 +69,87 @@ func New(
 	chain Chainer,
 	batchSize int,
 ) *Commander {
-	return &Commander{
-		store:      store,
-		locker:     locker,
-		compiler:   compiler,
-		chain:      chain,
-		referencer: referencer,
-		Batcher:    batching.NewBatcher(store.InsertLogs, 1, batchSize),
-		monitor:    monitor,
+	ret := &Commander{
+		store:            store,
+		locker:           locker,
+		compiler:         compiler,
+		chain:            chain,
+		referencer:       referencer,
+		monitor:          monitor,
+		onBatchProcessed: batching.NoOpOnBatchProcessed[*ledger.ChainedLog](),
+	}
+	ret.Batcher = batching.NewBatcher(ret.insertLogs, 1, batchSize)
+	return ret
+}
+
+// OnBatchProcessed registers a function called with the logs of each batch once they are inserted.
+// It must be called before running the commander.
+func (commander *Commander) OnBatchProcessed(fn batching.OnBatchProcessed[*ledger.ChainedLog]) {
+	commander.onBatchProcessed = fn
+}
+
+func (commander *Commander) insertLogs(ctx context.Context, logs ...*ledger.ChainedLog) error {
+	// A batch is empty when it only contains the barriers appended by drain
+	if len(logs) == 0 {
+		return nil
+	}
+	insertCtx, err := commander.CheckLeadership(ctx)
+	if err == nil {
+		err = commander.store.InsertLogs(insertCtx, logs...)
+		// The insert is rejected if the leadership has been lost in the meantime
+		if err != nil {
+			if _, leadErr := commander.CheckLeadership(ctx); leadErr != nil {
+				err = leadErr
+			}
+		}
+	}
+	if err != nil {
+		// The pending logs are chained on top of the ones which could not be inserted,
+		// they are dropped and the chain restarts from the last inserted log
+		if err := commander.chain.Reset(ctx, func() {
+			commander.DropPending(errors.Wrap(err, "inserting previous logs"))
+		}); err != nil {
+			panic(errors.Wrap(err, "reloading chain after a failed insert"))
+		}
+		return err
+	}
+	commander.onBatchProcessed(logs...)
+	return nil
+}
+
+// CheckLeadership returns an error if the instance may not be the writer of the ledger anymore,
+// when the writer is elected among several instances.
+// Otherwise, it returns the context to insert the logs with.
+func (commander *Commander) CheckLeadership(ctx context.Context) (context.Context, error) {
+	if checker, ok := commander.locker.(LeadershipChecker); ok {
+		return checker.CheckLeadership(ctx)
 	}
+	return ctx, nil
 }
 
 func (commander *Commander) GetLedgerStore() Store {
 	return commander.store
 }
 
-func (commander *Commander) exec(ctx context.Context, parameters Parameters, script ledger.RunScript,
-	logComputer func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) *ledger.Log) (*ledger.ChainedLog, error) {
+// SetReadOnly makes the commander reject all writes until it is set back to false.
+func (commander *Commander) SetReadOnly(readOnly bool) {
+	commander.readOnly.Store(readOnly)
+}
+
+// exec runs script and appends the log computed from its transaction.
+// hold is the account of the hold created or settled by the script, the only hold account it can use, if any.
+func (commander *Commander) exec(ctx context.Context, parameters Parameters, fingerprint string, script ledger.RunScript, hold string,
+	logComputer func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) (*ledger.Log, error)) (*ledger.ChainedLog, error) {
+
+	commander.rules.RLock()
+	defer commander.rules.RUnlock()
+
+	return commander.execWithRules(ctx, parameters, fingerprint, script, hold, logComputer)
+}
+
+// execWithRules is exec, for the callers having locked the rules already, typically using leadWithRules.
+func (commander *Commander) execWithRules(ctx context.Context, parameters Parameters, fingerprint string, script ledger.RunScript, hold string,
+	logComputer func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) (*ledger.Log, error)) (*ledger.ChainedLog, error) {
 
 	if script.Script.Plain == "" {
 		return nil, NewErrNoScript()
@@ -79,129 +159,289 @@ func (commander *Commander) exec(ctx context.Context, parameters Parameters, scr
 		script.Timestamp = time.Now()
 	}
 
-	execContext := newExecutionContext(commander, parameters)
-	return execContext.run(ctx, func(executionContext *executionContext) (*ledger.ChainedLog, error) {
+	execContext := newExecutionContext(commander, parameters, fingerprint)
+	return execContext.run(ctx, func(ctx context.Context, executionContext *executionContext) (*ledger.ChainedLog, error) {
+		if err := commander.checkPeriod(script.Timestamp); err != nil {
+			return nil, err
+		}
+
 		if script.Reference != "" {
 			if err := commander.referencer.take(referenceTxReference, script.Reference); err != nil {
 				return nil, NewErrConflict()
 			}
 			defer commander.referencer.release(referenceTxReference, script.Reference)
 
-			err := func() error {
-				ctx, span := tracer.Start(ctx, "CheckReference")
-				defer span.End()
-
-				_, err := commander.store.GetTransactionByReference(ctx, script.Reference)
-				if err == nil {
-					return NewErrConflict()
-				}
-				if err != nil && !storageerrors.IsNotFoundError(err) {
-					return err
-				}
-				return nil
-			}()
-			if err != nil {
+			if err := commander.checkReference(ctx, script.Reference); err != nil {
 				return nil, err
 			}
 		}
 
-		program, err := func() (*program.Program, error) {
-			_, span := tracer.Start(ctx, "CompileNumscript")
-			defer span.End()
+		m, lockAccounts, err := commander.prepare(ctx, commander.store, script)
+		if err != nil {
+			return nil, err
+		}
 
-			program, err := commander.compiler.Compile(script.Plain)
-			if err != nil {
-				return nil, NewErrCompilationFailed(err)
-			}
+		unlock, err := commander.lock(ctx, lockAccounts)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock(ctx)
 
-			return program, nil
-		}()
+		result, err := commander.run(ctx, m, commander.store, script, hold)
 		if err != nil {
 			return nil, err
 		}
 
-		m := vm.NewMachine(*program)
-		if err := m.SetVarsFromJSON(script.Vars); err != nil {
-			return nil, NewErrCompilationFailed(err)
+		txID := commander.chain.PredictNextTxID()
+		if !parameters.DryRun {
+			txID = commander.chain.AllocateNewTxID()
 		}
 
-		readLockAccounts, writeLockAccounts, err := m.ResolveResources(ctx, commander.store)
+		log, err := logComputer(newTransaction(result, script, txID), result.AccountMetadata)
 		if err != nil {
-			return nil, NewErrCompilationFailed(err)
-		}
-		lockAccounts := Accounts{
-			Read:  readLockAccounts,
-			Write: writeLockAccounts,
+			return nil, err
 		}
+		return executionContext.AppendLog(ctx, log)
+	})
+}
 
-		unlock, err := func() (Unlock, error) {
-			_, span := tracer.Start(ctx, "Lock")
-			defer span.End()
+func (commander *Commander) checkReference(ctx context.Context, reference string) error {
+	ctx, span := tracer.Start(ctx, "CheckReference")
+	defer span.End()
 
-			unlock, err := commander.locker.Lock(ctx, lockAccounts)
-			if err != nil {
-				return nil, errors.Wrap(err, "locking accounts for tx processing")
-			}
+	_, err := commander.store.GetTransactionByReference(ctx, reference)
+	if err == nil {
+		return NewErrConflict()
+	}
+	if err != nil && !storageerrors.IsNotFoundError(err) {
+		return err
+	}
+	return nil
+}
+
+// prepare compiles the script and resolves the accounts it needs to lock, reading their metadata from store.
+func (commander *Commander) prepare(ctx context.Context, store vm.Store, script ledger.RunScript) (*vm.Machine, Accounts, error) {
+	program, err := func() (*program.Program, error) {
+		_, span := tracer.Start(ctx, "CompileNumscript")
+		defer span.End()
 
-			return unlock, nil
-		}()
+		program, err := commander.compiler.Compile(script.Plain)
 		if err != nil {
-			return nil, err
+			return nil, NewErrCompilationFailed(err)
 		}
-		defer unlock(ctx)
 
-		err = func() error {
-			ctx, span := tracer.Start(ctx, "ResolveBalances")
-			defer span.End()
+		return program, nil
+	}()
+	if err != nil {
+		return nil, Accounts{}, err
+	}
 
-			err = m.ResolveBalances(ctx, commander.store)
-			if err != nil {
-				return errors.Wrap(err, "could not resolve balances")
-			}
+	m := vm.NewMachine(*program)
+	m.SetAccountPolicies(commander.getAccountPolicies())
+	if err := m.SetVarsFromJSON(script.Vars); err != nil {
+		return nil, Accounts{}, NewErrCompilationFailed(err)
+	}
+
+	readLockAccounts, writeLockAccounts, err := m.ResolveResources(ctx, store)
+	if err != nil {
+		return nil, Accounts{}, NewErrCompilationFailed(err)
+	}
+
+	return m, Accounts{
+		Read:  readLockAccounts,
+		Write: writeLockAccounts,
+	}, nil
+}
+
+func (commander *Commander) lock(ctx context.Context, accounts Accounts) (Unlock, error) {
+	_, span := tracer.Start(ctx, "Lock")
+	defer span.End()
+
+	unlock, err := commander.locker.Lock(ctx, accounts)
+	if err != nil {
+		return nil, errors.Wrap(err, "locking accounts for tx processing")
+	}
+
+	return unlock, nil
+}
+
+// lead takes a lock without accounts, which makes the instance the writer of the ledger
+// when using a LeaderLocker, so idempotency keys and references can be checked safely.
+// As every write goes through it, it also rejects writes on read-only ledgers.
+// The accounts must be locked with the returned context, which is marked as leading.
+func (commander *Commander) lead(ctx context.Context) (context.Context, Unlock, error) {
+	_, span := tracer.Start(ctx, "Lead")
+	defer span.End()
+
+	if commander.readOnly.Load() {
+		return nil, nil, NewErrLedgerReadOnly()
+	}
+	if commander.importing.Load() {
+		return nil, nil, NewErrLedgerImporting()
+	}
+
+	unlock, err := commander.locker.Lock(ctx, Accounts{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "locking ledger for writing")
+	}
+	// The ledger may have been made read-only by another instance, whose state is loaded with the leadership
+	if commander.readOnly.Load() {
+		unlock(ctx)
+		return nil, nil, NewErrLedgerReadOnly()
+	}
+
+	return withLeadership(ctx), unlock, nil
+}
+
+// leadWithRules leads the ledger with the rules locked, for the writes computing their script from the state of the ledger,
+// which must not be changed by another instance before their log is inserted.
+// The script must then be executed using execWithRules.
+func (commander *Commander) leadWithRules(ctx context.Context) (context.Context, Unlock, error) {
+	commander.rules.RLock()
+
+	ctx, unlead, err := commander.lead(ctx)
+	if err != nil {
+		commander.rules.RUnlock()
+		return nil, nil, err
+	}
 
-			return nil
-		}()
+	return ctx, func(ctx context.Context) {
+		unlead(ctx)
+		commander.rules.RUnlock()
+	}, nil
+}
+
+// Import calls importFn as the writer of the ledger, once the running writes are done and their logs inserted.
+// The writes are rejected until importFn returns, so it can insert logs after the last log of the chain.
+func (commander *Commander) Import(ctx context.Context, importFn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "Import")
+	defer span.End()
+
+	ctx, unlead, err := commander.startImport(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlead(ctx)
+	defer commander.importing.Store(false)
+
+	return importFn(ctx)
+}
+
+// startImport leads the ledger while no write is running, then rejects the next writes.
+func (commander *Commander) startImport(ctx context.Context) (context.Context, Unlock, error) {
+	commander.rules.Lock()
+	defer commander.rules.Unlock()
+
+	ctx, unlead, err := commander.lead(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := commander.drain(ctx); err != nil {
+		unlead(ctx)
+		return nil, nil, err
+	}
+	commander.importing.Store(true)
+
+	return ctx, unlead, nil
+}
+
+// drain waits for the logs already appended to be processed.
+// It must be called while no write is running, typically as the writer of the ledger.
+func (commander *Commander) drain(ctx context.Context) error {
+	done := make(chan error, 1)
+	commander.AppendAll(nil, func(err error) {
+		done <- err
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+// run executes a prepared script, using the balances of store.
+// The accounts used by the script must be locked.
+func (commander *Commander) run(ctx context.Context, m *vm.Machine, store vm.Store, script ledger.RunScript, hold string) (*vm.Result, error) {
+	err := func() error {
+		ctx, span := tracer.Start(ctx, "ResolveBalances")
+		defer span.End()
+
+		err := m.ResolveBalances(ctx, store)
 		if err != nil {
-			return nil, err
+			return errors.Wrap(err, "could not resolve balances")
 		}
-		result, err := func() (*vm.Result, error) {
-			_, span := tracer.Start(ctx, "RunNumscript")
-			defer span.End()
 
-			result, err := vm.Run(m, script)
-			if err != nil {
-				return nil, NewErrMachine(err)
-			}
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+	result, err := func() (*vm.Result, error) {
+		_, span := tracer.Start(ctx, "RunNumscript")
+		defer span.End()
 
-			return result, nil
-		}()
+		result, err := vm.Run(m, script)
 		if err != nil {
-			return nil, err
+			return nil, NewErrMachine(err)
 		}
 
-		if len(result.Postings) == 0 {
-			return nil, NewErrNoPostings()
-		}
+		return result, nil
+	}()
+	if err != nil {
+		return nil, err
+	}
 
-		txID := commander.chain.PredictNextTxID()
-		if !parameters.DryRun {
-			txID = commander.chain.AllocateNewTxID()
-		}
+	if len(result.Postings) == 0 {
+		return nil, NewErrNoPostings()
+	}
+
+	if err := commander.checkFreezes(result.Postings); err != nil {
+		return nil, err
+	}
+
+	if err := checkHolds(result, hold); err != nil {
+		return nil, err
+	}
 
-		tx := ledger.NewTransaction().
-			WithPostings(result.Postings...).
-			WithMetadata(result.Metadata).
-			WithDate(script.Timestamp).
-			WithID(txID).
-			WithReference(script.Reference)
+	err = func() error {
+		ctx, span := tracer.Start(ctx, "CheckChart")
+		defer span.End()
 
-		log := logComputer(tx, result.AccountMetadata)
-		if parameters.IdempotencyKey != "" {
-			log = log.WithIdempotencyKey(parameters.IdempotencyKey)
+		return commander.checkChart(ctx, store, result)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	err = func() error {
+		ctx, span := tracer.Start(ctx, "CheckAccountPolicies")
+		defer span.End()
+
+		if err := m.CheckAccountPolicies(ctx, store); err != nil {
+			if machine.IsAccountPolicyError(err) {
+				return NewErrMachine(err)
+			}
+			return errors.Wrap(err, "could not check account policies")
 		}
 
-		return executionContext.AppendLog(ctx, log)
-	})
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func newTransaction(result *vm.Result, script ledger.RunScript, txID *big.Int) *ledger.Transaction {
+	return ledger.NewTransaction().
+		WithPostings(result.Postings...).
+		WithMetadata(result.Metadata).
+		WithDate(script.Timestamp).
+		WithID(txID).
+		WithReference(script.Reference)
 }
 
 func (commander *Commander) CreateTransaction(ctx context.Context, parameters Parameters, script ledger.RunScript) (*ledger.Transaction, error) {
@@ -209,7 +449,10 @@ func (commander *Commander) CreateTransaction(ctx context.Context, parameters Pa
 	ctx, span := tracer.Start(ctx, "CreateTransaction")
 	defer span.End()
 
-	log, err := commander.exec(ctx, parameters, script, ledger.NewTransactionLog)
+	log, err := commander.exec(ctx, parameters, fingerprint(actionCreateTransaction, script), script, "",
+		func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) (*ledger.Log, error) {
+			return ledger.NewTransactionLog(tx, accountMetadata), nil
+		})
 	if err != nil {
 
 		return nil, err
@@ -221,8 +464,19 @@ func (commander *Commander) CreateTransaction(ctx context.Context, parameters Pa
 }
 
 func (commander *Commander) SaveMeta(ctx context.Context, parameters Parameters, targetType string, targetID interface{}, m metadata.Metadata) error {
-	execContext := newExecutionContext(commander, parameters)
-	_, err := execContext.run(ctx, func(executionContext *executionContext) (*ledger.ChainedLog, error) {
+	commander.rules.RLock()
+	defer commander.rules.RUnlock()
+
+	execContext := newExecutionContext(commander, parameters, fingerprint(actionSaveMetadata, SaveMetadataRequest{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   m,
+	}))
+	_, err := execContext.run(ctx, func(ctx context.Context, executionContext *executionContext) (*ledger.ChainedLog, error) {
+		if err := checkHoldMetadata(m); err != nil {
+			return nil, err
+		}
+
 		var (
 			log *ledger.Log
 			at  = time.Now()
@@ -262,20 +516,50 @@ func (commander *Commander) SaveMeta(ctx context.Context, parameters Parameters,
 
 func (commander *Commander) RevertTransaction(ctx context.Context, parameters Parameters, id *big.Int, force, atEffectiveDate bool) (*ledger.Transaction, error) {
 
+	ctx, unlead, err := commander.leadWithRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlead(ctx)
+
 	if err := commander.referencer.take(referenceReverts, id); err != nil {
 		return nil, NewErrRevertTransactionOccurring()
 	}
 	defer commander.referencer.release(referenceReverts, id)
 
-	transactionToRevert, err := commander.store.GetTransaction(ctx, id)
+	req := RevertTransactionRequest{
+		ID:              id,
+		Force:           force,
+		AtEffectiveDate: atEffectiveDate,
+	}
+	script, transactionToRevert, err := commander.revertScript(ctx, commander.store, req)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := commander.execWithRules(ctx, parameters, fingerprint(actionRevertTransaction, req), *script, "",
+		func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) (*ledger.Log, error) {
+			return ledger.NewRevertedTransactionLog(tx.Timestamp, transactionToRevert.ID, tx), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	commander.monitor.RevertedTransaction(ctx, log.Data.(ledger.RevertedTransactionLogPayload).RevertTransaction, transactionToRevert)
+
+	return log.Data.(ledger.RevertedTransactionLogPayload).RevertTransaction, nil
+}
+
+func (commander *Commander) revertScript(ctx context.Context, store transactionReader, req RevertTransactionRequest) (*ledger.RunScript, *ledger.Transaction, error) {
+	transactionToRevert, err := store.GetTransaction(ctx, req.ID)
 	if err != nil {
 		if storageerrors.IsNotFoundError(err) {
-			return nil, NewErrRevertTransactionNotFound()
+			return nil, nil, NewErrRevertTransactionNotFound()
 		}
-		return nil, err
+		return nil, nil, errors.Wrap(err, "getting transaction to revert")
 	}
 	if transactionToRevert.Reverted {
-		return nil, NewErrRevertTransactionAlreadyReverted()
+		return nil, nil, NewErrRevertTransactionAlreadyReverted()
 	}
 
 	rt := transactionToRevert.Reverse()
@@ -284,22 +568,14 @@ func (commander *Commander) RevertTransaction(ctx context.Context, parameters Pa
 	script := ledger.TxToScriptData(ledger.TransactionData{
 		Postings: rt.Postings,
 		Metadata: rt.Metadata,
-	}, force)
-	if atEffectiveDate {
+	}, req.Force)
+	if req.AtEffectiveDate {
 		script.Timestamp = transactionToRevert.Timestamp
+	} else {
+		script.Timestamp = time.Now()
 	}
 
-	log, err := commander.exec(ctx, parameters, script,
-		func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) *ledger.Log {
-			return ledger.NewRevertedTransactionLog(tx.Timestamp, transactionToRevert.ID, tx)
-		})
-	if err != nil {
-		return nil, err
-	}
-
-	commander.monitor.RevertedTransaction(ctx, log.Data.(ledger.RevertedTransactionLogPayload).RevertTransaction, transactionToRevert)
-
-	return log.Data.(ledger.RevertedTransactionLogPayload).RevertTransaction, nil
+	return &script, transactionToRevert, nil
 }
 
 func (commander *Commander) Close() {
@@ -308,8 +584,19 @@ func (commander *Commander) Close() {
 }
 
 func (commander *Commander) DeleteMetadata(ctx context.Context, parameters Parameters, targetType string, targetID any, key string) error {
-	execContext := newExecutionContext(commander, parameters)
-	_, err := execContext.run(ctx, func(executionContext *executionContext) (*ledger.ChainedLog, error) {
+	commander.rules.RLock()
+	defer commander.rules.RUnlock()
+
+	execContext := newExecutionContext(commander, parameters, fingerprint(actionDeleteMetadata, DeleteMetadataRequest{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Key:        key,
+	}))
+	_, err := execContext.run(ctx, func(ctx context.Context, executionContext *executionContext) (*ledger.ChainedLog, error) {
+		if ledger.IsHoldMetadata(key) {
+			return nil, NewErrHoldMetadataProtected(key)
+		}
+
 		var (
 			log *ledger.Log
 			at  = time.Now()
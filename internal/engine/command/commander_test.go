@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/formancehq/go-libs/testing/docker"
@@ -204,6 +205,392 @@ func TestCreateTransaction(t *testing.T) {
 	}
 }
 
+func TestIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	script := func(amount string) ledger.RunScript {
+		return ledger.RunScript{
+			Script: ledger.Script{
+				Plain: `send [USD/2 ` + amount + `] (
+					source = @world
+					destination = @bank
+				)`,
+			},
+		}
+	}
+	parameters := Parameters{
+		IdempotencyKey: "ik",
+	}
+
+	tx, err := commander.CreateTransaction(ctx, parameters, script("100"))
+	require.NoError(t, err)
+
+	// Replaying the same request returns the same transaction, even if the timestamp was defaulted
+	replayed, err := commander.CreateTransaction(ctx, parameters, script("100"))
+	require.NoError(t, err)
+	require.Equal(t, tx.ID, replayed.ID)
+
+	_, err = commander.CreateTransaction(ctx, parameters, script("200"))
+	require.True(t, IsErrIdempotencyKeyConflict(err))
+
+	// The key is also checked against the other kind of requests
+	err = commander.SaveMeta(ctx, parameters, ledger.MetaTargetTypeAccount, "bank", metadata.Metadata{"foo": "bar"})
+	require.True(t, IsErrIdempotencyKeyConflict(err))
+}
+
+func TestOnBatchProcessed(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	processed := make(chan []*ledger.ChainedLog, 1)
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	commander.OnBatchProcessed(func(logs ...*ledger.ChainedLog) {
+		processed <- logs
+	})
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	tx, err := commander.CreateTransaction(ctx, Parameters{}, ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 100] (
+				source = @world
+				destination = @bank
+			)`,
+		},
+	})
+	require.NoError(t, err)
+
+	// The logs are reported once inserted
+	logs := <-processed
+	require.Len(t, logs, 1)
+	require.Equal(t, tx.ID, logs[0].Data.(ledger.NewTransactionLogPayload).Transaction.ID)
+
+	lastLog, err := store.GetLastLog(ctx)
+	require.NoError(t, err)
+	require.Equal(t, logs[0].ID, lastLog.ID)
+}
+
+// failingStore fails the insertion of the logs until it is repaired
+type failingStore struct {
+	*storageerrors.InMemoryStore
+	failing atomic.Bool
+}
+
+func (s *failingStore) InsertLogs(ctx context.Context, logs ...*ledger.ChainedLog) error {
+	if s.failing.Load() {
+		return errors.New("insert failed")
+	}
+	return s.InMemoryStore.InsertLogs(ctx, logs...)
+}
+
+func TestInsertFailure(t *testing.T) {
+	t.Parallel()
+
+	store := &failingStore{InMemoryStore: storageerrors.NewInMemoryStore()}
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	_, err := commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+	require.NoError(t, err)
+
+	store.failing.Store(true)
+	_, err = commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+	require.Error(t, err)
+
+	// The chain restarts from the last inserted log
+	store.failing.Store(false)
+	tx, err := commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), tx.ID)
+
+	lastLog, err := store.GetLastLog(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), lastLog.ID)
+}
+
+func TestIdempotencyKeyWithoutHash(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	// Logs created before the payloads were fingerprinted have no hash
+	tx := ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100)))
+	require.NoError(t, store.InsertLogs(ctx,
+		ledger.NewTransactionLog(tx, map[string]metadata.Metadata{}).WithIdempotencyKey("ik").ChainLog(nil),
+	))
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	replayed, err := commander.CreateTransaction(ctx, Parameters{IdempotencyKey: "ik"}, ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 200] (
+				source = @world
+				destination = @bank
+			)`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, tx.ID, replayed.ID)
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	script := ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 100] (
+				source = @world
+				destination = @bank
+			)`,
+		},
+	}
+
+	commander.SetReadOnly(true)
+
+	_, err := commander.CreateTransaction(ctx, Parameters{}, script)
+	require.True(t, IsErrLedgerReadOnly(err))
+
+	err = commander.SaveMeta(ctx, Parameters{}, ledger.MetaTargetTypeAccount, "bank", metadata.Metadata{"foo": "bar"})
+	require.True(t, IsErrLedgerReadOnly(err))
+
+	_, err = commander.ExecuteAtomicBulk(ctx, []BulkElement{})
+	require.True(t, IsErrLedgerReadOnly(err))
+
+	commander.SetReadOnly(false)
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, script)
+	require.NoError(t, err)
+}
+
+func TestClosePeriod(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+	now := time.Now()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	script := ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 100] (
+				source = @world
+				destination = @bank
+			)`,
+		},
+		Timestamp: now.Add(-2 * time.Hour),
+	}
+	tx, err := commander.CreateTransaction(ctx, Parameters{}, script)
+	require.NoError(t, err)
+
+	closed := false
+	require.NoError(t, commander.ClosePeriod(ctx, now.Add(-time.Hour), func(ctx context.Context) error {
+		closed = true
+		return nil
+	}))
+	require.True(t, closed)
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, script)
+	require.True(t, IsPeriodError(err, ErrPeriodCodeClosed))
+
+	_, err = commander.RevertTransaction(ctx, Parameters{}, tx.ID, false, true)
+	require.True(t, IsPeriodError(err, ErrPeriodCodeClosed))
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		CreateTransaction: &script,
+	}})
+	require.NoError(t, err)
+	require.True(t, IsPeriodError(results[0].Err, ErrPeriodCodeClosed))
+
+	_, err = commander.RevertTransaction(ctx, Parameters{}, tx.ID, false, false)
+	require.NoError(t, err)
+
+	err = commander.ClosePeriod(ctx, now.Add(-2*time.Hour), func(ctx context.Context) error {
+		return nil
+	})
+	require.True(t, IsPeriodError(err, ErrPeriodCodeInvalidClosingDate))
+
+	err = commander.ClosePeriod(ctx, now.Add(time.Hour), func(ctx context.Context) error {
+		return nil
+	})
+	require.True(t, IsPeriodError(err, ErrPeriodCodeInvalidClosingDate))
+}
+
+func TestAccountPolicies(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	script := ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 100] (
+				source = @users:1 allowing unbounded overdraft
+				destination = @bank
+			)`,
+		},
+	}
+
+	_, err := commander.CreateTransaction(ctx, Parameters{}, script)
+	require.NoError(t, err)
+
+	require.NoError(t, commander.UpdateAccountPolicies(ctx, func(ctx context.Context) (ledger.AccountPolicies, error) {
+		return ledger.AccountPolicies{ledger.NewAccountPolicy("users:*")}, nil
+	}))
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, script)
+	require.True(t, IsErrMachine(err))
+	require.True(t, machine.IsAccountPolicyError(err))
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		CreateTransaction: &script,
+	}})
+	require.NoError(t, err)
+	require.True(t, machine.IsAccountPolicyError(results[0].Err))
+}
+
+func TestAccountFreezes(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	script := ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 100] (
+				source = @world
+				destination = @users:1
+			)`,
+		},
+	}
+
+	require.NoError(t, commander.UpdateAccountFreezes(ctx, func(ctx context.Context) (ledger.AccountFreezes, error) {
+		return ledger.AccountFreezes{
+			"users:1": ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionDebit, "").Freeze(),
+		}, nil
+	}))
+
+	// Credits are still allowed
+	_, err := commander.CreateTransaction(ctx, Parameters{}, script)
+	require.NoError(t, err)
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `send [USD/2 100] (
+				source = @users:1
+				destination = @bank
+			)`,
+		},
+	})
+	require.True(t, IsErrAccountFrozen(err))
+
+	require.NoError(t, commander.UpdateAccountFreezes(ctx, func(ctx context.Context) (ledger.AccountFreezes, error) {
+		return ledger.AccountFreezes{
+			"users:1": ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionBoth, "").Freeze(),
+		}, nil
+	}))
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		CreateTransaction: &script,
+	}})
+	require.NoError(t, err)
+	require.True(t, IsErrAccountFrozen(results[0].Err))
+
+	require.NoError(t, commander.UpdateAccountFreezes(ctx, func(ctx context.Context) (ledger.AccountFreezes, error) {
+		return ledger.AccountFreezes{}, nil
+	}))
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, script)
+	require.NoError(t, err)
+}
+
+func TestChart(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	newScript := func(destination string) ledger.RunScript {
+		return ledger.RunScript{
+			Script: ledger.Script{
+				Plain: `send [USD/2 100] (
+					source = @world
+					destination = @` + destination + `
+				)`,
+			},
+		}
+	}
+
+	chart := ledger.NewChart(ledger.ChartModeStrict, ledger.ChartAccount{
+		Template:        "users:{id}:wallet",
+		Type:            ledger.AccountTypeLiability,
+		DefaultMetadata: metadata.Metadata{"kind": "wallet"},
+	})
+	require.NoError(t, commander.UpdateChart(ctx, func(ctx context.Context) (*ledger.Chart, error) {
+		return &chart, nil
+	}))
+
+	_, err := commander.CreateTransaction(ctx, Parameters{}, newScript("users:1:wallet"))
+	require.NoError(t, err)
+	account, err := store.GetAccount(ctx, "users:1:wallet")
+	require.NoError(t, err)
+	require.Equal(t, metadata.Metadata{"kind": "wallet"}, account.Metadata)
+
+	typo := newScript("users:1:walet")
+	_, err = commander.CreateTransaction(ctx, Parameters{}, typo)
+	require.True(t, IsErrChartViolation(err))
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		CreateTransaction: &typo,
+	}})
+	require.NoError(t, err)
+	require.True(t, IsErrChartViolation(results[0].Err))
+
+	chart.Mode = ledger.ChartModeWarn
+	require.NoError(t, commander.UpdateChart(ctx, func(ctx context.Context) (*ledger.Chart, error) {
+		return &chart, nil
+	}))
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, typo)
+	require.NoError(t, err)
+}
+
 func TestRevert(t *testing.T) {
 	txID := big.NewInt(0)
 	store := storageerrors.NewInMemoryStore()
@@ -417,3 +804,52 @@ func TestParallelTransactions(t *testing.T) {
 	require.NoError(t, err)
 	internaltesting.RequireEqual(t, big.NewInt(1000), account.Volumes.Balances()["USD"])
 }
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	_, err := commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+	require.NoError(t, err)
+
+	require.NoError(t, commander.Import(ctx, func(ctx context.Context) error {
+		// The writes are rejected while the logs are imported
+		_, err := commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+		require.True(t, IsErrLedgerReadOnly(err))
+
+		err = commander.SaveMeta(ctx, Parameters{}, ledger.MetaTargetTypeAccount, "bank", metadata.Metadata{"foo": "bar"})
+		require.True(t, IsErrLedgerReadOnly(err))
+
+		return nil
+	}))
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+	require.NoError(t, err)
+}
+
+func TestReadOnlyOnLeadership(t *testing.T) {
+	t.Parallel()
+
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	// The ledger is made read-only by another instance, which is seen once the leadership is acquired
+	var commander *Commander
+	locker := NewLeaderLocker(NewDefaultLocker(), newInMemoryWriterLock(), func(ctx context.Context) error {
+		commander.SetReadOnly(true)
+		return nil
+	}, time.Second, time.Minute)
+
+	commander = New(store, locker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	defer commander.Close()
+
+	_, err := commander.CreateTransaction(ctx, Parameters{}, *sendScript("world", "bank", 100))
+	require.True(t, IsErrLedgerReadOnly(err))
+}
@@ -11,33 +11,36 @@ import (
 )
 
 type executionContext struct {
-	commander  *Commander
-	parameters Parameters
+	commander   *Commander
+	parameters  Parameters
+	fingerprint string
 }
 
 func (e *executionContext) AppendLog(ctx context.Context, log *ledger.Log) (*ledger.ChainedLog, error) {
 	ctx, span := tracer.Start(ctx, "AppendLog")
 	defer span.End()
 
+	if ik := e.parameters.IdempotencyKey; ik != "" {
+		log = log.WithIdempotencyKey(ik).WithIdempotencyHash(e.fingerprint)
+	}
+
 	if e.parameters.DryRun {
 		return log.ChainLog(nil), nil
 	}
 
+	done := make(chan error, 1)
 	chainedLog := func() *ledger.ChainedLog {
 		_, span := tracer.Start(ctx, "ChainLog")
 		defer span.End()
 
-		return e.commander.chain.ChainLog(log)
-	}()
+		return e.commander.chain.ChainLogs([]*ledger.Log{log}, func(logs []*ledger.ChainedLog) {
+			_, span := tracer.Start(ctx, "AppendLogToQueue")
+			defer span.End()
 
-	done := make(chan struct{})
-	func() {
-		_, span := tracer.Start(ctx, "AppendLogToQueue")
-		defer span.End()
-
-		e.commander.Append(chainedLog, func() {
-			close(done)
-		})
+			e.commander.AppendAll(logs, func(err error) {
+				done <- err
+			})
+		})[0]
 	}()
 
 	err := func() error {
@@ -47,8 +50,8 @@ func (e *executionContext) AppendLog(ctx context.Context, log *ledger.Log) (*led
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-done:
-			return nil
+		case err := <-done:
+			return err
 		}
 	}()
 	if err != nil {
@@ -58,7 +61,13 @@ func (e *executionContext) AppendLog(ctx context.Context, log *ledger.Log) (*led
 	return chainedLog, nil
 }
 
-func (e *executionContext) run(ctx context.Context, executor func(e *executionContext) (*ledger.ChainedLog, error)) (*ledger.ChainedLog, error) {
+func (e *executionContext) run(ctx context.Context, executor func(ctx context.Context, e *executionContext) (*ledger.ChainedLog, error)) (*ledger.ChainedLog, error) {
+	ctx, unlock, err := e.commander.lead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock(ctx)
+
 	if ik := e.parameters.IdempotencyKey; ik != "" {
 		if err := e.commander.referencer.take(referenceIks, ik); err != nil {
 			return nil, err
@@ -70,18 +79,31 @@ func (e *executionContext) run(ctx context.Context, executor func(e *executionCo
 
 		chainedLog, err := e.commander.store.ReadLogWithIdempotencyKey(ctx, ik)
 		if err == nil {
+			if err := checkIdempotencyHash(chainedLog, e.fingerprint); err != nil {
+				return nil, err
+			}
 			return chainedLog, nil
 		}
 		if err != nil && !storageerrors.IsNotFoundError(err) {
 			return nil, err
 		}
 	}
-	return executor(e)
+	return executor(ctx, e)
+}
+
+// checkIdempotencyHash rejects a request reusing the idempotency key of log with a different payload.
+// Logs created before the payloads were fingerprinted have an empty hash, and are always replayed.
+func checkIdempotencyHash(log *ledger.ChainedLog, fingerprint string) error {
+	if log.IdempotencyHash != "" && log.IdempotencyHash != fingerprint {
+		return NewErrIdempotencyKeyConflict(log.IdempotencyKey)
+	}
+	return nil
 }
 
-func newExecutionContext(commander *Commander, parameters Parameters) *executionContext {
+func newExecutionContext(commander *Commander, parameters Parameters, fingerprint string) *executionContext {
 	return &executionContext{
-		commander:  commander,
-		parameters: parameters,
+		commander:   commander,
+		parameters:  parameters,
+		fingerprint: fingerprint,
 	}
 }
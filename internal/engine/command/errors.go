@@ -3,14 +3,31 @@ package command
 import (
 	"fmt"
 
+	"github.com/formancehq/go-libs/time"
 	"github.com/pkg/errors"
 )
 
+// rejection is embedded in the errors rejecting a request, which would be rejected again if retried.
+type rejection struct{}
+
+func (rejection) rejected() bool {
+	return true
+}
+
+// IsRejection returns true if err rejects the request itself, so that it would be rejected again if retried.
+// The errors of the storage, and the ones depending on the state of the instance
+// (leadership, read-only ledger, concurrent writes on the same resources) are not rejections.
+func IsRejection(err error) bool {
+	var r interface{ rejected() bool }
+	return errors.As(err, &r) && r.rejected()
+}
+
 const (
 	ErrSaveMetaCodeTransactionNotFound = "TRANSACTION_NOT_FOUND"
 )
 
 type errSaveMeta struct {
+	rejection
 	code string
 }
 
@@ -47,6 +64,7 @@ const (
 )
 
 type errDeleteMeta struct {
+	rejection
 	code string
 }
 
@@ -91,6 +109,11 @@ func (e *errRevert) Is(err error) bool {
 	return ok
 }
 
+// rejected is false when the transaction is being reverted by a concurrent write.
+func (e *errRevert) rejected() bool {
+	return e.code != ErrRevertTransactionCodeOccurring
+}
+
 func NewErrRevert(code string) *errRevert {
 	return &errRevert{
 		code: code,
@@ -124,7 +147,78 @@ func IsRevertError(err error, code string) bool {
 	return false
 }
 
+type errHold struct {
+	code string
+	err  error
+}
+
+func (e *errHold) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("invalid hold: %s", e.code)
+	}
+	return fmt.Sprintf("invalid hold: %s (%s)", e.code, e.err)
+}
+
+func (e *errHold) Is(err error) bool {
+	_, ok := err.(*errHold)
+	return ok
+}
+
+func (e *errHold) Cause() error {
+	return e.err
+}
+
+// rejected is false when the hold is being settled by a concurrent write.
+func (e *errHold) rejected() bool {
+	return e.code != ErrHoldCodeOccurring
+}
+
+func NewErrHold(code string, err error) *errHold {
+	return &errHold{
+		code: code,
+		err:  err,
+	}
+}
+
+const (
+	ErrHoldCodeInvalid           = "INVALID"
+	ErrHoldCodeNotFound          = "NOT_FOUND"
+	ErrHoldCodeClosed            = "CLOSED"
+	ErrHoldCodeOccurring         = "HOLD_OCCURRING"
+	ErrHoldCodeInsufficientFunds = "INSUFFICIENT_FUNDS"
+)
+
+func NewErrInvalidHold(err error) *errHold {
+	return NewErrHold(ErrHoldCodeInvalid, err)
+}
+
+func NewErrHoldNotFound() *errHold {
+	return NewErrHold(ErrHoldCodeNotFound, nil)
+}
+
+func NewErrHoldClosed() *errHold {
+	return NewErrHold(ErrHoldCodeClosed, nil)
+}
+
+func NewErrHoldOccurring() *errHold {
+	return NewErrHold(ErrHoldCodeOccurring, nil)
+}
+
+func NewErrHoldInsufficientFunds() *errHold {
+	return NewErrHold(ErrHoldCodeInsufficientFunds, nil)
+}
+
+func IsHoldError(err error, code string) bool {
+	e := &errHold{}
+	if errors.As(err, &e) {
+		return e.code == code
+	}
+
+	return false
+}
+
 type errInvalidTransaction struct {
+	rejection
 	code string
 	err  error
 }
@@ -175,6 +269,10 @@ func NewErrConflict() *errInvalidTransaction {
 	return NewErrInvalidTransaction(ErrInvalidTransactionCodeConflict, nil)
 }
 
+func IsErrInvalidTransaction(err error) bool {
+	return errors.Is(err, &errInvalidTransaction{})
+}
+
 func IsInvalidTransactionError(err error, code string) bool {
 	e := &errInvalidTransaction{}
 	if errors.As(err, &e) {
@@ -185,6 +283,7 @@ func IsInvalidTransactionError(err error, code string) bool {
 }
 
 type errMachine struct {
+	rejection
 	err error
 }
 
@@ -210,3 +309,225 @@ func NewErrMachine(err error) *errMachine {
 func IsErrMachine(err error) bool {
 	return errors.Is(err, &errMachine{})
 }
+
+type errIdempotencyKeyConflict struct {
+	rejection
+	key        string
+	duplicated bool
+}
+
+func (e *errIdempotencyKeyConflict) Error() string {
+	if e.duplicated {
+		return fmt.Sprintf("idempotency key '%s' used by several elements of the bulk", e.key)
+	}
+	return fmt.Sprintf("idempotency key '%s' already used with a different payload", e.key)
+}
+
+func (e *errIdempotencyKeyConflict) Is(err error) bool {
+	_, ok := err.(*errIdempotencyKeyConflict)
+	return ok
+}
+
+func NewErrIdempotencyKeyConflict(key string) *errIdempotencyKeyConflict {
+	return &errIdempotencyKeyConflict{
+		key: key,
+	}
+}
+
+func NewErrIdempotencyKeyDuplicated(key string) *errIdempotencyKeyConflict {
+	return &errIdempotencyKeyConflict{
+		key:        key,
+		duplicated: true,
+	}
+}
+
+func IsErrIdempotencyKeyConflict(err error) bool {
+	return errors.Is(err, &errIdempotencyKeyConflict{})
+}
+
+type errLedgerReadOnly struct {
+	importing bool
+}
+
+func (e *errLedgerReadOnly) Error() string {
+	if e.importing {
+		return "ledger is read-only while logs are imported"
+	}
+	return "ledger is read-only"
+}
+
+func (e *errLedgerReadOnly) Is(err error) bool {
+	_, ok := err.(*errLedgerReadOnly)
+	return ok
+}
+
+func NewErrLedgerReadOnly() *errLedgerReadOnly {
+	return &errLedgerReadOnly{}
+}
+
+type errNotLeader struct{}
+
+func (e *errNotLeader) Error() string {
+	return "the ledger is written by another instance"
+}
+
+func (e *errNotLeader) Is(err error) bool {
+	_, ok := err.(*errNotLeader)
+	return ok
+}
+
+// NewErrNotLeader is returned when the instance could not become the writer of the ledger in time.
+func NewErrNotLeader() *errNotLeader {
+	return &errNotLeader{}
+}
+
+func IsErrNotLeader(err error) bool {
+	return errors.Is(err, &errNotLeader{})
+}
+
+// NewErrLedgerImporting is returned to the writes started during an import.
+func NewErrLedgerImporting() *errLedgerReadOnly {
+	return &errLedgerReadOnly{
+		importing: true,
+	}
+}
+
+func IsErrLedgerReadOnly(err error) bool {
+	return errors.Is(err, &errLedgerReadOnly{})
+}
+
+type errPeriod struct {
+	rejection
+	code string
+	err  error
+}
+
+func (e *errPeriod) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("invalid period: %s", e.code)
+	}
+	return fmt.Sprintf("invalid period: %s (%s)", e.code, e.err)
+}
+
+func (e *errPeriod) Is(err error) bool {
+	_, ok := err.(*errPeriod)
+	return ok
+}
+
+func (e *errPeriod) Cause() error {
+	return e.err
+}
+
+func NewErrPeriod(code string, err error) *errPeriod {
+	return &errPeriod{
+		code: code,
+		err:  err,
+	}
+}
+
+const (
+	ErrPeriodCodeClosed             = "CLOSED"
+	ErrPeriodCodeInvalidClosingDate = "INVALID_CLOSING_DATE"
+)
+
+func NewErrPeriodClosed(date, closingDate time.Time) *errPeriod {
+	return NewErrPeriod(ErrPeriodCodeClosed,
+		fmt.Errorf("date %s is before the closing date %s", date.Format(time.RFC3339Nano), closingDate.Format(time.RFC3339Nano)))
+}
+
+func NewErrInvalidClosingDate(err error) *errPeriod {
+	return NewErrPeriod(ErrPeriodCodeInvalidClosingDate, err)
+}
+
+func IsPeriodError(err error, code string) bool {
+	e := &errPeriod{}
+	if errors.As(err, &e) {
+		return e.code == code
+	}
+
+	return false
+}
+
+type errAccountFrozen struct {
+	rejection
+	address   string
+	direction string
+}
+
+func (e *errAccountFrozen) Error() string {
+	return fmt.Sprintf("account %s is frozen for %s", e.address, e.direction)
+}
+
+func (e *errAccountFrozen) Is(err error) bool {
+	_, ok := err.(*errAccountFrozen)
+	return ok
+}
+
+func NewErrAccountFrozen(address, direction string) *errAccountFrozen {
+	return &errAccountFrozen{
+		address:   address,
+		direction: direction,
+	}
+}
+
+func IsErrAccountFrozen(err error) bool {
+	return errors.Is(err, &errAccountFrozen{})
+}
+
+type errHoldProtected struct {
+	rejection
+	target string
+}
+
+func (e *errHoldProtected) Error() string {
+	return fmt.Sprintf("%s can only be modified by the hold operations", e.target)
+}
+
+func (e *errHoldProtected) Is(err error) bool {
+	_, ok := err.(*errHoldProtected)
+	return ok
+}
+
+func NewErrHoldAccountProtected(address string) *errHoldProtected {
+	return &errHoldProtected{
+		target: fmt.Sprintf("hold account %s", address),
+	}
+}
+
+func NewErrHoldMetadataProtected(key string) *errHoldProtected {
+	return &errHoldProtected{
+		target: fmt.Sprintf("hold metadata %s", key),
+	}
+}
+
+func IsErrHoldProtected(err error) bool {
+	return errors.Is(err, &errHoldProtected{})
+}
+
+type errChartViolation struct {
+	rejection
+	err error
+}
+
+func (e *errChartViolation) Error() string {
+	return fmt.Sprintf("chart of accounts violation: %s", e.err)
+}
+
+func (e *errChartViolation) Is(err error) bool {
+	_, ok := err.(*errChartViolation)
+	return ok
+}
+
+func (e *errChartViolation) Cause() error {
+	return e.err
+}
+
+func NewErrChartViolation(err error) *errChartViolation {
+	return &errChartViolation{
+		err: err,
+	}
+}
+
+func IsErrChartViolation(err error) bool {
+	return errors.Is(err, &errChartViolation{})
+}
@@ -0,0 +1,39 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRejection(t *testing.T) {
+	t.Parallel()
+
+	for _, err := range []error{
+		NewErrMachine(machine.NewErrInsufficientFund("missing funds")),
+		NewErrNoPostings(),
+		NewErrConflict(),
+		NewErrRevertTransactionAlreadyReverted(),
+		NewErrHoldClosed(),
+		NewErrAccountFrozen("alice", "outbound"),
+		NewErrIdempotencyKeyConflict("ik"),
+		errors.Wrap(NewErrHoldAccountProtected("holds:1"), "running script"),
+	} {
+		require.True(t, IsRejection(err), err.Error())
+	}
+
+	for _, err := range []error{
+		NewErrNotLeader(),
+		NewErrLedgerReadOnly(),
+		NewErrLedgerImporting(),
+		NewErrRevertTransactionOccurring(),
+		NewErrHoldOccurring(),
+		sqlutils.ErrNotFound,
+		errors.New("connection refused"),
+	} {
+		require.False(t, IsRejection(err), err.Error())
+	}
+}
@@ -0,0 +1,319 @@
+package command
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/formancehq/go-libs/metadata"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/machine/vm"
+	"github.com/formancehq/ledger/internal/opentelemetry/tracer"
+	storageerrors "github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// holdVariable is the variable the hold account is bound to in the scripts creating holds.
+const holdVariable = "hold"
+
+// CreateHold runs a script reserving funds on a new hold account.
+// The script must declare an account variable named $hold and send the reserved funds to it,
+// the hold is then settled to destination when confirmed.
+func (commander *Commander) CreateHold(ctx context.Context, parameters Parameters, destination string, script ledger.RunScript) (*ledger.Hold, *ledger.Transaction, error) {
+
+	ctx, span := tracer.Start(ctx, "CreateHold")
+	defer span.End()
+
+	// The fingerprint is computed before binding the generated hold account
+	fingerprint := fingerprint(actionCreateHold, struct {
+		Destination string           `json:"destination"`
+		Script      ledger.RunScript `json:"script"`
+	}{
+		Destination: destination,
+		Script:      script,
+	})
+
+	id := uuid.NewString()
+
+	vars := make(map[string]string, len(script.Vars)+1)
+	for k, v := range script.Vars {
+		vars[k] = v
+	}
+	vars[holdVariable] = ledger.HoldAccount(id)
+	script.Vars = vars
+
+	log, err := commander.exec(ctx, parameters, fingerprint, script, ledger.HoldAccount(id),
+		func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) (*ledger.Log, error) {
+			hold, err := ledger.NewHold(id, destination, tx.Postings)
+			if err != nil {
+				return nil, NewErrInvalidHold(err)
+			}
+			if accountMetadata == nil {
+				accountMetadata = map[string]metadata.Metadata{}
+			}
+			accountMetadata[hold.Account] = accountMetadata[hold.Account].Merge(hold.Metadata())
+
+			return ledger.NewTransactionLog(tx, accountMetadata), nil
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload := log.Data.(ledger.NewTransactionLogPayload)
+
+	// The log may come from a previous call using the same idempotency key,
+	// so the hold is read back from the log instead of using the generated id.
+	var hold *ledger.Hold
+	for _, m := range payload.AccountMetadata {
+		hold, err = ledger.HoldFromMetadata(m, nil)
+		if err == nil {
+			break
+		}
+	}
+	if hold == nil {
+		return nil, nil, errors.New("hold not found in log")
+	}
+	if hold.ID == id {
+		hold.Remaining = new(big.Int).Set(hold.Amount)
+	} else {
+		// The hold may have been settled since it was created
+		hold, err = commander.GetHold(ctx, hold.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	commander.monitor.CommittedTransactions(ctx, *payload.Transaction, payload.AccountMetadata)
+	commander.monitor.CreatedHold(ctx, *hold, *payload.Transaction)
+
+	return hold, payload.Transaction, nil
+}
+
+// ConfirmHold captures amount from the hold to its destination, or the whole
+// remaining amount if amount is nil.
+// The hold is closed once fully captured, or if final is set, in which case
+// the remaining funds are released to the sources within the same transaction.
+func (commander *Commander) ConfirmHold(ctx context.Context, parameters Parameters, id string, amount *big.Int, final bool) (*ledger.Hold, *ledger.Transaction, error) {
+
+	ctx, span := tracer.Start(ctx, "ConfirmHold")
+	defer span.End()
+
+	ctx, unlead, err := commander.leadWithRules(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlead(ctx)
+
+	fingerprint := fingerprint(actionConfirmHold, struct {
+		ID     string   `json:"id"`
+		Amount *big.Int `json:"amount"`
+		Final  bool     `json:"final"`
+	}{
+		ID:     id,
+		Amount: amount,
+		Final:  final,
+	})
+
+	if err := commander.referencer.take(referenceHolds, id); err != nil {
+		return nil, nil, NewErrHoldOccurring()
+	}
+	defer commander.referencer.release(referenceHolds, id)
+
+	if hold, tx, err := commander.replaySettlement(ctx, parameters, fingerprint, id); err != nil || hold != nil {
+		return hold, tx, err
+	}
+
+	hold, err := commander.getPendingHold(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if amount == nil {
+		amount = hold.Remaining
+	}
+	if amount.Sign() <= 0 {
+		return nil, nil, NewErrInvalidHold(errors.New("amount must be positive"))
+	}
+	if amount.Cmp(hold.Remaining) > 0 {
+		return nil, nil, NewErrHoldInsufficientFunds()
+	}
+
+	remaining := new(big.Int).Sub(hold.Remaining, amount)
+	postings := hold.Capture(amount)
+	state := ledger.HoldStatePending
+	if final || remaining.Sign() == 0 {
+		postings = append(postings, hold.Release(remaining)...)
+		remaining = new(big.Int)
+		state = ledger.HoldStateConfirmed
+	}
+
+	tx, err := commander.settleHold(ctx, parameters, fingerprint, *hold, postings, state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ret := hold.WithState(state).WithRemaining(remaining)
+	commander.monitor.ConfirmedHold(ctx, ret, *tx)
+
+	return &ret, tx, nil
+}
+
+// VoidHold releases the remaining funds of the hold to its sources.
+func (commander *Commander) VoidHold(ctx context.Context, parameters Parameters, id string) (*ledger.Hold, *ledger.Transaction, error) {
+
+	ctx, span := tracer.Start(ctx, "VoidHold")
+	defer span.End()
+
+	ctx, unlead, err := commander.leadWithRules(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlead(ctx)
+
+	if err := commander.referencer.take(referenceHolds, id); err != nil {
+		return nil, nil, NewErrHoldOccurring()
+	}
+	defer commander.referencer.release(referenceHolds, id)
+
+	fingerprint := fingerprint(actionVoidHold, id)
+	if hold, tx, err := commander.replaySettlement(ctx, parameters, fingerprint, id); err != nil || hold != nil {
+		return hold, tx, err
+	}
+
+	hold, err := commander.getPendingHold(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := commander.settleHold(ctx, parameters, fingerprint, *hold, hold.Release(hold.Remaining), ledger.HoldStateVoided)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ret := hold.WithState(ledger.HoldStateVoided).WithRemaining(new(big.Int))
+	commander.monitor.VoidedHold(ctx, ret, *tx)
+
+	return &ret, tx, nil
+}
+
+func (commander *Commander) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	account, err := commander.store.GetAccount(ctx, ledger.HoldAccount(id))
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := ledger.HoldFromMetadata(account.Metadata, nil)
+	if err != nil {
+		if errors.Is(err, ledger.ErrHoldNotFound) {
+			return nil, NewErrHoldNotFound()
+		}
+		return nil, err
+	}
+
+	hold.Remaining, err = commander.store.GetBalance(ctx, hold.Account, hold.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// replaySettlement returns the hold and the transaction of the settlement previously made with the idempotency key of parameters, if any.
+// A retry must be replayed before the hold is checked, as the settlement may have closed it,
+// and without notifying the settlement again.
+func (commander *Commander) replaySettlement(ctx context.Context, parameters Parameters, fingerprint, id string) (*ledger.Hold, *ledger.Transaction, error) {
+	ik := parameters.IdempotencyKey
+	if ik == "" {
+		return nil, nil, nil
+	}
+
+	log, err := commander.store.ReadLogWithIdempotencyKey(ctx, ik)
+	if err != nil {
+		if storageerrors.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if err := checkIdempotencyHash(log, fingerprint); err != nil {
+		return nil, nil, err
+	}
+	payload, ok := log.Data.(ledger.NewTransactionLogPayload)
+	if !ok {
+		return nil, nil, NewErrIdempotencyKeyConflict(ik)
+	}
+
+	// The hold may have been settled again since
+	hold, err := commander.GetHold(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hold, payload.Transaction, nil
+}
+
+func (commander *Commander) getPendingHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	hold, err := commander.GetHold(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hold.State != ledger.HoldStatePending {
+		return nil, NewErrHoldClosed()
+	}
+	return hold, nil
+}
+
+// settleHold posts the settling transaction of a hold and records its new state
+// on the hold account, within a single log.
+// It must be called as the writer of the ledger, using leadWithRules.
+func (commander *Commander) settleHold(ctx context.Context, parameters Parameters, fingerprint string, hold ledger.Hold, postings ledger.Postings, state string) (*ledger.Transaction, error) {
+	script := ledger.TxToScriptData(ledger.TransactionData{
+		Postings: postings,
+		Metadata: ledger.HoldTransactionMetadata(hold.ID),
+	}, false)
+
+	log, err := commander.execWithRules(ctx, parameters, fingerprint, script, hold.Account,
+		func(tx *ledger.Transaction, accountMetadata map[string]metadata.Metadata) (*ledger.Log, error) {
+			if accountMetadata == nil {
+				accountMetadata = map[string]metadata.Metadata{}
+			}
+			accountMetadata[hold.Account] = ledger.HoldStateMetadata(state)
+
+			return ledger.NewTransactionLog(tx, accountMetadata), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	payload := log.Data.(ledger.NewTransactionLogPayload)
+	commander.monitor.CommittedTransactions(ctx, *payload.Transaction, payload.AccountMetadata)
+
+	return payload.Transaction, nil
+}
+
+// checkHolds rejects the postings using hold accounts, and the metadata describing holds set by a script.
+// hold is the only hold account allowed, if the script creates or settles a hold.
+func checkHolds(result *vm.Result, hold string) error {
+	for _, posting := range result.Postings {
+		for _, address := range []string{posting.Source, posting.Destination} {
+			if address != hold && ledger.IsHoldAccount(address) {
+				return NewErrHoldAccountProtected(address)
+			}
+		}
+	}
+	for _, m := range result.AccountMetadata {
+		if err := checkHoldMetadata(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHoldMetadata rejects the metadata describing holds, which only the hold operations can write.
+func checkHoldMetadata(m metadata.Metadata) error {
+	for key := range m {
+		if ledger.IsHoldMetadata(key) {
+			return NewErrHoldMetadataProtected(key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package command
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/bus"
+	"github.com/formancehq/ledger/internal/engine/chain"
+	storageerrors "github.com/formancehq/ledger/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+const holdScript = `
+vars {
+	account $hold
+}
+send [USD 80] (
+	source = {
+		@alice
+		@bob
+	}
+	destination = $hold
+)`
+
+func newHoldTestingCommander(t *testing.T) (*Commander, *storageerrors.InMemoryStore) {
+	store := storageerrors.NewInMemoryStore()
+	ctx := logging.TestingContext()
+
+	err := store.InsertLogs(context.Background(), ledger.ChainLogs(
+		ledger.NewTransactionLog(ledger.NewTransaction().WithPostings(
+			ledger.NewPosting("world", "alice", "USD", big.NewInt(50)),
+			ledger.NewPosting("world", "bob", "USD", big.NewInt(50)),
+		), map[string]metadata.Metadata{}),
+	)...)
+	require.NoError(t, err)
+
+	commander := New(store, NoOpLocker, NewCompiler(1024), NewReferencer(), bus.NewNoOpMonitor(), chain.New(store), 50)
+	go commander.Run(ctx)
+	t.Cleanup(commander.Close)
+
+	return commander, store
+}
+
+func requireBalance(t *testing.T, store *storageerrors.InMemoryStore, account string, expected int64) {
+	balance, err := store.GetBalance(context.Background(), account, "USD")
+	require.NoError(t, err)
+	require.Equal(t, expected, balance.Int64())
+}
+
+func TestCreateHold(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newHoldTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	hold, tx, err := commander.CreateHold(ctx, Parameters{}, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: holdScript,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ledger.HoldStatePending, hold.State)
+	require.Equal(t, "USD", hold.Asset)
+	require.Equal(t, big.NewInt(80), hold.Amount)
+	require.Equal(t, big.NewInt(80), hold.Remaining)
+	require.Equal(t, []ledger.HoldSource{
+		{Account: "alice", Amount: big.NewInt(50)},
+		{Account: "bob", Amount: big.NewInt(30)},
+	}, hold.Sources)
+	require.Len(t, tx.Postings, 2)
+
+	requireBalance(t, store, hold.Account, 80)
+
+	fromStore, err := commander.GetHold(ctx, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, hold, fromStore)
+}
+
+func TestCreateHoldWithoutFunds(t *testing.T) {
+	t.Parallel()
+
+	commander, _ := newHoldTestingCommander(t)
+
+	_, _, err := commander.CreateHold(logging.TestingContext(), Parameters{}, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `
+vars {
+	account $hold
+}
+send [USD 10] (
+	source = @alice
+	destination = @bob
+)`,
+		},
+	})
+	require.True(t, IsHoldError(err, ErrHoldCodeInvalid))
+}
+
+func TestConfirmHold(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newHoldTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	hold, _, err := commander.CreateHold(ctx, Parameters{}, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: holdScript,
+		},
+	})
+	require.NoError(t, err)
+
+	_, _, err = commander.ConfirmHold(ctx, Parameters{}, hold.ID, big.NewInt(100), false)
+	require.True(t, IsHoldError(err, ErrHoldCodeInsufficientFunds))
+
+	hold, _, err = commander.ConfirmHold(ctx, Parameters{}, hold.ID, big.NewInt(30), false)
+	require.NoError(t, err)
+	require.Equal(t, ledger.HoldStatePending, hold.State)
+	require.Equal(t, big.NewInt(50), hold.Remaining)
+	requireBalance(t, store, "merchant", 30)
+
+	hold, tx, err := commander.ConfirmHold(ctx, Parameters{}, hold.ID, big.NewInt(20), true)
+	require.NoError(t, err)
+	require.Equal(t, ledger.HoldStateConfirmed, hold.State)
+	require.Equal(t, big.NewInt(0), hold.Remaining)
+	require.Equal(t, ledger.HoldTransactionMetadata(hold.ID), tx.Metadata)
+
+	requireBalance(t, store, "merchant", 50)
+	requireBalance(t, store, hold.Account, 0)
+	requireBalance(t, store, "alice", 0)
+	requireBalance(t, store, "bob", 50)
+
+	_, _, err = commander.ConfirmHold(ctx, Parameters{}, hold.ID, nil, false)
+	require.True(t, IsHoldError(err, ErrHoldCodeClosed))
+}
+
+func TestVoidHold(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newHoldTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	_, _, err := commander.VoidHold(ctx, Parameters{}, "unknown")
+	require.True(t, IsHoldError(err, ErrHoldCodeNotFound))
+
+	hold, _, err := commander.CreateHold(ctx, Parameters{}, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: holdScript,
+		},
+	})
+	require.NoError(t, err)
+
+	hold, _, err = commander.VoidHold(ctx, Parameters{}, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.HoldStateVoided, hold.State)
+
+	requireBalance(t, store, hold.Account, 0)
+	requireBalance(t, store, "alice", 50)
+	requireBalance(t, store, "bob", 50)
+
+	_, _, err = commander.VoidHold(ctx, Parameters{}, hold.ID)
+	require.True(t, IsHoldError(err, ErrHoldCodeClosed))
+}
+
+func TestHoldReplay(t *testing.T) {
+	t.Parallel()
+
+	commander, _ := newHoldTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	parameters := Parameters{IdempotencyKey: "create-hold"}
+	hold, _, err := commander.CreateHold(ctx, parameters, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: holdScript,
+		},
+	})
+	require.NoError(t, err)
+
+	_, _, err = commander.ConfirmHold(ctx, Parameters{}, hold.ID, big.NewInt(30), false)
+	require.NoError(t, err)
+
+	replayed, _, err := commander.CreateHold(ctx, parameters, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: holdScript,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, hold.ID, replayed.ID)
+	require.Equal(t, big.NewInt(50), replayed.Remaining)
+}
+
+// settlementsMonitor counts the notified hold settlements
+type settlementsMonitor struct {
+	bus.Monitor
+	transactions atomic.Int64
+	settlements  atomic.Int64
+}
+
+func (m *settlementsMonitor) CommittedTransactions(_ context.Context, _ ledger.Transaction, _ map[string]metadata.Metadata) {
+	m.transactions.Add(1)
+}
+
+func (m *settlementsMonitor) ConfirmedHold(_ context.Context, _ ledger.Hold, _ ledger.Transaction) {
+	m.settlements.Add(1)
+}
+
+func (m *settlementsMonitor) VoidedHold(_ context.Context, _ ledger.Hold, _ ledger.Transaction) {
+	m.settlements.Add(1)
+}
+
+func TestSettleHoldReplay(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newHoldTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	// Fund the holds created by the test
+	_, err := commander.CreateTransaction(ctx, Parameters{}, ledger.TxToScriptData(ledger.TransactionData{
+		Postings: ledger.Postings{ledger.NewPosting("world", "alice", "USD", big.NewInt(200))},
+	}, false))
+	require.NoError(t, err)
+
+	monitor := &settlementsMonitor{Monitor: bus.NewNoOpMonitor()}
+	commander.monitor = monitor
+
+	createHold := func() *ledger.Hold {
+		hold, _, err := commander.CreateHold(ctx, Parameters{}, "merchant", ledger.RunScript{
+			Script: ledger.Script{
+				Plain: holdScript,
+			},
+		})
+		require.NoError(t, err)
+		return hold
+	}
+
+	// A partial confirm is replayed without capturing the amount again
+	hold := createHold()
+	parameters := Parameters{IdempotencyKey: "partial-confirm"}
+	confirmed, tx, err := commander.ConfirmHold(ctx, parameters, hold.ID, big.NewInt(30), false)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50), confirmed.Remaining)
+
+	replayed, replayedTx, err := commander.ConfirmHold(ctx, parameters, hold.ID, big.NewInt(30), false)
+	require.NoError(t, err)
+	require.Equal(t, tx.ID, replayedTx.ID)
+	require.Equal(t, ledger.HoldStatePending, replayed.State)
+	require.Equal(t, big.NewInt(50), replayed.Remaining)
+	requireBalance(t, store, "merchant", 30)
+
+	// A final confirm is replayed instead of failing on the closed hold
+	parameters = Parameters{IdempotencyKey: "final-confirm"}
+	_, tx, err = commander.ConfirmHold(ctx, parameters, hold.ID, nil, false)
+	require.NoError(t, err)
+
+	replayed, replayedTx, err = commander.ConfirmHold(ctx, parameters, hold.ID, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, tx.ID, replayedTx.ID)
+	require.Equal(t, ledger.HoldStateConfirmed, replayed.State)
+	require.Zero(t, replayed.Remaining.Sign())
+	requireBalance(t, store, "merchant", 80)
+
+	// So is a void
+	hold = createHold()
+	parameters = Parameters{IdempotencyKey: "void"}
+	_, tx, err = commander.VoidHold(ctx, parameters, hold.ID)
+	require.NoError(t, err)
+
+	replayed, replayedTx, err = commander.VoidHold(ctx, parameters, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, tx.ID, replayedTx.ID)
+	require.Equal(t, ledger.HoldStateVoided, replayed.State)
+
+	// The replayed settlements are not notified again
+	require.EqualValues(t, 3, monitor.settlements.Load())
+	require.EqualValues(t, 5, monitor.transactions.Load())
+
+	// The idempotency key of a settlement cannot be used to settle another hold
+	_, _, err = commander.VoidHold(ctx, parameters, createHold().ID)
+	require.True(t, IsErrIdempotencyKeyConflict(err))
+}
+
+func TestHoldProtected(t *testing.T) {
+	t.Parallel()
+
+	commander, store := newHoldTestingCommander(t)
+	ctx := logging.TestingContext()
+
+	hold, _, err := commander.CreateHold(ctx, Parameters{}, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: holdScript,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, ledger.TxToScriptData(ledger.TransactionData{
+		Postings: ledger.Postings{ledger.NewPosting(hold.Account, "mallory", "USD", big.NewInt(80))},
+	}, false))
+	require.True(t, IsErrHoldProtected(err))
+
+	_, err = commander.CreateTransaction(ctx, Parameters{}, ledger.TxToScriptData(ledger.TransactionData{
+		Postings: ledger.Postings{ledger.NewPosting("world", hold.Account, "USD", big.NewInt(80))},
+	}, false))
+	require.True(t, IsErrHoldProtected(err))
+
+	_, _, err = commander.CreateHold(ctx, Parameters{}, "merchant", ledger.RunScript{
+		Script: ledger.Script{
+			Plain: `
+vars {
+	account $hold
+}
+send [USD 10] (
+	source = @world
+	destination = $hold
+)
+send [USD 80] (
+	source = @` + hold.Account + `
+	destination = @mallory
+)`,
+		},
+	})
+	require.True(t, IsErrHoldProtected(err))
+
+	err = commander.SaveMeta(ctx, Parameters{}, ledger.MetaTargetTypeAccount, hold.Account, ledger.HoldStateMetadata(ledger.HoldStateVoided))
+	require.True(t, IsErrHoldProtected(err))
+
+	for key := range ledger.HoldStateMetadata(ledger.HoldStateVoided) {
+		err = commander.DeleteMetadata(ctx, Parameters{}, ledger.MetaTargetTypeAccount, hold.Account, key)
+		require.True(t, IsErrHoldProtected(err))
+	}
+
+	results, err := commander.ExecuteAtomicBulk(ctx, []BulkElement{{
+		SaveMetadata: &SaveMetadataRequest{
+			TargetType: ledger.MetaTargetTypeAccount,
+			TargetID:   hold.Account,
+			Metadata:   ledger.HoldStateMetadata(ledger.HoldStateVoided),
+		},
+	}})
+	require.NoError(t, err)
+	require.True(t, IsErrHoldProtected(results[0].Err))
+
+	requireBalance(t, store, hold.Account, 80)
+
+	fromStore, err := commander.GetHold(ctx, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.HoldStatePending, fromStore.State)
+}
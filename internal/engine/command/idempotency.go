@@ -0,0 +1,35 @@
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	actionCreateTransaction = "CREATE_TRANSACTION"
+	actionRevertTransaction = "REVERT_TRANSACTION"
+	actionSaveMetadata      = "ADD_METADATA"
+	actionDeleteMetadata    = "DELETE_METADATA"
+	actionCreateHold        = "CREATE_HOLD"
+	actionConfirmHold       = "CONFIRM_HOLD"
+	actionVoidHold          = "VOID_HOLD"
+)
+
+// fingerprint hashes the payload of a request, so a request reusing an idempotency key
+// can be checked against the one which first used it.
+// The payload must be taken before applying any default value, as retries must produce the same fingerprint.
+func fingerprint(action string, payload any) string {
+	digest := sha256.New()
+	if err := json.NewEncoder(digest).Encode(struct {
+		Action  string `json:"action"`
+		Payload any    `json:"payload"`
+	}{
+		Action:  action,
+		Payload: payload,
+	}); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(digest.Sum(nil))
+}
@@ -0,0 +1,272 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/pkg/errors"
+)
+
+// leaderHandover is the delay an instance waits after the end of its tenure before taking the writer lock again,
+// so the instances waiting for it can take it.
+const leaderHandover = 50 * time.Millisecond
+
+// WriterLock elects the instance allowed to write on a ledger
+// when several instances share the same storage.
+type WriterLock interface {
+	AcquireWriterLock(ctx context.Context) (HeldWriterLock, error)
+}
+
+// HeldWriterLock is the writer lock of a ledger held by the instance.
+type HeldWriterLock interface {
+	// Check returns an error if the lock may have been taken by another instance
+	Check(ctx context.Context) error
+	// Fence returns a context whose inserts of logs fail if the lock has been taken by another instance in the meantime
+	Fence(ctx context.Context) context.Context
+	Release(ctx context.Context) error
+}
+
+// LeadershipChecker is implemented by the lockers electing a writer among several instances.
+type LeadershipChecker interface {
+	// CheckLeadership returns an error if the instance may not be the writer of the ledger anymore.
+	// Otherwise, it returns the context to insert the logs with, so the insert fails if the leadership is lost before.
+	CheckLeadership(ctx context.Context) (context.Context, error)
+}
+
+type leadingContextKey struct{}
+
+// withLeadership marks ctx as running as the writer of the ledger.
+// The locks taken with it do not wait for the leadership,
+// which would never be granted again once the tenure is over, as it is only released after the running writes.
+func withLeadership(ctx context.Context) context.Context {
+	return context.WithValue(ctx, leadingContextKey{}, true)
+}
+
+func isLeading(ctx context.Context) bool {
+	leading, _ := ctx.Value(leadingContextKey{}).(bool)
+	return leading
+}
+
+// LeaderLocker is a Locker for ledgers written by several instances.
+// It first makes the instance the writer of the ledger, then locks the accounts using the underlying locker.
+// The writer lock is kept for a tenure, during which the writes of the instance do not need to acquire it again.
+// Once the tenure is over, the lock is released as soon as the running writes are done, to let the other instances write.
+// The chain is synchronized with the storage each time the writer lock is acquired,
+// as other instances may have written on the ledger in the meantime.
+type LeaderLocker struct {
+	underlying Locker
+	writerLock WriterLock
+	sync       func(ctx context.Context) error
+	timeout    time.Duration
+	tenure     time.Duration
+
+	// sem guards the fields below, while allowing to wait for it using a context
+	sem     chan struct{}
+	holders int
+	lock    HeldWriterLock
+	// expired is set when the tenure is over or the lock is lost, no more writes are started until the lock is released
+	expired bool
+	// released is closed when the lock is released
+	released   chan struct{}
+	handoverAt time.Time
+}
+
+func (l *LeaderLocker) acquire(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *LeaderLocker) unacquire() {
+	<-l.sem
+}
+
+func (l *LeaderLocker) lead(ctx context.Context) (Unlock, error) {
+	// The instance gives up if another instance keeps the lock, instead of queuing the writes
+	leadCtx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	for {
+		if err := l.acquire(leadCtx); err != nil {
+			return nil, l.leadError(ctx, err)
+		}
+
+		switch {
+		case l.lock != nil && !l.expired:
+			l.holders++
+			l.unacquire()
+			return l.unlead, nil
+		case l.lock != nil:
+			// Wait for the running writes to be done
+			released := l.released
+			l.unacquire()
+
+			select {
+			case <-released:
+			case <-leadCtx.Done():
+				return nil, l.leadError(ctx, leadCtx.Err())
+			}
+		case time.Now().Before(l.handoverAt):
+			l.unacquire()
+
+			select {
+			case <-time.After(time.Until(l.handoverAt)):
+			case <-leadCtx.Done():
+				return nil, l.leadError(ctx, leadCtx.Err())
+			}
+		default:
+			err := l.acquireWriterLock(ctx, leadCtx)
+			if err == nil {
+				l.holders++
+			}
+			l.unacquire()
+			if err != nil {
+				return nil, err
+			}
+			return l.unlead, nil
+		}
+	}
+}
+
+// acquireWriterLock takes the writer lock, waiting for it until leadCtx is done.
+// It must be called with sem acquired.
+func (l *LeaderLocker) acquireWriterLock(ctx, leadCtx context.Context) error {
+	lock, err := l.writerLock.AcquireWriterLock(leadCtx)
+	if err != nil {
+		return l.leadError(ctx, errors.Wrap(err, "acquiring writer lock"))
+	}
+	if err := l.sync(ctx); err != nil {
+		if err := lock.Release(ctx); err != nil {
+			logging.FromContext(ctx).Errorf("releasing writer lock: %s", err)
+		}
+		return errors.Wrap(err, "synchronizing chain")
+	}
+	logging.FromContext(ctx).Debugf("Writer lock acquired")
+
+	l.lock = lock
+	l.expired = false
+	l.released = make(chan struct{})
+	time.AfterFunc(l.tenure, func() {
+		l.expire(context.WithoutCancel(ctx), lock)
+	})
+
+	return nil
+}
+
+// leadError reports the leadership timeout as ErrNotLeader, unless ctx itself is done.
+func (l *LeaderLocker) leadError(ctx context.Context, err error) error {
+	if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return NewErrNotLeader()
+	}
+	return errors.Wrap(err, "waiting for ledger leadership")
+}
+
+// expire ends the tenure of lock, which is released once the running writes are done.
+func (l *LeaderLocker) expire(ctx context.Context, lock HeldWriterLock) {
+	l.sem <- struct{}{}
+	defer l.unacquire()
+
+	if l.lock != lock {
+		return
+	}
+	l.expired = true
+	if l.holders == 0 {
+		l.releaseWriterLock(ctx)
+	}
+}
+
+// releaseWriterLock must be called with sem acquired.
+func (l *LeaderLocker) releaseWriterLock(ctx context.Context) {
+	if err := l.lock.Release(ctx); err != nil {
+		logging.FromContext(ctx).Errorf("releasing writer lock: %s", err)
+	} else {
+		logging.FromContext(ctx).Debugf("Writer lock released")
+	}
+	l.lock = nil
+	l.handoverAt = time.Now().Add(leaderHandover)
+	close(l.released)
+}
+
+func (l *LeaderLocker) unlead(ctx context.Context) {
+	l.sem <- struct{}{}
+	defer l.unacquire()
+
+	l.holders--
+	if l.holders == 0 && l.expired {
+		l.releaseWriterLock(ctx)
+	}
+}
+
+// CheckLeadership checks the writer lock is still held, and fences the logs inserted with the returned context with it.
+// If it is lost, the writes are rejected until the lock is acquired again.
+func (l *LeaderLocker) CheckLeadership(ctx context.Context) (context.Context, error) {
+	if err := l.acquire(ctx); err != nil {
+		return nil, err
+	}
+	lock := l.lock
+	l.unacquire()
+
+	if lock == nil {
+		return nil, NewErrNotLeader()
+	}
+	if err := lock.Check(ctx); err != nil {
+		logging.FromContext(ctx).Errorf("writer lock lost: %s", err)
+
+		l.sem <- struct{}{}
+		if l.lock == lock {
+			l.expired = true
+			if l.holders == 0 {
+				l.releaseWriterLock(ctx)
+			}
+		}
+		l.unacquire()
+
+		return nil, NewErrNotLeader()
+	}
+	return lock.Fence(ctx), nil
+}
+
+func (l *LeaderLocker) Lock(ctx context.Context, accounts Accounts) (Unlock, error) {
+	// The writer lock is already held by the caller
+	if isLeading(ctx) {
+		return l.underlying.Lock(ctx, accounts)
+	}
+
+	unlead, err := l.lead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := l.underlying.Lock(ctx, accounts)
+	if err != nil {
+		unlead(ctx)
+		return nil, err
+	}
+
+	return func(ctx context.Context) {
+		unlock(ctx)
+		unlead(ctx)
+	}, nil
+}
+
+var (
+	_ Locker            = (*LeaderLocker)(nil)
+	_ LeadershipChecker = (*LeaderLocker)(nil)
+)
+
+// NewLeaderLocker creates a LeaderLocker keeping the writer lock for tenure,
+// whose writes fail with ErrNotLeader if the writer lock cannot be acquired within timeout.
+func NewLeaderLocker(underlying Locker, writerLock WriterLock, sync func(ctx context.Context) error, timeout, tenure time.Duration) *LeaderLocker {
+	return &LeaderLocker{
+		underlying: underlying,
+		writerLock: writerLock,
+		sync:       sync,
+		timeout:    timeout,
+		tenure:     tenure,
+		sem:        make(chan struct{}, 1),
+	}
+}
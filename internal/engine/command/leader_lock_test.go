@@ -0,0 +1,245 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// inMemoryWriterLock emulates the writer lock of a ledger shared by several instances
+type inMemoryWriterLock struct {
+	sem          chan struct{}
+	acquisitions atomic.Int64
+	held         atomic.Pointer[inMemoryHeldWriterLock]
+}
+
+func (l *inMemoryWriterLock) AcquireWriterLock(ctx context.Context) (HeldWriterLock, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	l.acquisitions.Add(1)
+
+	held := &inMemoryHeldWriterLock{
+		writerLock: l,
+	}
+	l.held.Store(held)
+	return held, nil
+}
+
+type inMemoryHeldWriterLock struct {
+	writerLock *inMemoryWriterLock
+	lost       atomic.Bool
+}
+
+func (l *inMemoryHeldWriterLock) Check(_ context.Context) error {
+	if l.lost.Load() {
+		return errors.New("writer lock lost")
+	}
+	return nil
+}
+
+func (l *inMemoryHeldWriterLock) Fence(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (l *inMemoryHeldWriterLock) Release(_ context.Context) error {
+	<-l.writerLock.sem
+	return nil
+}
+
+func newInMemoryWriterLock() *inMemoryWriterLock {
+	return &inMemoryWriterLock{
+		sem: make(chan struct{}, 1),
+	}
+}
+
+func TestLeaderLocker(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	writerLock := newInMemoryWriterLock()
+	syncs := 0
+	locker := NewLeaderLocker(NewDefaultLocker(), writerLock, func(ctx context.Context) error {
+		syncs++
+		return nil
+	}, time.Second, 50*time.Millisecond)
+
+	unlock1, err := locker.Lock(ctx, Accounts{Write: []string{"world"}})
+	require.NoError(t, err)
+	unlock2, err := locker.Lock(ctx, Accounts{Write: []string{"bank"}})
+	require.NoError(t, err)
+
+	// The writer lock is shared by the locks of the instance
+	require.EqualValues(t, 1, writerLock.acquisitions.Load())
+	require.Equal(t, 1, syncs)
+
+	unlock1(ctx)
+	unlock2(ctx)
+
+	// The writer lock is kept during the tenure
+	unlock, err := locker.Lock(ctx, Accounts{Write: []string{"world"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, writerLock.acquisitions.Load())
+	unlock(ctx)
+
+	// Then released, so another instance may write
+	require.Eventually(t, func() bool {
+		return len(writerLock.sem) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	unlock, err = locker.Lock(ctx, Accounts{Write: []string{"world"}})
+	require.NoError(t, err)
+	defer unlock(ctx)
+
+	// Another instance may have written in the meantime, so the chain must be synchronized again
+	require.EqualValues(t, 2, writerLock.acquisitions.Load())
+	require.Equal(t, 2, syncs)
+}
+
+func TestLeaderLockerWithSeveralInstances(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	writerLock := newInMemoryWriterLock()
+	writer := ""
+	writerMu := sync.Mutex{}
+
+	const (
+		nbInstances = 3
+		nbLoop      = 50
+	)
+	wg := sync.WaitGroup{}
+	for i := 0; i < nbInstances; i++ {
+		instance := string(rune('a' + i))
+		locker := NewLeaderLocker(NewDefaultLocker(), writerLock, func(ctx context.Context) error {
+			return nil
+		}, 5*time.Second, 5*time.Millisecond)
+		for j := 0; j < nbLoop; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				unlock, err := locker.Lock(ctx, Accounts{Write: []string{"world"}})
+				require.NoError(t, err)
+				defer unlock(ctx)
+
+				writerMu.Lock()
+				require.True(t, writer == "" || writer == instance, "two instances writing at the same time")
+				writer = instance
+				writerMu.Unlock()
+
+				<-time.After(time.Millisecond)
+
+				writerMu.Lock()
+				writer = ""
+				writerMu.Unlock()
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestLeaderLockerCanceled(t *testing.T) {
+	t.Parallel()
+
+	writerLock := newInMemoryWriterLock()
+	release, err := writerLock.AcquireWriterLock(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, release.Release(context.Background()))
+	}()
+
+	locker := NewLeaderLocker(NewDefaultLocker(), writerLock, func(ctx context.Context) error {
+		return nil
+	}, time.Second, time.Second)
+
+	ctx, cancel := context.WithTimeout(logging.TestingContext(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = locker.Lock(ctx, Accounts{Write: []string{"world"}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLeaderLockerTimeout(t *testing.T) {
+	t.Parallel()
+
+	writerLock := newInMemoryWriterLock()
+	release, err := writerLock.AcquireWriterLock(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, release.Release(context.Background()))
+	}()
+
+	locker := NewLeaderLocker(NewDefaultLocker(), writerLock, func(ctx context.Context) error {
+		return nil
+	}, 10*time.Millisecond, time.Second)
+
+	// The writes fail instead of waiting for the other instance
+	_, err = locker.Lock(logging.TestingContext(), Accounts{Write: []string{"world"}})
+	require.True(t, IsErrNotLeader(err))
+}
+
+func TestLeaderLockerLost(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	writerLock := newInMemoryWriterLock()
+	syncs := 0
+	locker := NewLeaderLocker(NewDefaultLocker(), writerLock, func(ctx context.Context) error {
+		syncs++
+		return nil
+	}, time.Second, time.Minute)
+
+	unlock, err := locker.Lock(ctx, Accounts{Write: []string{"world"}})
+	require.NoError(t, err)
+	_, err = locker.CheckLeadership(ctx)
+	require.NoError(t, err)
+
+	writerLock.held.Load().lost.Store(true)
+	_, err = locker.CheckLeadership(ctx)
+	require.True(t, IsErrNotLeader(err))
+	unlock(ctx)
+
+	// The lock is acquired again, and the chain synchronized
+	unlock, err = locker.Lock(ctx, Accounts{Write: []string{"world"}})
+	require.NoError(t, err)
+	defer unlock(ctx)
+	_, err = locker.CheckLeadership(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, writerLock.acquisitions.Load())
+	require.Equal(t, 2, syncs)
+}
+
+func TestLeaderLockerNestedLockAfterTenure(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	writerLock := newInMemoryWriterLock()
+	locker := NewLeaderLocker(NewDefaultLocker(), writerLock, func(ctx context.Context) error {
+		return nil
+	}, time.Second, 10*time.Millisecond)
+
+	unlead, err := locker.Lock(ctx, Accounts{})
+	require.NoError(t, err)
+
+	// The tenure is over, but the accounts are locked by a write already leading the ledger
+	<-time.After(50 * time.Millisecond)
+	unlock, err := locker.Lock(withLeadership(ctx), Accounts{Write: []string{"world"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, writerLock.acquisitions.Load())
+
+	unlock(ctx)
+	unlead(ctx)
+
+	require.Eventually(t, func() bool {
+		return len(writerLock.sem) == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,6 +31,39 @@ type Accounts struct {
 	Write []string
 }
 
+// contains returns true if a lock on a covers the accounts.
+func (a Accounts) contains(accounts Accounts) bool {
+	for _, account := range accounts.Write {
+		if !slices.Contains(a.Write, account) {
+			return false
+		}
+	}
+	for _, account := range accounts.Read {
+		if !slices.Contains(a.Write, account) && !slices.Contains(a.Read, account) {
+			return false
+		}
+	}
+	return true
+}
+
+// merge returns the accounts of a and accounts, the accounts both read and written are only locked for writing.
+func (a Accounts) merge(accounts Accounts) Accounts {
+	write := append(slices.Clone(a.Write), accounts.Write...)
+	slices.Sort(write)
+	write = slices.Compact(write)
+
+	read := append(slices.Clone(a.Read), accounts.Read...)
+	slices.Sort(read)
+	read = collectionutils.Filter(slices.Compact(read), func(account string) bool {
+		return !slices.Contains(write, account)
+	})
+
+	return Accounts{
+		Read:  read,
+		Write: write,
+	}
+}
+
 type lockIntent struct {
 	accounts Accounts
 	acquired chan struct{}
@@ -13,8 +13,12 @@ const (
 	referenceReverts = iota
 	referenceIks
 	referenceTxReference
+	referenceHolds
 )
 
+// Referencer prevents the concurrent writes of the instance from using the same references.
+// The writes of the other instances are excluded by the writer lock, taken before the references,
+// and whose term fences the inserted logs.
 type Referencer struct {
 	references map[Reference]*sync.Map
 }
@@ -37,6 +41,7 @@ func NewReferencer() *Referencer {
 			referenceReverts:     {},
 			referenceIks:         {},
 			referenceTxReference: {},
+			referenceHolds:       {},
 		},
 	}
 }
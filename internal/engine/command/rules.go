@@ -0,0 +1,212 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/machine/vm"
+	"github.com/formancehq/ledger/internal/opentelemetry/tracer"
+	"github.com/pkg/errors"
+)
+
+// updateRules calls updateFn as the writer of the ledger, while no write is running.
+func (commander *Commander) updateRules(ctx context.Context, updateFn func(ctx context.Context) error) error {
+	commander.rules.Lock()
+	defer commander.rules.Unlock()
+
+	ctx, unlead, err := commander.lead(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlead(ctx)
+
+	return updateFn(ctx)
+}
+
+// SetClosingDate makes the commander reject transactions dated before closingDate.
+func (commander *Commander) SetClosingDate(closingDate time.Time) {
+	commander.closingDate.Store(&closingDate)
+}
+
+// checkPeriod rejects a transaction dated in a closed period.
+func (commander *Commander) checkPeriod(date time.Time) error {
+	closingDate := commander.closingDate.Load()
+	if closingDate != nil && date.Before(*closingDate) {
+		return NewErrPeriodClosed(date, *closingDate)
+	}
+	return nil
+}
+
+// ClosePeriod closes the period ending at closingDate.
+// closeFn is called to record the closing, while no transaction can be inserted.
+func (commander *Commander) ClosePeriod(ctx context.Context, closingDate time.Time, closeFn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "ClosePeriod")
+	defer span.End()
+
+	return commander.updateRules(ctx, func(ctx context.Context) error {
+		if closingDate.After(time.Now()) {
+			return NewErrInvalidClosingDate(errors.New("closing date is in the future"))
+		}
+		if current := commander.closingDate.Load(); current != nil && !closingDate.After(*current) {
+			return NewErrInvalidClosingDate(fmt.Errorf("closing date must be after the current closing date %s", current.Format(time.RFC3339Nano)))
+		}
+
+		if err := closeFn(ctx); err != nil {
+			return err
+		}
+
+		commander.SetClosingDate(closingDate)
+
+		return nil
+	})
+}
+
+// SetAccountPolicies sets the policies enforced on the accounts of the transactions.
+func (commander *Commander) SetAccountPolicies(policies ledger.AccountPolicies) {
+	commander.accountPolicies.Store(&policies)
+}
+
+func (commander *Commander) getAccountPolicies() ledger.AccountPolicies {
+	policies := commander.accountPolicies.Load()
+	if policies == nil {
+		return nil
+	}
+	return *policies
+}
+
+// UpdateAccountPolicies calls updateFn to record a change of the account policies as the writer of the ledger,
+// then enforces the policies it returns.
+func (commander *Commander) UpdateAccountPolicies(ctx context.Context, updateFn func(ctx context.Context) (ledger.AccountPolicies, error)) error {
+	ctx, span := tracer.Start(ctx, "UpdateAccountPolicies")
+	defer span.End()
+
+	return commander.updateRules(ctx, func(ctx context.Context) error {
+		policies, err := updateFn(ctx)
+		if err != nil {
+			return err
+		}
+		commander.SetAccountPolicies(policies)
+
+		return nil
+	})
+}
+
+// SetAccountFreezes sets the freezes enforced on the accounts of the transactions.
+func (commander *Commander) SetAccountFreezes(freezes ledger.AccountFreezes) {
+	commander.accountFreezes.Store(&freezes)
+}
+
+// checkFreezes rejects postings debiting or crediting an account frozen for that direction.
+func (commander *Commander) checkFreezes(postings ledger.Postings) error {
+	freezes := commander.accountFreezes.Load()
+	if freezes == nil || len(*freezes) == 0 {
+		return nil
+	}
+	for _, posting := range postings {
+		if freeze, ok := (*freezes)[posting.Source]; ok && freeze.BlocksDebits() {
+			return NewErrAccountFrozen(posting.Source, ledger.FreezeDirectionDebit)
+		}
+		if freeze, ok := (*freezes)[posting.Destination]; ok && freeze.BlocksCredits() {
+			return NewErrAccountFrozen(posting.Destination, ledger.FreezeDirectionCredit)
+		}
+	}
+	return nil
+}
+
+// UpdateAccountFreezes calls updateFn to record a freeze or an unfreeze as the writer of the ledger,
+// then enforces the freezes it returns.
+func (commander *Commander) UpdateAccountFreezes(ctx context.Context, updateFn func(ctx context.Context) (ledger.AccountFreezes, error)) error {
+	ctx, span := tracer.Start(ctx, "UpdateAccountFreezes")
+	defer span.End()
+
+	return commander.updateRules(ctx, func(ctx context.Context) error {
+		freezes, err := updateFn(ctx)
+		if err != nil {
+			return err
+		}
+		commander.SetAccountFreezes(freezes)
+
+		return nil
+	})
+}
+
+// SetChart sets the chart of accounts the transactions are checked against, or removes it if chart is nil.
+func (commander *Commander) SetChart(chart *ledger.Chart) {
+	commander.chart.Store(chart)
+}
+
+// checkChart checks the postings of result against the chart of accounts, only logging the violations in warn mode,
+// then adds the default metadata of the declared accounts to result.
+func (commander *Commander) checkChart(ctx context.Context, store vm.Store, result *vm.Result) error {
+	chart := commander.chart.Load()
+	if chart == nil {
+		return nil
+	}
+
+	if err := chart.Check(result.Postings); err != nil {
+		if chart.Mode == ledger.ChartModeStrict {
+			return NewErrChartViolation(err)
+		}
+		logging.FromContext(ctx).Infof("chart of accounts violation: %s", err)
+	}
+
+	addresses := make([]string, 0)
+	for _, posting := range result.Postings {
+		for _, address := range []string{posting.Source, posting.Destination} {
+			if !slices.Contains(addresses, address) {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+
+	for _, address := range addresses {
+		account := chart.Find(address)
+		if account == nil || len(account.DefaultMetadata) == 0 {
+			continue
+		}
+
+		current, err := store.GetAccount(ctx, address)
+		if err != nil {
+			return errors.Wrap(err, "could not get account")
+		}
+		for key, value := range account.DefaultMetadata {
+			if _, ok := current.Metadata[key]; ok {
+				continue
+			}
+			if _, ok := result.AccountMetadata[address][key]; ok {
+				continue
+			}
+			if result.AccountMetadata == nil {
+				result.AccountMetadata = map[string]metadata.Metadata{}
+			}
+			if result.AccountMetadata[address] == nil {
+				result.AccountMetadata[address] = metadata.Metadata{}
+			}
+			result.AccountMetadata[address][key] = value
+		}
+	}
+
+	return nil
+}
+
+// UpdateChart calls updateFn to record a change of the chart of accounts as the writer of the ledger,
+// then checks the transactions against the chart it returns.
+func (commander *Commander) UpdateChart(ctx context.Context, updateFn func(ctx context.Context) (*ledger.Chart, error)) error {
+	ctx, span := tracer.Start(ctx, "UpdateChart")
+	defer span.End()
+
+	return commander.updateRules(ctx, func(ctx context.Context) error {
+		chart, err := updateFn(ctx)
+		if err != nil {
+			return err
+		}
+		commander.SetChart(chart)
+
+		return nil
+	})
+}
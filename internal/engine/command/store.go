@@ -17,3 +17,8 @@ type Store interface {
 	GetTransactionByReference(ctx context.Context, ref string) (*ledger.ExpandedTransaction, error)
 	GetTransaction(ctx context.Context, txID *big.Int) (*ledger.Transaction, error)
 }
+
+// transactionReader reads the transactions targeted by the writes.
+type transactionReader interface {
+	GetTransaction(ctx context.Context, txID *big.Int) (*ledger.Transaction, error)
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/formancehq/go-libs/bun/bunpaginate"
 	ledger "github.com/formancehq/ledger/internal"
@@ -18,12 +19,51 @@ func (fn ExportWriterFn) Write(ctx context.Context, log *ledger.ChainedLog) erro
 	return fn(ctx, log)
 }
 
-func (l *Ledger) Export(ctx context.Context, w ExportWriter) error {
-	return bunpaginate.Iterate(
+// ExportCheckpointer is implemented by the export writers recording the progress of the export.
+// Checkpoint is called after each page of logs, with the manifest of the logs written so far,
+// whose cursor allows to resume an interrupted export.
+type ExportCheckpointer interface {
+	Checkpoint(ctx context.Context, manifest ExportManifest) error
+}
+
+// ExportManifest describes the logs written by an export.
+// Its cursor allows to resume the export after the last exported log, with the same filters.
+type ExportManifest struct {
+	Count      int      `json:"count"`
+	FirstLogID *big.Int `json:"firstLogID,omitempty"`
+	LastLogID  *big.Int `json:"lastLogID,omitempty"`
+	LastHash   []byte   `json:"lastHash,omitempty"`
+	Cursor     string   `json:"cursor"`
+}
+
+// ExportManifestDocument is the last document of an export when its manifest is requested
+type ExportManifestDocument struct {
+	Manifest *ExportManifest `json:"manifest"`
+}
+
+// ExportCheckpointDocument is written after each page of an export when its manifest is requested
+type ExportCheckpointDocument struct {
+	Checkpoint *ExportManifest `json:"checkpoint"`
+}
+
+var (
+	exportManifestPrefix   = []byte(`{"manifest":`)
+	exportCheckpointPrefix = []byte(`{"checkpoint":`)
+)
+
+func NewExportQuery(options ledgerstore.PaginatedQueryOptions[any]) ledgerstore.GetLogsQuery {
+	return ledgerstore.
+		NewGetLogsQuery(options.WithPageSize(100)).
+		WithOrder(bunpaginate.OrderAsc)
+}
+
+// Export writes the logs matching the query in ascending order.
+// The query is usually built with NewExportQuery, or decoded from the cursor of a previous manifest.
+func (l *Ledger) Export(ctx context.Context, q ledgerstore.GetLogsQuery, w ExportWriter) (*ExportManifest, error) {
+	manifest := &ExportManifest{}
+	err := bunpaginate.Iterate(
 		ctx,
-		ledgerstore.
-			NewGetLogsQuery(ledgerstore.NewPaginatedQueryOptions[any](nil).WithPageSize(100)).
-			WithOrder(bunpaginate.OrderAsc),
+		q,
 		func(ctx context.Context, q ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
 			return l.store.GetLogs(ctx, q)
 		},
@@ -32,8 +72,37 @@ func (l *Ledger) Export(ctx context.Context, w ExportWriter) error {
 				if err := w.Write(ctx, &data); err != nil {
 					return err
 				}
+				if manifest.FirstLogID == nil {
+					manifest.FirstLogID = data.ID
+				}
+				manifest.Count++
+				manifest.LastLogID = data.ID
+				manifest.LastHash = data.Hash
+			}
+
+			if checkpointer, ok := w.(ExportCheckpointer); ok && len(cursor.Data) > 0 {
+				checkpoint := *manifest
+				checkpoint.Cursor = exportCursor(q, manifest.LastLogID)
+				return checkpointer.Checkpoint(ctx, checkpoint)
 			}
 			return nil
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Cursor = exportCursor(q, manifest.LastLogID)
+
+	return manifest, nil
+}
+
+// exportCursor returns the cursor of the export query resuming after the given log.
+func exportCursor(q ledgerstore.GetLogsQuery, lastLogID *big.Int) string {
+	next := q
+	next.Bottom = nil
+	next.Reverse = false
+	if lastLogID != nil {
+		next.PaginationID = big.NewInt(0).Add(lastLogID, big.NewInt(1))
+	}
+	return bunpaginate.EncodeCursor(next)
 }
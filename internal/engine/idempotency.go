@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/logging"
+	libtime "github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/pkg/errors"
+)
+
+// idempotencyKeysPurger periodically expires the idempotency keys of all the ledgers
+// once they are older than the retention, so they can be reused.
+type idempotencyKeysPurger struct {
+	driver    *driver.Driver
+	retention time.Duration
+	interval  time.Duration
+	stopChan  chan chan struct{}
+}
+
+func (p *idempotencyKeysPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case done := <-p.stopChan:
+			close(done)
+			return
+		case <-ticker.C:
+			if err := p.purge(ctx); err != nil {
+				logging.FromContext(ctx).Errorf("purging idempotency keys: %s", err)
+			}
+		}
+	}
+}
+
+func (p *idempotencyKeysPurger) Close() {
+	done := make(chan struct{})
+	p.stopChan <- done
+	<-done
+}
+
+func (p *idempotencyKeysPurger) purge(ctx context.Context) error {
+	before := libtime.Now().Add(-p.retention)
+
+	return bunpaginate.Iterate(ctx, systemstore.NewListLedgersQuery(100),
+		func(ctx context.Context, q systemstore.ListLedgersQuery) (*bunpaginate.Cursor[systemstore.Ledger], error) {
+			return p.driver.GetSystemStore().ListLedgers(ctx, q)
+		},
+		func(cursor *bunpaginate.Cursor[systemstore.Ledger]) error {
+			for _, l := range cursor.Data {
+				store, err := p.driver.GetLedgerStore(ctx, l.Name, driver.LedgerState{
+					LedgerConfiguration: driver.LedgerConfiguration{
+						Bucket:   l.Bucket,
+						Metadata: l.Metadata,
+					},
+					State: l.State,
+				})
+				if err != nil {
+					return errors.Wrapf(err, "getting store of ledger %s", l.Name)
+				}
+
+				// A failing ledger, for example because its bucket is not up to date, must not prevent purging the others
+				purged, err := store.PurgeIdempotencyKeys(ctx, before)
+				if err != nil {
+					logging.FromContext(ctx).Errorf("purging idempotency keys of ledger %s: %s", l.Name, err)
+					continue
+				}
+				if purged > 0 {
+					logging.FromContext(ctx).Debugf("Purged %d idempotency keys of ledger %s", purged, l.Name)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func newIdempotencyKeysPurger(driver *driver.Driver, retention, interval time.Duration) *idempotencyKeysPurger {
+	return &idempotencyKeysPurger{
+		driver:    driver,
+		retention: retention,
+		interval:  interval,
+		stopChan:  make(chan chan struct{}),
+	}
+}
@@ -1,13 +1,18 @@
 package engine
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 
+	"github.com/formancehq/go-libs/logging"
 	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/engine/command"
 	"github.com/pkg/errors"
 )
 
@@ -27,6 +32,11 @@ func (i ImportError) Is(err error) bool {
 
 var _ error = (*ImportError)(nil)
 
+// LogID returns the id of the log which failed to be imported, if known.
+func (i ImportError) LogID() *big.Int {
+	return i.logID
+}
+
 func newImportError(logID *big.Int, err error) ImportError {
 	return ImportError{
 		logID: logID,
@@ -84,16 +94,90 @@ func newInvalidHashError(logID *big.Int, got, expected []byte) ImportError {
 	})
 }
 
-func (l *Ledger) Import(ctx context.Context, stream chan *ledger.ChainedLog) error {
-	if l.config.LedgerState.State != "initializing" {
-		return errors.New("ledger must be in initializing state to be imported")
+// ImportReport counts the logs of an import stream.
+type ImportReport struct {
+	Imported  int      `json:"imported"`
+	Skipped   int      `json:"skipped"`
+	LastLogID *big.Int `json:"lastLogID,omitempty"`
+}
+
+// ImportProgressFn is called each time a batch of logs has been imported.
+type ImportProgressFn func(ctx context.Context, report ImportReport)
+
+var ErrImportInProgress = errors.New("an import is already in progress on the ledger")
+
+// Import inserts the logs of the stream after the last log of the ledger, which may be non-empty.
+// The stream may start before the last log, typically when an interrupted import is resumed:
+// the logs already present are skipped, and the log of the stream having the id of the last log
+// must have the same hash, which ensures both chains are the same.
+func (l *Ledger) Import(ctx context.Context, stream chan *ledger.ChainedLog, progress ImportProgressFn) (*ImportReport, error) {
+	if l.isReadOnly() {
+		return nil, NewCommandError(command.NewErrLedgerReadOnly())
 	}
+	if !l.importMu.TryLock() {
+		return nil, ErrImportInProgress
+	}
+	defer l.importMu.Unlock()
+
+	report := &ImportReport{}
+	// The logs are imported as the writer of the ledger, which rejects the other writes until the import ends
+	err := l.commander.Import(ctx, func(ctx context.Context) error {
+		err := l.importStream(ctx, stream, report, progress)
+		if err != nil {
+			// The chain may contain logs of the last batch which have not been inserted
+			if err := l.chain.Init(context.WithoutCancel(ctx)); err != nil {
+				logging.FromContext(ctx).Errorf("reloading chain after failed import: %s", err)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		if command.IsErrLedgerReadOnly(err) {
+			return report, NewCommandError(err)
+		}
+		return report, err
+	}
+
+	return report, nil
+}
+
+func (l *Ledger) importStream(ctx context.Context, stream chan *ledger.ChainedLog, report *ImportReport, progress ImportProgressFn) error {
 	batch := make([]*ledger.ChainedLog, 0)
+	flush := func() error {
+		importCtx, err := l.commander.CheckLeadership(ctx)
+		if err != nil {
+			return err
+		}
+		if err := l.store.ImportLogs(importCtx, batch...); err != nil {
+			return err
+		}
+		l.logsNotifier.notify(batch...)
+		report.Imported += len(batch)
+		report.LastLogID = batch[len(batch)-1].ID
+		batch = make([]*ledger.ChainedLog, 0)
+		if progress != nil {
+			progress(ctx, *report)
+		}
+		return nil
+	}
+
 	for log := range stream {
 		lastLog := l.chain.GetLastLog()
 		nextLogID := big.NewInt(0)
 		if lastLog != nil {
 			nextLogID = nextLogID.Add(lastLog.ID, big.NewInt(1))
+
+			if log.ID.Cmp(lastLog.ID) < 0 {
+				report.Skipped++
+				continue
+			}
+			if log.ID.Cmp(lastLog.ID) == 0 {
+				if !reflect.DeepEqual(log.Hash, lastLog.Hash) {
+					return newInvalidHashError(log.ID, log.Hash, lastLog.Hash)
+				}
+				report.Skipped++
+				continue
+			}
 		}
 		if log.ID.String() != nextLogID.String() {
 			return newInvalidIdError(log.ID, nextLogID)
@@ -112,17 +196,91 @@ func (l *Ledger) Import(ctx context.Context, stream chan *ledger.ChainedLog) err
 
 		batch = append(batch, log)
 		if len(batch) == 100 { // notes(gfyrag): maybe we could parameterize that, but i don't think it will be useful
-			if err := l.store.InsertLogs(ctx, batch...); err != nil {
+			if err := flush(); err != nil {
 				return err
 			}
-			batch = make([]*ledger.ChainedLog, 0)
 		}
 	}
 	if len(batch) > 0 {
-		if err := l.store.InsertLogs(ctx, batch...); err != nil {
-			return err
-		}
+		return flush()
 	}
 
 	return nil
 }
+
+// ImportLogs imports a stream of json documents, as written by an export.
+// The checkpoints of the export are ignored, and when the stream ends with the manifest of the export,
+// the received logs are checked against it.
+func (l *Ledger) ImportLogs(ctx context.Context, r io.Reader, progress ImportProgressFn) (*ImportReport, error) {
+	stream := make(chan *ledger.ChainedLog)
+	importDone := make(chan struct{})
+	var (
+		report    *ImportReport
+		importErr error
+	)
+	go func() {
+		defer close(importDone)
+		report, importErr = l.Import(ctx, stream, progress)
+	}()
+
+	var (
+		received int
+		lastHash []byte
+		manifest *ExportManifest
+	)
+	decodeErr := func() error {
+		defer close(stream)
+
+		dec := json.NewDecoder(r)
+		for {
+			raw := json.RawMessage{}
+			if err := dec.Decode(&raw); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return newImportError(nil, errors.Wrap(err, "decoding logs"))
+			}
+
+			if bytes.HasPrefix(raw, exportCheckpointPrefix) {
+				continue
+			}
+			if bytes.HasPrefix(raw, exportManifestPrefix) {
+				document := ExportManifestDocument{}
+				if err := json.Unmarshal(raw, &document); err != nil {
+					return newImportError(nil, errors.Wrap(err, "decoding manifest"))
+				}
+				manifest = document.Manifest
+				return nil
+			}
+
+			log := &ledger.ChainedLog{}
+			if err := json.Unmarshal(raw, log); err != nil {
+				return newImportError(nil, errors.Wrap(err, "decoding log"))
+			}
+			received++
+			lastHash = log.Hash
+
+			select {
+			case stream <- log:
+			case <-importDone:
+				// The import failed, its error is reported
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}()
+	<-importDone
+
+	switch {
+	case importErr != nil:
+		return report, importErr
+	case decodeErr != nil:
+		return report, decodeErr
+	case manifest != nil && (manifest.Count != received || !bytes.Equal(manifest.LastHash, lastHash)):
+		return report, newImportError(manifest.LastLogID, fmt.Errorf(
+			"received logs do not match the manifest: %d logs received, %d expected", received, manifest.Count))
+	}
+
+	return report, nil
+}
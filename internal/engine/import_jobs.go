@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+	"io"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/logging"
+	libtime "github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ImportJobErrorCodeInvalidLogs is reported when the stream does not continue the chain of the ledger
+	ImportJobErrorCodeInvalidLogs = "IMPORT"
+	// ImportJobErrorCodeInProgress is reported when another import was running on the ledger
+	ImportJobErrorCodeInProgress = "IMPORT_IN_PROGRESS"
+	// ImportJobErrorCodeLedgerReadOnly is reported when the ledger does not accept writes
+	ImportJobErrorCodeLedgerReadOnly = "LEDGER_READ_ONLY"
+	ImportJobErrorCodeInternal       = "INTERNAL"
+)
+
+func importJobErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ImportError{}):
+		return ImportJobErrorCodeInvalidLogs
+	case errors.Is(err, ErrImportInProgress):
+		return ImportJobErrorCodeInProgress
+	case command.IsErrLedgerReadOnly(err):
+		return ImportJobErrorCodeLedgerReadOnly
+	default:
+		return ImportJobErrorCodeInternal
+	}
+}
+
+// StartImport imports the logs read from r in the background, and returns the job tracking the import.
+// r is closed once the import is terminated.
+// As the job outlives the request which started it, it is not canceled with ctx.
+func (l *Ledger) StartImport(ctx context.Context, r io.ReadCloser) (*ledger.ImportJob, error) {
+	job := ledger.NewImportJob()
+	if err := l.store.InsertImportJob(ctx, job); err != nil {
+		_ = r.Close()
+		return nil, newStorageError(err, "inserting import job")
+	}
+	ret := job
+
+	ctx = logging.ContextWithField(context.WithoutCancel(ctx), "import", job.ID)
+	go func() {
+		defer func() {
+			_ = r.Close()
+		}()
+
+		report, err := l.ImportLogs(ctx, r, func(ctx context.Context, report ImportReport) {
+			job = job.WithProgress(libtime.Now(), report.Imported, report.Skipped, report.LastLogID)
+			if err := l.store.UpdateImportJob(ctx, job); err != nil {
+				logging.FromContext(ctx).Errorf("updating import job progress: %s", err)
+			}
+		})
+		if report != nil {
+			job = job.WithProgress(libtime.Now(), report.Imported, report.Skipped, report.LastLogID)
+		}
+		if err != nil {
+			logging.FromContext(ctx).Errorf("import failed: %s", err)
+			job = job.WithFailure(libtime.Now(), importJobErrorCode(err), err)
+		} else {
+			job = job.WithSuccess(libtime.Now())
+		}
+
+		if err := l.store.UpdateImportJob(ctx, job); err != nil {
+			logging.FromContext(ctx).Errorf("updating import job: %s", err)
+		}
+	}()
+
+	return &ret, nil
+}
+
+func (l *Ledger) GetImportJob(ctx context.Context, id string) (*ledger.ImportJob, error) {
+	job, err := l.store.GetImportJob(ctx, id)
+	return job, newStorageError(err, "getting import job")
+}
+
+func (l *Ledger) GetImportJobs(ctx context.Context, q ledgerstore.GetImportJobsQuery) (*bunpaginate.Cursor[ledger.ImportJob], error) {
+	jobs, err := l.store.GetImportJobs(ctx, q)
+	return jobs, newStorageError(err, "getting import jobs")
+}
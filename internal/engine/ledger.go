@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/formancehq/go-libs/bun/bunpaginate"
 	"github.com/formancehq/ledger/internal/engine/chain"
@@ -13,23 +14,38 @@ import (
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/formancehq/go-libs/logging"
 	"github.com/formancehq/go-libs/metadata"
+	libtime "github.com/formancehq/go-libs/time"
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/bus"
 	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
 	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/pkg/events"
 )
 
 type Ledger struct {
-	commander   *command.Commander
-	systemStore *systemstore.Store
-	store       *ledgerstore.Store
-	mu          sync.Mutex
-	config      LedgerConfig
-	chain       *chain.Chain
+	commander    *command.Commander
+	systemStore  *systemstore.Store
+	store        *ledgerstore.Store
+	mu           sync.Mutex
+	config       LedgerConfig
+	chain        *chain.Chain
+	monitor      bus.Monitor
+	publisher    message.Publisher
+	scheduler    *scheduler
+	outbox       *outboxRelay
+	logsNotifier *logsNotifier
+	importMu     sync.Mutex
 }
 
 type GlobalLedgerConfig struct {
-	batchSize int
+	batchSize           int
+	schedulerInterval   time.Duration
+	outboxRelayInterval time.Duration
+	eventsVersion       string
+	lockStrategy        string
+	writerLockTimeout   time.Duration
+	writerTenure        time.Duration
 }
 
 type LedgerConfig struct {
@@ -38,39 +54,91 @@ type LedgerConfig struct {
 	isSchemaUpToDate bool
 }
 
+const (
+	// LockStrategyMemory locks accounts in memory, the ledger must be written by a single instance
+	LockStrategyMemory = "memory"
+	// LockStrategyPostgres elects a writer per ledger using PostgreSQL advisory locks,
+	// allowing several instances to write on the same ledger
+	LockStrategyPostgres = "postgres"
+)
+
 var (
 	defaultLedgerConfig = GlobalLedgerConfig{
-		batchSize: 50,
+		batchSize:           50,
+		schedulerInterval:   time.Second,
+		outboxRelayInterval: time.Second,
+		eventsVersion:       events.EventVersion,
+		lockStrategy:        LockStrategyMemory,
+		writerLockTimeout:   10 * time.Second,
+		writerTenure:        5 * time.Second,
 	}
 )
 
+// storeWriterLock elects the writer of a ledger using the advisory lock of its store
+type storeWriterLock struct {
+	store *ledgerstore.Store
+}
+
+func (l storeWriterLock) AcquireWriterLock(ctx context.Context) (command.HeldWriterLock, error) {
+	lock, err := l.store.AcquireWriterLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
 func New(
 	systemStore *systemstore.Store,
 	store *ledgerstore.Store,
 	publisher message.Publisher,
 	compiler *command.Compiler,
+	metricsRegistry metrics.GlobalRegistry,
 	ledgerConfig LedgerConfig,
 ) *Ledger {
 	var monitor bus.Monitor = bus.NewNoOpMonitor()
 	if publisher != nil {
 		monitor = bus.NewLedgerMonitor(publisher, store.Name())
 	}
+	// The events of the logs are published from the outbox, written along with the logs
+	outbox := newOutboxRelay(store, publisher, ledgerConfig.eventsVersion, ledgerConfig.outboxRelayInterval, metricsRegistry.OutboxLag())
+	monitor = bus.NewOutboxMonitor(monitor, outbox.Notify)
 	chain := chain.New(store)
-	ret := &Ledger{
+	ret := &Ledger{}
+	var locker command.Locker = command.NewDefaultLocker()
+	if ledgerConfig.lockStrategy == LockStrategyPostgres {
+		// The state of the ledger and the rules enforced by the commander may have been changed by the previous leader
+		locker = command.NewLeaderLocker(locker, storeWriterLock{store}, func(ctx context.Context) error {
+			if err := chain.Init(ctx); err != nil {
+				return err
+			}
+			if err := ret.loadState(ctx); err != nil {
+				return err
+			}
+			return ret.loadRules(ctx)
+		}, ledgerConfig.writerLockTimeout, ledgerConfig.writerTenure)
+	}
+	*ret = Ledger{
 		commander: command.New(
 			store,
-			command.NewDefaultLocker(),
+			locker,
 			compiler,
 			command.NewReferencer(),
 			monitor,
 			chain,
 			ledgerConfig.batchSize,
 		),
-		store:       store,
-		config:      ledgerConfig,
-		systemStore: systemStore,
-		chain:       chain,
+		store:        store,
+		config:       ledgerConfig,
+		systemStore:  systemStore,
+		chain:        chain,
+		monitor:      monitor,
+		publisher:    publisher,
+		outbox:       outbox,
+		logsNotifier: newLogsNotifier(),
 	}
+	ret.commander.OnBatchProcessed(ret.logsNotifier.notify)
+	ret.commander.SetReadOnly(ledgerConfig.State == systemstore.StateReadOnly)
+	ret.scheduler = newScheduler(ret, ledgerConfig.schedulerInterval)
 	return ret
 }
 
@@ -78,10 +146,33 @@ func (l *Ledger) Start(ctx context.Context) {
 	if err := l.chain.Init(ctx); err != nil {
 		panic(err)
 	}
+	if err := l.loadRules(ctx); err != nil {
+		panic(err)
+	}
 	go l.commander.Run(logging.ContextWithField(ctx, "component", "commander"))
+	go l.scheduler.Run(logging.ContextWithField(ctx, "component", "scheduler"))
+	go l.outbox.Run(logging.ContextWithField(ctx, "component", "outbox"))
+}
+
+// loadRules loads the rules enforced by the commander on the writes from the storage.
+func (l *Ledger) loadRules(ctx context.Context) error {
+	if err := l.loadClosingDate(ctx); err != nil {
+		return err
+	}
+	if err := l.loadAccountPolicies(ctx); err != nil {
+		return err
+	}
+	if err := l.loadAccountFreezes(ctx); err != nil {
+		return err
+	}
+	return l.loadChart(ctx)
 }
 
 func (l *Ledger) Close(ctx context.Context) {
+	logging.FromContext(ctx).Debugf("Close scheduler")
+	l.scheduler.Close()
+	logging.FromContext(ctx).Debugf("Close outbox relay")
+	l.outbox.Close()
 	logging.FromContext(ctx).Debugf("Close commander")
 	l.commander.Close()
 }
@@ -127,6 +218,9 @@ func (l *Ledger) GetLogs(ctx context.Context, q ledgerstore.GetLogsQuery) (*bunp
 }
 
 func (l *Ledger) markInUseIfNeeded(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	if l.config.LedgerState.State == systemstore.StateInitializing {
 		if err := l.systemStore.UpdateLedgerState(ctx, l.store.Name(), systemstore.StateInUse); err != nil {
 			logging.FromContext(ctx).Error("Unable to declare ledger as in use")
@@ -136,6 +230,50 @@ func (l *Ledger) markInUseIfNeeded(ctx context.Context) {
 	}
 }
 
+// updateState records the new state of the ledger, and applies it to the running ledger.
+func (l *Ledger) updateState(ctx context.Context, state string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.systemStore.UpdateLedgerState(ctx, l.store.Name(), state); err != nil {
+		return err
+	}
+	l.applyState(state)
+
+	return nil
+}
+
+// loadState applies the state of the ledger recorded in the system store, which may have been changed by another instance.
+func (l *Ledger) loadState(ctx context.Context) error {
+	configuration, err := l.systemStore.GetLedger(ctx, l.store.Name())
+	if err != nil {
+		return err
+	}
+	if configuration.State == systemstore.StateArchived {
+		return ErrLedgerArchived
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.applyState(configuration.State)
+
+	return nil
+}
+
+// applyState must be called with mu locked.
+func (l *Ledger) applyState(state string) {
+	l.config.LedgerState.State = state
+	l.commander.SetReadOnly(state == systemstore.StateReadOnly)
+}
+
+func (l *Ledger) isReadOnly() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.config.LedgerState.State == systemstore.StateReadOnly
+}
+
 func (l *Ledger) CreateTransaction(ctx context.Context, parameters command.Parameters, data ledger.RunScript) (*ledger.Transaction, error) {
 	ret, err := l.commander.CreateTransaction(ctx, parameters, data)
 	if err != nil {
@@ -154,6 +292,50 @@ func (l *Ledger) RevertTransaction(ctx context.Context, parameters command.Param
 	return ret, nil
 }
 
+func (l *Ledger) ExecuteAtomicBulk(ctx context.Context, elements []command.BulkElement) ([]command.BulkElementResult, error) {
+	results, err := l.commander.ExecuteAtomicBulk(ctx, elements)
+	if err != nil {
+		return nil, NewCommandError(err)
+	}
+	for i := range results {
+		results[i].Err = NewCommandError(results[i].Err)
+	}
+	l.markInUseIfNeeded(ctx)
+	return results, nil
+}
+
+func (l *Ledger) CreateHold(ctx context.Context, parameters command.Parameters, destination string, data ledger.RunScript) (*ledger.Hold, *ledger.Transaction, error) {
+	hold, tx, err := l.commander.CreateHold(ctx, parameters, destination, data)
+	if err != nil {
+		return nil, nil, NewCommandError(err)
+	}
+	l.markInUseIfNeeded(ctx)
+	return hold, tx, nil
+}
+
+func (l *Ledger) ConfirmHold(ctx context.Context, parameters command.Parameters, id string, amount *big.Int, final bool) (*ledger.Hold, *ledger.Transaction, error) {
+	hold, tx, err := l.commander.ConfirmHold(ctx, parameters, id, amount, final)
+	if err != nil {
+		return nil, nil, NewCommandError(err)
+	}
+	l.markInUseIfNeeded(ctx)
+	return hold, tx, nil
+}
+
+func (l *Ledger) VoidHold(ctx context.Context, parameters command.Parameters, id string) (*ledger.Hold, *ledger.Transaction, error) {
+	hold, tx, err := l.commander.VoidHold(ctx, parameters, id)
+	if err != nil {
+		return nil, nil, NewCommandError(err)
+	}
+	l.markInUseIfNeeded(ctx)
+	return hold, tx, nil
+}
+
+func (l *Ledger) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	hold, err := l.commander.GetHold(ctx, id)
+	return hold, NewCommandError(err)
+}
+
 func (l *Ledger) SaveMeta(ctx context.Context, parameters command.Parameters, targetType string, targetID any, m metadata.Metadata) error {
 	if err := l.commander.SaveMeta(ctx, parameters, targetType, targetID, m); err != nil {
 		return NewCommandError(err)
@@ -172,6 +354,24 @@ func (l *Ledger) DeleteMetadata(ctx context.Context, parameters command.Paramete
 	return nil
 }
 
+func (l *Ledger) CreateScheduledTransaction(ctx context.Context, data ledger.RunScript, executeAt libtime.Time) (*ledger.ScheduledTransaction, error) {
+	scheduled := ledger.NewScheduledTransaction(data, executeAt)
+	if err := l.store.InsertScheduledTransaction(ctx, scheduled); err != nil {
+		return nil, newStorageError(err, "inserting scheduled transaction")
+	}
+	return &scheduled, nil
+}
+
+func (l *Ledger) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	scheduled, err := l.store.GetScheduledTransaction(ctx, id)
+	return scheduled, newStorageError(err, "getting scheduled transaction")
+}
+
+func (l *Ledger) GetScheduledTransactions(ctx context.Context, q ledgerstore.GetScheduledTransactionsQuery) (*bunpaginate.Cursor[ledger.ScheduledTransaction], error) {
+	scheduled, err := l.store.GetScheduledTransactions(ctx, q)
+	return scheduled, newStorageError(err, "getting scheduled transactions")
+}
+
 func (l *Ledger) IsDatabaseUpToDate(ctx context.Context) (bool, error) {
 	if l.config.isSchemaUpToDate {
 		return true, nil
@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/query"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+)
+
+// logsStreamPollInterval is the interval between reads of the logs inserted by the other instances,
+// the logs inserted by the commander of the ledger are streamed as soon as they are acknowledged.
+const logsStreamPollInterval = time.Second
+
+// pollOtherWriters returns a channel ticking when the logs inserted by the other instances must be read, and a function to stop it.
+// Only the ledgers using the postgres lock strategy can be written by other instances, the channel is nil for the others,
+// whose streams are only woken up by the notifications of their commander.
+func (l *Ledger) pollOtherWriters() (<-chan time.Time, func()) {
+	if l.config.lockStrategy != LockStrategyPostgres {
+		return nil, func() {}
+	}
+	ticker := time.NewTicker(logsStreamPollInterval)
+	return ticker.C, ticker.Stop
+}
+
+// logsNotifier wakes up the streams of logs of a ledger when its commander inserts new logs.
+type logsNotifier struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func (n *logsNotifier) subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	n.subscribers[ch] = struct{}{}
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		delete(n.subscribers, ch)
+		n.mu.Unlock()
+	}
+}
+
+func (n *logsNotifier) notify(...*ledger.ChainedLog) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func newLogsNotifier() *logsNotifier {
+	return &logsNotifier{
+		subscribers: map[chan struct{}]struct{}{},
+	}
+}
+
+// StreamLogsFn is called with each log of a stream, the stream stops if it returns an error.
+type StreamLogsFn func(log ledger.ChainedLog) error
+
+// StreamLogs calls fn with the logs following the log with id after, in ascending order, until ctx is done.
+// The logs already stored are read first, then the new logs as they are inserted.
+// If after is nil, only the logs inserted from now are streamed.
+func (l *Ledger) StreamLogs(ctx context.Context, after *big.Int, fn StreamLogsFn) error {
+	notifications, unsubscribe := l.logsNotifier.subscribe()
+	defer unsubscribe()
+
+	if after == nil {
+		lastLog, err := l.store.GetLastLog(ctx)
+		switch {
+		case err == nil:
+			after = lastLog.ID
+		case sqlutils.IsNotFoundError(err):
+			after = big.NewInt(-1)
+		default:
+			return newStorageError(err, "getting last log")
+		}
+	}
+
+	poll, stopPolling := l.pollOtherWriters()
+	defer stopPolling()
+
+	for {
+		err := bunpaginate.Iterate(
+			ctx,
+			ledgerstore.NewGetLogsQuery(ledgerstore.NewPaginatedQueryOptions[any](nil).
+				WithPageSize(100).
+				WithQueryBuilder(query.Gt("id", (*bunpaginate.BigInt)(after)))).
+				WithOrder(bunpaginate.OrderAsc),
+			func(ctx context.Context, q ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
+				return l.store.GetLogs(ctx, q)
+			},
+			func(cursor *bunpaginate.Cursor[ledger.ChainedLog]) error {
+				for _, log := range cursor.Data {
+					if err := fn(log); err != nil {
+						return err
+					}
+					after = log.ID
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notifications:
+		case <-poll:
+		}
+	}
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/formancehq/go-libs/logging"
@@ -9,6 +10,7 @@ import (
 	"github.com/formancehq/ledger/internal/engine/command"
 	"github.com/formancehq/ledger/internal/opentelemetry/metrics"
 	"github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/formancehq/ledger/internal/webhooks"
 	"go.uber.org/fx"
 )
 
@@ -17,42 +19,123 @@ type NumscriptCacheConfiguration struct {
 }
 
 type Configuration struct {
-	NumscriptCache  NumscriptCacheConfiguration
-	LedgerBatchSize int
+	NumscriptCache    NumscriptCacheConfiguration
+	LedgerBatchSize   int
+	SchedulerInterval time.Duration
+	// OutboxRelayInterval is the interval between retries of the events whose publication failed
+	OutboxRelayInterval time.Duration
+	// EventsVersion is the version of the published events, the default version is used if empty
+	EventsVersion string
+	// CloudEventsMode wraps the published events in a CloudEvents envelope, using the headers of CloudEventsBinding in binary mode.
+	// The events are published without envelope if empty
+	CloudEventsMode    string
+	CloudEventsBinding string
+	LockStrategy       string
+	// WriterLockTimeout and WriterTenure configure the election of the writer of the ledgers with the postgres lock strategy
+	WriterLockTimeout time.Duration
+	WriterTenure      time.Duration
+	// IdempotencyKeysRetention is the duration after which idempotency keys can be reused,
+	// they never expire if zero
+	IdempotencyKeysRetention     time.Duration
+	IdempotencyKeysPurgeInterval time.Duration
+}
+
+type resolverParams struct {
+	fx.In
+
+	StorageDriver   *driver.Driver
+	Publisher       message.Publisher
+	MetricsRegistry metrics.GlobalRegistry
+	Logger          logging.Logger
+	// Webhooks is only provided when the webhooks module is enabled
+	Webhooks *webhooks.Dispatcher `optional:"true"`
 }
 
 func Module(configuration Configuration) fx.Option {
 	return fx.Options(
-		fx.Provide(func(
-			storageDriver *driver.Driver,
-			publisher message.Publisher,
-			metricsRegistry metrics.GlobalRegistry,
-			logger logging.Logger,
-		) *Resolver {
+		fx.Provide(func(params resolverParams) *Resolver {
+			publisher := params.Publisher
+			if configuration.CloudEventsMode != "" {
+				publisher = bus.NewCloudEventsPublisher(publisher, configuration.CloudEventsMode, configuration.CloudEventsBinding)
+			}
+			if params.Webhooks != nil {
+				publisher = webhooks.NewPublisher(publisher, params.Webhooks)
+			}
 			options := []option{
 				WithMessagePublisher(publisher),
-				WithMetricsRegistry(metricsRegistry),
-				WithLogger(logger),
+				WithMetricsRegistry(params.MetricsRegistry),
+				WithLogger(params.Logger),
 			}
 			if configuration.NumscriptCache.MaxCount != 0 {
 				options = append(options, WithCompiler(command.NewCompiler(configuration.NumscriptCache.MaxCount)))
 			}
+			ledgerConfig := defaultLedgerConfig
 			if configuration.LedgerBatchSize != 0 {
-				options = append(options, WithLedgerConfig(GlobalLedgerConfig{
-					batchSize: configuration.LedgerBatchSize,
-				}))
+				ledgerConfig.batchSize = configuration.LedgerBatchSize
+			}
+			if configuration.SchedulerInterval != 0 {
+				ledgerConfig.schedulerInterval = configuration.SchedulerInterval
+			}
+			if configuration.OutboxRelayInterval != 0 {
+				ledgerConfig.outboxRelayInterval = configuration.OutboxRelayInterval
+			}
+			if configuration.EventsVersion != "" {
+				ledgerConfig.eventsVersion = configuration.EventsVersion
+			}
+			if configuration.LockStrategy != "" {
+				ledgerConfig.lockStrategy = configuration.LockStrategy
+			}
+			if configuration.WriterLockTimeout != 0 {
+				ledgerConfig.writerLockTimeout = configuration.WriterLockTimeout
 			}
-			return NewResolver(storageDriver, options...)
+			if configuration.WriterTenure != 0 {
+				ledgerConfig.writerTenure = configuration.WriterTenure
+			}
+			options = append(options, WithLedgerConfig(ledgerConfig))
+			return NewResolver(params.StorageDriver, options...)
 		}),
 		fx.Provide(fx.Annotate(bus.NewNoOpMonitor, fx.As(new(bus.Monitor)))),
 		fx.Provide(fx.Annotate(metrics.NewNoOpRegistry, fx.As(new(metrics.GlobalRegistry)))),
+		fx.Invoke(func(lc fx.Lifecycle, driver *driver.Driver, logger logging.Logger) {
+			if configuration.IdempotencyKeysRetention == 0 {
+				return
+			}
+			interval := configuration.IdempotencyKeysPurgeInterval
+			if interval == 0 {
+				interval = time.Hour
+			}
+			purger := newIdempotencyKeysPurger(driver, configuration.IdempotencyKeysRetention, interval)
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go purger.Run(logging.ContextWithLogger(context.Background(), logger.WithField("component", "idempotency-keys-purger")))
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					purger.Close()
+					return nil
+				},
+			})
+		}),
 		//TODO(gfyrag): Move in pkg/ledger package
-		fx.Invoke(func(lc fx.Lifecycle, resolver *Resolver) {
+		fx.Invoke(func(lc fx.Lifecycle, resolver *Resolver, logger logging.Logger) {
 			lc.Append(fx.Hook{
 				OnStop: func(ctx context.Context) error {
 					return resolver.CloseLedgers(ctx)
 				},
 			})
+			// Other instances may change the states of the ledgers, the hook is stopped before the ledgers are closed
+			if configuration.LockStrategy == LockStrategyPostgres {
+				lc.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						go resolver.Run(logging.ContextWithLogger(context.Background(), logger.WithField("component", "resolver")))
+						return nil
+					},
+					OnStop: func(ctx context.Context) error {
+						resolver.Close()
+						return nil
+					},
+				})
+			}
 		}),
 	)
 }
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/publish"
+	libtime "github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/bus"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const outboxBatchSize = 100
+
+// outboxRelay publishes the events of the logs recorded in the outbox of a ledger.
+// An entry is removed from the outbox only once its event is published, so events are delivered at least once,
+// in the order of the logs. The relay runs when notified of new logs, and periodically to retry the failed publications.
+type outboxRelay struct {
+	store      *ledgerstore.Store
+	publisher  message.Publisher
+	version    string
+	interval   time.Duration
+	lag        metric.Int64Histogram
+	notifyChan chan struct{}
+	stopChan   chan chan struct{}
+}
+
+func (r *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case done := <-r.stopChan:
+			close(done)
+			return
+		case <-ticker.C:
+		case <-r.notifyChan:
+		}
+		if err := r.relay(ctx); err != nil {
+			logging.FromContext(ctx).Errorf("relaying outbox: %s", err)
+		}
+	}
+}
+
+func (r *outboxRelay) Close() {
+	done := make(chan struct{})
+	r.stopChan <- done
+	<-done
+}
+
+// Notify wakes up the relay, without blocking if it is already busy.
+func (r *outboxRelay) Notify() {
+	select {
+	case r.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (r *outboxRelay) relay(ctx context.Context) error {
+	for {
+		relayed, err := r.store.RelayOutbox(ctx, outboxBatchSize, r.publish)
+		if err != nil {
+			return err
+		}
+		if relayed < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+func (r *outboxRelay) publish(ctx context.Context, entries []ledgerstore.OutboxEntry) (int, error) {
+	for i, entry := range entries {
+		if r.publisher != nil {
+			topic, ev, err := newLogEvent(ctx, r.store, *entry.Log, r.version)
+			if err != nil {
+				return i, err
+			}
+			if err := r.publisher.Publish(topic, publish.NewMessage(ctx, ev)); err != nil {
+				return i, errors.Wrapf(err, "publishing event of log %s", entry.Log.ID)
+			}
+		}
+
+		r.lag.Record(ctx, libtime.Since(entry.CreatedAt).Milliseconds(),
+			metric.WithAttributes(attribute.String("ledger", r.store.Name())))
+	}
+	return len(entries), nil
+}
+
+// newLogEvent returns the topic and the event of a log in the given version,
+// loading the transaction reverted by a RevertedTransaction log, and the volumes required by the v3 events.
+func newLogEvent(ctx context.Context, store *ledgerstore.Store, log ledger.ChainedLog, version string) (string, publish.EventMessage, error) {
+	var reverted *ledger.Transaction
+	if payload, ok := log.Data.(ledger.RevertedTransactionLogPayload); ok {
+		var err error
+		reverted, err = store.GetTransaction(ctx, payload.RevertedTransactionID)
+		if err != nil {
+			return "", publish.EventMessage{}, errors.Wrapf(err, "getting transaction %s reverted by log %s", payload.RevertedTransactionID, log.ID)
+		}
+	}
+
+	if version != events.EventVersionV3 {
+		topic, ev := bus.NewLogEvent(store.Name(), log, reverted)
+		return topic, ev, nil
+	}
+
+	var tx *ledger.Transaction
+	switch payload := log.Data.(type) {
+	case ledger.NewTransactionLogPayload:
+		tx = payload.Transaction
+	case ledger.RevertedTransactionLogPayload:
+		tx = payload.RevertTransaction
+	}
+	var postCommitVolumes ledger.AccountsAssetsVolumes
+	if tx != nil {
+		expanded, err := store.GetTransactionWithVolumes(ctx, ledgerstore.NewGetTransactionQuery(tx.ID).WithExpandVolumes())
+		if err != nil {
+			return "", publish.EventMessage{}, errors.Wrapf(err, "getting volumes of transaction %s of log %s", tx.ID, log.ID)
+		}
+		postCommitVolumes = expanded.PostCommitVolumes
+	}
+
+	topic, ev := bus.NewLogEventV3(store.Name(), log, reverted, postCommitVolumes)
+	return topic, ev, nil
+}
+
+func newOutboxRelay(store *ledgerstore.Store, publisher message.Publisher, version string, interval time.Duration, lag metric.Int64Histogram) *outboxRelay {
+	return &outboxRelay{
+		store:      store,
+		publisher:  publisher,
+		version:    version,
+		interval:   interval,
+		lag:        lag,
+		notifyChan: make(chan struct{}, 1),
+		stopChan:   make(chan chan struct{}),
+	}
+}
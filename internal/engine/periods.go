@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	libtime "github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+)
+
+// loadClosingDate applies the closing date of the last closed period to the commander.
+func (l *Ledger) loadClosingDate(ctx context.Context) error {
+	period, err := l.store.GetLastClosedPeriod(ctx)
+	if err != nil {
+		if sqlutils.IsNotFoundError(err) {
+			return nil
+		}
+		return newStorageError(err, "getting last closed period")
+	}
+	l.commander.SetClosingDate(period.ClosingDate)
+
+	return nil
+}
+
+// ClosePeriod closes the period of the ledger ending at closingDate.
+// Once closed, transactions dated before the closing date are rejected.
+// If withBalances is set, the balances of the accounts at the closing date are recorded with the period.
+func (l *Ledger) ClosePeriod(ctx context.Context, closingDate libtime.Time, withBalances bool) (*ledger.ClosedPeriod, error) {
+	period := ledger.NewClosedPeriod(closingDate)
+	err := l.commander.ClosePeriod(ctx, closingDate, func(ctx context.Context) error {
+		if withBalances {
+			balances, err := l.store.GetBalancesAt(ctx, closingDate)
+			if err != nil {
+				return newStorageError(err, "getting balances at closing date")
+			}
+			period = period.WithBalances(balances)
+		}
+
+		return newStorageError(l.store.InsertClosedPeriod(ctx, period), "inserting closed period")
+	})
+	if err != nil {
+		return nil, NewCommandError(err)
+	}
+
+	return &period, nil
+}
+
+func (l *Ledger) GetClosedPeriod(ctx context.Context, id string) (*ledger.ClosedPeriod, error) {
+	period, err := l.store.GetClosedPeriod(ctx, id)
+	return period, newStorageError(err, "getting closed period")
+}
+
+func (l *Ledger) GetClosedPeriods(ctx context.Context, q ledgerstore.GetClosedPeriodsQuery) (*bunpaginate.Cursor[ledger.ClosedPeriod], error) {
+	periods, err := l.store.GetClosedPeriods(ctx, q)
+	return periods, newStorageError(err, "getting closed periods")
+}
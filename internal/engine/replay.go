@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/publish"
+	"github.com/formancehq/go-libs/query"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/bus"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/pkg/errors"
+)
+
+var ErrNoPublisher = errors.New("no message publisher configured")
+
+// ReplayQuery selects the logs whose events are replayed, both bounds are inclusive and optional.
+type ReplayQuery struct {
+	FromLog *big.Int `json:"fromLog,omitempty"`
+	ToLog   *big.Int `json:"toLog,omitempty"`
+	// Topic replaces the event types as the topic the events are published to, if not empty
+	Topic string `json:"topic,omitempty"`
+	// Version is the version of the events, the version configured for the ledger is used if empty
+	Version string `json:"version,omitempty"`
+}
+
+func (q ReplayQuery) Validate() error {
+	if q.FromLog != nil && q.ToLog != nil && q.FromLog.Cmp(q.ToLog) > 0 {
+		return errors.Errorf("from log %s is after to log %s", q.FromLog, q.ToLog)
+	}
+	if q.Version != "" && !slices.Contains(events.EventVersions, q.Version) {
+		return errors.Errorf("unknown events version '%s', expected one of %v", q.Version, events.EventVersions)
+	}
+	return nil
+}
+
+type ReplayReport struct {
+	ReplayedLogs int      `json:"replayedLogs"`
+	LastLogID    *big.Int `json:"lastLogID,omitempty"`
+}
+
+// ReplayProgressFn is called each time a page of logs has been replayed.
+type ReplayProgressFn func(ctx context.Context, report ReplayReport)
+
+// ReplayEvents publishes again the events of the logs of the store selected by q, in ascending order,
+// with the same payloads as the live events. The messages are marked with bus.ReplayMetadataKey.
+func ReplayEvents(ctx context.Context, store *ledgerstore.Store, publisher message.Publisher, q ReplayQuery, progress ReplayProgressFn) (*ReplayReport, error) {
+	if publisher == nil {
+		return nil, ErrNoPublisher
+	}
+
+	filters := make([]query.Builder, 0)
+	if q.FromLog != nil {
+		filters = append(filters, query.Gte("id", (*bunpaginate.BigInt)(q.FromLog)))
+	}
+	if q.ToLog != nil {
+		filters = append(filters, query.Lte("id", (*bunpaginate.BigInt)(q.ToLog)))
+	}
+	options := ledgerstore.NewPaginatedQueryOptions[any](nil).WithPageSize(100)
+	if len(filters) > 0 {
+		options = options.WithQueryBuilder(query.And(filters...))
+	}
+
+	report := &ReplayReport{}
+	err := bunpaginate.Iterate(
+		ctx,
+		ledgerstore.NewGetLogsQuery(options).WithOrder(bunpaginate.OrderAsc),
+		func(ctx context.Context, q ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
+			return store.GetLogs(ctx, q)
+		},
+		func(cursor *bunpaginate.Cursor[ledger.ChainedLog]) error {
+			for _, log := range cursor.Data {
+				topic, ev, err := newLogEvent(ctx, store, log, q.Version)
+				if err != nil {
+					return err
+				}
+				if q.Topic != "" {
+					topic = q.Topic
+				}
+
+				msg := publish.NewMessage(ctx, ev)
+				msg.Metadata.Set(bus.ReplayMetadataKey, log.ID.String())
+				if err := publisher.Publish(topic, msg); err != nil {
+					return errors.Wrapf(err, "publishing event of log %s", log.ID)
+				}
+
+				report.ReplayedLogs++
+				report.LastLogID = log.ID
+			}
+			if progress != nil {
+				progress(ctx, *report)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (l *Ledger) ReplayEvents(ctx context.Context, q ReplayQuery, progress ReplayProgressFn) (*ReplayReport, error) {
+	if q.Version == "" {
+		q.Version = l.config.eventsVersion
+	}
+	return ReplayEvents(ctx, l.store, l.publisher, q, progress)
+}
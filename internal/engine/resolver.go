@@ -3,8 +3,10 @@ package engine
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
 	"github.com/formancehq/ledger/internal/storage/systemstore"
 
 	"github.com/pkg/errors"
@@ -17,6 +19,15 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ledgerStatesRefreshInterval is the interval between reads of the states of the running ledgers,
+// which may have been changed by other instances.
+const ledgerStatesRefreshInterval = 5 * time.Second
+
+var (
+	ErrLedgerArchived     = errors.New("ledger is archived")
+	ErrInvalidLedgerState = errors.New("invalid ledger state")
+)
+
 type option func(r *Resolver)
 
 func WithMessagePublisher(publisher message.Publisher) option {
@@ -65,6 +76,7 @@ type Resolver struct {
 	compiler     *command.Compiler
 	logger       logging.Logger
 	publisher    message.Publisher
+	stopChan     chan chan struct{}
 }
 
 func NewResolver(storageDriver *driver.Driver, options ...option) *Resolver {
@@ -72,6 +84,7 @@ func NewResolver(storageDriver *driver.Driver, options ...option) *Resolver {
 		storageDriver: storageDriver,
 		ledgers:       map[string]*Ledger{},
 		ledgerConfig:  defaultLedgerConfig,
+		stopChan:      make(chan chan struct{}),
 	}
 	for _, opt := range append(defaultOptions, options...) {
 		opt(r)
@@ -82,7 +95,7 @@ func NewResolver(storageDriver *driver.Driver, options ...option) *Resolver {
 
 func (r *Resolver) startLedger(ctx context.Context, name string, store *ledgerstore.Store, state driver.LedgerState) (*Ledger, error) {
 
-	ledger := New(r.storageDriver.GetSystemStore(), store, r.publisher, r.compiler, LedgerConfig{
+	ledger := New(r.storageDriver.GetSystemStore(), store, r.publisher, r.compiler, r.metricsRegistry, LedgerConfig{
 		GlobalLedgerConfig: r.ledgerConfig,
 		LedgerState:        state,
 	})
@@ -114,6 +127,9 @@ func (r *Resolver) GetLedger(ctx context.Context, name string) (*Ledger, error)
 		if err != nil {
 			return nil, err
 		}
+		if ledgerConfiguration.State == systemstore.StateArchived {
+			return nil, ErrLedgerArchived
+		}
 
 		store, err := r.storageDriver.GetLedgerStore(ctx, name, driver.LedgerState{
 			LedgerConfiguration: driver.LedgerConfiguration{
@@ -131,6 +147,7 @@ func (r *Resolver) GetLedger(ctx context.Context, name string) (*Ledger, error)
 				Bucket:   ledgerConfiguration.Bucket,
 				Metadata: ledgerConfiguration.Metadata,
 			},
+			State: ledgerConfiguration.State,
 		})
 	}
 
@@ -156,6 +173,97 @@ func (r *Resolver) CreateLedger(ctx context.Context, name string, configuration
 	})
 }
 
+// UpdateLedgerState makes a ledger read-only, or writable again.
+// Archived ledgers are restored by making them writable.
+func (r *Resolver) UpdateLedgerState(ctx context.Context, name string, state string) error {
+	if state != systemstore.StateInUse && state != systemstore.StateReadOnly {
+		return errors.Wrapf(ErrInvalidLedgerState, "state must be '%s' or '%s'", systemstore.StateInUse, systemstore.StateReadOnly)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if ledger, ok := r.ledgers[name]; ok {
+		return ledger.updateState(ctx, state)
+	}
+
+	if _, err := r.storageDriver.GetSystemStore().GetLedger(ctx, name); err != nil {
+		return err
+	}
+
+	return r.storageDriver.GetSystemStore().UpdateLedgerState(ctx, name, state)
+}
+
+// DeleteLedger unloads the ledger, then archives or purges it.
+func (r *Resolver) DeleteLedger(ctx context.Context, name string, purge bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if ledger, ok := r.ledgers[name]; ok {
+		r.unloadLedger(ctx, name, ledger)
+	}
+
+	return r.storageDriver.DeleteLedger(ctx, name, purge)
+}
+
+// unloadLedger must be called with lock held.
+func (r *Resolver) unloadLedger(ctx context.Context, name string, ledger *Ledger) {
+	ledger.Close(logging.ContextWithLogger(ctx, r.logger.WithField("ledger", name)))
+	delete(r.ledgers, name)
+	r.metricsRegistry.ActiveLedgers().Add(ctx, -1)
+}
+
+// Run applies the states of the running ledgers changed by other instances, until Close is called.
+// It is only needed when several instances serve the same ledgers, using the postgres lock strategy.
+func (r *Resolver) Run(ctx context.Context) {
+	ticker := time.NewTicker(ledgerStatesRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case done := <-r.stopChan:
+			close(done)
+			return
+		case <-ticker.C:
+			r.refreshLedgers(ctx)
+		}
+	}
+}
+
+func (r *Resolver) Close() {
+	done := make(chan struct{})
+	r.stopChan <- done
+	<-done
+}
+
+// refreshLedgers unloads the running ledgers archived or deleted by other instances,
+// and makes the others read-only or writable again.
+func (r *Resolver) refreshLedgers(ctx context.Context) {
+	r.lock.RLock()
+	ledgers := make(map[string]*Ledger, len(r.ledgers))
+	for name, ledger := range r.ledgers {
+		ledgers[name] = ledger
+	}
+	r.lock.RUnlock()
+
+	for name, ledger := range ledgers {
+		err := ledger.loadState(ctx)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrLedgerArchived) || sqlutils.IsNotFoundError(err):
+			r.lock.Lock()
+			// The ledger may have been unloaded in the meantime
+			if r.ledgers[name] == ledger {
+				logging.FromContext(ctx).Infof("Unload ledger %s deleted by another instance", name)
+				r.unloadLedger(ctx, name, ledger)
+			}
+			r.lock.Unlock()
+		default:
+			logging.FromContext(ctx).Errorf("loading state of ledger %s: %s", name, err)
+		}
+	}
+}
+
 func (r *Resolver) CloseLedgers(ctx context.Context) error {
 	r.logger.Info("Close all ledgers")
 	defer func() {
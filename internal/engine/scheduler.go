@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/formancehq/go-libs/logging"
+	libtime "github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/engine/command"
+	"github.com/pkg/errors"
+)
+
+const scheduledTransactionsBatchSize = 100
+
+// scheduler periodically executes the scheduled transactions of a ledger
+// once their execution time is reached.
+type scheduler struct {
+	ledger   *Ledger
+	interval time.Duration
+
+	mu       sync.Mutex
+	stopped  bool
+	stopChan chan struct{}
+	// done is closed when Run returns, it is nil if Run has not been started
+	done chan struct{}
+}
+
+func (s *scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	s.done = done
+	s.mu.Unlock()
+	defer close(done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			if err := s.executeDueTransactions(ctx); err != nil {
+				logging.FromContext(ctx).Errorf("executing scheduled transactions: %s", err)
+			}
+		}
+	}
+}
+
+// Close stops the scheduler and waits for Run to return.
+// It can be called whether Run is running, has returned or has never been started.
+func (s *scheduler) Close() {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stopChan)
+	}
+	done := s.done
+	s.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// executeDueTransactions executes the due transactions in batches.
+// A transaction which cannot be executed is logged and left pending to be retried on the next tick,
+// without blocking the transactions due after it.
+func (s *scheduler) executeDueTransactions(ctx context.Context) error {
+	// Due transactions stay pending until the ledger accepts writes again
+	if s.ledger.isReadOnly() {
+		return nil
+	}
+
+	now := libtime.Now()
+	pending := 0
+	for {
+		due, err := s.ledger.store.GetDueScheduledTransactions(ctx, now, pending, scheduledTransactionsBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "listing due scheduled transactions")
+		}
+
+		for _, scheduled := range due {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := s.execute(ctx, scheduled); err != nil {
+				logging.FromContext(ctx).Errorf("executing scheduled transaction: %s", err)
+				pending++
+			}
+		}
+
+		if len(due) < scheduledTransactionsBatchSize {
+			return nil
+		}
+	}
+}
+
+// execute creates the transaction of a scheduled transaction and records the outcome.
+// Transactions rejected by the commander mark the scheduled transaction as failed,
+// while other errors leave it pending, so that it will be retried.
+// The scheduler runs on every instance: the transaction is created with the idempotency key of the scheduled transaction,
+// which is checked as the writer of the ledger, so a transaction created by another instance is replayed instead of created again.
+func (s *scheduler) execute(ctx context.Context, scheduled ledger.ScheduledTransaction) error {
+	tx, err := s.ledger.CreateTransaction(ctx, command.Parameters{
+		IdempotencyKey: scheduled.IdempotencyKey(),
+	}, scheduled.Script)
+	switch {
+	case err == nil:
+		scheduled = scheduled.WithSuccess(libtime.Now(), tx.ID)
+	case command.IsRejection(err):
+		scheduled = scheduled.WithFailure(libtime.Now(), err)
+	default:
+		return errors.Wrapf(err, "executing scheduled transaction %s", scheduled.ID)
+	}
+
+	if err := s.ledger.store.UpdateScheduledTransaction(ctx, scheduled); err != nil {
+		return errors.Wrapf(err, "updating scheduled transaction %s", scheduled.ID)
+	}
+	s.ledger.monitor.ExecutedScheduledTransaction(ctx, scheduled)
+
+	return nil
+}
+
+func newScheduler(ledger *Ledger, interval time.Duration) *scheduler {
+	return &scheduler{
+		ledger:   ledger,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+
+	"github.com/formancehq/go-libs/logging"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/pkg/errors"
+)
+
+// WriteSnapshot captures the accounts of the ledger, with their metadata and volumes, at the log logID,
+// or at its last log if logID is nil.
+// The snapshot is written as a gzip compressed stream of json documents: the header, followed by one document per account.
+func WriteSnapshot(ctx context.Context, store *ledgerstore.Store, logID *big.Int, w io.Writer) (*ledger.SnapshotHeader, error) {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+
+	var header ledger.SnapshotHeader
+	err := store.TakeSnapshot(ctx, logID,
+		func(h ledger.SnapshotHeader) error {
+			header = h
+			return enc.Encode(h)
+		},
+		func(account ledger.SnapshotAccount) error {
+			return enc.Encode(account)
+		},
+	)
+	if err != nil {
+		return nil, newStorageError(err, "taking snapshot")
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "closing snapshot")
+	}
+
+	return &header, nil
+}
+
+// RestoreSnapshot creates a new ledger from a snapshot written by WriteSnapshot.
+// The ledger does not contain the transactions of the snapshot, but its hash chain
+// and its transaction ids continue from the ones of the snapshot.
+// The ledger is left in the initializing state, so the logs written after the snapshot can be imported.
+func RestoreSnapshot(ctx context.Context, d *driver.Driver, name string, configuration driver.LedgerConfiguration, r io.Reader) (*ledger.SnapshotHeader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading snapshot")
+	}
+	defer func() {
+		_ = gz.Close()
+	}()
+	dec := json.NewDecoder(gz)
+
+	header := &ledger.SnapshotHeader{}
+	if err := dec.Decode(header); err != nil {
+		return nil, errors.Wrap(err, "reading snapshot header")
+	}
+	if header.Version != ledger.SnapshotVersion {
+		return nil, errors.Errorf("unsupported snapshot version %d", header.Version)
+	}
+
+	store, err := d.CreateLedgerStore(ctx, name, configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.RestoreSnapshot(ctx, *header, func() (*ledger.SnapshotAccount, error) {
+		account := &ledger.SnapshotAccount{}
+		if err := dec.Decode(account); err != nil {
+			return nil, err
+		}
+		return account, nil
+	})
+	if err != nil {
+		// Nothing has been written on the ledger, it can be dropped so the restore can be retried
+		if err := d.GetSystemStore().DeleteLedger(ctx, name); err != nil {
+			logging.FromContext(ctx).Errorf("deleting ledger %s after failed restore: %s", name, err)
+		}
+		return nil, newStorageError(err, "restoring snapshot")
+	}
+
+	return header, nil
+}
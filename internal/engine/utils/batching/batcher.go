@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/formancehq/go-libs/collectionutils"
 	"github.com/formancehq/ledger/internal/engine/utils/job"
 )
 
@@ -16,12 +15,13 @@ func NoOpOnBatchProcessed[T any]() func(...T) {
 }
 
 type pending[T any] struct {
-	object   T
-	callback func()
+	objects  []T
+	callback func(err error)
 }
 
 type batcherJob[T any] struct {
 	items []*pending[T]
+	err   error
 }
 
 func (b batcherJob[T]) String() string {
@@ -30,7 +30,7 @@ func (b batcherJob[T]) String() string {
 
 func (b batcherJob[T]) Terminated() {
 	for _, v := range b.items {
-		v.callback()
+		v.callback(b.err)
 	}
 }
 
@@ -41,16 +41,35 @@ type Batcher[T any] struct {
 	maxBatchSize int
 }
 
-func (s *Batcher[T]) Append(object T, callback func()) {
+// Append appends object to the next batch, callback is called with the error of the batch once it is processed.
+func (s *Batcher[T]) Append(object T, callback func(err error)) {
+	s.AppendAll([]T{object}, callback)
+}
+
+// AppendAll appends objects which are always processed within the same batch,
+// even if they exceed the max batch size.
+func (s *Batcher[T]) AppendAll(objects []T, callback func(err error)) {
 	s.mu.Lock()
 	s.pending = append(s.pending, &pending[T]{
 		callback: callback,
-		object:   object,
+		objects:  objects,
 	})
 	s.mu.Unlock()
 	s.Runner.Next()
 }
 
+// DropPending removes the objects waiting for a batch, their callbacks are called with err.
+func (s *Batcher[T]) DropPending(err error) {
+	s.mu.Lock()
+	dropped := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	for _, v := range dropped {
+		v.callback(err)
+	}
+}
+
 func (s *Batcher[T]) nextBatch() *batcherJob[T] {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -58,15 +77,18 @@ func (s *Batcher[T]) nextBatch() *batcherJob[T] {
 	if len(s.pending) == 0 {
 		return nil
 	}
-	if len(s.pending) > s.maxBatchSize {
-		batch := s.pending[:s.maxBatchSize]
-		s.pending = s.pending[s.maxBatchSize:]
-		return &batcherJob[T]{
-			items: batch,
+
+	size := len(s.pending[0].objects)
+	i := 1
+	for ; i < len(s.pending); i++ {
+		if size+len(s.pending[i].objects) > s.maxBatchSize {
+			break
 		}
+		size += len(s.pending[i].objects)
 	}
-	batch := s.pending
-	s.pending = make([]*pending[T], 0)
+
+	batch := s.pending[:i]
+	s.pending = s.pending[i:]
 	return &batcherJob[T]{
 		items: batch,
 	}
@@ -77,9 +99,13 @@ func NewBatcher[T any](runner func(context.Context, ...T) error, nbWorkers, maxB
 		maxBatchSize: maxBatchSize,
 	}
 	ret.Runner = job.NewJobRunner[batcherJob[T]](func(ctx context.Context, job *batcherJob[T]) error {
-		return runner(ctx, collectionutils.Map(job.items, func(from *pending[T]) T {
-			return from.object
-		})...)
+		objects := make([]T, 0, len(job.items))
+		for _, item := range job.items {
+			objects = append(objects, item.objects...)
+		}
+		// The error is reported to the callbacks of the batch, instead of stopping the runner
+		job.err = runner(ctx, objects...)
+		return nil
 	}, ret.nextBatch, nbWorkers)
 	return ret
 }
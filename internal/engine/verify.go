@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+)
+
+type BrokenLink struct {
+	ID       *big.Int `json:"id"`
+	Expected []byte   `json:"expected"`
+	Stored   []byte   `json:"stored"`
+}
+
+type VerifyReport struct {
+	Valid       bool        `json:"valid"`
+	CheckedLogs int         `json:"checkedLogs"`
+	LastLogID   *big.Int    `json:"lastLogID,omitempty"`
+	BrokenLink  *BrokenLink `json:"brokenLink,omitempty"`
+}
+
+// VerifyProgressFn is called each time a page of logs has been verified.
+type VerifyProgressFn func(ctx context.Context, report VerifyReport)
+
+var errChainBroken = errors.New("chain broken")
+
+// LogsStore is the part of the ledger store read to verify the logs.
+type LogsStore interface {
+	GetRestoredSnapshot(ctx context.Context) (*ledger.SnapshotHeader, error)
+	GetLogs(ctx context.Context, q ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error)
+}
+
+// VerifyLogs walks the logs of the store in ascending order and recomputes the hash of each log
+// from the stored hash of the previous one. It stops on the first broken link.
+func VerifyLogs(ctx context.Context, store LogsStore, progress VerifyProgressFn) (*VerifyReport, error) {
+	report := &VerifyReport{
+		Valid: true,
+	}
+	var previous *ledger.ChainedLog
+
+	// The first log of a ledger restored from a snapshot is chained to the last log of the snapshot
+	snapshot, err := store.GetRestoredSnapshot(ctx)
+	switch {
+	case err == nil:
+		previous = snapshot.LastLog()
+	case !sqlutils.IsNotFoundError(err):
+		return nil, newStorageError(err, "getting restored snapshot")
+	}
+
+	err = bunpaginate.Iterate(
+		ctx,
+		ledgerstore.
+			NewGetLogsQuery(ledgerstore.NewPaginatedQueryOptions[any](nil).WithPageSize(100)).
+			WithOrder(bunpaginate.OrderAsc),
+		func(ctx context.Context, q ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
+			return store.GetLogs(ctx, q)
+		},
+		func(cursor *bunpaginate.Cursor[ledger.ChainedLog]) error {
+			for _, log := range cursor.Data {
+				stored := log
+				log.Hash = nil
+				log.ID = big.NewInt(0)
+				log.ComputeHash(previous)
+
+				if !reflect.DeepEqual(log.Hash, stored.Hash) {
+					report.Valid = false
+					report.BrokenLink = &BrokenLink{
+						ID:       stored.ID,
+						Expected: log.Hash,
+						Stored:   stored.Hash,
+					}
+					return errChainBroken
+				}
+
+				report.CheckedLogs++
+				report.LastLogID = stored.ID
+				previous = &stored
+			}
+			if progress != nil {
+				progress(ctx, *report)
+			}
+			return nil
+		},
+	)
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, newStorageError(err, "verifying logs")
+	}
+
+	return report, nil
+}
+
+func (l *Ledger) Verify(ctx context.Context, progress VerifyProgressFn) (*VerifyReport, error) {
+	return VerifyLogs(ctx, l.store, progress)
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/ledgerstore"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemoryLogsStore struct {
+	snapshot *ledger.SnapshotHeader
+	logs     []ledger.ChainedLog
+}
+
+func (s *inMemoryLogsStore) GetRestoredSnapshot(_ context.Context) (*ledger.SnapshotHeader, error) {
+	if s.snapshot == nil {
+		return nil, sqlutils.ErrNotFound
+	}
+	return s.snapshot, nil
+}
+
+func (s *inMemoryLogsStore) GetLogs(_ context.Context, _ ledgerstore.GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
+	return &bunpaginate.Cursor[ledger.ChainedLog]{
+		Data: s.logs,
+	}, nil
+}
+
+func chainTestingLogs(previous *ledger.ChainedLog) []ledger.ChainedLog {
+	now := time.Now()
+	ret := make([]ledger.ChainedLog, 0)
+	for i := 0; i < 4; i++ {
+		log := ledger.NewTransactionLogWithDate(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))).
+				WithIDUint64(uint64(i)).
+				WithDate(now),
+			map[string]metadata.Metadata{},
+			now,
+		).ChainLog(previous)
+		ret = append(ret, *log)
+		previous = log
+	}
+	return ret
+}
+
+func TestVerifyLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	t.Run("valid chain", func(t *testing.T) {
+		t.Parallel()
+
+		report, err := VerifyLogs(ctx, &inMemoryLogsStore{
+			logs: chainTestingLogs(nil),
+		}, nil)
+		require.NoError(t, err)
+		require.True(t, report.Valid)
+		require.Equal(t, 4, report.CheckedLogs)
+		require.Equal(t, big.NewInt(3), report.LastLogID)
+		require.Nil(t, report.BrokenLink)
+	})
+
+	t.Run("corrupted hash", func(t *testing.T) {
+		t.Parallel()
+
+		logs := chainTestingLogs(nil)
+		logs[2].Hash = []byte("corrupted")
+
+		report, err := VerifyLogs(ctx, &inMemoryLogsStore{
+			logs: logs,
+		}, nil)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Equal(t, 2, report.CheckedLogs)
+		require.Equal(t, big.NewInt(2), report.BrokenLink.ID)
+		require.Equal(t, []byte("corrupted"), report.BrokenLink.Stored)
+	})
+
+	t.Run("corrupted payload", func(t *testing.T) {
+		t.Parallel()
+
+		logs := chainTestingLogs(nil)
+		payload := logs[1].Data.(ledger.NewTransactionLogPayload)
+		payload.Transaction = payload.Transaction.WithPostings(ledger.NewPosting("world", "mallory", "USD", big.NewInt(100)))
+		logs[1].Data = payload
+
+		report, err := VerifyLogs(ctx, &inMemoryLogsStore{
+			logs: logs,
+		}, nil)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Equal(t, 1, report.CheckedLogs)
+		require.Equal(t, big.NewInt(1), report.BrokenLink.ID)
+		require.Equal(t, logs[1].Hash, report.BrokenLink.Stored)
+	})
+
+	t.Run("restored from snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := &ledger.SnapshotHeader{
+			LastLogID:   big.NewInt(9),
+			LastLogHash: chainTestingLogs(nil)[3].Hash,
+		}
+		logs := chainTestingLogs(snapshot.LastLog())
+
+		report, err := VerifyLogs(ctx, &inMemoryLogsStore{
+			snapshot: snapshot,
+			logs:     logs,
+		}, nil)
+		require.NoError(t, err)
+		require.True(t, report.Valid)
+		require.Equal(t, 4, report.CheckedLogs)
+		require.Equal(t, big.NewInt(13), report.LastLogID)
+
+		// The first log must be chained to the last log of the snapshot
+		report, err = VerifyLogs(ctx, &inMemoryLogsStore{
+			snapshot: &ledger.SnapshotHeader{
+				LastLogID:   big.NewInt(9),
+				LastLogHash: []byte("other"),
+			},
+			logs: logs,
+		}, nil)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Equal(t, big.NewInt(10), report.BrokenLink.ID)
+	})
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/formancehq/ledger/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+// CreateWebhook registers a webhook on the ledger.
+// A secret is generated if the webhook is created without one.
+func (l *Ledger) CreateWebhook(ctx context.Context, webhook systemstore.Webhook) (*systemstore.Webhook, error) {
+	webhook.ID = uuid.NewString()
+	webhook.Ledger = l.store.Name()
+	webhook.CreatedAt = time.Now()
+	if webhook.Secret == "" {
+		webhook.Secret = webhooks.NewSecret()
+	}
+
+	if err := l.systemStore.InsertWebhook(ctx, &webhook); err != nil {
+		return nil, newStorageError(err, "inserting webhook")
+	}
+	return &webhook, nil
+}
+
+// UpdateWebhook replaces the configuration of a webhook.
+// The secret is left unchanged if not provided.
+func (l *Ledger) UpdateWebhook(ctx context.Context, webhook systemstore.Webhook) (*systemstore.Webhook, error) {
+	existing, err := l.systemStore.GetWebhook(ctx, l.store.Name(), webhook.ID)
+	if err != nil {
+		return nil, newStorageError(err, "getting webhook")
+	}
+
+	webhook.Ledger = existing.Ledger
+	webhook.CreatedAt = existing.CreatedAt
+	if webhook.Secret == "" {
+		webhook.Secret = existing.Secret
+	}
+
+	if err := l.systemStore.UpdateWebhook(ctx, &webhook); err != nil {
+		return nil, newStorageError(err, "updating webhook")
+	}
+	return &webhook, nil
+}
+
+func (l *Ledger) DeleteWebhook(ctx context.Context, id string) error {
+	return newStorageError(l.systemStore.DeleteWebhook(ctx, l.store.Name(), id), "deleting webhook")
+}
+
+func (l *Ledger) GetWebhook(ctx context.Context, id string) (*systemstore.Webhook, error) {
+	webhook, err := l.systemStore.GetWebhook(ctx, l.store.Name(), id)
+	return webhook, newStorageError(err, "getting webhook")
+}
+
+func (l *Ledger) ListWebhooks(ctx context.Context, q systemstore.ListWebhooksQuery) (*bunpaginate.Cursor[systemstore.Webhook], error) {
+	ret, err := l.systemStore.ListWebhooks(ctx, l.store.Name(), q)
+	return ret, newStorageError(err, "listing webhooks")
+}
+
+func (l *Ledger) ListWebhookAttempts(ctx context.Context, id string, q systemstore.ListWebhookAttemptsQuery) (*bunpaginate.Cursor[systemstore.WebhookAttempt], error) {
+	if _, err := l.systemStore.GetWebhook(ctx, l.store.Name(), id); err != nil {
+		return nil, newStorageError(err, "getting webhook")
+	}
+
+	ret, err := l.systemStore.ListWebhookAttempts(ctx, l.store.Name(), id, q)
+	return ret, newStorageError(err, "listing webhook attempts")
+}
@@ -0,0 +1,196 @@
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/pkg/errors"
+)
+
+const (
+	HoldStatePending   = "PENDING"
+	HoldStateConfirmed = "CONFIRMED"
+	HoldStateVoided    = "VOIDED"
+
+	holdAccountPrefix = "holds:"
+
+	holdMetadataPrefix = "hold/"
+	holdIDKey          = "hold/id"
+	holdDestinationKey = "hold/destination"
+	holdAssetKey       = "hold/asset"
+	holdAmountKey      = "hold/amount"
+	holdSourcesKey     = "hold/sources"
+	holdStateKey       = "hold/state"
+)
+
+var (
+	ErrHoldNoFunds        = errors.New("the script must send funds to the hold account")
+	ErrHoldMultipleAssets = errors.New("a hold can only reserve a single asset")
+	ErrHoldNotFound       = errors.New("hold not found")
+)
+
+// HoldSource is an account funds were reserved from.
+type HoldSource struct {
+	Account string   `json:"account"`
+	Amount  *big.Int `json:"amount"`
+}
+
+// Hold is a reservation of funds on a ledger-managed account.
+// The description of the hold is stored as metadata of the hold account, which only the hold operations can modify,
+// and its remaining amount is the balance of this account.
+type Hold struct {
+	ID          string       `json:"id"`
+	Account     string       `json:"account"`
+	Destination string       `json:"destination"`
+	Asset       string       `json:"asset"`
+	Amount      *big.Int     `json:"amount"`
+	Remaining   *big.Int     `json:"remaining"`
+	Sources     []HoldSource `json:"sources"`
+	State       string       `json:"state"`
+}
+
+func (h Hold) Metadata() metadata.Metadata {
+	sources, err := json.Marshal(h.Sources)
+	if err != nil {
+		panic(err)
+	}
+	return metadata.Metadata{
+		SpecMetadata(holdIDKey):          h.ID,
+		SpecMetadata(holdDestinationKey): h.Destination,
+		SpecMetadata(holdAssetKey):       h.Asset,
+		SpecMetadata(holdAmountKey):      h.Amount.String(),
+		SpecMetadata(holdSourcesKey):     string(sources),
+		SpecMetadata(holdStateKey):       h.State,
+	}
+}
+
+// Capture returns the postings moving amount from the hold to its destination.
+func (h Hold) Capture(amount *big.Int) Postings {
+	return Postings{NewPosting(h.Account, h.Destination, h.Asset, amount)}
+}
+
+// Release returns the postings giving amount back to the sources of the hold.
+// Captures are taken from the sources in order, so releases give funds back
+// in reverse order, each source getting at most what it provided.
+func (h Hold) Release(amount *big.Int) Postings {
+	postings := Postings{}
+	left := new(big.Int).Set(amount)
+	for i := len(h.Sources) - 1; i >= 0 && left.Sign() > 0; i-- {
+		released := new(big.Int).Set(h.Sources[i].Amount)
+		if released.Cmp(left) > 0 {
+			released.Set(left)
+		}
+		postings = append(postings, NewPosting(h.Account, h.Sources[i].Account, h.Asset, released))
+		left.Sub(left, released)
+	}
+	return postings
+}
+
+func (h Hold) WithState(state string) Hold {
+	h.State = state
+	return h
+}
+
+func (h Hold) WithRemaining(remaining *big.Int) Hold {
+	h.Remaining = remaining
+	return h
+}
+
+func HoldAccount(id string) string {
+	return holdAccountPrefix + id
+}
+
+// IsHoldAccount reports whether address is the account of a hold, which only the hold operations can move funds from or to.
+func IsHoldAccount(address string) bool {
+	return strings.HasPrefix(address, holdAccountPrefix)
+}
+
+// IsHoldMetadata reports whether key is one of the keys describing a hold, which only the hold operations can write.
+func IsHoldMetadata(key string) bool {
+	return strings.HasPrefix(key, SpecMetadata(holdMetadataPrefix))
+}
+
+func HoldStateMetadata(state string) metadata.Metadata {
+	return ComputeMetadata(SpecMetadata(holdStateKey), state)
+}
+
+// HoldTransactionMetadata marks the transactions of a hold.
+func HoldTransactionMetadata(id string) metadata.Metadata {
+	return ComputeMetadata(SpecMetadata(holdIDKey), id)
+}
+
+// NewHold creates a pending hold from the postings of the transaction
+// moving funds to the hold account.
+func NewHold(id, destination string, postings Postings) (*Hold, error) {
+	hold := &Hold{
+		ID:          id,
+		Account:     HoldAccount(id),
+		Destination: destination,
+		Amount:      new(big.Int),
+		Sources:     []HoldSource{},
+		State:       HoldStatePending,
+	}
+
+	sources := map[string]int{}
+	for _, posting := range postings {
+		if posting.Destination != hold.Account {
+			continue
+		}
+		if hold.Asset == "" {
+			hold.Asset = posting.Asset
+		}
+		if posting.Asset != hold.Asset {
+			return nil, ErrHoldMultipleAssets
+		}
+		hold.Amount.Add(hold.Amount, posting.Amount)
+
+		index, ok := sources[posting.Source]
+		if !ok {
+			index = len(hold.Sources)
+			sources[posting.Source] = index
+			hold.Sources = append(hold.Sources, HoldSource{
+				Account: posting.Source,
+				Amount:  new(big.Int),
+			})
+		}
+		hold.Sources[index].Amount.Add(hold.Sources[index].Amount, posting.Amount)
+	}
+	if hold.Amount.Sign() == 0 {
+		return nil, ErrHoldNoFunds
+	}
+	hold.Remaining = new(big.Int).Set(hold.Amount)
+
+	return hold, nil
+}
+
+// HoldFromMetadata rebuilds a hold from the metadata of its account.
+func HoldFromMetadata(m metadata.Metadata, remaining *big.Int) (*Hold, error) {
+	id, ok := m[SpecMetadata(holdIDKey)]
+	if !ok {
+		return nil, ErrHoldNotFound
+	}
+
+	amount, ok := new(big.Int).SetString(m[SpecMetadata(holdAmountKey)], 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount for hold %s", id)
+	}
+
+	sources := make([]HoldSource, 0)
+	if err := json.Unmarshal([]byte(m[SpecMetadata(holdSourcesKey)]), &sources); err != nil {
+		return nil, errors.Wrapf(err, "invalid sources for hold %s", id)
+	}
+
+	return &Hold{
+		ID:          id,
+		Account:     HoldAccount(id),
+		Destination: m[SpecMetadata(holdDestinationKey)],
+		Asset:       m[SpecMetadata(holdAssetKey)],
+		Amount:      amount,
+		Remaining:   remaining,
+		Sources:     sources,
+		State:       m[SpecMetadata(holdStateKey)],
+	}, nil
+}
@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"math/big"
+
+	"github.com/formancehq/go-libs/time"
+	"github.com/google/uuid"
+)
+
+const (
+	ImportJobStateRunning   = "RUNNING"
+	ImportJobStateSucceeded = "SUCCEEDED"
+	ImportJobStateFailed    = "FAILED"
+)
+
+// ImportJob tracks an import of logs running in the background.
+type ImportJob struct {
+	ID           string     `json:"id"`
+	State        string     `json:"state"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	TerminatedAt *time.Time `json:"terminatedAt,omitempty"`
+	// ImportedLogs and SkippedLogs count the logs of the stream respectively inserted,
+	// and ignored because the ledger already contained them.
+	ImportedLogs int      `json:"importedLogs"`
+	SkippedLogs  int      `json:"skippedLogs"`
+	LastLogID    *big.Int `json:"lastLogID,omitempty"`
+	ErrorCode    string   `json:"errorCode,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+func (j ImportJob) WithProgress(at time.Time, imported, skipped int, lastLogID *big.Int) ImportJob {
+	j.UpdatedAt = at
+	j.ImportedLogs = imported
+	j.SkippedLogs = skipped
+	j.LastLogID = lastLogID
+	return j
+}
+
+func (j ImportJob) WithSuccess(at time.Time) ImportJob {
+	j.State = ImportJobStateSucceeded
+	j.UpdatedAt = at
+	j.TerminatedAt = &at
+	return j
+}
+
+func (j ImportJob) WithFailure(at time.Time, code string, err error) ImportJob {
+	j.State = ImportJobStateFailed
+	j.UpdatedAt = at
+	j.TerminatedAt = &at
+	j.ErrorCode = code
+	j.Error = err.Error()
+	return j
+}
+
+func NewImportJob() ImportJob {
+	now := time.Now()
+	return ImportJob{
+		ID:        uuid.NewString(),
+		State:     ImportJobStateRunning,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
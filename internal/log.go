@@ -130,6 +130,9 @@ type Log struct {
 	Data           any       `json:"data"`
 	Date           time.Time `json:"date"`
 	IdempotencyKey string    `json:"idempotencyKey"`
+	// IdempotencyHash is the fingerprint of the request which created the log using the idempotency key.
+	// It is not part of the hash of the log, as it is dropped once the idempotency key expires.
+	IdempotencyHash string `json:"-"`
 }
 
 func (l *Log) WithDate(date time.Time) *Log {
@@ -142,6 +145,11 @@ func (l *Log) WithIdempotencyKey(key string) *Log {
 	return l
 }
 
+func (l *Log) WithIdempotencyHash(hash string) *Log {
+	l.IdempotencyHash = hash
+	return l
+}
+
 func (l *Log) ChainLog(previous *ChainedLog) *ChainedLog {
 	ret := &ChainedLog{
 		Log: *l,
@@ -55,6 +55,29 @@ func IsInsufficientFundError(err error) bool {
 	return errors.Is(err, &ErrInsufficientFund{})
 }
 
+type ErrAssertionFailed struct {
+	msg string
+}
+
+func (e *ErrAssertionFailed) Error() string {
+	return e.msg
+}
+
+func (e *ErrAssertionFailed) Is(err error) bool {
+	_, ok := err.(*ErrAssertionFailed)
+	return ok
+}
+
+func NewErrAssertionFailed(f string, args ...any) *ErrAssertionFailed {
+	return &ErrAssertionFailed{
+		msg: fmt.Sprintf(f, args...),
+	}
+}
+
+func IsAssertionFailedError(err error) bool {
+	return errors.Is(err, &ErrAssertionFailed{})
+}
+
 type ErrNegativeAmount struct {
 	msg string
 }
@@ -134,3 +157,26 @@ func NewErrMetadataOverride(key string) *ErrMetadataOverride {
 func IsMetadataOverride(err error) bool {
 	return errors.Is(err, &ErrMetadataOverride{})
 }
+
+type ErrAccountPolicy struct {
+	msg string
+}
+
+func (e *ErrAccountPolicy) Error() string {
+	return e.msg
+}
+
+func (e *ErrAccountPolicy) Is(err error) bool {
+	_, ok := err.(*ErrAccountPolicy)
+	return ok
+}
+
+func NewErrAccountPolicy(f string, args ...any) *ErrAccountPolicy {
+	return &ErrAccountPolicy{
+		msg: fmt.Sprintf(f, args...),
+	}
+}
+
+func IsAccountPolicyError(err error) bool {
+	return errors.Is(err, &ErrAccountPolicy{})
+}
@@ -55,6 +55,12 @@ func NewValueFromString(typ Type, data string) (Value, error) {
 			return nil, err
 		}
 		value = *res
+	case TypeRate:
+		res, err := ParseRate(data)
+		if err != nil {
+			return nil, err
+		}
+		value = *res
 	case TypeString:
 		value = String(data)
 	default:
@@ -79,6 +85,8 @@ func NewStringFromValue(value Value) (string, error) {
 		return fmt.Sprintf("%s %s", m.Asset, m.Amount), nil
 	case TypePortion:
 		return value.(Portion).String(), nil
+	case TypeRate:
+		return value.(Rate).String(), nil
 	default:
 		return "", fmt.Errorf("invalid type '%v'", value.GetType())
 	}
@@ -68,6 +68,23 @@ func TestPortionTypedJSON(t *testing.T) {
 	}
 }
 
+func TestRateTypedJSON(t *testing.T) {
+	for _, j := range []string{"1.0823", "10823/10000"} {
+		value, err := NewValueFromString(TypeRate, j)
+		require.NoError(t, err)
+
+		rate, err := NewRate(*big.NewRat(10823, 10000))
+		require.NoError(t, err)
+
+		if !ValueEquals(value, *rate) {
+			t.Fatalf("unexpected value: %v", value)
+		}
+	}
+
+	_, err := NewValueFromString(TypeRate, "0")
+	require.Error(t, err)
+}
+
 func TestMarshalJSON(t *testing.T) {
 	t.Run("account", func(t *testing.T) {
 		by, err := json.Marshal(AccountAddress("platform"))
@@ -0,0 +1,51 @@
+package machine
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Rate is an exchange rate, the amount of the target asset obtained
+// for one unit of the source asset.
+type Rate struct {
+	Value *big.Rat `json:"value"`
+}
+
+func (Rate) GetType() Type { return TypeRate }
+
+func NewRate(r big.Rat) (*Rate, error) {
+	if r.Sign() <= 0 {
+		return nil, errors.New("rate must be strictly positive")
+	}
+	return &Rate{
+		Value: &r,
+	}, nil
+}
+
+// ParseRate parses a rate written either as a decimal ("1.0823")
+// or as a fraction ("10823/10000").
+func ParseRate(input string) (*Rate, error) {
+	r, ok := new(big.Rat).SetString(input)
+	if !ok {
+		return nil, errors.New("invalid rate format")
+	}
+	return NewRate(*r)
+}
+
+func ValidateRate(r Rate) error {
+	if r.Value == nil {
+		return errors.New("rate should not be nil")
+	}
+	if r.Value.Sign() <= 0 {
+		return errors.New("rate must be strictly positive")
+	}
+	return nil
+}
+
+func (lhs Rate) Equals(rhs Rate) bool {
+	return lhs.Value.Cmp(rhs.Value) == 0
+}
+
+func (r Rate) String() string {
+	return r.Value.RatString()
+}
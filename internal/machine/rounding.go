@@ -0,0 +1,58 @@
+package machine
+
+import (
+	"errors"
+	"math/big"
+)
+
+type Rounding byte
+
+const (
+	RoundingNone     = Rounding(iota) // fails on inexact results
+	RoundingFloor                     // towards negative infinity
+	RoundingCeil                      // towards positive infinity
+	RoundingHalfEven                  // to the nearest integer, ties to even
+)
+
+func (r Rounding) String() string {
+	switch r {
+	case RoundingNone:
+		return "none"
+	case RoundingFloor:
+		return "floor"
+	case RoundingCeil:
+		return "ceil"
+	case RoundingHalfEven:
+		return "half-even"
+	default:
+		return "invalid rounding"
+	}
+}
+
+var ErrInexactResult = errors.New("inexact result without rounding mode")
+
+// Round converts r to an integer using the given rounding mode.
+func Round(r *big.Rat, mode Rounding) (*MonetaryInt, error) {
+	q, m := new(big.Int).DivMod(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() == 0 {
+		return (*MonetaryInt)(q), nil
+	}
+
+	switch mode {
+	case RoundingFloor:
+	case RoundingCeil:
+		q.Add(q, big.NewInt(1))
+	case RoundingHalfEven:
+		switch new(big.Int).Lsh(m, 1).Cmp(r.Denom()) {
+		case 1:
+			q.Add(q, big.NewInt(1))
+		case 0:
+			if q.Bit(0) == 1 {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	default:
+		return nil, ErrInexactResult
+	}
+	return (*MonetaryInt)(q), nil
+}
@@ -0,0 +1,38 @@
+package machine
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in   *big.Rat
+		mode Rounding
+		want int64
+	}{
+		{in: big.NewRat(5, 2), mode: RoundingFloor, want: 2},
+		{in: big.NewRat(5, 2), mode: RoundingCeil, want: 3},
+		{in: big.NewRat(5, 2), mode: RoundingHalfEven, want: 2},
+		{in: big.NewRat(7, 2), mode: RoundingHalfEven, want: 4},
+		{in: big.NewRat(8, 3), mode: RoundingHalfEven, want: 3},
+		{in: big.NewRat(7, 3), mode: RoundingHalfEven, want: 2},
+		{in: big.NewRat(-5, 2), mode: RoundingFloor, want: -3},
+		{in: big.NewRat(-5, 2), mode: RoundingCeil, want: -2},
+		{in: big.NewRat(-5, 2), mode: RoundingHalfEven, want: -2},
+		{in: big.NewRat(-7, 2), mode: RoundingHalfEven, want: -4},
+		{in: big.NewRat(6, 2), mode: RoundingNone, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in.String()+" "+tt.mode.String(), func(t *testing.T) {
+			got, err := Round(tt.in, tt.mode)
+			require.NoError(t, err)
+			require.Equal(t, NewMonetaryInt(tt.want), got)
+		})
+	}
+
+	_, err := Round(big.NewRat(5, 2), RoundingNone)
+	require.ErrorIs(t, err, ErrInexactResult)
+}
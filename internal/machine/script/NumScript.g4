@@ -11,15 +11,33 @@ SET_TX_META: 'set_tx_meta';
 SET_ACCOUNT_META: 'set_account_meta';
 PRINT: 'print';
 FAIL: 'fail';
+ASSERT: 'assert';
+IF: 'if';
+ELSE: 'else';
 SEND: 'send';
 SOURCE: 'source';
 FROM: 'from';
 MAX: 'max';
+MIN: 'min';
+FLOOR: 'floor';
+CEIL: 'ceil';
+ROUND: 'round';
 DESTINATION: 'destination';
 TO: 'to';
+CONVERT: 'convert';
+AT: 'at';
+VIA: 'via';
 ALLOCATE: 'allocate';
 OP_ADD: '+';
 OP_SUB: '-';
+OP_MUL: '*';
+OP_DIV: '/';
+OP_EQ: '==';
+OP_NEQ: '!=';
+OP_LTE: '<=';
+OP_GTE: '>=';
+OP_LT: '<';
+OP_GT: '>';
 LPAREN: '(';
 RPAREN: ')';
 LBRACK: '[';
@@ -33,6 +51,7 @@ TY_NUMBER: 'number';
 TY_MONETARY: 'monetary';
 TY_PORTION: 'portion';
 TY_STRING: 'string';
+TY_RATE: 'rate';
 STRING: '"' ('\\"' | ~[\r\n"])* '"';
 PORTION:
     ( [0-9]+ [ ]? '/' [ ]? [0-9]+
@@ -53,7 +72,7 @@ ASSET: [A-Z/0-9]+;
 
 monetary: LBRACK asset=expression amt=NUMBER RBRACK;
 
-monetaryAll: LBRACK asset=expression '*' RBRACK;
+monetaryAll: LBRACK asset=expression OP_MUL RBRACK;
 
 literal
     : ACCOUNT # LitAccount
@@ -67,7 +86,11 @@ literal
 variable: VARIABLE_NAME;
 
 expression
-    : lhs=expression op=(OP_ADD|OP_SUB) rhs=expression # ExprAddSub
+    : lhs=expression op=(OP_MUL|OP_DIV) rhs=expression # ExprMulDiv
+    | lhs=expression op=(OP_ADD|OP_SUB) rhs=expression # ExprAddSub
+    | LPAREN expr=expression RPAREN # ExprParens
+    | fn=(MIN|MAX) LPAREN lhs=expression ',' rhs=expression RPAREN # ExprMinMax
+    | rounding=(FLOOR|CEIL|ROUND) LPAREN expr=expression RPAREN # ExprRound
     | lit=literal # ExprLiteral
     | var_=variable # ExprVariable
     ;
@@ -102,6 +125,13 @@ destination
     | destinationAllotment # DestAllotment
     ;
 
+condOperand
+    : BALANCE '(' account=expression ',' asset=expression ')' # CondBalance
+    | expr=expression # CondExpr
+    ;
+
+condition: lhs=condOperand op=(OP_EQ|OP_NEQ|OP_LT|OP_LTE|OP_GT|OP_GTE) rhs=condOperand;
+
 sourceAccountOverdraft
     : 'allowing overdraft up to' specific=expression # SrcAccountOverdraftSpecific
     | 'allowing unbounded overdraft' # SrcAccountOverdraftUnbounded
@@ -140,9 +170,22 @@ statement
     | SET_TX_META '(' key=STRING ',' value=expression ')' # SetTxMeta
     | SET_ACCOUNT_META '(' acc=expression ',' key=STRING ',' value=expression ')' # SetAccountMeta
     | FAIL # Fail
+    | ASSERT cond=condition # Assert
+    | IF cond=condition thenBlock=block (ELSE elseBlock=block)? # If
     | SEND (mon=expression | monAll=monetaryAll) LPAREN NEWLINE
         ( SOURCE '=' src=valueAwareSource NEWLINE DESTINATION '=' dest=destination
         | DESTINATION '=' dest=destination NEWLINE SOURCE '=' src=valueAwareSource) NEWLINE RPAREN # Send
+    | CONVERT mon=expression TO asset=expression AT rate=expression LPAREN NEWLINE
+        SOURCE '=' src=source NEWLINE
+        VIA '=' via=expression NEWLINE
+        DESTINATION '=' dest=destination NEWLINE
+      RPAREN # Convert
+    ;
+
+block
+    : LBRACE NEWLINE+
+        (stmts+=statement NEWLINE+)+
+    RBRACE
     ;
 
 type_
@@ -152,6 +195,7 @@ type_
     | TY_STRING
     | TY_MONETARY
     | TY_PORTION
+    | TY_RATE
     ;
 
 origin
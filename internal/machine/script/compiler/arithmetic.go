@@ -0,0 +1,110 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/formancehq/ledger/internal/machine/script/parser"
+	"github.com/formancehq/ledger/internal/machine/vm/program"
+)
+
+// VisitExprMulDiv compiles a multiplication or a division. Results which may
+// not be integers (divisions, multiplications by a portion) must be wrapped
+// in floor(), ceil() or round().
+func (p *parseVisitor) VisitExprMulDiv(c *parser.ExprMulDivContext, push bool) (machine.Type, *machine.Address, *CompileError) {
+	lhsType, lhsAddr, err := p.VisitExpr(c.GetLhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	if lhsType != machine.TypeNumber && lhsType != machine.TypeMonetary {
+		return 0, nil, LogicError(c, fmt.Errorf(
+			"tried to do an arithmetic operation with unsupported left-hand side operand type: %s",
+			lhsType))
+	}
+
+	rhsType, _, err := p.VisitExpr(c.GetRhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var (
+		opcode byte
+		exact  bool
+	)
+	switch c.GetOp().GetTokenType() {
+	case parser.NumScriptLexerOP_MUL:
+		if rhsType != machine.TypeNumber && rhsType != machine.TypePortion {
+			return 0, nil, LogicError(c, fmt.Errorf(
+				"tried to multiply by an operand of unsupported type: %s", rhsType))
+		}
+		opcode = program.OP_MUL
+		exact = rhsType == machine.TypeNumber
+	case parser.NumScriptLexerOP_DIV:
+		if rhsType != machine.TypeNumber {
+			return 0, nil, LogicError(c, fmt.Errorf(
+				"tried to divide by an operand of unsupported type: %s", rhsType))
+		}
+		opcode = program.OP_DIV
+	default:
+		return 0, nil, InternalError(c)
+	}
+
+	if !exact && p.rounding == machine.RoundingNone {
+		return 0, nil, LogicError(c, fmt.Errorf(
+			"the result of '%s' may not be an integer, wrap it in floor(), ceil() or round()",
+			c.GetText()))
+	}
+
+	if push {
+		p.instructions = append(p.instructions, opcode, byte(p.rounding))
+	}
+
+	return lhsType, lhsAddr, nil
+}
+
+func (p *parseVisitor) VisitExprMinMax(c *parser.ExprMinMaxContext, push bool) (machine.Type, *machine.Address, *CompileError) {
+	lhsType, lhsAddr, err := p.VisitExpr(c.GetLhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	if lhsType != machine.TypeNumber && lhsType != machine.TypeMonetary {
+		return 0, nil, LogicError(c, fmt.Errorf(
+			"%s: unsupported argument type: %s", c.GetFn().GetText(), lhsType))
+	}
+
+	rhsType, _, err := p.VisitExpr(c.GetRhs(), push)
+	if err != nil {
+		return 0, nil, err
+	}
+	if rhsType != lhsType {
+		return 0, nil, LogicError(c, fmt.Errorf(
+			"%s: incompatible argument types: %s and %s", c.GetFn().GetText(), lhsType, rhsType))
+	}
+
+	if push {
+		switch c.GetFn().GetTokenType() {
+		case parser.NumScriptLexerMIN:
+			p.AppendInstruction(program.OP_MIN)
+		case parser.NumScriptLexerMAX:
+			p.AppendInstruction(program.OP_MAX)
+		default:
+			return 0, nil, InternalError(c)
+		}
+	}
+
+	return lhsType, lhsAddr, nil
+}
+
+// roundingOf returns the rounding mode of a floor(), ceil() or round() expression.
+func roundingOf(c *parser.ExprRoundContext) (machine.Rounding, *CompileError) {
+	switch c.GetRounding().GetTokenType() {
+	case parser.NumScriptLexerFLOOR:
+		return machine.RoundingFloor, nil
+	case parser.NumScriptLexerCEIL:
+		return machine.RoundingCeil, nil
+	case parser.NumScriptLexerROUND:
+		return machine.RoundingHalfEven, nil
+	default:
+		return 0, InternalError(c)
+	}
+}
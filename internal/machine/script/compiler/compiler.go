@@ -35,6 +35,9 @@ type parseVisitor struct {
 	// all the accounts that appear in either the destination
 	// or in the balance() function
 	readLockAccounts map[machine.Address]struct{}
+
+	// rounding mode of the innermost enclosing floor(), ceil() or round()
+	rounding machine.Rounding
 }
 
 // Allocates constants if it hasn't already been,
@@ -144,6 +147,22 @@ func (p *parseVisitor) VisitExpr(c parser.IExpressionContext, push bool) (machin
 				"tried to do an arithmetic operation with unsupported left-hand side operand type: %s",
 				lhsType))
 		}
+	case *parser.ExprMulDivContext:
+		return p.VisitExprMulDiv(c, push)
+	case *parser.ExprParensContext:
+		return p.VisitExpr(c.GetExpr(), push)
+	case *parser.ExprMinMaxContext:
+		return p.VisitExprMinMax(c, push)
+	case *parser.ExprRoundContext:
+		outer := p.rounding
+		rounding, err := roundingOf(c)
+		if err != nil {
+			return 0, nil, err
+		}
+		p.rounding = rounding
+		ty, addr, err := p.VisitExpr(c.GetExpr(), push)
+		p.rounding = outer
+		return ty, addr, err
 	case *parser.ExprLiteralContext:
 		return p.VisitLit(c.GetLit(), push)
 	case *parser.ExprVariableContext:
@@ -526,6 +545,8 @@ func (p *parseVisitor) VisitVars(c *parser.VarListDeclContext) *CompileError {
 			ty = machine.TypeMonetary
 		case "portion":
 			ty = machine.TypePortion
+		case "rate":
+			ty = machine.TypeRate
 		default:
 			return InternalError(c)
 		}
@@ -604,6 +625,32 @@ func (p *parseVisitor) VisitVars(c *parser.VarListDeclContext) *CompileError {
 	return nil
 }
 
+func (p *parseVisitor) VisitStatement(c parser.IStatementContext) *CompileError {
+	switch c := c.(type) {
+	case *parser.PrintContext:
+		return p.VisitPrint(c)
+	case *parser.FailContext:
+		p.AppendInstruction(program.OP_FAIL)
+	case *parser.AssertContext:
+		return p.VisitAssert(c)
+	case *parser.IfContext:
+		return p.VisitIf(c)
+	case *parser.SendContext:
+		return p.VisitSend(c)
+	case *parser.ConvertContext:
+		return p.VisitConvert(c)
+	case *parser.SetTxMetaContext:
+		return p.VisitSetTxMeta(c)
+	case *parser.SetAccountMetaContext:
+		return p.VisitSetAccountMeta(c)
+	case *parser.SaveFromAccountContext:
+		return p.VisitSaveFromAccount(c)
+	default:
+		return InternalError(c)
+	}
+	return nil
+}
+
 func (p *parseVisitor) VisitScript(c parser.IScriptContext) *CompileError {
 	switch c := c.(type) {
 	case *parser.ScriptContext:
@@ -620,24 +667,7 @@ func (p *parseVisitor) VisitScript(c parser.IScriptContext) *CompileError {
 		}
 
 		for _, stmt := range c.GetStmts() {
-			var err *CompileError
-			switch c := stmt.(type) {
-			case *parser.PrintContext:
-				err = p.VisitPrint(c)
-			case *parser.FailContext:
-				p.AppendInstruction(program.OP_FAIL)
-			case *parser.SendContext:
-				err = p.VisitSend(c)
-			case *parser.SetTxMetaContext:
-				err = p.VisitSetTxMeta(c)
-			case *parser.SetAccountMetaContext:
-				err = p.VisitSetAccountMeta(c)
-			case *parser.SaveFromAccountContext:
-				err = p.VisitSaveFromAccount(c)
-			default:
-				return InternalError(c)
-			}
-			if err != nil {
+			if err := p.VisitStatement(stmt); err != nil {
 				return err
 			}
 		}
@@ -1291,7 +1291,7 @@ func TestVariableBalance(t *testing.T) {
 				destination = @bob
 			)`,
 			Expected: CaseResult{
-				Error: "mismatched input 'balance'",
+				Error: "extraneous input 'balance'",
 			},
 		})
 	})
@@ -1742,3 +1742,303 @@ func TestSaveFromAccount(t *testing.T) {
 		})
 	})
 }
+
+func TestAssert(t *testing.T) {
+	t.Run("nominal", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `assert balance(@a, COIN) >= [COIN 10]`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 00, 00,
+					program2.OP_APUSH, 01, 00,
+					program2.OP_BALANCE,
+					program2.OP_APUSH, 02, 00,
+					program2.OP_GTE,
+					program2.OP_APUSH, 03, 00,
+					program2.OP_ASSERT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.AccountAddress("a")},
+					program2.Constant{Inner: machine.Asset("COIN")},
+					program2.Monetary{
+						Asset:  1,
+						Amount: machine.NewMonetaryInt(10),
+					},
+					program2.Constant{Inner: machine.String("balance(@a, COIN) >= [COIN 10]")},
+				},
+			},
+		})
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `assert 1 + 2 != 4`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 00, 00,
+					program2.OP_APUSH, 01, 00,
+					program2.OP_IADD,
+					program2.OP_APUSH, 02, 00,
+					program2.OP_NEQ,
+					program2.OP_APUSH, 03, 00,
+					program2.OP_ASSERT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.NewMonetaryInt(1)},
+					program2.Constant{Inner: machine.NewMonetaryInt(2)},
+					program2.Constant{Inner: machine.NewMonetaryInt(4)},
+					program2.Constant{Inner: machine.String("1 + 2 != 4")},
+				},
+			},
+		})
+	})
+
+	t.Run("error incompatible types", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `assert balance(@a, COIN) > 10`,
+			Expected: CaseResult{
+				Error: "tried to do a comparison with incompatible left and right-hand side operand types: monetary and number",
+			},
+		})
+	})
+
+	t.Run("error unsupported type", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `assert @a < @b`,
+			Expected: CaseResult{
+				Error: "tried to do a comparison with unsupported left-hand side operand type: account",
+			},
+		})
+	})
+
+	t.Run("error wrong balance argument", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `assert balance(COIN, @a) > [COIN 0]`,
+			Expected: CaseResult{
+				Error: "balance: the first argument should be of type 'account' instead of 'asset'",
+			},
+		})
+	})
+}
+
+func TestIf(t *testing.T) {
+	t.Run("if else", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `
+				if 1 < 2 {
+					print 1
+				} else {
+					print 2
+				}`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 00, 00,
+					program2.OP_APUSH, 01, 00,
+					program2.OP_LT,
+					program2.OP_JUMP_IF_FALSE, 17, 00,
+					program2.OP_APUSH, 00, 00,
+					program2.OP_PRINT,
+					program2.OP_JUMP, 21, 00,
+					program2.OP_APUSH, 01, 00,
+					program2.OP_PRINT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.NewMonetaryInt(1)},
+					program2.Constant{Inner: machine.NewMonetaryInt(2)},
+				},
+			},
+		})
+	})
+
+	t.Run("if without else", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `
+				if "a" == "b" {
+					fail
+				}
+				print 1`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 00, 00,
+					program2.OP_APUSH, 01, 00,
+					program2.OP_EQ,
+					program2.OP_JUMP_IF_FALSE, 11, 00,
+					program2.OP_FAIL,
+					program2.OP_APUSH, 02, 00,
+					program2.OP_PRINT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.String("a")},
+					program2.Constant{Inner: machine.String("b")},
+					program2.Constant{Inner: machine.NewMonetaryInt(1)},
+				},
+			},
+		})
+	})
+
+	t.Run("error condition types", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `
+				if "a" == 1 {
+					fail
+				}`,
+			Expected: CaseResult{
+				Error: "tried to do a comparison with incompatible left and right-hand side operand types: string and number",
+			},
+		})
+	})
+}
+
+func TestMulDiv(t *testing.T) {
+	t.Run("number", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print (1 + 2) * 3`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 00, 00,
+					program2.OP_APUSH, 01, 00,
+					program2.OP_IADD,
+					program2.OP_APUSH, 02, 00,
+					program2.OP_MUL, byte(machine.RoundingNone),
+					program2.OP_PRINT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.NewMonetaryInt(1)},
+					program2.Constant{Inner: machine.NewMonetaryInt(2)},
+					program2.Constant{Inner: machine.NewMonetaryInt(3)},
+				},
+			},
+		})
+	})
+
+	t.Run("monetary with rounding", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print floor([EUR/2 1000] * 2.9%) + ceil([EUR/2 30] / 2)`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 01, 00,
+					program2.OP_APUSH, 02, 00,
+					program2.OP_MUL, byte(machine.RoundingFloor),
+					program2.OP_APUSH, 03, 00,
+					program2.OP_APUSH, 04, 00,
+					program2.OP_DIV, byte(machine.RoundingCeil),
+					program2.OP_MONETARY_ADD,
+					program2.OP_PRINT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.Asset("EUR/2")},
+					program2.Monetary{Asset: 0, Amount: machine.NewMonetaryInt(1000)},
+					program2.Constant{Inner: machine.Portion{
+						Specific: big.NewRat(29, 1000),
+					}},
+					program2.Monetary{Asset: 0, Amount: machine.NewMonetaryInt(30)},
+					program2.Constant{Inner: machine.NewMonetaryInt(2)},
+				},
+			},
+		})
+	})
+
+	t.Run("error missing rounding", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print 10 / (3)`,
+			Expected: CaseResult{
+				Error: "wrap it in floor(), ceil() or round()",
+			},
+		})
+	})
+
+	t.Run("error divide by portion", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print floor([EUR/2 10] / 50%)`,
+			Expected: CaseResult{
+				Error: "tried to divide by an operand of unsupported type: portion",
+			},
+		})
+	})
+
+	t.Run("error multiply monetaries", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print [EUR/2 10] * [EUR/2 10]`,
+			Expected: CaseResult{
+				Error: "tried to multiply by an operand of unsupported type: monetary",
+			},
+		})
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	t.Run("monetary", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print max([EUR/2 30], [EUR/2 50])`,
+			Expected: CaseResult{
+				Instructions: []byte{
+					program2.OP_APUSH, 01, 00,
+					program2.OP_APUSH, 02, 00,
+					program2.OP_MAX,
+					program2.OP_PRINT,
+				},
+				Resources: []program2.Resource{
+					program2.Constant{Inner: machine.Asset("EUR/2")},
+					program2.Monetary{Asset: 0, Amount: machine.NewMonetaryInt(30)},
+					program2.Monetary{Asset: 0, Amount: machine.NewMonetaryInt(50)},
+				},
+			},
+		})
+	})
+
+	t.Run("error incompatible types", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `print min(1, [EUR/2 50])`,
+			Expected: CaseResult{
+				Error: "min: incompatible argument types: number and monetary",
+			},
+		})
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("error rate type", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `convert [EUR/2 100] to USD/2 at 50% (
+				source = @alice
+				via = @fx
+				destination = @bob
+			)`,
+			Expected: CaseResult{
+				Error: "convert: the rate should be of type 'rate' instead of 'portion'",
+			},
+		})
+	})
+
+	t.Run("error target type", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `vars {
+				rate $rate
+			}
+			convert [EUR/2 100] to @usd at $rate (
+				source = @alice
+				via = @fx
+				destination = @bob
+			)`,
+			Expected: CaseResult{
+				Error: "convert: the target should be of type 'asset' instead of 'account'",
+			},
+		})
+	})
+
+	t.Run("error conversion account type", func(t *testing.T) {
+		test(t, TestCase{
+			Case: `vars {
+				rate $rate
+			}
+			convert [EUR/2 100] to USD/2 at $rate (
+				source = @alice
+				via = "fx"
+				destination = @bob
+			)`,
+			Expected: CaseResult{
+				Error: "convert: the conversion account should be of type 'account' instead of 'string'",
+			},
+		})
+	})
+}
@@ -0,0 +1,155 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/formancehq/ledger/internal/machine"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/formancehq/ledger/internal/machine/script/parser"
+	program2 "github.com/formancehq/ledger/internal/machine/vm/program"
+)
+
+func (p *parseVisitor) VisitCondOperand(c parser.ICondOperandContext) (machine.Type, *CompileError) {
+	switch c := c.(type) {
+	case *parser.CondBalanceContext:
+		accTy, accAddr, compErr := p.VisitExpr(c.GetAccount(), false)
+		if compErr != nil {
+			return 0, compErr
+		}
+		if accTy != machine.TypeAccount {
+			return 0, LogicError(c, fmt.Errorf(
+				"balance: the first argument should be of type 'account' instead of '%s'", accTy))
+		}
+
+		assTy, assAddr, compErr := p.VisitExpr(c.GetAsset(), false)
+		if compErr != nil {
+			return 0, compErr
+		}
+		if assTy != machine.TypeAsset {
+			return 0, LogicError(c, fmt.Errorf(
+				"balance: the second argument should be of type 'asset' instead of '%s'", assTy))
+		}
+
+		p.PushAddress(*accAddr)
+		p.PushAddress(*assAddr)
+		p.AppendInstruction(program2.OP_BALANCE)
+
+		p.setNeededBalances(map[machine.Address]struct{}{*accAddr: {}}, assAddr)
+		p.readLockAccounts[*accAddr] = struct{}{}
+
+		return machine.TypeMonetary, nil
+	case *parser.CondExprContext:
+		ty, _, compErr := p.VisitExpr(c.GetExpr(), true)
+		if compErr != nil {
+			return 0, compErr
+		}
+		return ty, nil
+	default:
+		return 0, InternalError(c)
+	}
+}
+
+func (p *parseVisitor) VisitCondition(c parser.IConditionContext) *CompileError {
+	op := c.GetOp().GetTokenType()
+
+	lhsType, compErr := p.VisitCondOperand(c.GetLhs())
+	if compErr != nil {
+		return compErr
+	}
+	isEquality := op == parser.NumScriptLexerOP_EQ || op == parser.NumScriptLexerOP_NEQ
+	if !isEquality && lhsType != machine.TypeNumber && lhsType != machine.TypeMonetary {
+		return LogicError(c, fmt.Errorf(
+			"tried to do a comparison with unsupported left-hand side operand type: %s", lhsType))
+	}
+
+	rhsType, compErr := p.VisitCondOperand(c.GetRhs())
+	if compErr != nil {
+		return compErr
+	}
+	if rhsType != lhsType {
+		return LogicError(c, fmt.Errorf(
+			"tried to do a comparison with incompatible left and right-hand side operand types: %s and %s",
+			lhsType, rhsType))
+	}
+
+	switch op {
+	case parser.NumScriptLexerOP_EQ:
+		p.AppendInstruction(program2.OP_EQ)
+	case parser.NumScriptLexerOP_NEQ:
+		p.AppendInstruction(program2.OP_NEQ)
+	case parser.NumScriptLexerOP_LT:
+		p.AppendInstruction(program2.OP_LT)
+	case parser.NumScriptLexerOP_LTE:
+		p.AppendInstruction(program2.OP_LTE)
+	case parser.NumScriptLexerOP_GT:
+		p.AppendInstruction(program2.OP_GT)
+	case parser.NumScriptLexerOP_GTE:
+		p.AppendInstruction(program2.OP_GTE)
+	default:
+		return InternalError(c)
+	}
+
+	return nil
+}
+
+// VisitAssert compiles an assertion. The condition is evaluated against
+// the balances as they stand once the postings of the previous statements
+// have been computed.
+func (p *parseVisitor) VisitAssert(c *parser.AssertContext) *CompileError {
+	if err := p.VisitCondition(c.GetCond()); err != nil {
+		return err
+	}
+
+	cond := c.GetCond()
+	text := cond.GetStart().GetInputStream().GetTextFromInterval(
+		antlr.NewInterval(cond.GetStart().GetStart(), cond.GetStop().GetStop()))
+	addr, err := p.AllocateResource(program2.Constant{Inner: machine.String(text)})
+	if err != nil {
+		return LogicError(c, err)
+	}
+	p.PushAddress(*addr)
+
+	p.AppendInstruction(program2.OP_ASSERT)
+
+	return nil
+}
+
+// VisitIf compiles both branches, so that the resources, needed balances
+// and locked accounts of the program cover whichever branch is taken.
+func (p *parseVisitor) VisitIf(c *parser.IfContext) *CompileError {
+	if err := p.VisitCondition(c.GetCond()); err != nil {
+		return err
+	}
+	jumpToElse := p.AppendJump(program2.OP_JUMP_IF_FALSE)
+
+	if err := p.VisitBlock(c.GetThenBlock()); err != nil {
+		return err
+	}
+
+	if elseBlock := c.GetElseBlock(); elseBlock != nil {
+		jumpToEnd := p.AppendJump(program2.OP_JUMP)
+		if err := p.PatchJump(jumpToElse); err != nil {
+			return LogicError(c, err)
+		}
+		if err := p.VisitBlock(elseBlock); err != nil {
+			return err
+		}
+		if err := p.PatchJump(jumpToEnd); err != nil {
+			return LogicError(c, err)
+		}
+	} else if err := p.PatchJump(jumpToElse); err != nil {
+		return LogicError(c, err)
+	}
+
+	return nil
+}
+
+func (p *parseVisitor) VisitBlock(c parser.IBlockContext) *CompileError {
+	for _, stmt := range c.GetStmts() {
+		if err := p.VisitStatement(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
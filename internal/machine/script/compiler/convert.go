@@ -0,0 +1,95 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/formancehq/ledger/internal/machine/script/parser"
+	"github.com/formancehq/ledger/internal/machine/vm/program"
+)
+
+// VisitConvert compiles a conversion as two legs going through the conversion
+// account: the source pays the monetary to the conversion account, which then
+// pays the converted monetary to the destination, without being bound by its
+// balance in the target asset.
+// The converted amount is rounded as specified by wrapping the rate in floor(), ceil() or round(),
+// and rounded half to even by default.
+func (p *parseVisitor) VisitConvert(c *parser.ConvertContext) *CompileError {
+	monType, monAddr, compErr := p.VisitExpr(c.GetMon(), false)
+	if compErr != nil {
+		return compErr
+	}
+	if monType != machine.TypeMonetary {
+		return LogicError(c, fmt.Errorf(
+			"convert: the expression should be of type 'monetary' instead of '%s'", monType))
+	}
+
+	assetType, assetAddr, compErr := p.VisitExpr(c.GetAsset(), false)
+	if compErr != nil {
+		return compErr
+	}
+	if assetType != machine.TypeAsset {
+		return LogicError(c, fmt.Errorf(
+			"convert: the target should be of type 'asset' instead of '%s'", assetType))
+	}
+
+	rounding := machine.RoundingHalfEven
+	if round, ok := c.GetRate().(*parser.ExprRoundContext); ok {
+		var compErr *CompileError
+		rounding, compErr = roundingOf(round)
+		if compErr != nil {
+			return compErr
+		}
+	}
+	rateType, rateAddr, compErr := p.VisitExpr(c.GetRate(), false)
+	if compErr != nil {
+		return compErr
+	}
+	if rateType != machine.TypeRate {
+		return LogicError(c, fmt.Errorf(
+			"convert: the rate should be of type 'rate' instead of '%s'", rateType))
+	}
+
+	viaType, viaAddr, compErr := p.VisitExpr(c.GetVia(), false)
+	if compErr != nil {
+		return compErr
+	}
+	if viaType != machine.TypeAccount {
+		return LogicError(c, fmt.Errorf(
+			"convert: the conversion account should be of type 'account' instead of '%s'", viaType))
+	}
+	if !p.isWorld(*viaAddr) {
+		p.readLockAccounts[*viaAddr] = struct{}{}
+	}
+
+	// source -> conversion account
+	accounts, _, fallback, compErr := p.VisitSource(c.GetSrc(), func() {
+		p.PushAddress(*monAddr)
+		p.AppendInstruction(program.OP_ASSET)
+	}, false)
+	if compErr != nil {
+		return compErr
+	}
+	p.setNeededBalances(accounts, monAddr)
+
+	if _, _, err := p.VisitExpr(c.GetMon(), true); err != nil {
+		return err
+	}
+	if err := p.TakeFromSource(fallback); err != nil {
+		return LogicError(c, err)
+	}
+	p.PushAddress(*viaAddr)
+	p.AppendInstruction(program.OP_SEND)
+
+	// conversion account -> destination
+	p.PushAddress(*viaAddr)
+	if _, _, err := p.VisitExpr(c.GetMon(), true); err != nil {
+		return err
+	}
+	p.PushAddress(*assetAddr)
+	p.PushAddress(*rateAddr)
+	p.instructions = append(p.instructions, program.OP_CONVERT, byte(rounding))
+	p.AppendInstruction(program.OP_TAKE_ALWAYS)
+
+	return p.VisitDestination(c.GetDest())
+}
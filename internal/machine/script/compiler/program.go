@@ -1,6 +1,10 @@
 package compiler
 
 import (
+	"encoding/binary"
+	"errors"
+	"math"
+
 	"github.com/formancehq/ledger/internal/machine"
 	program2 "github.com/formancehq/ledger/internal/machine/vm/program"
 )
@@ -34,3 +38,19 @@ func (p *parseVisitor) Bump(n int64) error {
 	p.instructions = append(p.instructions, program2.OP_BUMP)
 	return nil
 }
+
+// AppendJump appends a jump instruction with a placeholder target
+// and returns the position of the target, to be set with PatchJump.
+func (p *parseVisitor) AppendJump(instruction byte) int {
+	p.instructions = append(p.instructions, instruction, 0, 0)
+	return len(p.instructions) - 2
+}
+
+// PatchJump makes the jump whose target is at pos go to the next instruction.
+func (p *parseVisitor) PatchJump(pos int) error {
+	if len(p.instructions) > math.MaxUint16 {
+		return errors.New("number of instructions exceeded 65535")
+	}
+	binary.LittleEndian.PutUint16(p.instructions[pos:pos+2], uint16(len(p.instructions)))
+	return nil
+}
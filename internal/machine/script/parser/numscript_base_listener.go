@@ -75,18 +75,42 @@ func (s *BaseNumScriptListener) EnterVariable(ctx *VariableContext) {}
 // ExitVariable is called when production variable is exited.
 func (s *BaseNumScriptListener) ExitVariable(ctx *VariableContext) {}
 
+// EnterExprRound is called when production ExprRound is entered.
+func (s *BaseNumScriptListener) EnterExprRound(ctx *ExprRoundContext) {}
+
+// ExitExprRound is called when production ExprRound is exited.
+func (s *BaseNumScriptListener) ExitExprRound(ctx *ExprRoundContext) {}
+
 // EnterExprAddSub is called when production ExprAddSub is entered.
 func (s *BaseNumScriptListener) EnterExprAddSub(ctx *ExprAddSubContext) {}
 
 // ExitExprAddSub is called when production ExprAddSub is exited.
 func (s *BaseNumScriptListener) ExitExprAddSub(ctx *ExprAddSubContext) {}
 
+// EnterExprMulDiv is called when production ExprMulDiv is entered.
+func (s *BaseNumScriptListener) EnterExprMulDiv(ctx *ExprMulDivContext) {}
+
+// ExitExprMulDiv is called when production ExprMulDiv is exited.
+func (s *BaseNumScriptListener) ExitExprMulDiv(ctx *ExprMulDivContext) {}
+
+// EnterExprParens is called when production ExprParens is entered.
+func (s *BaseNumScriptListener) EnterExprParens(ctx *ExprParensContext) {}
+
+// ExitExprParens is called when production ExprParens is exited.
+func (s *BaseNumScriptListener) ExitExprParens(ctx *ExprParensContext) {}
+
 // EnterExprLiteral is called when production ExprLiteral is entered.
 func (s *BaseNumScriptListener) EnterExprLiteral(ctx *ExprLiteralContext) {}
 
 // ExitExprLiteral is called when production ExprLiteral is exited.
 func (s *BaseNumScriptListener) ExitExprLiteral(ctx *ExprLiteralContext) {}
 
+// EnterExprMinMax is called when production ExprMinMax is entered.
+func (s *BaseNumScriptListener) EnterExprMinMax(ctx *ExprMinMaxContext) {}
+
+// ExitExprMinMax is called when production ExprMinMax is exited.
+func (s *BaseNumScriptListener) ExitExprMinMax(ctx *ExprMinMaxContext) {}
+
 // EnterExprVariable is called when production ExprVariable is entered.
 func (s *BaseNumScriptListener) EnterExprVariable(ctx *ExprVariableContext) {}
 
@@ -155,6 +179,24 @@ func (s *BaseNumScriptListener) EnterDestAllotment(ctx *DestAllotmentContext) {}
 // ExitDestAllotment is called when production DestAllotment is exited.
 func (s *BaseNumScriptListener) ExitDestAllotment(ctx *DestAllotmentContext) {}
 
+// EnterCondBalance is called when production CondBalance is entered.
+func (s *BaseNumScriptListener) EnterCondBalance(ctx *CondBalanceContext) {}
+
+// ExitCondBalance is called when production CondBalance is exited.
+func (s *BaseNumScriptListener) ExitCondBalance(ctx *CondBalanceContext) {}
+
+// EnterCondExpr is called when production CondExpr is entered.
+func (s *BaseNumScriptListener) EnterCondExpr(ctx *CondExprContext) {}
+
+// ExitCondExpr is called when production CondExpr is exited.
+func (s *BaseNumScriptListener) ExitCondExpr(ctx *CondExprContext) {}
+
+// EnterCondition is called when production condition is entered.
+func (s *BaseNumScriptListener) EnterCondition(ctx *ConditionContext) {}
+
+// ExitCondition is called when production condition is exited.
+func (s *BaseNumScriptListener) ExitCondition(ctx *ConditionContext) {}
+
 // EnterSrcAccountOverdraftSpecific is called when production SrcAccountOverdraftSpecific is entered.
 func (s *BaseNumScriptListener) EnterSrcAccountOverdraftSpecific(ctx *SrcAccountOverdraftSpecificContext) {
 }
@@ -255,12 +297,36 @@ func (s *BaseNumScriptListener) EnterFail(ctx *FailContext) {}
 // ExitFail is called when production Fail is exited.
 func (s *BaseNumScriptListener) ExitFail(ctx *FailContext) {}
 
+// EnterAssert is called when production Assert is entered.
+func (s *BaseNumScriptListener) EnterAssert(ctx *AssertContext) {}
+
+// ExitAssert is called when production Assert is exited.
+func (s *BaseNumScriptListener) ExitAssert(ctx *AssertContext) {}
+
+// EnterIf is called when production If is entered.
+func (s *BaseNumScriptListener) EnterIf(ctx *IfContext) {}
+
+// ExitIf is called when production If is exited.
+func (s *BaseNumScriptListener) ExitIf(ctx *IfContext) {}
+
 // EnterSend is called when production Send is entered.
 func (s *BaseNumScriptListener) EnterSend(ctx *SendContext) {}
 
 // ExitSend is called when production Send is exited.
 func (s *BaseNumScriptListener) ExitSend(ctx *SendContext) {}
 
+// EnterConvert is called when production Convert is entered.
+func (s *BaseNumScriptListener) EnterConvert(ctx *ConvertContext) {}
+
+// ExitConvert is called when production Convert is exited.
+func (s *BaseNumScriptListener) ExitConvert(ctx *ConvertContext) {}
+
+// EnterBlock is called when production block is entered.
+func (s *BaseNumScriptListener) EnterBlock(ctx *BlockContext) {}
+
+// ExitBlock is called when production block is exited.
+func (s *BaseNumScriptListener) ExitBlock(ctx *BlockContext) {}
+
 // EnterType_ is called when production type_ is entered.
 func (s *BaseNumScriptListener) EnterType_(ctx *Type_Context) {}
 
@@ -44,36 +44,43 @@ func numscriptlexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.literalNames = []string{
-		"", "'*'", "'allowing overdraft up to'", "'allowing unbounded overdraft'",
-		"','", "", "", "", "", "'vars'", "'meta'", "'set_tx_meta'", "'set_account_meta'",
-		"'print'", "'fail'", "'send'", "'source'", "'from'", "'max'", "'destination'",
-		"'to'", "'allocate'", "'+'", "'-'", "'('", "')'", "'['", "']'", "'{'",
-		"'}'", "'='", "'account'", "'asset'", "'number'", "'monetary'", "'portion'",
-		"'string'", "", "", "'remaining'", "'kept'", "'balance'", "'save'",
-		"", "'%'",
+		"", "','", "'allowing overdraft up to'", "'allowing unbounded overdraft'",
+		"", "", "", "", "'vars'", "'meta'", "'set_tx_meta'", "'set_account_meta'",
+		"'print'", "'fail'", "'assert'", "'if'", "'else'", "'send'", "'source'",
+		"'from'", "'max'", "'min'", "'floor'", "'ceil'", "'round'", "'destination'",
+		"'to'", "'convert'", "'at'", "'via'", "'allocate'", "'+'", "'-'", "'*'",
+		"'/'", "'=='", "'!='", "'<='", "'>='", "'<'", "'>'", "'('", "')'", "'['",
+		"']'", "'{'", "'}'", "'='", "'account'", "'asset'", "'number'", "'monetary'",
+		"'portion'", "'string'", "'rate'", "", "", "'remaining'", "'kept'",
+		"'balance'", "'save'", "", "'%'",
 	}
 	staticData.symbolicNames = []string{
-		"", "", "", "", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
+		"", "", "", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
 		"VARS", "META", "SET_TX_META", "SET_ACCOUNT_META", "PRINT", "FAIL",
-		"SEND", "SOURCE", "FROM", "MAX", "DESTINATION", "TO", "ALLOCATE", "OP_ADD",
-		"OP_SUB", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "LBRACE", "RBRACE",
-		"EQ", "TY_ACCOUNT", "TY_ASSET", "TY_NUMBER", "TY_MONETARY", "TY_PORTION",
-		"TY_STRING", "STRING", "PORTION", "REMAINING", "KEPT", "BALANCE", "SAVE",
-		"NUMBER", "PERCENT", "VARIABLE_NAME", "ACCOUNT", "ASSET",
+		"ASSERT", "IF", "ELSE", "SEND", "SOURCE", "FROM", "MAX", "MIN", "FLOOR",
+		"CEIL", "ROUND", "DESTINATION", "TO", "CONVERT", "AT", "VIA", "ALLOCATE",
+		"OP_ADD", "OP_SUB", "OP_MUL", "OP_DIV", "OP_EQ", "OP_NEQ", "OP_LTE",
+		"OP_GTE", "OP_LT", "OP_GT", "LPAREN", "RPAREN", "LBRACK", "RBRACK",
+		"LBRACE", "RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET", "TY_NUMBER", "TY_MONETARY",
+		"TY_PORTION", "TY_STRING", "TY_RATE", "STRING", "PORTION", "REMAINING",
+		"KEPT", "BALANCE", "SAVE", "NUMBER", "PERCENT", "VARIABLE_NAME", "ACCOUNT",
+		"ASSET",
 	}
 	staticData.ruleNames = []string{
-		"T__0", "T__1", "T__2", "T__3", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT",
+		"T__0", "T__1", "T__2", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT",
 		"LINE_COMMENT", "VARS", "META", "SET_TX_META", "SET_ACCOUNT_META", "PRINT",
-		"FAIL", "SEND", "SOURCE", "FROM", "MAX", "DESTINATION", "TO", "ALLOCATE",
-		"OP_ADD", "OP_SUB", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "LBRACE",
-		"RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET", "TY_NUMBER", "TY_MONETARY",
-		"TY_PORTION", "TY_STRING", "STRING", "PORTION", "REMAINING", "KEPT",
-		"BALANCE", "SAVE", "NUMBER", "PERCENT", "VARIABLE_NAME", "ACCOUNT",
-		"ASSET",
+		"FAIL", "ASSERT", "IF", "ELSE", "SEND", "SOURCE", "FROM", "MAX", "MIN",
+		"FLOOR", "CEIL", "ROUND", "DESTINATION", "TO", "CONVERT", "AT", "VIA",
+		"ALLOCATE", "OP_ADD", "OP_SUB", "OP_MUL", "OP_DIV", "OP_EQ", "OP_NEQ",
+		"OP_LTE", "OP_GTE", "OP_LT", "OP_GT", "LPAREN", "RPAREN", "LBRACK",
+		"RBRACK", "LBRACE", "RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET", "TY_NUMBER",
+		"TY_MONETARY", "TY_PORTION", "TY_STRING", "TY_RATE", "STRING", "PORTION",
+		"REMAINING", "KEPT", "BALANCE", "SAVE", "NUMBER", "PERCENT", "VARIABLE_NAME",
+		"ACCOUNT", "ASSET",
 	}
 	staticData.predictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 47, 464, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 65, 574, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
@@ -82,203 +89,250 @@ func numscriptlexerLexerInit() {
 		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
 		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
 		41, 2, 42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 2, 46, 7, 46,
-		1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		2, 47, 7, 47, 2, 48, 7, 48, 2, 49, 7, 49, 2, 50, 7, 50, 2, 51, 7, 51, 2,
+		52, 7, 52, 2, 53, 7, 53, 2, 54, 7, 54, 2, 55, 7, 55, 2, 56, 7, 56, 2, 57,
+		7, 57, 2, 58, 7, 58, 2, 59, 7, 59, 2, 60, 7, 60, 2, 61, 7, 61, 2, 62, 7,
+		62, 2, 63, 7, 63, 2, 64, 7, 64, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2,
-		1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2,
-		1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4, 4, 4,
-		155, 8, 4, 11, 4, 12, 4, 156, 1, 5, 4, 5, 160, 8, 5, 11, 5, 12, 5, 161,
-		1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 5, 6, 171, 8, 6, 10, 6, 12, 6,
-		174, 9, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 5, 7,
-		185, 8, 7, 10, 7, 12, 7, 188, 9, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8,
-		1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 1,
-		10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 11, 1, 11,
-		1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1,
-		11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12,
-		1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1,
-		15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16,
-		1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1,
-		18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 20,
-		1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1,
-		22, 1, 22, 1, 23, 1, 23, 1, 24, 1, 24, 1, 25, 1, 25, 1, 26, 1, 26, 1, 27,
-		1, 27, 1, 28, 1, 28, 1, 29, 1, 29, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1,
-		30, 1, 30, 1, 30, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 32, 1, 32,
-		1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1,
-		33, 1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34,
-		1, 34, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 36, 1, 36, 1,
-		36, 1, 36, 5, 36, 356, 8, 36, 10, 36, 12, 36, 359, 9, 36, 1, 36, 1, 36,
-		1, 37, 4, 37, 364, 8, 37, 11, 37, 12, 37, 365, 1, 37, 3, 37, 369, 8, 37,
-		1, 37, 1, 37, 3, 37, 373, 8, 37, 1, 37, 4, 37, 376, 8, 37, 11, 37, 12,
-		37, 377, 1, 37, 4, 37, 381, 8, 37, 11, 37, 12, 37, 382, 1, 37, 1, 37, 4,
-		37, 387, 8, 37, 11, 37, 12, 37, 388, 3, 37, 391, 8, 37, 1, 37, 3, 37, 394,
-		8, 37, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1,
-		38, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40,
-		1, 40, 1, 40, 1, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 42, 4, 42, 425,
-		8, 42, 11, 42, 12, 42, 426, 1, 43, 1, 43, 1, 44, 1, 44, 4, 44, 433, 8,
-		44, 11, 44, 12, 44, 434, 1, 44, 5, 44, 438, 8, 44, 10, 44, 12, 44, 441,
-		9, 44, 1, 45, 1, 45, 4, 45, 445, 8, 45, 11, 45, 12, 45, 446, 1, 45, 1,
-		45, 4, 45, 451, 8, 45, 11, 45, 12, 45, 452, 5, 45, 455, 8, 45, 10, 45,
-		12, 45, 458, 9, 45, 1, 46, 4, 46, 461, 8, 46, 11, 46, 12, 46, 462, 2, 172,
-		186, 0, 47, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19,
-		10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37,
-		19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55,
-		28, 57, 29, 59, 30, 61, 31, 63, 32, 65, 33, 67, 34, 69, 35, 71, 36, 73,
-		37, 75, 38, 77, 39, 79, 40, 81, 41, 83, 42, 85, 43, 87, 44, 89, 45, 91,
-		46, 93, 47, 1, 0, 9, 2, 0, 10, 10, 13, 13, 2, 0, 9, 9, 32, 32, 3, 0, 10,
-		10, 13, 13, 34, 34, 1, 0, 48, 57, 1, 0, 32, 32, 2, 0, 95, 95, 97, 122,
-		3, 0, 48, 57, 95, 95, 97, 122, 5, 0, 45, 45, 48, 57, 65, 90, 95, 95, 97,
-		122, 2, 0, 47, 57, 65, 90, 485, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0,
-		5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0,
-		13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0,
-		0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0,
-		0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0,
-		0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1,
-		0, 0, 0, 0, 45, 1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51,
-		1, 0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0,
-		59, 1, 0, 0, 0, 0, 61, 1, 0, 0, 0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0,
-		0, 67, 1, 0, 0, 0, 0, 69, 1, 0, 0, 0, 0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0,
-		0, 0, 75, 1, 0, 0, 0, 0, 77, 1, 0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0,
-		0, 0, 0, 83, 1, 0, 0, 0, 0, 85, 1, 0, 0, 0, 0, 87, 1, 0, 0, 0, 0, 89, 1,
-		0, 0, 0, 0, 91, 1, 0, 0, 0, 0, 93, 1, 0, 0, 0, 1, 95, 1, 0, 0, 0, 3, 97,
-		1, 0, 0, 0, 5, 122, 1, 0, 0, 0, 7, 151, 1, 0, 0, 0, 9, 154, 1, 0, 0, 0,
-		11, 159, 1, 0, 0, 0, 13, 165, 1, 0, 0, 0, 15, 180, 1, 0, 0, 0, 17, 193,
-		1, 0, 0, 0, 19, 198, 1, 0, 0, 0, 21, 203, 1, 0, 0, 0, 23, 215, 1, 0, 0,
-		0, 25, 232, 1, 0, 0, 0, 27, 238, 1, 0, 0, 0, 29, 243, 1, 0, 0, 0, 31, 248,
-		1, 0, 0, 0, 33, 255, 1, 0, 0, 0, 35, 260, 1, 0, 0, 0, 37, 264, 1, 0, 0,
-		0, 39, 276, 1, 0, 0, 0, 41, 279, 1, 0, 0, 0, 43, 288, 1, 0, 0, 0, 45, 290,
-		1, 0, 0, 0, 47, 292, 1, 0, 0, 0, 49, 294, 1, 0, 0, 0, 51, 296, 1, 0, 0,
-		0, 53, 298, 1, 0, 0, 0, 55, 300, 1, 0, 0, 0, 57, 302, 1, 0, 0, 0, 59, 304,
-		1, 0, 0, 0, 61, 306, 1, 0, 0, 0, 63, 314, 1, 0, 0, 0, 65, 320, 1, 0, 0,
-		0, 67, 327, 1, 0, 0, 0, 69, 336, 1, 0, 0, 0, 71, 344, 1, 0, 0, 0, 73, 351,
-		1, 0, 0, 0, 75, 393, 1, 0, 0, 0, 77, 395, 1, 0, 0, 0, 79, 405, 1, 0, 0,
-		0, 81, 410, 1, 0, 0, 0, 83, 418, 1, 0, 0, 0, 85, 424, 1, 0, 0, 0, 87, 428,
-		1, 0, 0, 0, 89, 430, 1, 0, 0, 0, 91, 442, 1, 0, 0, 0, 93, 460, 1, 0, 0,
-		0, 95, 96, 5, 42, 0, 0, 96, 2, 1, 0, 0, 0, 97, 98, 5, 97, 0, 0, 98, 99,
-		5, 108, 0, 0, 99, 100, 5, 108, 0, 0, 100, 101, 5, 111, 0, 0, 101, 102,
-		5, 119, 0, 0, 102, 103, 5, 105, 0, 0, 103, 104, 5, 110, 0, 0, 104, 105,
-		5, 103, 0, 0, 105, 106, 5, 32, 0, 0, 106, 107, 5, 111, 0, 0, 107, 108,
-		5, 118, 0, 0, 108, 109, 5, 101, 0, 0, 109, 110, 5, 114, 0, 0, 110, 111,
-		5, 100, 0, 0, 111, 112, 5, 114, 0, 0, 112, 113, 5, 97, 0, 0, 113, 114,
-		5, 102, 0, 0, 114, 115, 5, 116, 0, 0, 115, 116, 5, 32, 0, 0, 116, 117,
-		5, 117, 0, 0, 117, 118, 5, 112, 0, 0, 118, 119, 5, 32, 0, 0, 119, 120,
-		5, 116, 0, 0, 120, 121, 5, 111, 0, 0, 121, 4, 1, 0, 0, 0, 122, 123, 5,
-		97, 0, 0, 123, 124, 5, 108, 0, 0, 124, 125, 5, 108, 0, 0, 125, 126, 5,
-		111, 0, 0, 126, 127, 5, 119, 0, 0, 127, 128, 5, 105, 0, 0, 128, 129, 5,
-		110, 0, 0, 129, 130, 5, 103, 0, 0, 130, 131, 5, 32, 0, 0, 131, 132, 5,
-		117, 0, 0, 132, 133, 5, 110, 0, 0, 133, 134, 5, 98, 0, 0, 134, 135, 5,
-		111, 0, 0, 135, 136, 5, 117, 0, 0, 136, 137, 5, 110, 0, 0, 137, 138, 5,
-		100, 0, 0, 138, 139, 5, 101, 0, 0, 139, 140, 5, 100, 0, 0, 140, 141, 5,
-		32, 0, 0, 141, 142, 5, 111, 0, 0, 142, 143, 5, 118, 0, 0, 143, 144, 5,
-		101, 0, 0, 144, 145, 5, 114, 0, 0, 145, 146, 5, 100, 0, 0, 146, 147, 5,
-		114, 0, 0, 147, 148, 5, 97, 0, 0, 148, 149, 5, 102, 0, 0, 149, 150, 5,
-		116, 0, 0, 150, 6, 1, 0, 0, 0, 151, 152, 5, 44, 0, 0, 152, 8, 1, 0, 0,
-		0, 153, 155, 7, 0, 0, 0, 154, 153, 1, 0, 0, 0, 155, 156, 1, 0, 0, 0, 156,
-		154, 1, 0, 0, 0, 156, 157, 1, 0, 0, 0, 157, 10, 1, 0, 0, 0, 158, 160, 7,
-		1, 0, 0, 159, 158, 1, 0, 0, 0, 160, 161, 1, 0, 0, 0, 161, 159, 1, 0, 0,
-		0, 161, 162, 1, 0, 0, 0, 162, 163, 1, 0, 0, 0, 163, 164, 6, 5, 0, 0, 164,
-		12, 1, 0, 0, 0, 165, 166, 5, 47, 0, 0, 166, 167, 5, 42, 0, 0, 167, 172,
-		1, 0, 0, 0, 168, 171, 3, 13, 6, 0, 169, 171, 9, 0, 0, 0, 170, 168, 1, 0,
-		0, 0, 170, 169, 1, 0, 0, 0, 171, 174, 1, 0, 0, 0, 172, 173, 1, 0, 0, 0,
-		172, 170, 1, 0, 0, 0, 173, 175, 1, 0, 0, 0, 174, 172, 1, 0, 0, 0, 175,
-		176, 5, 42, 0, 0, 176, 177, 5, 47, 0, 0, 177, 178, 1, 0, 0, 0, 178, 179,
-		6, 6, 0, 0, 179, 14, 1, 0, 0, 0, 180, 181, 5, 47, 0, 0, 181, 182, 5, 47,
-		0, 0, 182, 186, 1, 0, 0, 0, 183, 185, 9, 0, 0, 0, 184, 183, 1, 0, 0, 0,
-		185, 188, 1, 0, 0, 0, 186, 187, 1, 0, 0, 0, 186, 184, 1, 0, 0, 0, 187,
-		189, 1, 0, 0, 0, 188, 186, 1, 0, 0, 0, 189, 190, 3, 9, 4, 0, 190, 191,
-		1, 0, 0, 0, 191, 192, 6, 7, 0, 0, 192, 16, 1, 0, 0, 0, 193, 194, 5, 118,
-		0, 0, 194, 195, 5, 97, 0, 0, 195, 196, 5, 114, 0, 0, 196, 197, 5, 115,
-		0, 0, 197, 18, 1, 0, 0, 0, 198, 199, 5, 109, 0, 0, 199, 200, 5, 101, 0,
-		0, 200, 201, 5, 116, 0, 0, 201, 202, 5, 97, 0, 0, 202, 20, 1, 0, 0, 0,
-		203, 204, 5, 115, 0, 0, 204, 205, 5, 101, 0, 0, 205, 206, 5, 116, 0, 0,
-		206, 207, 5, 95, 0, 0, 207, 208, 5, 116, 0, 0, 208, 209, 5, 120, 0, 0,
-		209, 210, 5, 95, 0, 0, 210, 211, 5, 109, 0, 0, 211, 212, 5, 101, 0, 0,
-		212, 213, 5, 116, 0, 0, 213, 214, 5, 97, 0, 0, 214, 22, 1, 0, 0, 0, 215,
-		216, 5, 115, 0, 0, 216, 217, 5, 101, 0, 0, 217, 218, 5, 116, 0, 0, 218,
-		219, 5, 95, 0, 0, 219, 220, 5, 97, 0, 0, 220, 221, 5, 99, 0, 0, 221, 222,
-		5, 99, 0, 0, 222, 223, 5, 111, 0, 0, 223, 224, 5, 117, 0, 0, 224, 225,
-		5, 110, 0, 0, 225, 226, 5, 116, 0, 0, 226, 227, 5, 95, 0, 0, 227, 228,
-		5, 109, 0, 0, 228, 229, 5, 101, 0, 0, 229, 230, 5, 116, 0, 0, 230, 231,
-		5, 97, 0, 0, 231, 24, 1, 0, 0, 0, 232, 233, 5, 112, 0, 0, 233, 234, 5,
-		114, 0, 0, 234, 235, 5, 105, 0, 0, 235, 236, 5, 110, 0, 0, 236, 237, 5,
-		116, 0, 0, 237, 26, 1, 0, 0, 0, 238, 239, 5, 102, 0, 0, 239, 240, 5, 97,
-		0, 0, 240, 241, 5, 105, 0, 0, 241, 242, 5, 108, 0, 0, 242, 28, 1, 0, 0,
-		0, 243, 244, 5, 115, 0, 0, 244, 245, 5, 101, 0, 0, 245, 246, 5, 110, 0,
-		0, 246, 247, 5, 100, 0, 0, 247, 30, 1, 0, 0, 0, 248, 249, 5, 115, 0, 0,
-		249, 250, 5, 111, 0, 0, 250, 251, 5, 117, 0, 0, 251, 252, 5, 114, 0, 0,
-		252, 253, 5, 99, 0, 0, 253, 254, 5, 101, 0, 0, 254, 32, 1, 0, 0, 0, 255,
-		256, 5, 102, 0, 0, 256, 257, 5, 114, 0, 0, 257, 258, 5, 111, 0, 0, 258,
-		259, 5, 109, 0, 0, 259, 34, 1, 0, 0, 0, 260, 261, 5, 109, 0, 0, 261, 262,
-		5, 97, 0, 0, 262, 263, 5, 120, 0, 0, 263, 36, 1, 0, 0, 0, 264, 265, 5,
-		100, 0, 0, 265, 266, 5, 101, 0, 0, 266, 267, 5, 115, 0, 0, 267, 268, 5,
-		116, 0, 0, 268, 269, 5, 105, 0, 0, 269, 270, 5, 110, 0, 0, 270, 271, 5,
-		97, 0, 0, 271, 272, 5, 116, 0, 0, 272, 273, 5, 105, 0, 0, 273, 274, 5,
-		111, 0, 0, 274, 275, 5, 110, 0, 0, 275, 38, 1, 0, 0, 0, 276, 277, 5, 116,
-		0, 0, 277, 278, 5, 111, 0, 0, 278, 40, 1, 0, 0, 0, 279, 280, 5, 97, 0,
-		0, 280, 281, 5, 108, 0, 0, 281, 282, 5, 108, 0, 0, 282, 283, 5, 111, 0,
-		0, 283, 284, 5, 99, 0, 0, 284, 285, 5, 97, 0, 0, 285, 286, 5, 116, 0, 0,
-		286, 287, 5, 101, 0, 0, 287, 42, 1, 0, 0, 0, 288, 289, 5, 43, 0, 0, 289,
-		44, 1, 0, 0, 0, 290, 291, 5, 45, 0, 0, 291, 46, 1, 0, 0, 0, 292, 293, 5,
-		40, 0, 0, 293, 48, 1, 0, 0, 0, 294, 295, 5, 41, 0, 0, 295, 50, 1, 0, 0,
-		0, 296, 297, 5, 91, 0, 0, 297, 52, 1, 0, 0, 0, 298, 299, 5, 93, 0, 0, 299,
-		54, 1, 0, 0, 0, 300, 301, 5, 123, 0, 0, 301, 56, 1, 0, 0, 0, 302, 303,
-		5, 125, 0, 0, 303, 58, 1, 0, 0, 0, 304, 305, 5, 61, 0, 0, 305, 60, 1, 0,
-		0, 0, 306, 307, 5, 97, 0, 0, 307, 308, 5, 99, 0, 0, 308, 309, 5, 99, 0,
-		0, 309, 310, 5, 111, 0, 0, 310, 311, 5, 117, 0, 0, 311, 312, 5, 110, 0,
-		0, 312, 313, 5, 116, 0, 0, 313, 62, 1, 0, 0, 0, 314, 315, 5, 97, 0, 0,
-		315, 316, 5, 115, 0, 0, 316, 317, 5, 115, 0, 0, 317, 318, 5, 101, 0, 0,
-		318, 319, 5, 116, 0, 0, 319, 64, 1, 0, 0, 0, 320, 321, 5, 110, 0, 0, 321,
-		322, 5, 117, 0, 0, 322, 323, 5, 109, 0, 0, 323, 324, 5, 98, 0, 0, 324,
-		325, 5, 101, 0, 0, 325, 326, 5, 114, 0, 0, 326, 66, 1, 0, 0, 0, 327, 328,
-		5, 109, 0, 0, 328, 329, 5, 111, 0, 0, 329, 330, 5, 110, 0, 0, 330, 331,
-		5, 101, 0, 0, 331, 332, 5, 116, 0, 0, 332, 333, 5, 97, 0, 0, 333, 334,
-		5, 114, 0, 0, 334, 335, 5, 121, 0, 0, 335, 68, 1, 0, 0, 0, 336, 337, 5,
-		112, 0, 0, 337, 338, 5, 111, 0, 0, 338, 339, 5, 114, 0, 0, 339, 340, 5,
-		116, 0, 0, 340, 341, 5, 105, 0, 0, 341, 342, 5, 111, 0, 0, 342, 343, 5,
-		110, 0, 0, 343, 70, 1, 0, 0, 0, 344, 345, 5, 115, 0, 0, 345, 346, 5, 116,
-		0, 0, 346, 347, 5, 114, 0, 0, 347, 348, 5, 105, 0, 0, 348, 349, 5, 110,
-		0, 0, 349, 350, 5, 103, 0, 0, 350, 72, 1, 0, 0, 0, 351, 357, 5, 34, 0,
-		0, 352, 353, 5, 92, 0, 0, 353, 356, 5, 34, 0, 0, 354, 356, 8, 2, 0, 0,
-		355, 352, 1, 0, 0, 0, 355, 354, 1, 0, 0, 0, 356, 359, 1, 0, 0, 0, 357,
-		355, 1, 0, 0, 0, 357, 358, 1, 0, 0, 0, 358, 360, 1, 0, 0, 0, 359, 357,
-		1, 0, 0, 0, 360, 361, 5, 34, 0, 0, 361, 74, 1, 0, 0, 0, 362, 364, 7, 3,
-		0, 0, 363, 362, 1, 0, 0, 0, 364, 365, 1, 0, 0, 0, 365, 363, 1, 0, 0, 0,
-		365, 366, 1, 0, 0, 0, 366, 368, 1, 0, 0, 0, 367, 369, 7, 4, 0, 0, 368,
-		367, 1, 0, 0, 0, 368, 369, 1, 0, 0, 0, 369, 370, 1, 0, 0, 0, 370, 372,
-		5, 47, 0, 0, 371, 373, 7, 4, 0, 0, 372, 371, 1, 0, 0, 0, 372, 373, 1, 0,
-		0, 0, 373, 375, 1, 0, 0, 0, 374, 376, 7, 3, 0, 0, 375, 374, 1, 0, 0, 0,
-		376, 377, 1, 0, 0, 0, 377, 375, 1, 0, 0, 0, 377, 378, 1, 0, 0, 0, 378,
-		394, 1, 0, 0, 0, 379, 381, 7, 3, 0, 0, 380, 379, 1, 0, 0, 0, 381, 382,
-		1, 0, 0, 0, 382, 380, 1, 0, 0, 0, 382, 383, 1, 0, 0, 0, 383, 390, 1, 0,
-		0, 0, 384, 386, 5, 46, 0, 0, 385, 387, 7, 3, 0, 0, 386, 385, 1, 0, 0, 0,
-		387, 388, 1, 0, 0, 0, 388, 386, 1, 0, 0, 0, 388, 389, 1, 0, 0, 0, 389,
-		391, 1, 0, 0, 0, 390, 384, 1, 0, 0, 0, 390, 391, 1, 0, 0, 0, 391, 392,
-		1, 0, 0, 0, 392, 394, 5, 37, 0, 0, 393, 363, 1, 0, 0, 0, 393, 380, 1, 0,
-		0, 0, 394, 76, 1, 0, 0, 0, 395, 396, 5, 114, 0, 0, 396, 397, 5, 101, 0,
-		0, 397, 398, 5, 109, 0, 0, 398, 399, 5, 97, 0, 0, 399, 400, 5, 105, 0,
-		0, 400, 401, 5, 110, 0, 0, 401, 402, 5, 105, 0, 0, 402, 403, 5, 110, 0,
-		0, 403, 404, 5, 103, 0, 0, 404, 78, 1, 0, 0, 0, 405, 406, 5, 107, 0, 0,
-		406, 407, 5, 101, 0, 0, 407, 408, 5, 112, 0, 0, 408, 409, 5, 116, 0, 0,
-		409, 80, 1, 0, 0, 0, 410, 411, 5, 98, 0, 0, 411, 412, 5, 97, 0, 0, 412,
-		413, 5, 108, 0, 0, 413, 414, 5, 97, 0, 0, 414, 415, 5, 110, 0, 0, 415,
-		416, 5, 99, 0, 0, 416, 417, 5, 101, 0, 0, 417, 82, 1, 0, 0, 0, 418, 419,
-		5, 115, 0, 0, 419, 420, 5, 97, 0, 0, 420, 421, 5, 118, 0, 0, 421, 422,
-		5, 101, 0, 0, 422, 84, 1, 0, 0, 0, 423, 425, 7, 3, 0, 0, 424, 423, 1, 0,
-		0, 0, 425, 426, 1, 0, 0, 0, 426, 424, 1, 0, 0, 0, 426, 427, 1, 0, 0, 0,
-		427, 86, 1, 0, 0, 0, 428, 429, 5, 37, 0, 0, 429, 88, 1, 0, 0, 0, 430, 432,
-		5, 36, 0, 0, 431, 433, 7, 5, 0, 0, 432, 431, 1, 0, 0, 0, 433, 434, 1, 0,
-		0, 0, 434, 432, 1, 0, 0, 0, 434, 435, 1, 0, 0, 0, 435, 439, 1, 0, 0, 0,
-		436, 438, 7, 6, 0, 0, 437, 436, 1, 0, 0, 0, 438, 441, 1, 0, 0, 0, 439,
-		437, 1, 0, 0, 0, 439, 440, 1, 0, 0, 0, 440, 90, 1, 0, 0, 0, 441, 439, 1,
-		0, 0, 0, 442, 444, 5, 64, 0, 0, 443, 445, 7, 7, 0, 0, 444, 443, 1, 0, 0,
-		0, 445, 446, 1, 0, 0, 0, 446, 444, 1, 0, 0, 0, 446, 447, 1, 0, 0, 0, 447,
-		456, 1, 0, 0, 0, 448, 450, 5, 58, 0, 0, 449, 451, 7, 7, 0, 0, 450, 449,
-		1, 0, 0, 0, 451, 452, 1, 0, 0, 0, 452, 450, 1, 0, 0, 0, 452, 453, 1, 0,
-		0, 0, 453, 455, 1, 0, 0, 0, 454, 448, 1, 0, 0, 0, 455, 458, 1, 0, 0, 0,
-		456, 454, 1, 0, 0, 0, 456, 457, 1, 0, 0, 0, 457, 92, 1, 0, 0, 0, 458, 456,
-		1, 0, 0, 0, 459, 461, 7, 8, 0, 0, 460, 459, 1, 0, 0, 0, 461, 462, 1, 0,
-		0, 0, 462, 460, 1, 0, 0, 0, 462, 463, 1, 0, 0, 0, 463, 94, 1, 0, 0, 0,
-		23, 0, 156, 161, 170, 172, 186, 355, 357, 365, 368, 372, 377, 382, 388,
-		390, 393, 426, 434, 439, 446, 452, 456, 462, 1, 6, 0, 0,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1,
+		2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1,
+		2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1,
+		2, 1, 2, 1, 3, 4, 3, 189, 8, 3, 11, 3, 12, 3, 190, 1, 4, 4, 4, 194, 8,
+		4, 11, 4, 12, 4, 195, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 5, 5, 205,
+		8, 5, 10, 5, 12, 5, 208, 9, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6,
+		1, 6, 1, 6, 5, 6, 219, 8, 6, 10, 6, 12, 6, 222, 9, 6, 1, 6, 1, 6, 1, 6,
+		1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 9,
+		1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10,
+		1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1,
+		10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11,
+		1, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1,
+		13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15,
+		1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1,
+		17, 1, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19,
+		1, 20, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1,
+		22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23,
+		1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1,
+		24, 1, 24, 1, 25, 1, 25, 1, 25, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26,
+		1, 26, 1, 26, 1, 27, 1, 27, 1, 27, 1, 28, 1, 28, 1, 28, 1, 28, 1, 29, 1,
+		29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 30, 1, 30, 1, 31,
+		1, 31, 1, 32, 1, 32, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 35, 1, 35, 1,
+		35, 1, 36, 1, 36, 1, 36, 1, 37, 1, 37, 1, 37, 1, 38, 1, 38, 1, 39, 1, 39,
+		1, 40, 1, 40, 1, 41, 1, 41, 1, 42, 1, 42, 1, 43, 1, 43, 1, 44, 1, 44, 1,
+		45, 1, 45, 1, 46, 1, 46, 1, 47, 1, 47, 1, 47, 1, 47, 1, 47, 1, 47, 1, 47,
+		1, 47, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 49, 1, 49, 1, 49, 1,
+		49, 1, 49, 1, 49, 1, 49, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50,
+		1, 50, 1, 50, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1, 51, 1,
+		52, 1, 52, 1, 52, 1, 52, 1, 52, 1, 52, 1, 52, 1, 53, 1, 53, 1, 53, 1, 53,
+		1, 53, 1, 54, 1, 54, 1, 54, 1, 54, 5, 54, 466, 8, 54, 10, 54, 12, 54, 469,
+		9, 54, 1, 54, 1, 54, 1, 55, 4, 55, 474, 8, 55, 11, 55, 12, 55, 475, 1,
+		55, 3, 55, 479, 8, 55, 1, 55, 1, 55, 3, 55, 483, 8, 55, 1, 55, 4, 55, 486,
+		8, 55, 11, 55, 12, 55, 487, 1, 55, 4, 55, 491, 8, 55, 11, 55, 12, 55, 492,
+		1, 55, 1, 55, 4, 55, 497, 8, 55, 11, 55, 12, 55, 498, 3, 55, 501, 8, 55,
+		1, 55, 3, 55, 504, 8, 55, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1, 56, 1,
+		56, 1, 56, 1, 56, 1, 56, 1, 57, 1, 57, 1, 57, 1, 57, 1, 57, 1, 58, 1, 58,
+		1, 58, 1, 58, 1, 58, 1, 58, 1, 58, 1, 58, 1, 59, 1, 59, 1, 59, 1, 59, 1,
+		59, 1, 60, 4, 60, 535, 8, 60, 11, 60, 12, 60, 536, 1, 61, 1, 61, 1, 62,
+		1, 62, 4, 62, 543, 8, 62, 11, 62, 12, 62, 544, 1, 62, 5, 62, 548, 8, 62,
+		10, 62, 12, 62, 551, 9, 62, 1, 63, 1, 63, 4, 63, 555, 8, 63, 11, 63, 12,
+		63, 556, 1, 63, 1, 63, 4, 63, 561, 8, 63, 11, 63, 12, 63, 562, 5, 63, 565,
+		8, 63, 10, 63, 12, 63, 568, 9, 63, 1, 64, 4, 64, 571, 8, 64, 11, 64, 12,
+		64, 572, 2, 206, 220, 0, 65, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7,
+		15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33,
+		17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51,
+		26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31, 63, 32, 65, 33, 67, 34, 69,
+		35, 71, 36, 73, 37, 75, 38, 77, 39, 79, 40, 81, 41, 83, 42, 85, 43, 87,
+		44, 89, 45, 91, 46, 93, 47, 95, 48, 97, 49, 99, 50, 101, 51, 103, 52, 105,
+		53, 107, 54, 109, 55, 111, 56, 113, 57, 115, 58, 117, 59, 119, 60, 121,
+		61, 123, 62, 125, 63, 127, 64, 129, 65, 1, 0, 9, 2, 0, 10, 10, 13, 13,
+		2, 0, 9, 9, 32, 32, 3, 0, 10, 10, 13, 13, 34, 34, 1, 0, 48, 57, 1, 0, 32,
+		32, 2, 0, 95, 95, 97, 122, 3, 0, 48, 57, 95, 95, 97, 122, 5, 0, 45, 45,
+		48, 57, 65, 90, 95, 95, 97, 122, 2, 0, 47, 57, 65, 90, 595, 0, 1, 1, 0,
+		0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0,
+		0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1,
+		0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25,
+		1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0,
+		33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0,
+		0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0, 47, 1, 0, 0,
+		0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55, 1, 0,
+		0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0, 61, 1, 0, 0, 0, 0, 63, 1,
+		0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0, 0, 0, 0, 69, 1, 0, 0, 0, 0, 71,
+		1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1, 0, 0, 0, 0, 77, 1, 0, 0, 0, 0,
+		79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83, 1, 0, 0, 0, 0, 85, 1, 0, 0, 0,
+		0, 87, 1, 0, 0, 0, 0, 89, 1, 0, 0, 0, 0, 91, 1, 0, 0, 0, 0, 93, 1, 0, 0,
+		0, 0, 95, 1, 0, 0, 0, 0, 97, 1, 0, 0, 0, 0, 99, 1, 0, 0, 0, 0, 101, 1,
+		0, 0, 0, 0, 103, 1, 0, 0, 0, 0, 105, 1, 0, 0, 0, 0, 107, 1, 0, 0, 0, 0,
+		109, 1, 0, 0, 0, 0, 111, 1, 0, 0, 0, 0, 113, 1, 0, 0, 0, 0, 115, 1, 0,
+		0, 0, 0, 117, 1, 0, 0, 0, 0, 119, 1, 0, 0, 0, 0, 121, 1, 0, 0, 0, 0, 123,
+		1, 0, 0, 0, 0, 125, 1, 0, 0, 0, 0, 127, 1, 0, 0, 0, 0, 129, 1, 0, 0, 0,
+		1, 131, 1, 0, 0, 0, 3, 133, 1, 0, 0, 0, 5, 158, 1, 0, 0, 0, 7, 188, 1,
+		0, 0, 0, 9, 193, 1, 0, 0, 0, 11, 199, 1, 0, 0, 0, 13, 214, 1, 0, 0, 0,
+		15, 227, 1, 0, 0, 0, 17, 232, 1, 0, 0, 0, 19, 237, 1, 0, 0, 0, 21, 249,
+		1, 0, 0, 0, 23, 266, 1, 0, 0, 0, 25, 272, 1, 0, 0, 0, 27, 277, 1, 0, 0,
+		0, 29, 284, 1, 0, 0, 0, 31, 287, 1, 0, 0, 0, 33, 292, 1, 0, 0, 0, 35, 297,
+		1, 0, 0, 0, 37, 304, 1, 0, 0, 0, 39, 309, 1, 0, 0, 0, 41, 313, 1, 0, 0,
+		0, 43, 317, 1, 0, 0, 0, 45, 323, 1, 0, 0, 0, 47, 328, 1, 0, 0, 0, 49, 334,
+		1, 0, 0, 0, 51, 346, 1, 0, 0, 0, 53, 349, 1, 0, 0, 0, 55, 357, 1, 0, 0,
+		0, 57, 360, 1, 0, 0, 0, 59, 364, 1, 0, 0, 0, 61, 373, 1, 0, 0, 0, 63, 375,
+		1, 0, 0, 0, 65, 377, 1, 0, 0, 0, 67, 379, 1, 0, 0, 0, 69, 381, 1, 0, 0,
+		0, 71, 384, 1, 0, 0, 0, 73, 387, 1, 0, 0, 0, 75, 390, 1, 0, 0, 0, 77, 393,
+		1, 0, 0, 0, 79, 395, 1, 0, 0, 0, 81, 397, 1, 0, 0, 0, 83, 399, 1, 0, 0,
+		0, 85, 401, 1, 0, 0, 0, 87, 403, 1, 0, 0, 0, 89, 405, 1, 0, 0, 0, 91, 407,
+		1, 0, 0, 0, 93, 409, 1, 0, 0, 0, 95, 411, 1, 0, 0, 0, 97, 419, 1, 0, 0,
+		0, 99, 425, 1, 0, 0, 0, 101, 432, 1, 0, 0, 0, 103, 441, 1, 0, 0, 0, 105,
+		449, 1, 0, 0, 0, 107, 456, 1, 0, 0, 0, 109, 461, 1, 0, 0, 0, 111, 503,
+		1, 0, 0, 0, 113, 505, 1, 0, 0, 0, 115, 515, 1, 0, 0, 0, 117, 520, 1, 0,
+		0, 0, 119, 528, 1, 0, 0, 0, 121, 534, 1, 0, 0, 0, 123, 538, 1, 0, 0, 0,
+		125, 540, 1, 0, 0, 0, 127, 552, 1, 0, 0, 0, 129, 570, 1, 0, 0, 0, 131,
+		132, 5, 44, 0, 0, 132, 2, 1, 0, 0, 0, 133, 134, 5, 97, 0, 0, 134, 135,
+		5, 108, 0, 0, 135, 136, 5, 108, 0, 0, 136, 137, 5, 111, 0, 0, 137, 138,
+		5, 119, 0, 0, 138, 139, 5, 105, 0, 0, 139, 140, 5, 110, 0, 0, 140, 141,
+		5, 103, 0, 0, 141, 142, 5, 32, 0, 0, 142, 143, 5, 111, 0, 0, 143, 144,
+		5, 118, 0, 0, 144, 145, 5, 101, 0, 0, 145, 146, 5, 114, 0, 0, 146, 147,
+		5, 100, 0, 0, 147, 148, 5, 114, 0, 0, 148, 149, 5, 97, 0, 0, 149, 150,
+		5, 102, 0, 0, 150, 151, 5, 116, 0, 0, 151, 152, 5, 32, 0, 0, 152, 153,
+		5, 117, 0, 0, 153, 154, 5, 112, 0, 0, 154, 155, 5, 32, 0, 0, 155, 156,
+		5, 116, 0, 0, 156, 157, 5, 111, 0, 0, 157, 4, 1, 0, 0, 0, 158, 159, 5,
+		97, 0, 0, 159, 160, 5, 108, 0, 0, 160, 161, 5, 108, 0, 0, 161, 162, 5,
+		111, 0, 0, 162, 163, 5, 119, 0, 0, 163, 164, 5, 105, 0, 0, 164, 165, 5,
+		110, 0, 0, 165, 166, 5, 103, 0, 0, 166, 167, 5, 32, 0, 0, 167, 168, 5,
+		117, 0, 0, 168, 169, 5, 110, 0, 0, 169, 170, 5, 98, 0, 0, 170, 171, 5,
+		111, 0, 0, 171, 172, 5, 117, 0, 0, 172, 173, 5, 110, 0, 0, 173, 174, 5,
+		100, 0, 0, 174, 175, 5, 101, 0, 0, 175, 176, 5, 100, 0, 0, 176, 177, 5,
+		32, 0, 0, 177, 178, 5, 111, 0, 0, 178, 179, 5, 118, 0, 0, 179, 180, 5,
+		101, 0, 0, 180, 181, 5, 114, 0, 0, 181, 182, 5, 100, 0, 0, 182, 183, 5,
+		114, 0, 0, 183, 184, 5, 97, 0, 0, 184, 185, 5, 102, 0, 0, 185, 186, 5,
+		116, 0, 0, 186, 6, 1, 0, 0, 0, 187, 189, 7, 0, 0, 0, 188, 187, 1, 0, 0,
+		0, 189, 190, 1, 0, 0, 0, 190, 188, 1, 0, 0, 0, 190, 191, 1, 0, 0, 0, 191,
+		8, 1, 0, 0, 0, 192, 194, 7, 1, 0, 0, 193, 192, 1, 0, 0, 0, 194, 195, 1,
+		0, 0, 0, 195, 193, 1, 0, 0, 0, 195, 196, 1, 0, 0, 0, 196, 197, 1, 0, 0,
+		0, 197, 198, 6, 4, 0, 0, 198, 10, 1, 0, 0, 0, 199, 200, 5, 47, 0, 0, 200,
+		201, 5, 42, 0, 0, 201, 206, 1, 0, 0, 0, 202, 205, 3, 11, 5, 0, 203, 205,
+		9, 0, 0, 0, 204, 202, 1, 0, 0, 0, 204, 203, 1, 0, 0, 0, 205, 208, 1, 0,
+		0, 0, 206, 207, 1, 0, 0, 0, 206, 204, 1, 0, 0, 0, 207, 209, 1, 0, 0, 0,
+		208, 206, 1, 0, 0, 0, 209, 210, 5, 42, 0, 0, 210, 211, 5, 47, 0, 0, 211,
+		212, 1, 0, 0, 0, 212, 213, 6, 5, 0, 0, 213, 12, 1, 0, 0, 0, 214, 215, 5,
+		47, 0, 0, 215, 216, 5, 47, 0, 0, 216, 220, 1, 0, 0, 0, 217, 219, 9, 0,
+		0, 0, 218, 217, 1, 0, 0, 0, 219, 222, 1, 0, 0, 0, 220, 221, 1, 0, 0, 0,
+		220, 218, 1, 0, 0, 0, 221, 223, 1, 0, 0, 0, 222, 220, 1, 0, 0, 0, 223,
+		224, 3, 7, 3, 0, 224, 225, 1, 0, 0, 0, 225, 226, 6, 6, 0, 0, 226, 14, 1,
+		0, 0, 0, 227, 228, 5, 118, 0, 0, 228, 229, 5, 97, 0, 0, 229, 230, 5, 114,
+		0, 0, 230, 231, 5, 115, 0, 0, 231, 16, 1, 0, 0, 0, 232, 233, 5, 109, 0,
+		0, 233, 234, 5, 101, 0, 0, 234, 235, 5, 116, 0, 0, 235, 236, 5, 97, 0,
+		0, 236, 18, 1, 0, 0, 0, 237, 238, 5, 115, 0, 0, 238, 239, 5, 101, 0, 0,
+		239, 240, 5, 116, 0, 0, 240, 241, 5, 95, 0, 0, 241, 242, 5, 116, 0, 0,
+		242, 243, 5, 120, 0, 0, 243, 244, 5, 95, 0, 0, 244, 245, 5, 109, 0, 0,
+		245, 246, 5, 101, 0, 0, 246, 247, 5, 116, 0, 0, 247, 248, 5, 97, 0, 0,
+		248, 20, 1, 0, 0, 0, 249, 250, 5, 115, 0, 0, 250, 251, 5, 101, 0, 0, 251,
+		252, 5, 116, 0, 0, 252, 253, 5, 95, 0, 0, 253, 254, 5, 97, 0, 0, 254, 255,
+		5, 99, 0, 0, 255, 256, 5, 99, 0, 0, 256, 257, 5, 111, 0, 0, 257, 258, 5,
+		117, 0, 0, 258, 259, 5, 110, 0, 0, 259, 260, 5, 116, 0, 0, 260, 261, 5,
+		95, 0, 0, 261, 262, 5, 109, 0, 0, 262, 263, 5, 101, 0, 0, 263, 264, 5,
+		116, 0, 0, 264, 265, 5, 97, 0, 0, 265, 22, 1, 0, 0, 0, 266, 267, 5, 112,
+		0, 0, 267, 268, 5, 114, 0, 0, 268, 269, 5, 105, 0, 0, 269, 270, 5, 110,
+		0, 0, 270, 271, 5, 116, 0, 0, 271, 24, 1, 0, 0, 0, 272, 273, 5, 102, 0,
+		0, 273, 274, 5, 97, 0, 0, 274, 275, 5, 105, 0, 0, 275, 276, 5, 108, 0,
+		0, 276, 26, 1, 0, 0, 0, 277, 278, 5, 97, 0, 0, 278, 279, 5, 115, 0, 0,
+		279, 280, 5, 115, 0, 0, 280, 281, 5, 101, 0, 0, 281, 282, 5, 114, 0, 0,
+		282, 283, 5, 116, 0, 0, 283, 28, 1, 0, 0, 0, 284, 285, 5, 105, 0, 0, 285,
+		286, 5, 102, 0, 0, 286, 30, 1, 0, 0, 0, 287, 288, 5, 101, 0, 0, 288, 289,
+		5, 108, 0, 0, 289, 290, 5, 115, 0, 0, 290, 291, 5, 101, 0, 0, 291, 32,
+		1, 0, 0, 0, 292, 293, 5, 115, 0, 0, 293, 294, 5, 101, 0, 0, 294, 295, 5,
+		110, 0, 0, 295, 296, 5, 100, 0, 0, 296, 34, 1, 0, 0, 0, 297, 298, 5, 115,
+		0, 0, 298, 299, 5, 111, 0, 0, 299, 300, 5, 117, 0, 0, 300, 301, 5, 114,
+		0, 0, 301, 302, 5, 99, 0, 0, 302, 303, 5, 101, 0, 0, 303, 36, 1, 0, 0,
+		0, 304, 305, 5, 102, 0, 0, 305, 306, 5, 114, 0, 0, 306, 307, 5, 111, 0,
+		0, 307, 308, 5, 109, 0, 0, 308, 38, 1, 0, 0, 0, 309, 310, 5, 109, 0, 0,
+		310, 311, 5, 97, 0, 0, 311, 312, 5, 120, 0, 0, 312, 40, 1, 0, 0, 0, 313,
+		314, 5, 109, 0, 0, 314, 315, 5, 105, 0, 0, 315, 316, 5, 110, 0, 0, 316,
+		42, 1, 0, 0, 0, 317, 318, 5, 102, 0, 0, 318, 319, 5, 108, 0, 0, 319, 320,
+		5, 111, 0, 0, 320, 321, 5, 111, 0, 0, 321, 322, 5, 114, 0, 0, 322, 44,
+		1, 0, 0, 0, 323, 324, 5, 99, 0, 0, 324, 325, 5, 101, 0, 0, 325, 326, 5,
+		105, 0, 0, 326, 327, 5, 108, 0, 0, 327, 46, 1, 0, 0, 0, 328, 329, 5, 114,
+		0, 0, 329, 330, 5, 111, 0, 0, 330, 331, 5, 117, 0, 0, 331, 332, 5, 110,
+		0, 0, 332, 333, 5, 100, 0, 0, 333, 48, 1, 0, 0, 0, 334, 335, 5, 100, 0,
+		0, 335, 336, 5, 101, 0, 0, 336, 337, 5, 115, 0, 0, 337, 338, 5, 116, 0,
+		0, 338, 339, 5, 105, 0, 0, 339, 340, 5, 110, 0, 0, 340, 341, 5, 97, 0,
+		0, 341, 342, 5, 116, 0, 0, 342, 343, 5, 105, 0, 0, 343, 344, 5, 111, 0,
+		0, 344, 345, 5, 110, 0, 0, 345, 50, 1, 0, 0, 0, 346, 347, 5, 116, 0, 0,
+		347, 348, 5, 111, 0, 0, 348, 52, 1, 0, 0, 0, 349, 350, 5, 99, 0, 0, 350,
+		351, 5, 111, 0, 0, 351, 352, 5, 110, 0, 0, 352, 353, 5, 118, 0, 0, 353,
+		354, 5, 101, 0, 0, 354, 355, 5, 114, 0, 0, 355, 356, 5, 116, 0, 0, 356,
+		54, 1, 0, 0, 0, 357, 358, 5, 97, 0, 0, 358, 359, 5, 116, 0, 0, 359, 56,
+		1, 0, 0, 0, 360, 361, 5, 118, 0, 0, 361, 362, 5, 105, 0, 0, 362, 363, 5,
+		97, 0, 0, 363, 58, 1, 0, 0, 0, 364, 365, 5, 97, 0, 0, 365, 366, 5, 108,
+		0, 0, 366, 367, 5, 108, 0, 0, 367, 368, 5, 111, 0, 0, 368, 369, 5, 99,
+		0, 0, 369, 370, 5, 97, 0, 0, 370, 371, 5, 116, 0, 0, 371, 372, 5, 101,
+		0, 0, 372, 60, 1, 0, 0, 0, 373, 374, 5, 43, 0, 0, 374, 62, 1, 0, 0, 0,
+		375, 376, 5, 45, 0, 0, 376, 64, 1, 0, 0, 0, 377, 378, 5, 42, 0, 0, 378,
+		66, 1, 0, 0, 0, 379, 380, 5, 47, 0, 0, 380, 68, 1, 0, 0, 0, 381, 382, 5,
+		61, 0, 0, 382, 383, 5, 61, 0, 0, 383, 70, 1, 0, 0, 0, 384, 385, 5, 33,
+		0, 0, 385, 386, 5, 61, 0, 0, 386, 72, 1, 0, 0, 0, 387, 388, 5, 60, 0, 0,
+		388, 389, 5, 61, 0, 0, 389, 74, 1, 0, 0, 0, 390, 391, 5, 62, 0, 0, 391,
+		392, 5, 61, 0, 0, 392, 76, 1, 0, 0, 0, 393, 394, 5, 60, 0, 0, 394, 78,
+		1, 0, 0, 0, 395, 396, 5, 62, 0, 0, 396, 80, 1, 0, 0, 0, 397, 398, 5, 40,
+		0, 0, 398, 82, 1, 0, 0, 0, 399, 400, 5, 41, 0, 0, 400, 84, 1, 0, 0, 0,
+		401, 402, 5, 91, 0, 0, 402, 86, 1, 0, 0, 0, 403, 404, 5, 93, 0, 0, 404,
+		88, 1, 0, 0, 0, 405, 406, 5, 123, 0, 0, 406, 90, 1, 0, 0, 0, 407, 408,
+		5, 125, 0, 0, 408, 92, 1, 0, 0, 0, 409, 410, 5, 61, 0, 0, 410, 94, 1, 0,
+		0, 0, 411, 412, 5, 97, 0, 0, 412, 413, 5, 99, 0, 0, 413, 414, 5, 99, 0,
+		0, 414, 415, 5, 111, 0, 0, 415, 416, 5, 117, 0, 0, 416, 417, 5, 110, 0,
+		0, 417, 418, 5, 116, 0, 0, 418, 96, 1, 0, 0, 0, 419, 420, 5, 97, 0, 0,
+		420, 421, 5, 115, 0, 0, 421, 422, 5, 115, 0, 0, 422, 423, 5, 101, 0, 0,
+		423, 424, 5, 116, 0, 0, 424, 98, 1, 0, 0, 0, 425, 426, 5, 110, 0, 0, 426,
+		427, 5, 117, 0, 0, 427, 428, 5, 109, 0, 0, 428, 429, 5, 98, 0, 0, 429,
+		430, 5, 101, 0, 0, 430, 431, 5, 114, 0, 0, 431, 100, 1, 0, 0, 0, 432, 433,
+		5, 109, 0, 0, 433, 434, 5, 111, 0, 0, 434, 435, 5, 110, 0, 0, 435, 436,
+		5, 101, 0, 0, 436, 437, 5, 116, 0, 0, 437, 438, 5, 97, 0, 0, 438, 439,
+		5, 114, 0, 0, 439, 440, 5, 121, 0, 0, 440, 102, 1, 0, 0, 0, 441, 442, 5,
+		112, 0, 0, 442, 443, 5, 111, 0, 0, 443, 444, 5, 114, 0, 0, 444, 445, 5,
+		116, 0, 0, 445, 446, 5, 105, 0, 0, 446, 447, 5, 111, 0, 0, 447, 448, 5,
+		110, 0, 0, 448, 104, 1, 0, 0, 0, 449, 450, 5, 115, 0, 0, 450, 451, 5, 116,
+		0, 0, 451, 452, 5, 114, 0, 0, 452, 453, 5, 105, 0, 0, 453, 454, 5, 110,
+		0, 0, 454, 455, 5, 103, 0, 0, 455, 106, 1, 0, 0, 0, 456, 457, 5, 114, 0,
+		0, 457, 458, 5, 97, 0, 0, 458, 459, 5, 116, 0, 0, 459, 460, 5, 101, 0,
+		0, 460, 108, 1, 0, 0, 0, 461, 467, 5, 34, 0, 0, 462, 463, 5, 92, 0, 0,
+		463, 466, 5, 34, 0, 0, 464, 466, 8, 2, 0, 0, 465, 462, 1, 0, 0, 0, 465,
+		464, 1, 0, 0, 0, 466, 469, 1, 0, 0, 0, 467, 465, 1, 0, 0, 0, 467, 468,
+		1, 0, 0, 0, 468, 470, 1, 0, 0, 0, 469, 467, 1, 0, 0, 0, 470, 471, 5, 34,
+		0, 0, 471, 110, 1, 0, 0, 0, 472, 474, 7, 3, 0, 0, 473, 472, 1, 0, 0, 0,
+		474, 475, 1, 0, 0, 0, 475, 473, 1, 0, 0, 0, 475, 476, 1, 0, 0, 0, 476,
+		478, 1, 0, 0, 0, 477, 479, 7, 4, 0, 0, 478, 477, 1, 0, 0, 0, 478, 479,
+		1, 0, 0, 0, 479, 480, 1, 0, 0, 0, 480, 482, 5, 47, 0, 0, 481, 483, 7, 4,
+		0, 0, 482, 481, 1, 0, 0, 0, 482, 483, 1, 0, 0, 0, 483, 485, 1, 0, 0, 0,
+		484, 486, 7, 3, 0, 0, 485, 484, 1, 0, 0, 0, 486, 487, 1, 0, 0, 0, 487,
+		485, 1, 0, 0, 0, 487, 488, 1, 0, 0, 0, 488, 504, 1, 0, 0, 0, 489, 491,
+		7, 3, 0, 0, 490, 489, 1, 0, 0, 0, 491, 492, 1, 0, 0, 0, 492, 490, 1, 0,
+		0, 0, 492, 493, 1, 0, 0, 0, 493, 500, 1, 0, 0, 0, 494, 496, 5, 46, 0, 0,
+		495, 497, 7, 3, 0, 0, 496, 495, 1, 0, 0, 0, 497, 498, 1, 0, 0, 0, 498,
+		496, 1, 0, 0, 0, 498, 499, 1, 0, 0, 0, 499, 501, 1, 0, 0, 0, 500, 494,
+		1, 0, 0, 0, 500, 501, 1, 0, 0, 0, 501, 502, 1, 0, 0, 0, 502, 504, 5, 37,
+		0, 0, 503, 473, 1, 0, 0, 0, 503, 490, 1, 0, 0, 0, 504, 112, 1, 0, 0, 0,
+		505, 506, 5, 114, 0, 0, 506, 507, 5, 101, 0, 0, 507, 508, 5, 109, 0, 0,
+		508, 509, 5, 97, 0, 0, 509, 510, 5, 105, 0, 0, 510, 511, 5, 110, 0, 0,
+		511, 512, 5, 105, 0, 0, 512, 513, 5, 110, 0, 0, 513, 514, 5, 103, 0, 0,
+		514, 114, 1, 0, 0, 0, 515, 516, 5, 107, 0, 0, 516, 517, 5, 101, 0, 0, 517,
+		518, 5, 112, 0, 0, 518, 519, 5, 116, 0, 0, 519, 116, 1, 0, 0, 0, 520, 521,
+		5, 98, 0, 0, 521, 522, 5, 97, 0, 0, 522, 523, 5, 108, 0, 0, 523, 524, 5,
+		97, 0, 0, 524, 525, 5, 110, 0, 0, 525, 526, 5, 99, 0, 0, 526, 527, 5, 101,
+		0, 0, 527, 118, 1, 0, 0, 0, 528, 529, 5, 115, 0, 0, 529, 530, 5, 97, 0,
+		0, 530, 531, 5, 118, 0, 0, 531, 532, 5, 101, 0, 0, 532, 120, 1, 0, 0, 0,
+		533, 535, 7, 3, 0, 0, 534, 533, 1, 0, 0, 0, 535, 536, 1, 0, 0, 0, 536,
+		534, 1, 0, 0, 0, 536, 537, 1, 0, 0, 0, 537, 122, 1, 0, 0, 0, 538, 539,
+		5, 37, 0, 0, 539, 124, 1, 0, 0, 0, 540, 542, 5, 36, 0, 0, 541, 543, 7,
+		5, 0, 0, 542, 541, 1, 0, 0, 0, 543, 544, 1, 0, 0, 0, 544, 542, 1, 0, 0,
+		0, 544, 545, 1, 0, 0, 0, 545, 549, 1, 0, 0, 0, 546, 548, 7, 6, 0, 0, 547,
+		546, 1, 0, 0, 0, 548, 551, 1, 0, 0, 0, 549, 547, 1, 0, 0, 0, 549, 550,
+		1, 0, 0, 0, 550, 126, 1, 0, 0, 0, 551, 549, 1, 0, 0, 0, 552, 554, 5, 64,
+		0, 0, 553, 555, 7, 7, 0, 0, 554, 553, 1, 0, 0, 0, 555, 556, 1, 0, 0, 0,
+		556, 554, 1, 0, 0, 0, 556, 557, 1, 0, 0, 0, 557, 566, 1, 0, 0, 0, 558,
+		560, 5, 58, 0, 0, 559, 561, 7, 7, 0, 0, 560, 559, 1, 0, 0, 0, 561, 562,
+		1, 0, 0, 0, 562, 560, 1, 0, 0, 0, 562, 563, 1, 0, 0, 0, 563, 565, 1, 0,
+		0, 0, 564, 558, 1, 0, 0, 0, 565, 568, 1, 0, 0, 0, 566, 564, 1, 0, 0, 0,
+		566, 567, 1, 0, 0, 0, 567, 128, 1, 0, 0, 0, 568, 566, 1, 0, 0, 0, 569,
+		571, 7, 8, 0, 0, 570, 569, 1, 0, 0, 0, 571, 572, 1, 0, 0, 0, 572, 570,
+		1, 0, 0, 0, 572, 573, 1, 0, 0, 0, 573, 130, 1, 0, 0, 0, 23, 0, 190, 195,
+		204, 206, 220, 465, 467, 475, 478, 482, 487, 492, 498, 500, 503, 536, 544,
+		549, 556, 562, 566, 572, 1, 6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -322,48 +376,66 @@ const (
 	NumScriptLexerT__0              = 1
 	NumScriptLexerT__1              = 2
 	NumScriptLexerT__2              = 3
-	NumScriptLexerT__3              = 4
-	NumScriptLexerNEWLINE           = 5
-	NumScriptLexerWHITESPACE        = 6
-	NumScriptLexerMULTILINE_COMMENT = 7
-	NumScriptLexerLINE_COMMENT      = 8
-	NumScriptLexerVARS              = 9
-	NumScriptLexerMETA              = 10
-	NumScriptLexerSET_TX_META       = 11
-	NumScriptLexerSET_ACCOUNT_META  = 12
-	NumScriptLexerPRINT             = 13
-	NumScriptLexerFAIL              = 14
-	NumScriptLexerSEND              = 15
-	NumScriptLexerSOURCE            = 16
-	NumScriptLexerFROM              = 17
-	NumScriptLexerMAX               = 18
-	NumScriptLexerDESTINATION       = 19
-	NumScriptLexerTO                = 20
-	NumScriptLexerALLOCATE          = 21
-	NumScriptLexerOP_ADD            = 22
-	NumScriptLexerOP_SUB            = 23
-	NumScriptLexerLPAREN            = 24
-	NumScriptLexerRPAREN            = 25
-	NumScriptLexerLBRACK            = 26
-	NumScriptLexerRBRACK            = 27
-	NumScriptLexerLBRACE            = 28
-	NumScriptLexerRBRACE            = 29
-	NumScriptLexerEQ                = 30
-	NumScriptLexerTY_ACCOUNT        = 31
-	NumScriptLexerTY_ASSET          = 32
-	NumScriptLexerTY_NUMBER         = 33
-	NumScriptLexerTY_MONETARY       = 34
-	NumScriptLexerTY_PORTION        = 35
-	NumScriptLexerTY_STRING         = 36
-	NumScriptLexerSTRING            = 37
-	NumScriptLexerPORTION           = 38
-	NumScriptLexerREMAINING         = 39
-	NumScriptLexerKEPT              = 40
-	NumScriptLexerBALANCE           = 41
-	NumScriptLexerSAVE              = 42
-	NumScriptLexerNUMBER            = 43
-	NumScriptLexerPERCENT           = 44
-	NumScriptLexerVARIABLE_NAME     = 45
-	NumScriptLexerACCOUNT           = 46
-	NumScriptLexerASSET             = 47
+	NumScriptLexerNEWLINE           = 4
+	NumScriptLexerWHITESPACE        = 5
+	NumScriptLexerMULTILINE_COMMENT = 6
+	NumScriptLexerLINE_COMMENT      = 7
+	NumScriptLexerVARS              = 8
+	NumScriptLexerMETA              = 9
+	NumScriptLexerSET_TX_META       = 10
+	NumScriptLexerSET_ACCOUNT_META  = 11
+	NumScriptLexerPRINT             = 12
+	NumScriptLexerFAIL              = 13
+	NumScriptLexerASSERT            = 14
+	NumScriptLexerIF                = 15
+	NumScriptLexerELSE              = 16
+	NumScriptLexerSEND              = 17
+	NumScriptLexerSOURCE            = 18
+	NumScriptLexerFROM              = 19
+	NumScriptLexerMAX               = 20
+	NumScriptLexerMIN               = 21
+	NumScriptLexerFLOOR             = 22
+	NumScriptLexerCEIL              = 23
+	NumScriptLexerROUND             = 24
+	NumScriptLexerDESTINATION       = 25
+	NumScriptLexerTO                = 26
+	NumScriptLexerCONVERT           = 27
+	NumScriptLexerAT                = 28
+	NumScriptLexerVIA               = 29
+	NumScriptLexerALLOCATE          = 30
+	NumScriptLexerOP_ADD            = 31
+	NumScriptLexerOP_SUB            = 32
+	NumScriptLexerOP_MUL            = 33
+	NumScriptLexerOP_DIV            = 34
+	NumScriptLexerOP_EQ             = 35
+	NumScriptLexerOP_NEQ            = 36
+	NumScriptLexerOP_LTE            = 37
+	NumScriptLexerOP_GTE            = 38
+	NumScriptLexerOP_LT             = 39
+	NumScriptLexerOP_GT             = 40
+	NumScriptLexerLPAREN            = 41
+	NumScriptLexerRPAREN            = 42
+	NumScriptLexerLBRACK            = 43
+	NumScriptLexerRBRACK            = 44
+	NumScriptLexerLBRACE            = 45
+	NumScriptLexerRBRACE            = 46
+	NumScriptLexerEQ                = 47
+	NumScriptLexerTY_ACCOUNT        = 48
+	NumScriptLexerTY_ASSET          = 49
+	NumScriptLexerTY_NUMBER         = 50
+	NumScriptLexerTY_MONETARY       = 51
+	NumScriptLexerTY_PORTION        = 52
+	NumScriptLexerTY_STRING         = 53
+	NumScriptLexerTY_RATE           = 54
+	NumScriptLexerSTRING            = 55
+	NumScriptLexerPORTION           = 56
+	NumScriptLexerREMAINING         = 57
+	NumScriptLexerKEPT              = 58
+	NumScriptLexerBALANCE           = 59
+	NumScriptLexerSAVE              = 60
+	NumScriptLexerNUMBER            = 61
+	NumScriptLexerPERCENT           = 62
+	NumScriptLexerVARIABLE_NAME     = 63
+	NumScriptLexerACCOUNT           = 64
+	NumScriptLexerASSET             = 65
 )
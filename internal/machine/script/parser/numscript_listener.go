@@ -35,12 +35,24 @@ type NumScriptListener interface {
 	// EnterVariable is called when entering the variable production.
 	EnterVariable(c *VariableContext)
 
+	// EnterExprRound is called when entering the ExprRound production.
+	EnterExprRound(c *ExprRoundContext)
+
 	// EnterExprAddSub is called when entering the ExprAddSub production.
 	EnterExprAddSub(c *ExprAddSubContext)
 
+	// EnterExprMulDiv is called when entering the ExprMulDiv production.
+	EnterExprMulDiv(c *ExprMulDivContext)
+
+	// EnterExprParens is called when entering the ExprParens production.
+	EnterExprParens(c *ExprParensContext)
+
 	// EnterExprLiteral is called when entering the ExprLiteral production.
 	EnterExprLiteral(c *ExprLiteralContext)
 
+	// EnterExprMinMax is called when entering the ExprMinMax production.
+	EnterExprMinMax(c *ExprMinMaxContext)
+
 	// EnterExprVariable is called when entering the ExprVariable production.
 	EnterExprVariable(c *ExprVariableContext)
 
@@ -74,6 +86,15 @@ type NumScriptListener interface {
 	// EnterDestAllotment is called when entering the DestAllotment production.
 	EnterDestAllotment(c *DestAllotmentContext)
 
+	// EnterCondBalance is called when entering the CondBalance production.
+	EnterCondBalance(c *CondBalanceContext)
+
+	// EnterCondExpr is called when entering the CondExpr production.
+	EnterCondExpr(c *CondExprContext)
+
+	// EnterCondition is called when entering the condition production.
+	EnterCondition(c *ConditionContext)
+
 	// EnterSrcAccountOverdraftSpecific is called when entering the SrcAccountOverdraftSpecific production.
 	EnterSrcAccountOverdraftSpecific(c *SrcAccountOverdraftSpecificContext)
 
@@ -122,9 +143,21 @@ type NumScriptListener interface {
 	// EnterFail is called when entering the Fail production.
 	EnterFail(c *FailContext)
 
+	// EnterAssert is called when entering the Assert production.
+	EnterAssert(c *AssertContext)
+
+	// EnterIf is called when entering the If production.
+	EnterIf(c *IfContext)
+
 	// EnterSend is called when entering the Send production.
 	EnterSend(c *SendContext)
 
+	// EnterConvert is called when entering the Convert production.
+	EnterConvert(c *ConvertContext)
+
+	// EnterBlock is called when entering the block production.
+	EnterBlock(c *BlockContext)
+
 	// EnterType_ is called when entering the type_ production.
 	EnterType_(c *Type_Context)
 
@@ -170,12 +203,24 @@ type NumScriptListener interface {
 	// ExitVariable is called when exiting the variable production.
 	ExitVariable(c *VariableContext)
 
+	// ExitExprRound is called when exiting the ExprRound production.
+	ExitExprRound(c *ExprRoundContext)
+
 	// ExitExprAddSub is called when exiting the ExprAddSub production.
 	ExitExprAddSub(c *ExprAddSubContext)
 
+	// ExitExprMulDiv is called when exiting the ExprMulDiv production.
+	ExitExprMulDiv(c *ExprMulDivContext)
+
+	// ExitExprParens is called when exiting the ExprParens production.
+	ExitExprParens(c *ExprParensContext)
+
 	// ExitExprLiteral is called when exiting the ExprLiteral production.
 	ExitExprLiteral(c *ExprLiteralContext)
 
+	// ExitExprMinMax is called when exiting the ExprMinMax production.
+	ExitExprMinMax(c *ExprMinMaxContext)
+
 	// ExitExprVariable is called when exiting the ExprVariable production.
 	ExitExprVariable(c *ExprVariableContext)
 
@@ -209,6 +254,15 @@ type NumScriptListener interface {
 	// ExitDestAllotment is called when exiting the DestAllotment production.
 	ExitDestAllotment(c *DestAllotmentContext)
 
+	// ExitCondBalance is called when exiting the CondBalance production.
+	ExitCondBalance(c *CondBalanceContext)
+
+	// ExitCondExpr is called when exiting the CondExpr production.
+	ExitCondExpr(c *CondExprContext)
+
+	// ExitCondition is called when exiting the condition production.
+	ExitCondition(c *ConditionContext)
+
 	// ExitSrcAccountOverdraftSpecific is called when exiting the SrcAccountOverdraftSpecific production.
 	ExitSrcAccountOverdraftSpecific(c *SrcAccountOverdraftSpecificContext)
 
@@ -257,9 +311,21 @@ type NumScriptListener interface {
 	// ExitFail is called when exiting the Fail production.
 	ExitFail(c *FailContext)
 
+	// ExitAssert is called when exiting the Assert production.
+	ExitAssert(c *AssertContext)
+
+	// ExitIf is called when exiting the If production.
+	ExitIf(c *IfContext)
+
 	// ExitSend is called when exiting the Send production.
 	ExitSend(c *SendContext)
 
+	// ExitConvert is called when exiting the Convert production.
+	ExitConvert(c *ConvertContext)
+
+	// ExitBlock is called when exiting the block production.
+	ExitBlock(c *BlockContext)
+
 	// ExitType_ is called when exiting the type_ production.
 	ExitType_(c *Type_Context)
 
@@ -33,158 +33,203 @@ var numscriptParserStaticData struct {
 func numscriptParserInit() {
 	staticData := &numscriptParserStaticData
 	staticData.literalNames = []string{
-		"", "'*'", "'allowing overdraft up to'", "'allowing unbounded overdraft'",
-		"','", "", "", "", "", "'vars'", "'meta'", "'set_tx_meta'", "'set_account_meta'",
-		"'print'", "'fail'", "'send'", "'source'", "'from'", "'max'", "'destination'",
-		"'to'", "'allocate'", "'+'", "'-'", "'('", "')'", "'['", "']'", "'{'",
-		"'}'", "'='", "'account'", "'asset'", "'number'", "'monetary'", "'portion'",
-		"'string'", "", "", "'remaining'", "'kept'", "'balance'", "'save'",
-		"", "'%'",
+		"", "','", "'allowing overdraft up to'", "'allowing unbounded overdraft'",
+		"", "", "", "", "'vars'", "'meta'", "'set_tx_meta'", "'set_account_meta'",
+		"'print'", "'fail'", "'assert'", "'if'", "'else'", "'send'", "'source'",
+		"'from'", "'max'", "'min'", "'floor'", "'ceil'", "'round'", "'destination'",
+		"'to'", "'convert'", "'at'", "'via'", "'allocate'", "'+'", "'-'", "'*'",
+		"'/'", "'=='", "'!='", "'<='", "'>='", "'<'", "'>'", "'('", "')'", "'['",
+		"']'", "'{'", "'}'", "'='", "'account'", "'asset'", "'number'", "'monetary'",
+		"'portion'", "'string'", "'rate'", "", "", "'remaining'", "'kept'",
+		"'balance'", "'save'", "", "'%'",
 	}
 	staticData.symbolicNames = []string{
-		"", "", "", "", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
+		"", "", "", "", "NEWLINE", "WHITESPACE", "MULTILINE_COMMENT", "LINE_COMMENT",
 		"VARS", "META", "SET_TX_META", "SET_ACCOUNT_META", "PRINT", "FAIL",
-		"SEND", "SOURCE", "FROM", "MAX", "DESTINATION", "TO", "ALLOCATE", "OP_ADD",
-		"OP_SUB", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "LBRACE", "RBRACE",
-		"EQ", "TY_ACCOUNT", "TY_ASSET", "TY_NUMBER", "TY_MONETARY", "TY_PORTION",
-		"TY_STRING", "STRING", "PORTION", "REMAINING", "KEPT", "BALANCE", "SAVE",
-		"NUMBER", "PERCENT", "VARIABLE_NAME", "ACCOUNT", "ASSET",
+		"ASSERT", "IF", "ELSE", "SEND", "SOURCE", "FROM", "MAX", "MIN", "FLOOR",
+		"CEIL", "ROUND", "DESTINATION", "TO", "CONVERT", "AT", "VIA", "ALLOCATE",
+		"OP_ADD", "OP_SUB", "OP_MUL", "OP_DIV", "OP_EQ", "OP_NEQ", "OP_LTE",
+		"OP_GTE", "OP_LT", "OP_GT", "LPAREN", "RPAREN", "LBRACK", "RBRACK",
+		"LBRACE", "RBRACE", "EQ", "TY_ACCOUNT", "TY_ASSET", "TY_NUMBER", "TY_MONETARY",
+		"TY_PORTION", "TY_STRING", "TY_RATE", "STRING", "PORTION", "REMAINING",
+		"KEPT", "BALANCE", "SAVE", "NUMBER", "PERCENT", "VARIABLE_NAME", "ACCOUNT",
+		"ASSET",
 	}
 	staticData.ruleNames = []string{
 		"monetary", "monetaryAll", "literal", "variable", "expression", "allotmentPortion",
 		"destinationInOrder", "destinationAllotment", "keptOrDestination", "destination",
-		"sourceAccountOverdraft", "sourceAccount", "sourceInOrder", "sourceMaxed",
-		"source", "sourceAllotment", "valueAwareSource", "statement", "type_",
-		"origin", "varDecl", "varListDecl", "script",
+		"condOperand", "condition", "sourceAccountOverdraft", "sourceAccount",
+		"sourceInOrder", "sourceMaxed", "source", "sourceAllotment", "valueAwareSource",
+		"statement", "block", "type_", "origin", "varDecl", "varListDecl", "script",
 	}
 	staticData.predictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 47, 292, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 65, 380, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
 		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
-		21, 7, 21, 2, 22, 7, 22, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 63, 8, 2, 1, 3, 1,
-		3, 1, 4, 1, 4, 1, 4, 3, 4, 70, 8, 4, 1, 4, 1, 4, 1, 4, 5, 4, 75, 8, 4,
-		10, 4, 12, 4, 78, 9, 4, 1, 5, 1, 5, 1, 5, 3, 5, 83, 8, 5, 1, 6, 1, 6, 1,
-		6, 1, 6, 1, 6, 1, 6, 1, 6, 4, 6, 92, 8, 6, 11, 6, 12, 6, 93, 1, 6, 1, 6,
-		1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 4, 7, 107, 8, 7,
-		11, 7, 12, 7, 108, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8, 116, 8, 8, 1, 9,
-		1, 9, 1, 9, 3, 9, 121, 8, 9, 1, 10, 1, 10, 1, 10, 3, 10, 126, 8, 10, 1,
-		11, 1, 11, 3, 11, 130, 8, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 4, 12,
-		137, 8, 12, 11, 12, 12, 12, 138, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1,
-		13, 1, 13, 1, 14, 1, 14, 1, 14, 3, 14, 151, 8, 14, 1, 15, 1, 15, 1, 15,
-		1, 15, 1, 15, 1, 15, 1, 15, 4, 15, 160, 8, 15, 11, 15, 12, 15, 161, 1,
-		15, 1, 15, 1, 16, 1, 16, 3, 16, 168, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17,
-		1, 17, 3, 17, 175, 8, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1,
-		17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
-		1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 200, 8, 17, 1, 17, 1,
-		17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
-		1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 220, 8, 17, 1, 17, 1,
-		17, 1, 17, 3, 17, 225, 8, 17, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19,
-		1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3,
-		19, 243, 8, 19, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 249, 8, 20, 1, 21, 1,
-		21, 1, 21, 1, 21, 1, 21, 4, 21, 256, 8, 21, 11, 21, 12, 21, 257, 4, 21,
-		260, 8, 21, 11, 21, 12, 21, 261, 1, 21, 1, 21, 1, 21, 1, 22, 5, 22, 268,
-		8, 22, 10, 22, 12, 22, 271, 9, 22, 1, 22, 3, 22, 274, 8, 22, 1, 22, 1,
-		22, 1, 22, 5, 22, 279, 8, 22, 10, 22, 12, 22, 282, 9, 22, 1, 22, 5, 22,
-		285, 8, 22, 10, 22, 12, 22, 288, 9, 22, 1, 22, 1, 22, 1, 22, 0, 1, 8, 23,
-		0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36,
-		38, 40, 42, 44, 0, 2, 1, 0, 22, 23, 1, 0, 31, 36, 305, 0, 46, 1, 0, 0,
-		0, 2, 51, 1, 0, 0, 0, 4, 62, 1, 0, 0, 0, 6, 64, 1, 0, 0, 0, 8, 69, 1, 0,
-		0, 0, 10, 82, 1, 0, 0, 0, 12, 84, 1, 0, 0, 0, 14, 100, 1, 0, 0, 0, 16,
-		115, 1, 0, 0, 0, 18, 120, 1, 0, 0, 0, 20, 125, 1, 0, 0, 0, 22, 127, 1,
-		0, 0, 0, 24, 131, 1, 0, 0, 0, 26, 142, 1, 0, 0, 0, 28, 150, 1, 0, 0, 0,
-		30, 152, 1, 0, 0, 0, 32, 167, 1, 0, 0, 0, 34, 224, 1, 0, 0, 0, 36, 226,
-		1, 0, 0, 0, 38, 242, 1, 0, 0, 0, 40, 244, 1, 0, 0, 0, 42, 250, 1, 0, 0,
-		0, 44, 269, 1, 0, 0, 0, 46, 47, 5, 26, 0, 0, 47, 48, 3, 8, 4, 0, 48, 49,
-		5, 43, 0, 0, 49, 50, 5, 27, 0, 0, 50, 1, 1, 0, 0, 0, 51, 52, 5, 26, 0,
-		0, 52, 53, 3, 8, 4, 0, 53, 54, 5, 1, 0, 0, 54, 55, 5, 27, 0, 0, 55, 3,
-		1, 0, 0, 0, 56, 63, 5, 46, 0, 0, 57, 63, 5, 47, 0, 0, 58, 63, 5, 43, 0,
-		0, 59, 63, 5, 37, 0, 0, 60, 63, 5, 38, 0, 0, 61, 63, 3, 0, 0, 0, 62, 56,
-		1, 0, 0, 0, 62, 57, 1, 0, 0, 0, 62, 58, 1, 0, 0, 0, 62, 59, 1, 0, 0, 0,
-		62, 60, 1, 0, 0, 0, 62, 61, 1, 0, 0, 0, 63, 5, 1, 0, 0, 0, 64, 65, 5, 45,
-		0, 0, 65, 7, 1, 0, 0, 0, 66, 67, 6, 4, -1, 0, 67, 70, 3, 4, 2, 0, 68, 70,
-		3, 6, 3, 0, 69, 66, 1, 0, 0, 0, 69, 68, 1, 0, 0, 0, 70, 76, 1, 0, 0, 0,
-		71, 72, 10, 3, 0, 0, 72, 73, 7, 0, 0, 0, 73, 75, 3, 8, 4, 4, 74, 71, 1,
-		0, 0, 0, 75, 78, 1, 0, 0, 0, 76, 74, 1, 0, 0, 0, 76, 77, 1, 0, 0, 0, 77,
-		9, 1, 0, 0, 0, 78, 76, 1, 0, 0, 0, 79, 83, 5, 38, 0, 0, 80, 83, 3, 6, 3,
-		0, 81, 83, 5, 39, 0, 0, 82, 79, 1, 0, 0, 0, 82, 80, 1, 0, 0, 0, 82, 81,
-		1, 0, 0, 0, 83, 11, 1, 0, 0, 0, 84, 85, 5, 28, 0, 0, 85, 91, 5, 5, 0, 0,
-		86, 87, 5, 18, 0, 0, 87, 88, 3, 8, 4, 0, 88, 89, 3, 16, 8, 0, 89, 90, 5,
-		5, 0, 0, 90, 92, 1, 0, 0, 0, 91, 86, 1, 0, 0, 0, 92, 93, 1, 0, 0, 0, 93,
-		91, 1, 0, 0, 0, 93, 94, 1, 0, 0, 0, 94, 95, 1, 0, 0, 0, 95, 96, 5, 39,
-		0, 0, 96, 97, 3, 16, 8, 0, 97, 98, 5, 5, 0, 0, 98, 99, 5, 29, 0, 0, 99,
-		13, 1, 0, 0, 0, 100, 101, 5, 28, 0, 0, 101, 106, 5, 5, 0, 0, 102, 103,
-		3, 10, 5, 0, 103, 104, 3, 16, 8, 0, 104, 105, 5, 5, 0, 0, 105, 107, 1,
-		0, 0, 0, 106, 102, 1, 0, 0, 0, 107, 108, 1, 0, 0, 0, 108, 106, 1, 0, 0,
-		0, 108, 109, 1, 0, 0, 0, 109, 110, 1, 0, 0, 0, 110, 111, 5, 29, 0, 0, 111,
-		15, 1, 0, 0, 0, 112, 113, 5, 20, 0, 0, 113, 116, 3, 18, 9, 0, 114, 116,
-		5, 40, 0, 0, 115, 112, 1, 0, 0, 0, 115, 114, 1, 0, 0, 0, 116, 17, 1, 0,
-		0, 0, 117, 121, 3, 8, 4, 0, 118, 121, 3, 12, 6, 0, 119, 121, 3, 14, 7,
-		0, 120, 117, 1, 0, 0, 0, 120, 118, 1, 0, 0, 0, 120, 119, 1, 0, 0, 0, 121,
-		19, 1, 0, 0, 0, 122, 123, 5, 2, 0, 0, 123, 126, 3, 8, 4, 0, 124, 126, 5,
-		3, 0, 0, 125, 122, 1, 0, 0, 0, 125, 124, 1, 0, 0, 0, 126, 21, 1, 0, 0,
-		0, 127, 129, 3, 8, 4, 0, 128, 130, 3, 20, 10, 0, 129, 128, 1, 0, 0, 0,
-		129, 130, 1, 0, 0, 0, 130, 23, 1, 0, 0, 0, 131, 132, 5, 28, 0, 0, 132,
-		136, 5, 5, 0, 0, 133, 134, 3, 28, 14, 0, 134, 135, 5, 5, 0, 0, 135, 137,
-		1, 0, 0, 0, 136, 133, 1, 0, 0, 0, 137, 138, 1, 0, 0, 0, 138, 136, 1, 0,
-		0, 0, 138, 139, 1, 0, 0, 0, 139, 140, 1, 0, 0, 0, 140, 141, 5, 29, 0, 0,
-		141, 25, 1, 0, 0, 0, 142, 143, 5, 18, 0, 0, 143, 144, 3, 8, 4, 0, 144,
-		145, 5, 17, 0, 0, 145, 146, 3, 28, 14, 0, 146, 27, 1, 0, 0, 0, 147, 151,
-		3, 22, 11, 0, 148, 151, 3, 26, 13, 0, 149, 151, 3, 24, 12, 0, 150, 147,
-		1, 0, 0, 0, 150, 148, 1, 0, 0, 0, 150, 149, 1, 0, 0, 0, 151, 29, 1, 0,
-		0, 0, 152, 153, 5, 28, 0, 0, 153, 159, 5, 5, 0, 0, 154, 155, 3, 10, 5,
-		0, 155, 156, 5, 17, 0, 0, 156, 157, 3, 28, 14, 0, 157, 158, 5, 5, 0, 0,
-		158, 160, 1, 0, 0, 0, 159, 154, 1, 0, 0, 0, 160, 161, 1, 0, 0, 0, 161,
-		159, 1, 0, 0, 0, 161, 162, 1, 0, 0, 0, 162, 163, 1, 0, 0, 0, 163, 164,
-		5, 29, 0, 0, 164, 31, 1, 0, 0, 0, 165, 168, 3, 28, 14, 0, 166, 168, 3,
-		30, 15, 0, 167, 165, 1, 0, 0, 0, 167, 166, 1, 0, 0, 0, 168, 33, 1, 0, 0,
-		0, 169, 170, 5, 13, 0, 0, 170, 225, 3, 8, 4, 0, 171, 174, 5, 42, 0, 0,
-		172, 175, 3, 8, 4, 0, 173, 175, 3, 2, 1, 0, 174, 172, 1, 0, 0, 0, 174,
-		173, 1, 0, 0, 0, 175, 176, 1, 0, 0, 0, 176, 177, 5, 17, 0, 0, 177, 178,
-		3, 8, 4, 0, 178, 225, 1, 0, 0, 0, 179, 180, 5, 11, 0, 0, 180, 181, 5, 24,
-		0, 0, 181, 182, 5, 37, 0, 0, 182, 183, 5, 4, 0, 0, 183, 184, 3, 8, 4, 0,
-		184, 185, 5, 25, 0, 0, 185, 225, 1, 0, 0, 0, 186, 187, 5, 12, 0, 0, 187,
-		188, 5, 24, 0, 0, 188, 189, 3, 8, 4, 0, 189, 190, 5, 4, 0, 0, 190, 191,
-		5, 37, 0, 0, 191, 192, 5, 4, 0, 0, 192, 193, 3, 8, 4, 0, 193, 194, 5, 25,
-		0, 0, 194, 225, 1, 0, 0, 0, 195, 225, 5, 14, 0, 0, 196, 199, 5, 15, 0,
-		0, 197, 200, 3, 8, 4, 0, 198, 200, 3, 2, 1, 0, 199, 197, 1, 0, 0, 0, 199,
-		198, 1, 0, 0, 0, 200, 201, 1, 0, 0, 0, 201, 202, 5, 24, 0, 0, 202, 219,
-		5, 5, 0, 0, 203, 204, 5, 16, 0, 0, 204, 205, 5, 30, 0, 0, 205, 206, 3,
-		32, 16, 0, 206, 207, 5, 5, 0, 0, 207, 208, 5, 19, 0, 0, 208, 209, 5, 30,
-		0, 0, 209, 210, 3, 18, 9, 0, 210, 220, 1, 0, 0, 0, 211, 212, 5, 19, 0,
-		0, 212, 213, 5, 30, 0, 0, 213, 214, 3, 18, 9, 0, 214, 215, 5, 5, 0, 0,
-		215, 216, 5, 16, 0, 0, 216, 217, 5, 30, 0, 0, 217, 218, 3, 32, 16, 0, 218,
-		220, 1, 0, 0, 0, 219, 203, 1, 0, 0, 0, 219, 211, 1, 0, 0, 0, 220, 221,
-		1, 0, 0, 0, 221, 222, 5, 5, 0, 0, 222, 223, 5, 25, 0, 0, 223, 225, 1, 0,
-		0, 0, 224, 169, 1, 0, 0, 0, 224, 171, 1, 0, 0, 0, 224, 179, 1, 0, 0, 0,
-		224, 186, 1, 0, 0, 0, 224, 195, 1, 0, 0, 0, 224, 196, 1, 0, 0, 0, 225,
-		35, 1, 0, 0, 0, 226, 227, 7, 1, 0, 0, 227, 37, 1, 0, 0, 0, 228, 229, 5,
-		10, 0, 0, 229, 230, 5, 24, 0, 0, 230, 231, 3, 8, 4, 0, 231, 232, 5, 4,
-		0, 0, 232, 233, 5, 37, 0, 0, 233, 234, 5, 25, 0, 0, 234, 243, 1, 0, 0,
-		0, 235, 236, 5, 41, 0, 0, 236, 237, 5, 24, 0, 0, 237, 238, 3, 8, 4, 0,
-		238, 239, 5, 4, 0, 0, 239, 240, 3, 8, 4, 0, 240, 241, 5, 25, 0, 0, 241,
-		243, 1, 0, 0, 0, 242, 228, 1, 0, 0, 0, 242, 235, 1, 0, 0, 0, 243, 39, 1,
-		0, 0, 0, 244, 245, 3, 36, 18, 0, 245, 248, 3, 6, 3, 0, 246, 247, 5, 30,
-		0, 0, 247, 249, 3, 38, 19, 0, 248, 246, 1, 0, 0, 0, 248, 249, 1, 0, 0,
-		0, 249, 41, 1, 0, 0, 0, 250, 251, 5, 9, 0, 0, 251, 252, 5, 28, 0, 0, 252,
-		259, 5, 5, 0, 0, 253, 255, 3, 40, 20, 0, 254, 256, 5, 5, 0, 0, 255, 254,
-		1, 0, 0, 0, 256, 257, 1, 0, 0, 0, 257, 255, 1, 0, 0, 0, 257, 258, 1, 0,
-		0, 0, 258, 260, 1, 0, 0, 0, 259, 253, 1, 0, 0, 0, 260, 261, 1, 0, 0, 0,
-		261, 259, 1, 0, 0, 0, 261, 262, 1, 0, 0, 0, 262, 263, 1, 0, 0, 0, 263,
-		264, 5, 29, 0, 0, 264, 265, 5, 5, 0, 0, 265, 43, 1, 0, 0, 0, 266, 268,
-		5, 5, 0, 0, 267, 266, 1, 0, 0, 0, 268, 271, 1, 0, 0, 0, 269, 267, 1, 0,
-		0, 0, 269, 270, 1, 0, 0, 0, 270, 273, 1, 0, 0, 0, 271, 269, 1, 0, 0, 0,
-		272, 274, 3, 42, 21, 0, 273, 272, 1, 0, 0, 0, 273, 274, 1, 0, 0, 0, 274,
-		275, 1, 0, 0, 0, 275, 280, 3, 34, 17, 0, 276, 277, 5, 5, 0, 0, 277, 279,
-		3, 34, 17, 0, 278, 276, 1, 0, 0, 0, 279, 282, 1, 0, 0, 0, 280, 278, 1,
-		0, 0, 0, 280, 281, 1, 0, 0, 0, 281, 286, 1, 0, 0, 0, 282, 280, 1, 0, 0,
-		0, 283, 285, 5, 5, 0, 0, 284, 283, 1, 0, 0, 0, 285, 288, 1, 0, 0, 0, 286,
-		284, 1, 0, 0, 0, 286, 287, 1, 0, 0, 0, 287, 289, 1, 0, 0, 0, 288, 286,
-		1, 0, 0, 0, 289, 290, 5, 0, 0, 1, 290, 45, 1, 0, 0, 0, 26, 62, 69, 76,
-		82, 93, 108, 115, 120, 125, 129, 138, 150, 161, 167, 174, 199, 219, 224,
-		242, 248, 257, 261, 269, 273, 280, 286,
+		21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25, 1, 0,
+		1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2,
+		1, 2, 1, 2, 1, 2, 3, 2, 69, 8, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 4, 1,
+		4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1,
+		4, 1, 4, 1, 4, 3, 4, 92, 8, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 5, 4,
+		100, 8, 4, 10, 4, 12, 4, 103, 9, 4, 1, 5, 1, 5, 1, 5, 3, 5, 108, 8, 5,
+		1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 4, 6, 117, 8, 6, 11, 6, 12, 6,
+		118, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7,
+		4, 7, 132, 8, 7, 11, 7, 12, 7, 133, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 3, 8,
+		141, 8, 8, 1, 9, 1, 9, 1, 9, 3, 9, 146, 8, 9, 1, 10, 1, 10, 1, 10, 1, 10,
+		1, 10, 1, 10, 1, 10, 1, 10, 3, 10, 156, 8, 10, 1, 11, 1, 11, 1, 11, 1,
+		11, 1, 12, 1, 12, 1, 12, 3, 12, 165, 8, 12, 1, 13, 1, 13, 3, 13, 169, 8,
+		13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 4, 14, 176, 8, 14, 11, 14, 12, 14,
+		177, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1,
+		16, 3, 16, 190, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
+		4, 17, 199, 8, 17, 11, 17, 12, 17, 200, 1, 17, 1, 17, 1, 18, 1, 18, 3,
+		18, 207, 8, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 214, 8, 19, 1,
+		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19,
+		1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 243, 8, 19, 1, 19, 1, 19,
+		1, 19, 3, 19, 248, 8, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19,
+		1, 19, 3, 19, 268, 8, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19,
+		1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 295, 8,
+		19, 1, 20, 1, 20, 4, 20, 299, 8, 20, 11, 20, 12, 20, 300, 1, 20, 1, 20,
+		4, 20, 305, 8, 20, 11, 20, 12, 20, 306, 4, 20, 309, 8, 20, 11, 20, 12,
+		20, 310, 1, 20, 1, 20, 1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22,
+		1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 3, 22, 331,
+		8, 22, 1, 23, 1, 23, 1, 23, 1, 23, 3, 23, 337, 8, 23, 1, 24, 1, 24, 1,
+		24, 1, 24, 1, 24, 4, 24, 344, 8, 24, 11, 24, 12, 24, 345, 4, 24, 348, 8,
+		24, 11, 24, 12, 24, 349, 1, 24, 1, 24, 1, 24, 1, 25, 5, 25, 356, 8, 25,
+		10, 25, 12, 25, 359, 9, 25, 1, 25, 3, 25, 362, 8, 25, 1, 25, 1, 25, 1,
+		25, 5, 25, 367, 8, 25, 10, 25, 12, 25, 370, 9, 25, 1, 25, 5, 25, 373, 8,
+		25, 10, 25, 12, 25, 376, 9, 25, 1, 25, 1, 25, 1, 25, 0, 1, 8, 26, 0, 2,
+		4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40,
+		42, 44, 46, 48, 50, 0, 6, 1, 0, 20, 21, 1, 0, 22, 24, 1, 0, 33, 34, 1,
+		0, 31, 32, 1, 0, 35, 40, 1, 0, 48, 54, 402, 0, 52, 1, 0, 0, 0, 2, 57, 1,
+		0, 0, 0, 4, 68, 1, 0, 0, 0, 6, 70, 1, 0, 0, 0, 8, 91, 1, 0, 0, 0, 10, 107,
+		1, 0, 0, 0, 12, 109, 1, 0, 0, 0, 14, 125, 1, 0, 0, 0, 16, 140, 1, 0, 0,
+		0, 18, 145, 1, 0, 0, 0, 20, 155, 1, 0, 0, 0, 22, 157, 1, 0, 0, 0, 24, 164,
+		1, 0, 0, 0, 26, 166, 1, 0, 0, 0, 28, 170, 1, 0, 0, 0, 30, 181, 1, 0, 0,
+		0, 32, 189, 1, 0, 0, 0, 34, 191, 1, 0, 0, 0, 36, 206, 1, 0, 0, 0, 38, 294,
+		1, 0, 0, 0, 40, 296, 1, 0, 0, 0, 42, 314, 1, 0, 0, 0, 44, 330, 1, 0, 0,
+		0, 46, 332, 1, 0, 0, 0, 48, 338, 1, 0, 0, 0, 50, 357, 1, 0, 0, 0, 52, 53,
+		5, 43, 0, 0, 53, 54, 3, 8, 4, 0, 54, 55, 5, 61, 0, 0, 55, 56, 5, 44, 0,
+		0, 56, 1, 1, 0, 0, 0, 57, 58, 5, 43, 0, 0, 58, 59, 3, 8, 4, 0, 59, 60,
+		5, 33, 0, 0, 60, 61, 5, 44, 0, 0, 61, 3, 1, 0, 0, 0, 62, 69, 5, 64, 0,
+		0, 63, 69, 5, 65, 0, 0, 64, 69, 5, 61, 0, 0, 65, 69, 5, 55, 0, 0, 66, 69,
+		5, 56, 0, 0, 67, 69, 3, 0, 0, 0, 68, 62, 1, 0, 0, 0, 68, 63, 1, 0, 0, 0,
+		68, 64, 1, 0, 0, 0, 68, 65, 1, 0, 0, 0, 68, 66, 1, 0, 0, 0, 68, 67, 1,
+		0, 0, 0, 69, 5, 1, 0, 0, 0, 70, 71, 5, 63, 0, 0, 71, 7, 1, 0, 0, 0, 72,
+		73, 6, 4, -1, 0, 73, 74, 5, 41, 0, 0, 74, 75, 3, 8, 4, 0, 75, 76, 5, 42,
+		0, 0, 76, 92, 1, 0, 0, 0, 77, 78, 7, 0, 0, 0, 78, 79, 5, 41, 0, 0, 79,
+		80, 3, 8, 4, 0, 80, 81, 5, 1, 0, 0, 81, 82, 3, 8, 4, 0, 82, 83, 5, 42,
+		0, 0, 83, 92, 1, 0, 0, 0, 84, 85, 7, 1, 0, 0, 85, 86, 5, 41, 0, 0, 86,
+		87, 3, 8, 4, 0, 87, 88, 5, 42, 0, 0, 88, 92, 1, 0, 0, 0, 89, 92, 3, 4,
+		2, 0, 90, 92, 3, 6, 3, 0, 91, 72, 1, 0, 0, 0, 91, 77, 1, 0, 0, 0, 91, 84,
+		1, 0, 0, 0, 91, 89, 1, 0, 0, 0, 91, 90, 1, 0, 0, 0, 92, 101, 1, 0, 0, 0,
+		93, 94, 10, 7, 0, 0, 94, 95, 7, 2, 0, 0, 95, 100, 3, 8, 4, 8, 96, 97, 10,
+		6, 0, 0, 97, 98, 7, 3, 0, 0, 98, 100, 3, 8, 4, 7, 99, 93, 1, 0, 0, 0, 99,
+		96, 1, 0, 0, 0, 100, 103, 1, 0, 0, 0, 101, 99, 1, 0, 0, 0, 101, 102, 1,
+		0, 0, 0, 102, 9, 1, 0, 0, 0, 103, 101, 1, 0, 0, 0, 104, 108, 5, 56, 0,
+		0, 105, 108, 3, 6, 3, 0, 106, 108, 5, 57, 0, 0, 107, 104, 1, 0, 0, 0, 107,
+		105, 1, 0, 0, 0, 107, 106, 1, 0, 0, 0, 108, 11, 1, 0, 0, 0, 109, 110, 5,
+		45, 0, 0, 110, 116, 5, 4, 0, 0, 111, 112, 5, 20, 0, 0, 112, 113, 3, 8,
+		4, 0, 113, 114, 3, 16, 8, 0, 114, 115, 5, 4, 0, 0, 115, 117, 1, 0, 0, 0,
+		116, 111, 1, 0, 0, 0, 117, 118, 1, 0, 0, 0, 118, 116, 1, 0, 0, 0, 118,
+		119, 1, 0, 0, 0, 119, 120, 1, 0, 0, 0, 120, 121, 5, 57, 0, 0, 121, 122,
+		3, 16, 8, 0, 122, 123, 5, 4, 0, 0, 123, 124, 5, 46, 0, 0, 124, 13, 1, 0,
+		0, 0, 125, 126, 5, 45, 0, 0, 126, 131, 5, 4, 0, 0, 127, 128, 3, 10, 5,
+		0, 128, 129, 3, 16, 8, 0, 129, 130, 5, 4, 0, 0, 130, 132, 1, 0, 0, 0, 131,
+		127, 1, 0, 0, 0, 132, 133, 1, 0, 0, 0, 133, 131, 1, 0, 0, 0, 133, 134,
+		1, 0, 0, 0, 134, 135, 1, 0, 0, 0, 135, 136, 5, 46, 0, 0, 136, 15, 1, 0,
+		0, 0, 137, 138, 5, 26, 0, 0, 138, 141, 3, 18, 9, 0, 139, 141, 5, 58, 0,
+		0, 140, 137, 1, 0, 0, 0, 140, 139, 1, 0, 0, 0, 141, 17, 1, 0, 0, 0, 142,
+		146, 3, 8, 4, 0, 143, 146, 3, 12, 6, 0, 144, 146, 3, 14, 7, 0, 145, 142,
+		1, 0, 0, 0, 145, 143, 1, 0, 0, 0, 145, 144, 1, 0, 0, 0, 146, 19, 1, 0,
+		0, 0, 147, 148, 5, 59, 0, 0, 148, 149, 5, 41, 0, 0, 149, 150, 3, 8, 4,
+		0, 150, 151, 5, 1, 0, 0, 151, 152, 3, 8, 4, 0, 152, 153, 5, 42, 0, 0, 153,
+		156, 1, 0, 0, 0, 154, 156, 3, 8, 4, 0, 155, 147, 1, 0, 0, 0, 155, 154,
+		1, 0, 0, 0, 156, 21, 1, 0, 0, 0, 157, 158, 3, 20, 10, 0, 158, 159, 7, 4,
+		0, 0, 159, 160, 3, 20, 10, 0, 160, 23, 1, 0, 0, 0, 161, 162, 5, 2, 0, 0,
+		162, 165, 3, 8, 4, 0, 163, 165, 5, 3, 0, 0, 164, 161, 1, 0, 0, 0, 164,
+		163, 1, 0, 0, 0, 165, 25, 1, 0, 0, 0, 166, 168, 3, 8, 4, 0, 167, 169, 3,
+		24, 12, 0, 168, 167, 1, 0, 0, 0, 168, 169, 1, 0, 0, 0, 169, 27, 1, 0, 0,
+		0, 170, 171, 5, 45, 0, 0, 171, 175, 5, 4, 0, 0, 172, 173, 3, 32, 16, 0,
+		173, 174, 5, 4, 0, 0, 174, 176, 1, 0, 0, 0, 175, 172, 1, 0, 0, 0, 176,
+		177, 1, 0, 0, 0, 177, 175, 1, 0, 0, 0, 177, 178, 1, 0, 0, 0, 178, 179,
+		1, 0, 0, 0, 179, 180, 5, 46, 0, 0, 180, 29, 1, 0, 0, 0, 181, 182, 5, 20,
+		0, 0, 182, 183, 3, 8, 4, 0, 183, 184, 5, 19, 0, 0, 184, 185, 3, 32, 16,
+		0, 185, 31, 1, 0, 0, 0, 186, 190, 3, 26, 13, 0, 187, 190, 3, 30, 15, 0,
+		188, 190, 3, 28, 14, 0, 189, 186, 1, 0, 0, 0, 189, 187, 1, 0, 0, 0, 189,
+		188, 1, 0, 0, 0, 190, 33, 1, 0, 0, 0, 191, 192, 5, 45, 0, 0, 192, 198,
+		5, 4, 0, 0, 193, 194, 3, 10, 5, 0, 194, 195, 5, 19, 0, 0, 195, 196, 3,
+		32, 16, 0, 196, 197, 5, 4, 0, 0, 197, 199, 1, 0, 0, 0, 198, 193, 1, 0,
+		0, 0, 199, 200, 1, 0, 0, 0, 200, 198, 1, 0, 0, 0, 200, 201, 1, 0, 0, 0,
+		201, 202, 1, 0, 0, 0, 202, 203, 5, 46, 0, 0, 203, 35, 1, 0, 0, 0, 204,
+		207, 3, 32, 16, 0, 205, 207, 3, 34, 17, 0, 206, 204, 1, 0, 0, 0, 206, 205,
+		1, 0, 0, 0, 207, 37, 1, 0, 0, 0, 208, 209, 5, 12, 0, 0, 209, 295, 3, 8,
+		4, 0, 210, 213, 5, 60, 0, 0, 211, 214, 3, 8, 4, 0, 212, 214, 3, 2, 1, 0,
+		213, 211, 1, 0, 0, 0, 213, 212, 1, 0, 0, 0, 214, 215, 1, 0, 0, 0, 215,
+		216, 5, 19, 0, 0, 216, 217, 3, 8, 4, 0, 217, 295, 1, 0, 0, 0, 218, 219,
+		5, 10, 0, 0, 219, 220, 5, 41, 0, 0, 220, 221, 5, 55, 0, 0, 221, 222, 5,
+		1, 0, 0, 222, 223, 3, 8, 4, 0, 223, 224, 5, 42, 0, 0, 224, 295, 1, 0, 0,
+		0, 225, 226, 5, 11, 0, 0, 226, 227, 5, 41, 0, 0, 227, 228, 3, 8, 4, 0,
+		228, 229, 5, 1, 0, 0, 229, 230, 5, 55, 0, 0, 230, 231, 5, 1, 0, 0, 231,
+		232, 3, 8, 4, 0, 232, 233, 5, 42, 0, 0, 233, 295, 1, 0, 0, 0, 234, 295,
+		5, 13, 0, 0, 235, 236, 5, 14, 0, 0, 236, 295, 3, 22, 11, 0, 237, 238, 5,
+		15, 0, 0, 238, 239, 3, 22, 11, 0, 239, 242, 3, 40, 20, 0, 240, 241, 5,
+		16, 0, 0, 241, 243, 3, 40, 20, 0, 242, 240, 1, 0, 0, 0, 242, 243, 1, 0,
+		0, 0, 243, 295, 1, 0, 0, 0, 244, 247, 5, 17, 0, 0, 245, 248, 3, 8, 4, 0,
+		246, 248, 3, 2, 1, 0, 247, 245, 1, 0, 0, 0, 247, 246, 1, 0, 0, 0, 248,
+		249, 1, 0, 0, 0, 249, 250, 5, 41, 0, 0, 250, 267, 5, 4, 0, 0, 251, 252,
+		5, 18, 0, 0, 252, 253, 5, 47, 0, 0, 253, 254, 3, 36, 18, 0, 254, 255, 5,
+		4, 0, 0, 255, 256, 5, 25, 0, 0, 256, 257, 5, 47, 0, 0, 257, 258, 3, 18,
+		9, 0, 258, 268, 1, 0, 0, 0, 259, 260, 5, 25, 0, 0, 260, 261, 5, 47, 0,
+		0, 261, 262, 3, 18, 9, 0, 262, 263, 5, 4, 0, 0, 263, 264, 5, 18, 0, 0,
+		264, 265, 5, 47, 0, 0, 265, 266, 3, 36, 18, 0, 266, 268, 1, 0, 0, 0, 267,
+		251, 1, 0, 0, 0, 267, 259, 1, 0, 0, 0, 268, 269, 1, 0, 0, 0, 269, 270,
+		5, 4, 0, 0, 270, 271, 5, 42, 0, 0, 271, 295, 1, 0, 0, 0, 272, 273, 5, 27,
+		0, 0, 273, 274, 3, 8, 4, 0, 274, 275, 5, 26, 0, 0, 275, 276, 3, 8, 4, 0,
+		276, 277, 5, 28, 0, 0, 277, 278, 3, 8, 4, 0, 278, 279, 5, 41, 0, 0, 279,
+		280, 5, 4, 0, 0, 280, 281, 5, 18, 0, 0, 281, 282, 5, 47, 0, 0, 282, 283,
+		3, 32, 16, 0, 283, 284, 5, 4, 0, 0, 284, 285, 5, 29, 0, 0, 285, 286, 5,
+		47, 0, 0, 286, 287, 3, 8, 4, 0, 287, 288, 5, 4, 0, 0, 288, 289, 5, 25,
+		0, 0, 289, 290, 5, 47, 0, 0, 290, 291, 3, 18, 9, 0, 291, 292, 5, 4, 0,
+		0, 292, 293, 5, 42, 0, 0, 293, 295, 1, 0, 0, 0, 294, 208, 1, 0, 0, 0, 294,
+		210, 1, 0, 0, 0, 294, 218, 1, 0, 0, 0, 294, 225, 1, 0, 0, 0, 294, 234,
+		1, 0, 0, 0, 294, 235, 1, 0, 0, 0, 294, 237, 1, 0, 0, 0, 294, 244, 1, 0,
+		0, 0, 294, 272, 1, 0, 0, 0, 295, 39, 1, 0, 0, 0, 296, 298, 5, 45, 0, 0,
+		297, 299, 5, 4, 0, 0, 298, 297, 1, 0, 0, 0, 299, 300, 1, 0, 0, 0, 300,
+		298, 1, 0, 0, 0, 300, 301, 1, 0, 0, 0, 301, 308, 1, 0, 0, 0, 302, 304,
+		3, 38, 19, 0, 303, 305, 5, 4, 0, 0, 304, 303, 1, 0, 0, 0, 305, 306, 1,
+		0, 0, 0, 306, 304, 1, 0, 0, 0, 306, 307, 1, 0, 0, 0, 307, 309, 1, 0, 0,
+		0, 308, 302, 1, 0, 0, 0, 309, 310, 1, 0, 0, 0, 310, 308, 1, 0, 0, 0, 310,
+		311, 1, 0, 0, 0, 311, 312, 1, 0, 0, 0, 312, 313, 5, 46, 0, 0, 313, 41,
+		1, 0, 0, 0, 314, 315, 7, 5, 0, 0, 315, 43, 1, 0, 0, 0, 316, 317, 5, 9,
+		0, 0, 317, 318, 5, 41, 0, 0, 318, 319, 3, 8, 4, 0, 319, 320, 5, 1, 0, 0,
+		320, 321, 5, 55, 0, 0, 321, 322, 5, 42, 0, 0, 322, 331, 1, 0, 0, 0, 323,
+		324, 5, 59, 0, 0, 324, 325, 5, 41, 0, 0, 325, 326, 3, 8, 4, 0, 326, 327,
+		5, 1, 0, 0, 327, 328, 3, 8, 4, 0, 328, 329, 5, 42, 0, 0, 329, 331, 1, 0,
+		0, 0, 330, 316, 1, 0, 0, 0, 330, 323, 1, 0, 0, 0, 331, 45, 1, 0, 0, 0,
+		332, 333, 3, 42, 21, 0, 333, 336, 3, 6, 3, 0, 334, 335, 5, 47, 0, 0, 335,
+		337, 3, 44, 22, 0, 336, 334, 1, 0, 0, 0, 336, 337, 1, 0, 0, 0, 337, 47,
+		1, 0, 0, 0, 338, 339, 5, 8, 0, 0, 339, 340, 5, 45, 0, 0, 340, 347, 5, 4,
+		0, 0, 341, 343, 3, 46, 23, 0, 342, 344, 5, 4, 0, 0, 343, 342, 1, 0, 0,
+		0, 344, 345, 1, 0, 0, 0, 345, 343, 1, 0, 0, 0, 345, 346, 1, 0, 0, 0, 346,
+		348, 1, 0, 0, 0, 347, 341, 1, 0, 0, 0, 348, 349, 1, 0, 0, 0, 349, 347,
+		1, 0, 0, 0, 349, 350, 1, 0, 0, 0, 350, 351, 1, 0, 0, 0, 351, 352, 5, 46,
+		0, 0, 352, 353, 5, 4, 0, 0, 353, 49, 1, 0, 0, 0, 354, 356, 5, 4, 0, 0,
+		355, 354, 1, 0, 0, 0, 356, 359, 1, 0, 0, 0, 357, 355, 1, 0, 0, 0, 357,
+		358, 1, 0, 0, 0, 358, 361, 1, 0, 0, 0, 359, 357, 1, 0, 0, 0, 360, 362,
+		3, 48, 24, 0, 361, 360, 1, 0, 0, 0, 361, 362, 1, 0, 0, 0, 362, 363, 1,
+		0, 0, 0, 363, 368, 3, 38, 19, 0, 364, 365, 5, 4, 0, 0, 365, 367, 3, 38,
+		19, 0, 366, 364, 1, 0, 0, 0, 367, 370, 1, 0, 0, 0, 368, 366, 1, 0, 0, 0,
+		368, 369, 1, 0, 0, 0, 369, 374, 1, 0, 0, 0, 370, 368, 1, 0, 0, 0, 371,
+		373, 5, 4, 0, 0, 372, 371, 1, 0, 0, 0, 373, 376, 1, 0, 0, 0, 374, 372,
+		1, 0, 0, 0, 374, 375, 1, 0, 0, 0, 375, 377, 1, 0, 0, 0, 376, 374, 1, 0,
+		0, 0, 377, 378, 5, 0, 0, 1, 378, 51, 1, 0, 0, 0, 32, 68, 91, 99, 101, 107,
+		118, 133, 140, 145, 155, 164, 168, 177, 189, 200, 206, 213, 242, 247, 267,
+		294, 300, 306, 310, 330, 336, 345, 349, 357, 361, 368, 374,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -226,50 +271,68 @@ const (
 	NumScriptParserT__0              = 1
 	NumScriptParserT__1              = 2
 	NumScriptParserT__2              = 3
-	NumScriptParserT__3              = 4
-	NumScriptParserNEWLINE           = 5
-	NumScriptParserWHITESPACE        = 6
-	NumScriptParserMULTILINE_COMMENT = 7
-	NumScriptParserLINE_COMMENT      = 8
-	NumScriptParserVARS              = 9
-	NumScriptParserMETA              = 10
-	NumScriptParserSET_TX_META       = 11
-	NumScriptParserSET_ACCOUNT_META  = 12
-	NumScriptParserPRINT             = 13
-	NumScriptParserFAIL              = 14
-	NumScriptParserSEND              = 15
-	NumScriptParserSOURCE            = 16
-	NumScriptParserFROM              = 17
-	NumScriptParserMAX               = 18
-	NumScriptParserDESTINATION       = 19
-	NumScriptParserTO                = 20
-	NumScriptParserALLOCATE          = 21
-	NumScriptParserOP_ADD            = 22
-	NumScriptParserOP_SUB            = 23
-	NumScriptParserLPAREN            = 24
-	NumScriptParserRPAREN            = 25
-	NumScriptParserLBRACK            = 26
-	NumScriptParserRBRACK            = 27
-	NumScriptParserLBRACE            = 28
-	NumScriptParserRBRACE            = 29
-	NumScriptParserEQ                = 30
-	NumScriptParserTY_ACCOUNT        = 31
-	NumScriptParserTY_ASSET          = 32
-	NumScriptParserTY_NUMBER         = 33
-	NumScriptParserTY_MONETARY       = 34
-	NumScriptParserTY_PORTION        = 35
-	NumScriptParserTY_STRING         = 36
-	NumScriptParserSTRING            = 37
-	NumScriptParserPORTION           = 38
-	NumScriptParserREMAINING         = 39
-	NumScriptParserKEPT              = 40
-	NumScriptParserBALANCE           = 41
-	NumScriptParserSAVE              = 42
-	NumScriptParserNUMBER            = 43
-	NumScriptParserPERCENT           = 44
-	NumScriptParserVARIABLE_NAME     = 45
-	NumScriptParserACCOUNT           = 46
-	NumScriptParserASSET             = 47
+	NumScriptParserNEWLINE           = 4
+	NumScriptParserWHITESPACE        = 5
+	NumScriptParserMULTILINE_COMMENT = 6
+	NumScriptParserLINE_COMMENT      = 7
+	NumScriptParserVARS              = 8
+	NumScriptParserMETA              = 9
+	NumScriptParserSET_TX_META       = 10
+	NumScriptParserSET_ACCOUNT_META  = 11
+	NumScriptParserPRINT             = 12
+	NumScriptParserFAIL              = 13
+	NumScriptParserASSERT            = 14
+	NumScriptParserIF                = 15
+	NumScriptParserELSE              = 16
+	NumScriptParserSEND              = 17
+	NumScriptParserSOURCE            = 18
+	NumScriptParserFROM              = 19
+	NumScriptParserMAX               = 20
+	NumScriptParserMIN               = 21
+	NumScriptParserFLOOR             = 22
+	NumScriptParserCEIL              = 23
+	NumScriptParserROUND             = 24
+	NumScriptParserDESTINATION       = 25
+	NumScriptParserTO                = 26
+	NumScriptParserCONVERT           = 27
+	NumScriptParserAT                = 28
+	NumScriptParserVIA               = 29
+	NumScriptParserALLOCATE          = 30
+	NumScriptParserOP_ADD            = 31
+	NumScriptParserOP_SUB            = 32
+	NumScriptParserOP_MUL            = 33
+	NumScriptParserOP_DIV            = 34
+	NumScriptParserOP_EQ             = 35
+	NumScriptParserOP_NEQ            = 36
+	NumScriptParserOP_LTE            = 37
+	NumScriptParserOP_GTE            = 38
+	NumScriptParserOP_LT             = 39
+	NumScriptParserOP_GT             = 40
+	NumScriptParserLPAREN            = 41
+	NumScriptParserRPAREN            = 42
+	NumScriptParserLBRACK            = 43
+	NumScriptParserRBRACK            = 44
+	NumScriptParserLBRACE            = 45
+	NumScriptParserRBRACE            = 46
+	NumScriptParserEQ                = 47
+	NumScriptParserTY_ACCOUNT        = 48
+	NumScriptParserTY_ASSET          = 49
+	NumScriptParserTY_NUMBER         = 50
+	NumScriptParserTY_MONETARY       = 51
+	NumScriptParserTY_PORTION        = 52
+	NumScriptParserTY_STRING         = 53
+	NumScriptParserTY_RATE           = 54
+	NumScriptParserSTRING            = 55
+	NumScriptParserPORTION           = 56
+	NumScriptParserREMAINING         = 57
+	NumScriptParserKEPT              = 58
+	NumScriptParserBALANCE           = 59
+	NumScriptParserSAVE              = 60
+	NumScriptParserNUMBER            = 61
+	NumScriptParserPERCENT           = 62
+	NumScriptParserVARIABLE_NAME     = 63
+	NumScriptParserACCOUNT           = 64
+	NumScriptParserASSET             = 65
 )
 
 // NumScriptParser rules.
@@ -284,19 +347,22 @@ const (
 	NumScriptParserRULE_destinationAllotment   = 7
 	NumScriptParserRULE_keptOrDestination      = 8
 	NumScriptParserRULE_destination            = 9
-	NumScriptParserRULE_sourceAccountOverdraft = 10
-	NumScriptParserRULE_sourceAccount          = 11
-	NumScriptParserRULE_sourceInOrder          = 12
-	NumScriptParserRULE_sourceMaxed            = 13
-	NumScriptParserRULE_source                 = 14
-	NumScriptParserRULE_sourceAllotment        = 15
-	NumScriptParserRULE_valueAwareSource       = 16
-	NumScriptParserRULE_statement              = 17
-	NumScriptParserRULE_type_                  = 18
-	NumScriptParserRULE_origin                 = 19
-	NumScriptParserRULE_varDecl                = 20
-	NumScriptParserRULE_varListDecl            = 21
-	NumScriptParserRULE_script                 = 22
+	NumScriptParserRULE_condOperand            = 10
+	NumScriptParserRULE_condition              = 11
+	NumScriptParserRULE_sourceAccountOverdraft = 12
+	NumScriptParserRULE_sourceAccount          = 13
+	NumScriptParserRULE_sourceInOrder          = 14
+	NumScriptParserRULE_sourceMaxed            = 15
+	NumScriptParserRULE_source                 = 16
+	NumScriptParserRULE_sourceAllotment        = 17
+	NumScriptParserRULE_valueAwareSource       = 18
+	NumScriptParserRULE_statement              = 19
+	NumScriptParserRULE_block                  = 20
+	NumScriptParserRULE_type_                  = 21
+	NumScriptParserRULE_origin                 = 22
+	NumScriptParserRULE_varDecl                = 23
+	NumScriptParserRULE_varListDecl            = 24
+	NumScriptParserRULE_script                 = 25
 )
 
 // IMonetaryContext is an interface to support dynamic dispatch.
@@ -432,25 +498,25 @@ func (p *NumScriptParser) Monetary() (localctx IMonetaryContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(46)
+		p.SetState(52)
 		p.Match(NumScriptParserLBRACK)
 	}
 	{
-		p.SetState(47)
+		p.SetState(53)
 
 		var _x = p.expression(0)
 
 		localctx.(*MonetaryContext).asset = _x
 	}
 	{
-		p.SetState(48)
+		p.SetState(54)
 
 		var _m = p.Match(NumScriptParserNUMBER)
 
 		localctx.(*MonetaryContext).amt = _m
 	}
 	{
-		p.SetState(49)
+		p.SetState(55)
 		p.Match(NumScriptParserRBRACK)
 	}
 
@@ -510,6 +576,10 @@ func (s *MonetaryAllContext) LBRACK() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLBRACK, 0)
 }
 
+func (s *MonetaryAllContext) OP_MUL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_MUL, 0)
+}
+
 func (s *MonetaryAllContext) RBRACK() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRBRACK, 0)
 }
@@ -575,22 +645,22 @@ func (p *NumScriptParser) MonetaryAll() (localctx IMonetaryAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(51)
+		p.SetState(57)
 		p.Match(NumScriptParserLBRACK)
 	}
 	{
-		p.SetState(52)
+		p.SetState(58)
 
 		var _x = p.expression(0)
 
 		localctx.(*MonetaryAllContext).asset = _x
 	}
 	{
-		p.SetState(53)
-		p.Match(NumScriptParserT__0)
+		p.SetState(59)
+		p.Match(NumScriptParserOP_MUL)
 	}
 	{
-		p.SetState(54)
+		p.SetState(60)
 		p.Match(NumScriptParserRBRACK)
 	}
 
@@ -886,7 +956,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		}
 	}()
 
-	p.SetState(62)
+	p.SetState(68)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
@@ -894,7 +964,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitAccountContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(56)
+			p.SetState(62)
 			p.Match(NumScriptParserACCOUNT)
 		}
 
@@ -902,7 +972,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitAssetContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(57)
+			p.SetState(63)
 			p.Match(NumScriptParserASSET)
 		}
 
@@ -910,7 +980,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitNumberContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(58)
+			p.SetState(64)
 			p.Match(NumScriptParserNUMBER)
 		}
 
@@ -918,7 +988,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(59)
+			p.SetState(65)
 			p.Match(NumScriptParserSTRING)
 		}
 
@@ -926,7 +996,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitPortionContext(p, localctx)
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(60)
+			p.SetState(66)
 			p.Match(NumScriptParserPORTION)
 		}
 
@@ -934,7 +1004,7 @@ func (p *NumScriptParser) Literal() (localctx ILiteralContext) {
 		localctx = NewLitMonetaryContext(p, localctx)
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(61)
+			p.SetState(67)
 			p.Monetary()
 		}
 
@@ -1032,7 +1102,7 @@ func (p *NumScriptParser) Variable() (localctx IVariableContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(64)
+		p.SetState(70)
 		p.Match(NumScriptParserVARIABLE_NAME)
 	}
 
@@ -1089,6 +1159,82 @@ func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recogni
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
+type ExprRoundContext struct {
+	*ExpressionContext
+	rounding antlr.Token
+	expr     IExpressionContext
+}
+
+func NewExprRoundContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprRoundContext {
+	var p = new(ExprRoundContext)
+
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ExprRoundContext) GetRounding() antlr.Token { return s.rounding }
+
+func (s *ExprRoundContext) SetRounding(v antlr.Token) { s.rounding = v }
+
+func (s *ExprRoundContext) GetExpr() IExpressionContext { return s.expr }
+
+func (s *ExprRoundContext) SetExpr(v IExpressionContext) { s.expr = v }
+
+func (s *ExprRoundContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExprRoundContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *ExprRoundContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *ExprRoundContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ExprRoundContext) FLOOR() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFLOOR, 0)
+}
+
+func (s *ExprRoundContext) CEIL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserCEIL, 0)
+}
+
+func (s *ExprRoundContext) ROUND() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserROUND, 0)
+}
+
+func (s *ExprRoundContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprRound(s)
+	}
+}
+
+func (s *ExprRoundContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprRound(s)
+	}
+}
+
 type ExprAddSubContext struct {
 	*ExpressionContext
 	lhs IExpressionContext
@@ -1183,13 +1329,15 @@ func (s *ExprAddSubContext) ExitRule(listener antlr.ParseTreeListener) {
 	}
 }
 
-type ExprLiteralContext struct {
+type ExprMulDivContext struct {
 	*ExpressionContext
-	lit ILiteralContext
+	lhs IExpressionContext
+	op  antlr.Token
+	rhs IExpressionContext
 }
 
-func NewExprLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprLiteralContext {
-	var p = new(ExprLiteralContext)
+func NewExprMulDivContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprMulDivContext {
+	var p = new(ExprMulDivContext)
 
 	p.ExpressionContext = NewEmptyExpressionContext()
 	p.parser = parser
@@ -1198,20 +1346,53 @@ func NewExprLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Ex
 	return p
 }
 
-func (s *ExprLiteralContext) GetLit() ILiteralContext { return s.lit }
+func (s *ExprMulDivContext) GetOp() antlr.Token { return s.op }
 
-func (s *ExprLiteralContext) SetLit(v ILiteralContext) { s.lit = v }
+func (s *ExprMulDivContext) SetOp(v antlr.Token) { s.op = v }
 
-func (s *ExprLiteralContext) GetRuleContext() antlr.RuleContext {
+func (s *ExprMulDivContext) GetLhs() IExpressionContext { return s.lhs }
+
+func (s *ExprMulDivContext) GetRhs() IExpressionContext { return s.rhs }
+
+func (s *ExprMulDivContext) SetLhs(v IExpressionContext) { s.lhs = v }
+
+func (s *ExprMulDivContext) SetRhs(v IExpressionContext) { s.rhs = v }
+
+func (s *ExprMulDivContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExprLiteralContext) Literal() ILiteralContext {
+func (s *ExprMulDivContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ExprMulDivContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ILiteralContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -1219,28 +1400,36 @@ func (s *ExprLiteralContext) Literal() ILiteralContext {
 		return nil
 	}
 
-	return t.(ILiteralContext)
+	return t.(IExpressionContext)
 }
 
-func (s *ExprLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExprMulDivContext) OP_MUL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_MUL, 0)
+}
+
+func (s *ExprMulDivContext) OP_DIV() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_DIV, 0)
+}
+
+func (s *ExprMulDivContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterExprLiteral(s)
+		listenerT.EnterExprMulDiv(s)
 	}
 }
 
-func (s *ExprLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExprMulDivContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitExprLiteral(s)
+		listenerT.ExitExprMulDiv(s)
 	}
 }
 
-type ExprVariableContext struct {
+type ExprParensContext struct {
 	*ExpressionContext
-	var_ IVariableContext
+	expr IExpressionContext
 }
 
-func NewExprVariableContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprVariableContext {
-	var p = new(ExprVariableContext)
+func NewExprParensContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprParensContext {
+	var p = new(ExprParensContext)
 
 	p.ExpressionContext = NewEmptyExpressionContext()
 	p.parser = parser
@@ -1249,18 +1438,26 @@ func NewExprVariableContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *E
 	return p
 }
 
-func (s *ExprVariableContext) GetVar_() IVariableContext { return s.var_ }
+func (s *ExprParensContext) GetExpr() IExpressionContext { return s.expr }
 
-func (s *ExprVariableContext) SetVar_(v IVariableContext) { s.var_ = v }
+func (s *ExprParensContext) SetExpr(v IExpressionContext) { s.expr = v }
 
-func (s *ExprVariableContext) GetRuleContext() antlr.RuleContext {
+func (s *ExprParensContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExprVariableContext) Variable() IVariableContext {
+func (s *ExprParensContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *ExprParensContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *ExprParensContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IVariableContext); ok {
+		if _, ok := ctx.(IExpressionContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -1270,252 +1467,198 @@ func (s *ExprVariableContext) Variable() IVariableContext {
 		return nil
 	}
 
-	return t.(IVariableContext)
+	return t.(IExpressionContext)
 }
 
-func (s *ExprVariableContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExprParensContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterExprVariable(s)
+		listenerT.EnterExprParens(s)
 	}
 }
 
-func (s *ExprVariableContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExprParensContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitExprVariable(s)
+		listenerT.ExitExprParens(s)
 	}
 }
 
-func (p *NumScriptParser) Expression() (localctx IExpressionContext) {
-	return p.expression(0)
+type ExprLiteralContext struct {
+	*ExpressionContext
+	lit ILiteralContext
 }
 
-func (p *NumScriptParser) expression(_p int) (localctx IExpressionContext) {
-	this := p
-	_ = this
-
-	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
-	_parentState := p.GetState()
-	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
-	var _prevctx IExpressionContext = localctx
-	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 8
-	p.EnterRecursionRule(localctx, 8, NumScriptParserRULE_expression, _p)
-	var _la int
-
-	defer func() {
-		p.UnrollRecursionContexts(_parentctx)
-	}()
-
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
-			}
-		}
-	}()
+func NewExprLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprLiteralContext {
+	var p = new(ExprLiteralContext)
 
-	var _alt int
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
 
-	p.EnterOuterAlt(localctx, 1)
-	p.SetState(69)
-	p.GetErrorHandler().Sync(p)
+	return p
+}
 
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserLBRACK, NumScriptParserSTRING, NumScriptParserPORTION, NumScriptParserNUMBER, NumScriptParserACCOUNT, NumScriptParserASSET:
-		localctx = NewExprLiteralContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
+func (s *ExprLiteralContext) GetLit() ILiteralContext { return s.lit }
 
-		{
-			p.SetState(67)
+func (s *ExprLiteralContext) SetLit(v ILiteralContext) { s.lit = v }
 
-			var _x = p.Literal()
+func (s *ExprLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-			localctx.(*ExprLiteralContext).lit = _x
+func (s *ExprLiteralContext) Literal() ILiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
+	}
 
-	case NumScriptParserVARIABLE_NAME:
-		localctx = NewExprVariableContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(68)
+	if t == nil {
+		return nil
+	}
 
-			var _x = p.Variable()
+	return t.(ILiteralContext)
+}
 
-			localctx.(*ExprVariableContext).var_ = _x
-		}
+func (s *ExprLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterExprLiteral(s)
+	}
+}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+func (s *ExprLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitExprLiteral(s)
 	}
-	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(76)
-	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
+}
 
-	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-		if _alt == 1 {
-			if p.GetParseListeners() != nil {
-				p.TriggerExitRuleEvent()
-			}
-			_prevctx = localctx
-			localctx = NewExprAddSubContext(p, NewExpressionContext(p, _parentctx, _parentState))
-			localctx.(*ExprAddSubContext).lhs = _prevctx
+type ExprMinMaxContext struct {
+	*ExpressionContext
+	fn  antlr.Token
+	lhs IExpressionContext
+	rhs IExpressionContext
+}
 
-			p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
-			p.SetState(71)
+func NewExprMinMaxContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprMinMaxContext {
+	var p = new(ExprMinMaxContext)
 
-			if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-				panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
-			}
-			{
-				p.SetState(72)
+	p.ExpressionContext = NewEmptyExpressionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ExpressionContext))
 
-				var _lt = p.GetTokenStream().LT(1)
+	return p
+}
 
-				localctx.(*ExprAddSubContext).op = _lt
+func (s *ExprMinMaxContext) GetFn() antlr.Token { return s.fn }
 
-				_la = p.GetTokenStream().LA(1)
+func (s *ExprMinMaxContext) SetFn(v antlr.Token) { s.fn = v }
 
-				if !(_la == NumScriptParserOP_ADD || _la == NumScriptParserOP_SUB) {
-					var _ri = p.GetErrorHandler().RecoverInline(p)
+func (s *ExprMinMaxContext) GetLhs() IExpressionContext { return s.lhs }
 
-					localctx.(*ExprAddSubContext).op = _ri
-				} else {
-					p.GetErrorHandler().ReportMatch(p)
-					p.Consume()
-				}
-			}
-			{
-				p.SetState(73)
+func (s *ExprMinMaxContext) GetRhs() IExpressionContext { return s.rhs }
 
-				var _x = p.expression(4)
+func (s *ExprMinMaxContext) SetLhs(v IExpressionContext) { s.lhs = v }
 
-				localctx.(*ExprAddSubContext).rhs = _x
-			}
+func (s *ExprMinMaxContext) SetRhs(v IExpressionContext) { s.rhs = v }
 
-		}
-		p.SetState(78)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext())
-	}
+func (s *ExprMinMaxContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-	return localctx
-}
-
-// IAllotmentPortionContext is an interface to support dynamic dispatch.
-type IAllotmentPortionContext interface {
-	antlr.ParserRuleContext
-
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
-
-	// IsAllotmentPortionContext differentiates from other interfaces.
-	IsAllotmentPortionContext()
-}
-
-type AllotmentPortionContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
-}
-
-func NewEmptyAllotmentPortionContext() *AllotmentPortionContext {
-	var p = new(AllotmentPortionContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_allotmentPortion
-	return p
-}
-
-func (*AllotmentPortionContext) IsAllotmentPortionContext() {}
-
-func NewAllotmentPortionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AllotmentPortionContext {
-	var p = new(AllotmentPortionContext)
-
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
-
-	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_allotmentPortion
-
-	return p
+func (s *ExprMinMaxContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
 }
 
-func (s *AllotmentPortionContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *AllotmentPortionContext) CopyFrom(ctx *AllotmentPortionContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+func (s *ExprMinMaxContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
 }
 
-func (s *AllotmentPortionContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *ExprMinMaxContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
 
-func (s *AllotmentPortionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
 
-type AllotmentPortionRemainingContext struct {
-	*AllotmentPortionContext
+	return tst
 }
 
-func NewAllotmentPortionRemainingContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionRemainingContext {
-	var p = new(AllotmentPortionRemainingContext)
+func (s *ExprMinMaxContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
 
-	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*AllotmentPortionContext))
+	if t == nil {
+		return nil
+	}
 
-	return p
+	return t.(IExpressionContext)
 }
 
-func (s *AllotmentPortionRemainingContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *ExprMinMaxContext) MIN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserMIN, 0)
 }
 
-func (s *AllotmentPortionRemainingContext) REMAINING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserREMAINING, 0)
+func (s *ExprMinMaxContext) MAX() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserMAX, 0)
 }
 
-func (s *AllotmentPortionRemainingContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExprMinMaxContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterAllotmentPortionRemaining(s)
+		listenerT.EnterExprMinMax(s)
 	}
 }
 
-func (s *AllotmentPortionRemainingContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExprMinMaxContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitAllotmentPortionRemaining(s)
+		listenerT.ExitExprMinMax(s)
 	}
 }
 
-type AllotmentPortionVarContext struct {
-	*AllotmentPortionContext
-	por IVariableContext
+type ExprVariableContext struct {
+	*ExpressionContext
+	var_ IVariableContext
 }
 
-func NewAllotmentPortionVarContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionVarContext {
-	var p = new(AllotmentPortionVarContext)
+func NewExprVariableContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExprVariableContext {
+	var p = new(ExprVariableContext)
 
-	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.ExpressionContext = NewEmptyExpressionContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*AllotmentPortionContext))
+	p.CopyFrom(ctx.(*ExpressionContext))
 
 	return p
 }
 
-func (s *AllotmentPortionVarContext) GetPor() IVariableContext { return s.por }
+func (s *ExprVariableContext) GetVar_() IVariableContext { return s.var_ }
 
-func (s *AllotmentPortionVarContext) SetPor(v IVariableContext) { s.por = v }
+func (s *ExprVariableContext) SetVar_(v IVariableContext) { s.var_ = v }
 
-func (s *AllotmentPortionVarContext) GetRuleContext() antlr.RuleContext {
+func (s *ExprVariableContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *AllotmentPortionVarContext) Variable() IVariableContext {
+func (s *ExprVariableContext) Variable() IVariableContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IVariableContext); ok {
@@ -1531,61 +1674,37 @@ func (s *AllotmentPortionVarContext) Variable() IVariableContext {
 	return t.(IVariableContext)
 }
 
-func (s *AllotmentPortionVarContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterAllotmentPortionVar(s)
-	}
-}
-
-func (s *AllotmentPortionVarContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExprVariableContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitAllotmentPortionVar(s)
+		listenerT.EnterExprVariable(s)
 	}
 }
 
-type AllotmentPortionConstContext struct {
-	*AllotmentPortionContext
-}
-
-func NewAllotmentPortionConstContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionConstContext {
-	var p = new(AllotmentPortionConstContext)
-
-	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*AllotmentPortionContext))
-
-	return p
-}
-
-func (s *AllotmentPortionConstContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *AllotmentPortionConstContext) PORTION() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserPORTION, 0)
-}
-
-func (s *AllotmentPortionConstContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExprVariableContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterAllotmentPortionConst(s)
+		listenerT.ExitExprVariable(s)
 	}
 }
 
-func (s *AllotmentPortionConstContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitAllotmentPortionConst(s)
-	}
+func (p *NumScriptParser) Expression() (localctx IExpressionContext) {
+	return p.expression(0)
 }
 
-func (p *NumScriptParser) AllotmentPortion() (localctx IAllotmentPortionContext) {
+func (p *NumScriptParser) expression(_p int) (localctx IExpressionContext) {
 	this := p
 	_ = this
 
-	localctx = NewAllotmentPortionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, NumScriptParserRULE_allotmentPortion)
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+	_parentState := p.GetState()
+	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IExpressionContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 8
+	p.EnterRecursionRule(localctx, 8, NumScriptParserRULE_expression, _p)
+	var _la int
 
 	defer func() {
-		p.ExitRule()
+		p.UnrollRecursionContexts(_parentctx)
 	}()
 
 	defer func() {
@@ -1600,196 +1719,358 @@ func (p *NumScriptParser) AllotmentPortion() (localctx IAllotmentPortionContext)
 		}
 	}()
 
-	p.SetState(82)
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(91)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserPORTION:
-		localctx = NewAllotmentPortionConstContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	case NumScriptParserLPAREN:
+		localctx = NewExprParensContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
 		{
-			p.SetState(79)
-			p.Match(NumScriptParserPORTION)
+			p.SetState(73)
+			p.Match(NumScriptParserLPAREN)
 		}
-
-	case NumScriptParserVARIABLE_NAME:
-		localctx = NewAllotmentPortionVarContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(80)
+			p.SetState(74)
 
-			var _x = p.Variable()
+			var _x = p.expression(0)
 
-			localctx.(*AllotmentPortionVarContext).por = _x
+			localctx.(*ExprParensContext).expr = _x
 		}
-
-	case NumScriptParserREMAINING:
-		localctx = NewAllotmentPortionRemainingContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(81)
-			p.Match(NumScriptParserREMAINING)
+			p.SetState(75)
+			p.Match(NumScriptParserRPAREN)
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-	}
+	case NumScriptParserMAX, NumScriptParserMIN:
+		localctx = NewExprMinMaxContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(77)
 
-	return localctx
-}
+			var _lt = p.GetTokenStream().LT(1)
 
-// IDestinationInOrderContext is an interface to support dynamic dispatch.
-type IDestinationInOrderContext interface {
-	antlr.ParserRuleContext
+			localctx.(*ExprMinMaxContext).fn = _lt
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+			_la = p.GetTokenStream().LA(1)
 
-	// Get_expression returns the _expression rule contexts.
-	Get_expression() IExpressionContext
+			if !(_la == NumScriptParserMAX || _la == NumScriptParserMIN) {
+				var _ri = p.GetErrorHandler().RecoverInline(p)
 
-	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
-	Get_keptOrDestination() IKeptOrDestinationContext
+				localctx.(*ExprMinMaxContext).fn = _ri
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(78)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(79)
 
-	// GetRemainingDest returns the remainingDest rule contexts.
-	GetRemainingDest() IKeptOrDestinationContext
+			var _x = p.expression(0)
 
-	// Set_expression sets the _expression rule contexts.
-	Set_expression(IExpressionContext)
+			localctx.(*ExprMinMaxContext).lhs = _x
+		}
+		{
+			p.SetState(80)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(81)
 
-	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
-	Set_keptOrDestination(IKeptOrDestinationContext)
+			var _x = p.expression(0)
 
-	// SetRemainingDest sets the remainingDest rule contexts.
-	SetRemainingDest(IKeptOrDestinationContext)
+			localctx.(*ExprMinMaxContext).rhs = _x
+		}
+		{
+			p.SetState(82)
+			p.Match(NumScriptParserRPAREN)
+		}
 
-	// GetAmounts returns the amounts rule context list.
-	GetAmounts() []IExpressionContext
+	case NumScriptParserFLOOR, NumScriptParserCEIL, NumScriptParserROUND:
+		localctx = NewExprRoundContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(84)
 
-	// GetDests returns the dests rule context list.
-	GetDests() []IKeptOrDestinationContext
+			var _lt = p.GetTokenStream().LT(1)
 
-	// SetAmounts sets the amounts rule context list.
-	SetAmounts([]IExpressionContext)
+			localctx.(*ExprRoundContext).rounding = _lt
 
-	// SetDests sets the dests rule context list.
-	SetDests([]IKeptOrDestinationContext)
+			_la = p.GetTokenStream().LA(1)
 
-	// IsDestinationInOrderContext differentiates from other interfaces.
-	IsDestinationInOrderContext()
+			if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<NumScriptParserFLOOR)|(1<<NumScriptParserCEIL)|(1<<NumScriptParserROUND))) != 0) {
+				var _ri = p.GetErrorHandler().RecoverInline(p)
+
+				localctx.(*ExprRoundContext).rounding = _ri
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(85)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(86)
+
+			var _x = p.expression(0)
+
+			localctx.(*ExprRoundContext).expr = _x
+		}
+		{
+			p.SetState(87)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserLBRACK, NumScriptParserSTRING, NumScriptParserPORTION, NumScriptParserNUMBER, NumScriptParserACCOUNT, NumScriptParserASSET:
+		localctx = NewExprLiteralContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(89)
+
+			var _x = p.Literal()
+
+			localctx.(*ExprLiteralContext).lit = _x
+		}
+
+	case NumScriptParserVARIABLE_NAME:
+		localctx = NewExprVariableContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(90)
+
+			var _x = p.Variable()
+
+			localctx.(*ExprVariableContext).var_ = _x
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(101)
+	p.GetErrorHandler().Sync(p)
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext())
+
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(99)
+			p.GetErrorHandler().Sync(p)
+			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewExprMulDivContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprMulDivContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(93)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				}
+				{
+					p.SetState(94)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*ExprMulDivContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == NumScriptParserOP_MUL || _la == NumScriptParserOP_DIV) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*ExprMulDivContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(95)
+
+					var _x = p.expression(8)
+
+					localctx.(*ExprMulDivContext).rhs = _x
+				}
+
+			case 2:
+				localctx = NewExprAddSubContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				localctx.(*ExprAddSubContext).lhs = _prevctx
+
+				p.PushNewRecursionContext(localctx, _startState, NumScriptParserRULE_expression)
+				p.SetState(96)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				}
+				{
+					p.SetState(97)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*ExprAddSubContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == NumScriptParserOP_ADD || _la == NumScriptParserOP_SUB) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*ExprAddSubContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(98)
+
+					var _x = p.expression(7)
+
+					localctx.(*ExprAddSubContext).rhs = _x
+				}
+
+			}
+
+		}
+		p.SetState(103)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext())
+	}
+
+	return localctx
 }
 
-type DestinationInOrderContext struct {
+// IAllotmentPortionContext is an interface to support dynamic dispatch.
+type IAllotmentPortionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsAllotmentPortionContext differentiates from other interfaces.
+	IsAllotmentPortionContext()
+}
+
+type AllotmentPortionContext struct {
 	*antlr.BaseParserRuleContext
-	parser             antlr.Parser
-	_expression        IExpressionContext
-	amounts            []IExpressionContext
-	_keptOrDestination IKeptOrDestinationContext
-	dests              []IKeptOrDestinationContext
-	remainingDest      IKeptOrDestinationContext
+	parser antlr.Parser
 }
 
-func NewEmptyDestinationInOrderContext() *DestinationInOrderContext {
-	var p = new(DestinationInOrderContext)
+func NewEmptyAllotmentPortionContext() *AllotmentPortionContext {
+	var p = new(AllotmentPortionContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_destinationInOrder
+	p.RuleIndex = NumScriptParserRULE_allotmentPortion
 	return p
 }
 
-func (*DestinationInOrderContext) IsDestinationInOrderContext() {}
+func (*AllotmentPortionContext) IsAllotmentPortionContext() {}
 
-func NewDestinationInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationInOrderContext {
-	var p = new(DestinationInOrderContext)
+func NewAllotmentPortionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AllotmentPortionContext {
+	var p = new(AllotmentPortionContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_destinationInOrder
+	p.RuleIndex = NumScriptParserRULE_allotmentPortion
 
 	return p
 }
 
-func (s *DestinationInOrderContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *DestinationInOrderContext) Get_expression() IExpressionContext { return s._expression }
+func (s *AllotmentPortionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *DestinationInOrderContext) Get_keptOrDestination() IKeptOrDestinationContext {
-	return s._keptOrDestination
+func (s *AllotmentPortionContext) CopyFrom(ctx *AllotmentPortionContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *DestinationInOrderContext) GetRemainingDest() IKeptOrDestinationContext {
-	return s.remainingDest
+func (s *AllotmentPortionContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *DestinationInOrderContext) Set_expression(v IExpressionContext) { s._expression = v }
-
-func (s *DestinationInOrderContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
-	s._keptOrDestination = v
+func (s *AllotmentPortionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *DestinationInOrderContext) SetRemainingDest(v IKeptOrDestinationContext) {
-	s.remainingDest = v
+type AllotmentPortionRemainingContext struct {
+	*AllotmentPortionContext
 }
 
-func (s *DestinationInOrderContext) GetAmounts() []IExpressionContext { return s.amounts }
+func NewAllotmentPortionRemainingContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionRemainingContext {
+	var p = new(AllotmentPortionRemainingContext)
 
-func (s *DestinationInOrderContext) GetDests() []IKeptOrDestinationContext { return s.dests }
+	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*AllotmentPortionContext))
 
-func (s *DestinationInOrderContext) SetAmounts(v []IExpressionContext) { s.amounts = v }
+	return p
+}
 
-func (s *DestinationInOrderContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+func (s *AllotmentPortionRemainingContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-func (s *DestinationInOrderContext) LBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLBRACE, 0)
+func (s *AllotmentPortionRemainingContext) REMAINING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserREMAINING, 0)
 }
 
-func (s *DestinationInOrderContext) AllNEWLINE() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserNEWLINE)
+func (s *AllotmentPortionRemainingContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAllotmentPortionRemaining(s)
+	}
 }
 
-func (s *DestinationInOrderContext) NEWLINE(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserNEWLINE, i)
+func (s *AllotmentPortionRemainingContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAllotmentPortionRemaining(s)
+	}
 }
 
-func (s *DestinationInOrderContext) REMAINING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserREMAINING, 0)
+type AllotmentPortionVarContext struct {
+	*AllotmentPortionContext
+	por IVariableContext
 }
 
-func (s *DestinationInOrderContext) RBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRBRACE, 0)
+func NewAllotmentPortionVarContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionVarContext {
+	var p = new(AllotmentPortionVarContext)
+
+	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*AllotmentPortionContext))
+
+	return p
 }
 
-func (s *DestinationInOrderContext) AllKeptOrDestination() []IKeptOrDestinationContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
-			len++
-		}
-	}
+func (s *AllotmentPortionVarContext) GetPor() IVariableContext { return s.por }
 
-	tst := make([]IKeptOrDestinationContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IKeptOrDestinationContext); ok {
-			tst[i] = t.(IKeptOrDestinationContext)
-			i++
-		}
-	}
+func (s *AllotmentPortionVarContext) SetPor(v IVariableContext) { s.por = v }
 
-	return tst
+func (s *AllotmentPortionVarContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *DestinationInOrderContext) KeptOrDestination(i int) IKeptOrDestinationContext {
+func (s *AllotmentPortionVarContext) Variable() IVariableContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(IVariableContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -1797,85 +2078,61 @@ func (s *DestinationInOrderContext) KeptOrDestination(i int) IKeptOrDestinationC
 		return nil
 	}
 
-	return t.(IKeptOrDestinationContext)
+	return t.(IVariableContext)
 }
 
-func (s *DestinationInOrderContext) AllMAX() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserMAX)
+func (s *AllotmentPortionVarContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAllotmentPortionVar(s)
+	}
 }
 
-func (s *DestinationInOrderContext) MAX(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserMAX, i)
+func (s *AllotmentPortionVarContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAllotmentPortionVar(s)
+	}
 }
 
-func (s *DestinationInOrderContext) AllExpression() []IExpressionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
-			len++
-		}
-	}
+type AllotmentPortionConstContext struct {
+	*AllotmentPortionContext
+}
 
-	tst := make([]IExpressionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
-			i++
-		}
-	}
-
-	return tst
-}
-
-func (s *DestinationInOrderContext) Expression(i int) IExpressionContext {
-	var t antlr.RuleContext
-	j := 0
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
-		}
-	}
+func NewAllotmentPortionConstContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AllotmentPortionConstContext {
+	var p = new(AllotmentPortionConstContext)
 
-	if t == nil {
-		return nil
-	}
+	p.AllotmentPortionContext = NewEmptyAllotmentPortionContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*AllotmentPortionContext))
 
-	return t.(IExpressionContext)
+	return p
 }
 
-func (s *DestinationInOrderContext) GetRuleContext() antlr.RuleContext {
+func (s *AllotmentPortionConstContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *DestinationInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *AllotmentPortionConstContext) PORTION() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserPORTION, 0)
 }
 
-func (s *DestinationInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AllotmentPortionConstContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestinationInOrder(s)
+		listenerT.EnterAllotmentPortionConst(s)
 	}
 }
 
-func (s *DestinationInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AllotmentPortionConstContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestinationInOrder(s)
+		listenerT.ExitAllotmentPortionConst(s)
 	}
 }
 
-func (p *NumScriptParser) DestinationInOrder() (localctx IDestinationInOrderContext) {
+func (p *NumScriptParser) AllotmentPortion() (localctx IAllotmentPortionContext) {
 	this := p
 	_ = this
 
-	localctx = NewDestinationInOrderContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, NumScriptParserRULE_destinationInOrder)
-	var _la int
+	localctx = NewAllotmentPortionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, NumScriptParserRULE_allotmentPortion)
 
 	defer func() {
 		p.ExitRule()
@@ -1893,192 +2150,179 @@ func (p *NumScriptParser) DestinationInOrder() (localctx IDestinationInOrderCont
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(84)
-		p.Match(NumScriptParserLBRACE)
-	}
-	{
-		p.SetState(85)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	p.SetState(91)
+	p.SetState(107)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = _la == NumScriptParserMAX {
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserPORTION:
+		localctx = NewAllotmentPortionConstContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(86)
-			p.Match(NumScriptParserMAX)
+			p.SetState(104)
+			p.Match(NumScriptParserPORTION)
 		}
-		{
-			p.SetState(87)
 
-			var _x = p.expression(0)
-
-			localctx.(*DestinationInOrderContext)._expression = _x
-		}
-		localctx.(*DestinationInOrderContext).amounts = append(localctx.(*DestinationInOrderContext).amounts, localctx.(*DestinationInOrderContext)._expression)
+	case NumScriptParserVARIABLE_NAME:
+		localctx = NewAllotmentPortionVarContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(88)
+			p.SetState(105)
 
-			var _x = p.KeptOrDestination()
+			var _x = p.Variable()
 
-			localctx.(*DestinationInOrderContext)._keptOrDestination = _x
+			localctx.(*AllotmentPortionVarContext).por = _x
 		}
-		localctx.(*DestinationInOrderContext).dests = append(localctx.(*DestinationInOrderContext).dests, localctx.(*DestinationInOrderContext)._keptOrDestination)
+
+	case NumScriptParserREMAINING:
+		localctx = NewAllotmentPortionRemainingContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(89)
-			p.Match(NumScriptParserNEWLINE)
+			p.SetState(106)
+			p.Match(NumScriptParserREMAINING)
 		}
 
-		p.SetState(93)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
-	}
-	{
-		p.SetState(95)
-		p.Match(NumScriptParserREMAINING)
-	}
-	{
-		p.SetState(96)
-
-		var _x = p.KeptOrDestination()
-
-		localctx.(*DestinationInOrderContext).remainingDest = _x
-	}
-	{
-		p.SetState(97)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	{
-		p.SetState(98)
-		p.Match(NumScriptParserRBRACE)
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IDestinationAllotmentContext is an interface to support dynamic dispatch.
-type IDestinationAllotmentContext interface {
+// IDestinationInOrderContext is an interface to support dynamic dispatch.
+type IDestinationInOrderContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
-	Get_allotmentPortion() IAllotmentPortionContext
+	// Get_expression returns the _expression rule contexts.
+	Get_expression() IExpressionContext
 
 	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
 	Get_keptOrDestination() IKeptOrDestinationContext
 
-	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
-	Set_allotmentPortion(IAllotmentPortionContext)
+	// GetRemainingDest returns the remainingDest rule contexts.
+	GetRemainingDest() IKeptOrDestinationContext
+
+	// Set_expression sets the _expression rule contexts.
+	Set_expression(IExpressionContext)
 
 	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
 	Set_keptOrDestination(IKeptOrDestinationContext)
 
-	// GetPortions returns the portions rule context list.
-	GetPortions() []IAllotmentPortionContext
+	// SetRemainingDest sets the remainingDest rule contexts.
+	SetRemainingDest(IKeptOrDestinationContext)
+
+	// GetAmounts returns the amounts rule context list.
+	GetAmounts() []IExpressionContext
 
 	// GetDests returns the dests rule context list.
 	GetDests() []IKeptOrDestinationContext
 
-	// SetPortions sets the portions rule context list.
-	SetPortions([]IAllotmentPortionContext)
+	// SetAmounts sets the amounts rule context list.
+	SetAmounts([]IExpressionContext)
 
 	// SetDests sets the dests rule context list.
 	SetDests([]IKeptOrDestinationContext)
 
-	// IsDestinationAllotmentContext differentiates from other interfaces.
-	IsDestinationAllotmentContext()
+	// IsDestinationInOrderContext differentiates from other interfaces.
+	IsDestinationInOrderContext()
 }
 
-type DestinationAllotmentContext struct {
+type DestinationInOrderContext struct {
 	*antlr.BaseParserRuleContext
 	parser             antlr.Parser
-	_allotmentPortion  IAllotmentPortionContext
-	portions           []IAllotmentPortionContext
+	_expression        IExpressionContext
+	amounts            []IExpressionContext
 	_keptOrDestination IKeptOrDestinationContext
 	dests              []IKeptOrDestinationContext
+	remainingDest      IKeptOrDestinationContext
 }
 
-func NewEmptyDestinationAllotmentContext() *DestinationAllotmentContext {
-	var p = new(DestinationAllotmentContext)
+func NewEmptyDestinationInOrderContext() *DestinationInOrderContext {
+	var p = new(DestinationInOrderContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_destinationAllotment
+	p.RuleIndex = NumScriptParserRULE_destinationInOrder
 	return p
 }
 
-func (*DestinationAllotmentContext) IsDestinationAllotmentContext() {}
+func (*DestinationInOrderContext) IsDestinationInOrderContext() {}
 
-func NewDestinationAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationAllotmentContext {
-	var p = new(DestinationAllotmentContext)
+func NewDestinationInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationInOrderContext {
+	var p = new(DestinationInOrderContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_destinationAllotment
+	p.RuleIndex = NumScriptParserRULE_destinationInOrder
 
 	return p
 }
 
-func (s *DestinationAllotmentContext) GetParser() antlr.Parser { return s.parser }
+func (s *DestinationInOrderContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *DestinationAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
-	return s._allotmentPortion
-}
+func (s *DestinationInOrderContext) Get_expression() IExpressionContext { return s._expression }
 
-func (s *DestinationAllotmentContext) Get_keptOrDestination() IKeptOrDestinationContext {
+func (s *DestinationInOrderContext) Get_keptOrDestination() IKeptOrDestinationContext {
 	return s._keptOrDestination
 }
 
-func (s *DestinationAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
-	s._allotmentPortion = v
+func (s *DestinationInOrderContext) GetRemainingDest() IKeptOrDestinationContext {
+	return s.remainingDest
 }
 
-func (s *DestinationAllotmentContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
+func (s *DestinationInOrderContext) Set_expression(v IExpressionContext) { s._expression = v }
+
+func (s *DestinationInOrderContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
 	s._keptOrDestination = v
 }
 
-func (s *DestinationAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
+func (s *DestinationInOrderContext) SetRemainingDest(v IKeptOrDestinationContext) {
+	s.remainingDest = v
+}
 
-func (s *DestinationAllotmentContext) GetDests() []IKeptOrDestinationContext { return s.dests }
+func (s *DestinationInOrderContext) GetAmounts() []IExpressionContext { return s.amounts }
 
-func (s *DestinationAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
+func (s *DestinationInOrderContext) GetDests() []IKeptOrDestinationContext { return s.dests }
 
-func (s *DestinationAllotmentContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+func (s *DestinationInOrderContext) SetAmounts(v []IExpressionContext) { s.amounts = v }
 
-func (s *DestinationAllotmentContext) LBRACE() antlr.TerminalNode {
+func (s *DestinationInOrderContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+
+func (s *DestinationInOrderContext) LBRACE() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *DestinationAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
+func (s *DestinationInOrderContext) AllNEWLINE() []antlr.TerminalNode {
 	return s.GetTokens(NumScriptParserNEWLINE)
 }
 
-func (s *DestinationAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
+func (s *DestinationInOrderContext) NEWLINE(i int) antlr.TerminalNode {
 	return s.GetToken(NumScriptParserNEWLINE, i)
 }
 
-func (s *DestinationAllotmentContext) RBRACE() antlr.TerminalNode {
+func (s *DestinationInOrderContext) REMAINING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserREMAINING, 0)
+}
+
+func (s *DestinationInOrderContext) RBRACE() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *DestinationAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
+func (s *DestinationInOrderContext) AllKeptOrDestination() []IKeptOrDestinationContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IAllotmentPortionContext, len)
+	tst := make([]IKeptOrDestinationContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IAllotmentPortionContext); ok {
-			tst[i] = t.(IAllotmentPortionContext)
+		if t, ok := ctx.(IKeptOrDestinationContext); ok {
+			tst[i] = t.(IKeptOrDestinationContext)
 			i++
 		}
 	}
@@ -2086,11 +2330,11 @@ func (s *DestinationAllotmentContext) AllAllotmentPortion() []IAllotmentPortionC
 	return tst
 }
 
-func (s *DestinationAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
+func (s *DestinationInOrderContext) KeptOrDestination(i int) IKeptOrDestinationContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -2103,23 +2347,31 @@ func (s *DestinationAllotmentContext) AllotmentPortion(i int) IAllotmentPortionC
 		return nil
 	}
 
-	return t.(IAllotmentPortionContext)
+	return t.(IKeptOrDestinationContext)
 }
 
-func (s *DestinationAllotmentContext) AllKeptOrDestination() []IKeptOrDestinationContext {
+func (s *DestinationInOrderContext) AllMAX() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserMAX)
+}
+
+func (s *DestinationInOrderContext) MAX(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserMAX, i)
+}
+
+func (s *DestinationInOrderContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+		if _, ok := ctx.(IExpressionContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IKeptOrDestinationContext, len)
+	tst := make([]IExpressionContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IKeptOrDestinationContext); ok {
-			tst[i] = t.(IKeptOrDestinationContext)
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
 			i++
 		}
 	}
@@ -2127,11 +2379,11 @@ func (s *DestinationAllotmentContext) AllKeptOrDestination() []IKeptOrDestinatio
 	return tst
 }
 
-func (s *DestinationAllotmentContext) KeptOrDestination(i int) IKeptOrDestinationContext {
+func (s *DestinationInOrderContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+		if _, ok := ctx.(IExpressionContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -2144,35 +2396,35 @@ func (s *DestinationAllotmentContext) KeptOrDestination(i int) IKeptOrDestinatio
 		return nil
 	}
 
-	return t.(IKeptOrDestinationContext)
+	return t.(IExpressionContext)
 }
 
-func (s *DestinationAllotmentContext) GetRuleContext() antlr.RuleContext {
+func (s *DestinationInOrderContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *DestinationAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *DestinationInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *DestinationAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DestinationInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterDestinationAllotment(s)
+		listenerT.EnterDestinationInOrder(s)
 	}
 }
 
-func (s *DestinationAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DestinationInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitDestinationAllotment(s)
+		listenerT.ExitDestinationInOrder(s)
 	}
 }
 
-func (p *NumScriptParser) DestinationAllotment() (localctx IDestinationAllotmentContext) {
+func (p *NumScriptParser) DestinationInOrder() (localctx IDestinationInOrderContext) {
 	this := p
 	_ = this
 
-	localctx = NewDestinationAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, NumScriptParserRULE_destinationAllotment)
+	localctx = NewDestinationInOrderContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, NumScriptParserRULE_destinationInOrder)
 	var _la int
 
 	defer func() {
@@ -2193,163 +2445,248 @@ func (p *NumScriptParser) DestinationAllotment() (localctx IDestinationAllotment
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(100)
+		p.SetState(109)
 		p.Match(NumScriptParserLBRACE)
 	}
 	{
-		p.SetState(101)
+		p.SetState(110)
 		p.Match(NumScriptParserNEWLINE)
 	}
-	p.SetState(106)
+	p.SetState(116)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la-38)&-(0x1f+1)) == 0 && ((1<<uint((_la-38)))&((1<<(NumScriptParserPORTION-38))|(1<<(NumScriptParserREMAINING-38))|(1<<(NumScriptParserVARIABLE_NAME-38)))) != 0) {
+	for ok := true; ok; ok = _la == NumScriptParserMAX {
+		{
+			p.SetState(111)
+			p.Match(NumScriptParserMAX)
+		}
 		{
-			p.SetState(102)
+			p.SetState(112)
 
-			var _x = p.AllotmentPortion()
+			var _x = p.expression(0)
 
-			localctx.(*DestinationAllotmentContext)._allotmentPortion = _x
+			localctx.(*DestinationInOrderContext)._expression = _x
 		}
-		localctx.(*DestinationAllotmentContext).portions = append(localctx.(*DestinationAllotmentContext).portions, localctx.(*DestinationAllotmentContext)._allotmentPortion)
+		localctx.(*DestinationInOrderContext).amounts = append(localctx.(*DestinationInOrderContext).amounts, localctx.(*DestinationInOrderContext)._expression)
 		{
-			p.SetState(103)
+			p.SetState(113)
 
 			var _x = p.KeptOrDestination()
 
-			localctx.(*DestinationAllotmentContext)._keptOrDestination = _x
+			localctx.(*DestinationInOrderContext)._keptOrDestination = _x
 		}
-		localctx.(*DestinationAllotmentContext).dests = append(localctx.(*DestinationAllotmentContext).dests, localctx.(*DestinationAllotmentContext)._keptOrDestination)
+		localctx.(*DestinationInOrderContext).dests = append(localctx.(*DestinationInOrderContext).dests, localctx.(*DestinationInOrderContext)._keptOrDestination)
 		{
-			p.SetState(104)
+			p.SetState(114)
 			p.Match(NumScriptParserNEWLINE)
 		}
 
-		p.SetState(108)
+		p.SetState(118)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(110)
+		p.SetState(120)
+		p.Match(NumScriptParserREMAINING)
+	}
+	{
+		p.SetState(121)
+
+		var _x = p.KeptOrDestination()
+
+		localctx.(*DestinationInOrderContext).remainingDest = _x
+	}
+	{
+		p.SetState(122)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	{
+		p.SetState(123)
 		p.Match(NumScriptParserRBRACE)
 	}
 
 	return localctx
 }
 
-// IKeptOrDestinationContext is an interface to support dynamic dispatch.
-type IKeptOrDestinationContext interface {
+// IDestinationAllotmentContext is an interface to support dynamic dispatch.
+type IDestinationAllotmentContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsKeptOrDestinationContext differentiates from other interfaces.
-	IsKeptOrDestinationContext()
+	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
+	Get_allotmentPortion() IAllotmentPortionContext
+
+	// Get_keptOrDestination returns the _keptOrDestination rule contexts.
+	Get_keptOrDestination() IKeptOrDestinationContext
+
+	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
+	Set_allotmentPortion(IAllotmentPortionContext)
+
+	// Set_keptOrDestination sets the _keptOrDestination rule contexts.
+	Set_keptOrDestination(IKeptOrDestinationContext)
+
+	// GetPortions returns the portions rule context list.
+	GetPortions() []IAllotmentPortionContext
+
+	// GetDests returns the dests rule context list.
+	GetDests() []IKeptOrDestinationContext
+
+	// SetPortions sets the portions rule context list.
+	SetPortions([]IAllotmentPortionContext)
+
+	// SetDests sets the dests rule context list.
+	SetDests([]IKeptOrDestinationContext)
+
+	// IsDestinationAllotmentContext differentiates from other interfaces.
+	IsDestinationAllotmentContext()
 }
 
-type KeptOrDestinationContext struct {
+type DestinationAllotmentContext struct {
 	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+	parser             antlr.Parser
+	_allotmentPortion  IAllotmentPortionContext
+	portions           []IAllotmentPortionContext
+	_keptOrDestination IKeptOrDestinationContext
+	dests              []IKeptOrDestinationContext
 }
 
-func NewEmptyKeptOrDestinationContext() *KeptOrDestinationContext {
-	var p = new(KeptOrDestinationContext)
+func NewEmptyDestinationAllotmentContext() *DestinationAllotmentContext {
+	var p = new(DestinationAllotmentContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+	p.RuleIndex = NumScriptParserRULE_destinationAllotment
 	return p
 }
 
-func (*KeptOrDestinationContext) IsKeptOrDestinationContext() {}
+func (*DestinationAllotmentContext) IsDestinationAllotmentContext() {}
 
-func NewKeptOrDestinationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KeptOrDestinationContext {
-	var p = new(KeptOrDestinationContext)
+func NewDestinationAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DestinationAllotmentContext {
+	var p = new(DestinationAllotmentContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+	p.RuleIndex = NumScriptParserRULE_destinationAllotment
 
 	return p
 }
 
-func (s *KeptOrDestinationContext) GetParser() antlr.Parser { return s.parser }
+func (s *DestinationAllotmentContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *KeptOrDestinationContext) CopyFrom(ctx *KeptOrDestinationContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+func (s *DestinationAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
+	return s._allotmentPortion
 }
 
-func (s *KeptOrDestinationContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *DestinationAllotmentContext) Get_keptOrDestination() IKeptOrDestinationContext {
+	return s._keptOrDestination
 }
 
-func (s *KeptOrDestinationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *DestinationAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
+	s._allotmentPortion = v
 }
 
-type IsKeptContext struct {
-	*KeptOrDestinationContext
+func (s *DestinationAllotmentContext) Set_keptOrDestination(v IKeptOrDestinationContext) {
+	s._keptOrDestination = v
 }
 
-func NewIsKeptContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsKeptContext {
-	var p = new(IsKeptContext)
+func (s *DestinationAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
 
-	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+func (s *DestinationAllotmentContext) GetDests() []IKeptOrDestinationContext { return s.dests }
 
-	return p
+func (s *DestinationAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
+
+func (s *DestinationAllotmentContext) SetDests(v []IKeptOrDestinationContext) { s.dests = v }
+
+func (s *DestinationAllotmentContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *IsKeptContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *DestinationAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
 }
 
-func (s *IsKeptContext) KEPT() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserKEPT, 0)
+func (s *DestinationAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
 }
 
-func (s *IsKeptContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterIsKept(s)
-	}
+func (s *DestinationAllotmentContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *IsKeptContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitIsKept(s)
+func (s *DestinationAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
+			len++
+		}
 	}
-}
 
-type IsDestinationContext struct {
-	*KeptOrDestinationContext
+	tst := make([]IAllotmentPortionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IAllotmentPortionContext); ok {
+			tst[i] = t.(IAllotmentPortionContext)
+			i++
+		}
+	}
+
+	return tst
 }
 
-func NewIsDestinationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsDestinationContext {
-	var p = new(IsDestinationContext)
+func (s *DestinationAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
 
-	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+	if t == nil {
+		return nil
+	}
 
-	return p
+	return t.(IAllotmentPortionContext)
 }
 
-func (s *IsDestinationContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *DestinationAllotmentContext) AllKeptOrDestination() []IKeptOrDestinationContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+			len++
+		}
+	}
 
-func (s *IsDestinationContext) TO() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserTO, 0)
+	tst := make([]IKeptOrDestinationContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IKeptOrDestinationContext); ok {
+			tst[i] = t.(IKeptOrDestinationContext)
+			i++
+		}
+	}
+
+	return tst
 }
 
-func (s *IsDestinationContext) Destination() IDestinationContext {
+func (s *DestinationAllotmentContext) KeptOrDestination(i int) IKeptOrDestinationContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IDestinationContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IKeptOrDestinationContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -2357,27 +2694,36 @@ func (s *IsDestinationContext) Destination() IDestinationContext {
 		return nil
 	}
 
-	return t.(IDestinationContext)
+	return t.(IKeptOrDestinationContext)
 }
 
-func (s *IsDestinationContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *DestinationAllotmentContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DestinationAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DestinationAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterIsDestination(s)
+		listenerT.EnterDestinationAllotment(s)
 	}
 }
 
-func (s *IsDestinationContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *DestinationAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitIsDestination(s)
+		listenerT.ExitDestinationAllotment(s)
 	}
 }
 
-func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContext) {
+func (p *NumScriptParser) DestinationAllotment() (localctx IDestinationAllotmentContext) {
 	this := p
 	_ = this
 
-	localctx = NewKeptOrDestinationContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, NumScriptParserRULE_keptOrDestination)
+	localctx = NewDestinationAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, NumScriptParserRULE_destinationAllotment)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -2395,19 +2741,223 @@ func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContex
 		}
 	}()
 
-	p.SetState(115)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(125)
+		p.Match(NumScriptParserLBRACE)
+	}
+	{
+		p.SetState(126)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	p.SetState(131)
 	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserTO:
-		localctx = NewIsDestinationContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	for ok := true; ok; ok = (((_la-56)&-(0x1f+1)) == 0 && ((1<<uint((_la-56)))&((1<<(NumScriptParserPORTION-56))|(1<<(NumScriptParserREMAINING-56))|(1<<(NumScriptParserVARIABLE_NAME-56)))) != 0) {
 		{
-			p.SetState(112)
-			p.Match(NumScriptParserTO)
-		}
+			p.SetState(127)
+
+			var _x = p.AllotmentPortion()
+
+			localctx.(*DestinationAllotmentContext)._allotmentPortion = _x
+		}
+		localctx.(*DestinationAllotmentContext).portions = append(localctx.(*DestinationAllotmentContext).portions, localctx.(*DestinationAllotmentContext)._allotmentPortion)
 		{
-			p.SetState(113)
+			p.SetState(128)
+
+			var _x = p.KeptOrDestination()
+
+			localctx.(*DestinationAllotmentContext)._keptOrDestination = _x
+		}
+		localctx.(*DestinationAllotmentContext).dests = append(localctx.(*DestinationAllotmentContext).dests, localctx.(*DestinationAllotmentContext)._keptOrDestination)
+		{
+			p.SetState(129)
+			p.Match(NumScriptParserNEWLINE)
+		}
+
+		p.SetState(133)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(135)
+		p.Match(NumScriptParserRBRACE)
+	}
+
+	return localctx
+}
+
+// IKeptOrDestinationContext is an interface to support dynamic dispatch.
+type IKeptOrDestinationContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsKeptOrDestinationContext differentiates from other interfaces.
+	IsKeptOrDestinationContext()
+}
+
+type KeptOrDestinationContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyKeptOrDestinationContext() *KeptOrDestinationContext {
+	var p = new(KeptOrDestinationContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+	return p
+}
+
+func (*KeptOrDestinationContext) IsKeptOrDestinationContext() {}
+
+func NewKeptOrDestinationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KeptOrDestinationContext {
+	var p = new(KeptOrDestinationContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_keptOrDestination
+
+	return p
+}
+
+func (s *KeptOrDestinationContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *KeptOrDestinationContext) CopyFrom(ctx *KeptOrDestinationContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *KeptOrDestinationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *KeptOrDestinationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type IsKeptContext struct {
+	*KeptOrDestinationContext
+}
+
+func NewIsKeptContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsKeptContext {
+	var p = new(IsKeptContext)
+
+	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+
+	return p
+}
+
+func (s *IsKeptContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IsKeptContext) KEPT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserKEPT, 0)
+}
+
+func (s *IsKeptContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterIsKept(s)
+	}
+}
+
+func (s *IsKeptContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitIsKept(s)
+	}
+}
+
+type IsDestinationContext struct {
+	*KeptOrDestinationContext
+}
+
+func NewIsDestinationContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IsDestinationContext {
+	var p = new(IsDestinationContext)
+
+	p.KeptOrDestinationContext = NewEmptyKeptOrDestinationContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*KeptOrDestinationContext))
+
+	return p
+}
+
+func (s *IsDestinationContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IsDestinationContext) TO() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTO, 0)
+}
+
+func (s *IsDestinationContext) Destination() IDestinationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDestinationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDestinationContext)
+}
+
+func (s *IsDestinationContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterIsDestination(s)
+	}
+}
+
+func (s *IsDestinationContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitIsDestination(s)
+	}
+}
+
+func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContext) {
+	this := p
+	_ = this
+
+	localctx = NewKeptOrDestinationContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, NumScriptParserRULE_keptOrDestination)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(140)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserTO:
+		localctx = NewIsDestinationContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(137)
+			p.Match(NumScriptParserTO)
+		}
+		{
+			p.SetState(138)
 			p.Destination()
 		}
 
@@ -2415,7 +2965,7 @@ func (p *NumScriptParser) KeptOrDestination() (localctx IKeptOrDestinationContex
 		localctx = NewIsKeptContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(114)
+			p.SetState(139)
 			p.Match(NumScriptParserKEPT)
 		}
 
@@ -2637,14 +3187,14 @@ func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 		}
 	}()
 
-	p.SetState(120)
+	p.SetState(145)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 7, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 8, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewDestAccountContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(117)
+			p.SetState(142)
 			p.expression(0)
 		}
 
@@ -2652,7 +3202,7 @@ func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 		localctx = NewDestInOrderContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(118)
+			p.SetState(143)
 			p.DestinationInOrder()
 		}
 
@@ -2660,7 +3210,7 @@ func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 		localctx = NewDestAllotmentContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(119)
+			p.SetState(144)
 			p.DestinationAllotment()
 		}
 
@@ -2669,143 +3219,206 @@ func (p *NumScriptParser) Destination() (localctx IDestinationContext) {
 	return localctx
 }
 
-// ISourceAccountOverdraftContext is an interface to support dynamic dispatch.
-type ISourceAccountOverdraftContext interface {
+// ICondOperandContext is an interface to support dynamic dispatch.
+type ICondOperandContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSourceAccountOverdraftContext differentiates from other interfaces.
-	IsSourceAccountOverdraftContext()
+	// IsCondOperandContext differentiates from other interfaces.
+	IsCondOperandContext()
 }
 
-type SourceAccountOverdraftContext struct {
+type CondOperandContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySourceAccountOverdraftContext() *SourceAccountOverdraftContext {
-	var p = new(SourceAccountOverdraftContext)
+func NewEmptyCondOperandContext() *CondOperandContext {
+	var p = new(CondOperandContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceAccountOverdraft
+	p.RuleIndex = NumScriptParserRULE_condOperand
 	return p
 }
 
-func (*SourceAccountOverdraftContext) IsSourceAccountOverdraftContext() {}
+func (*CondOperandContext) IsCondOperandContext() {}
 
-func NewSourceAccountOverdraftContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAccountOverdraftContext {
-	var p = new(SourceAccountOverdraftContext)
+func NewCondOperandContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondOperandContext {
+	var p = new(CondOperandContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceAccountOverdraft
+	p.RuleIndex = NumScriptParserRULE_condOperand
 
 	return p
 }
 
-func (s *SourceAccountOverdraftContext) GetParser() antlr.Parser { return s.parser }
+func (s *CondOperandContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceAccountOverdraftContext) CopyFrom(ctx *SourceAccountOverdraftContext) {
+func (s *CondOperandContext) CopyFrom(ctx *CondOperandContext) {
 	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *SourceAccountOverdraftContext) GetRuleContext() antlr.RuleContext {
+func (s *CondOperandContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceAccountOverdraftContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *CondOperandContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type SrcAccountOverdraftSpecificContext struct {
-	*SourceAccountOverdraftContext
-	specific IExpressionContext
+type CondBalanceContext struct {
+	*CondOperandContext
+	account IExpressionContext
+	asset   IExpressionContext
 }
 
-func NewSrcAccountOverdraftSpecificContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountOverdraftSpecificContext {
-	var p = new(SrcAccountOverdraftSpecificContext)
+func NewCondBalanceContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CondBalanceContext {
+	var p = new(CondBalanceContext)
 
-	p.SourceAccountOverdraftContext = NewEmptySourceAccountOverdraftContext()
+	p.CondOperandContext = NewEmptyCondOperandContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*SourceAccountOverdraftContext))
+	p.CopyFrom(ctx.(*CondOperandContext))
 
 	return p
 }
 
-func (s *SrcAccountOverdraftSpecificContext) GetSpecific() IExpressionContext { return s.specific }
+func (s *CondBalanceContext) GetAccount() IExpressionContext { return s.account }
 
-func (s *SrcAccountOverdraftSpecificContext) SetSpecific(v IExpressionContext) { s.specific = v }
+func (s *CondBalanceContext) GetAsset() IExpressionContext { return s.asset }
 
-func (s *SrcAccountOverdraftSpecificContext) GetRuleContext() antlr.RuleContext {
+func (s *CondBalanceContext) SetAccount(v IExpressionContext) { s.account = v }
+
+func (s *CondBalanceContext) SetAsset(v IExpressionContext) { s.asset = v }
+
+func (s *CondBalanceContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcAccountOverdraftSpecificContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
+func (s *CondBalanceContext) BALANCE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserBALANCE, 0)
+}
+
+func (s *CondBalanceContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *CondBalanceContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *CondBalanceContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
 		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
 	}
 
-	return t.(IExpressionContext)
+	return tst
 }
 
-func (s *SrcAccountOverdraftSpecificContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *CondBalanceContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *CondBalanceContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcAccountOverdraftSpecific(s)
+		listenerT.EnterCondBalance(s)
 	}
 }
 
-func (s *SrcAccountOverdraftSpecificContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *CondBalanceContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcAccountOverdraftSpecific(s)
+		listenerT.ExitCondBalance(s)
 	}
 }
 
-type SrcAccountOverdraftUnboundedContext struct {
-	*SourceAccountOverdraftContext
+type CondExprContext struct {
+	*CondOperandContext
+	expr IExpressionContext
 }
 
-func NewSrcAccountOverdraftUnboundedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountOverdraftUnboundedContext {
-	var p = new(SrcAccountOverdraftUnboundedContext)
+func NewCondExprContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CondExprContext {
+	var p = new(CondExprContext)
 
-	p.SourceAccountOverdraftContext = NewEmptySourceAccountOverdraftContext()
+	p.CondOperandContext = NewEmptyCondOperandContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*SourceAccountOverdraftContext))
+	p.CopyFrom(ctx.(*CondOperandContext))
 
 	return p
 }
 
-func (s *SrcAccountOverdraftUnboundedContext) GetRuleContext() antlr.RuleContext {
+func (s *CondExprContext) GetExpr() IExpressionContext { return s.expr }
+
+func (s *CondExprContext) SetExpr(v IExpressionContext) { s.expr = v }
+
+func (s *CondExprContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcAccountOverdraftUnboundedContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *CondExprContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *CondExprContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcAccountOverdraftUnbounded(s)
+		listenerT.EnterCondExpr(s)
 	}
 }
 
-func (s *SrcAccountOverdraftUnboundedContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *CondExprContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcAccountOverdraftUnbounded(s)
+		listenerT.ExitCondExpr(s)
 	}
 }
 
-func (p *NumScriptParser) SourceAccountOverdraft() (localctx ISourceAccountOverdraftContext) {
+func (p *NumScriptParser) CondOperand() (localctx ICondOperandContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceAccountOverdraftContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, NumScriptParserRULE_sourceAccountOverdraft)
+	localctx = NewCondOperandContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, NumScriptParserRULE_condOperand)
 
 	defer func() {
 		p.ExitRule()
@@ -2823,31 +3436,53 @@ func (p *NumScriptParser) SourceAccountOverdraft() (localctx ISourceAccountOverd
 		}
 	}()
 
-	p.SetState(125)
+	p.SetState(155)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserT__1:
-		localctx = NewSrcAccountOverdraftSpecificContext(p, localctx)
+	case NumScriptParserBALANCE:
+		localctx = NewCondBalanceContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(122)
-			p.Match(NumScriptParserT__1)
+			p.SetState(147)
+			p.Match(NumScriptParserBALANCE)
+		}
+		{
+			p.SetState(148)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(149)
+
+			var _x = p.expression(0)
+
+			localctx.(*CondBalanceContext).account = _x
+		}
+		{
+			p.SetState(150)
+			p.Match(NumScriptParserT__0)
 		}
 		{
-			p.SetState(123)
+			p.SetState(151)
 
 			var _x = p.expression(0)
 
-			localctx.(*SrcAccountOverdraftSpecificContext).specific = _x
+			localctx.(*CondBalanceContext).asset = _x
+		}
+		{
+			p.SetState(152)
+			p.Match(NumScriptParserRPAREN)
 		}
 
-	case NumScriptParserT__2:
-		localctx = NewSrcAccountOverdraftUnboundedContext(p, localctx)
+	case NumScriptParserMAX, NumScriptParserMIN, NumScriptParserFLOOR, NumScriptParserCEIL, NumScriptParserROUND, NumScriptParserLPAREN, NumScriptParserLBRACK, NumScriptParserSTRING, NumScriptParserPORTION, NumScriptParserNUMBER, NumScriptParserVARIABLE_NAME, NumScriptParserACCOUNT, NumScriptParserASSET:
+		localctx = NewCondExprContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(124)
-			p.Match(NumScriptParserT__2)
+			p.SetState(154)
+
+			var _x = p.expression(0)
+
+			localctx.(*CondExprContext).expr = _x
 		}
 
 	default:
@@ -2857,88 +3492,108 @@ func (p *NumScriptParser) SourceAccountOverdraft() (localctx ISourceAccountOverd
 	return localctx
 }
 
-// ISourceAccountContext is an interface to support dynamic dispatch.
-type ISourceAccountContext interface {
+// IConditionContext is an interface to support dynamic dispatch.
+type IConditionContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// GetAccount returns the account rule contexts.
-	GetAccount() IExpressionContext
+	// GetOp returns the op token.
+	GetOp() antlr.Token
 
-	// GetOverdraft returns the overdraft rule contexts.
-	GetOverdraft() ISourceAccountOverdraftContext
+	// SetOp sets the op token.
+	SetOp(antlr.Token)
 
-	// SetAccount sets the account rule contexts.
-	SetAccount(IExpressionContext)
+	// GetLhs returns the lhs rule contexts.
+	GetLhs() ICondOperandContext
 
-	// SetOverdraft sets the overdraft rule contexts.
-	SetOverdraft(ISourceAccountOverdraftContext)
+	// GetRhs returns the rhs rule contexts.
+	GetRhs() ICondOperandContext
 
-	// IsSourceAccountContext differentiates from other interfaces.
-	IsSourceAccountContext()
+	// SetLhs sets the lhs rule contexts.
+	SetLhs(ICondOperandContext)
+
+	// SetRhs sets the rhs rule contexts.
+	SetRhs(ICondOperandContext)
+
+	// IsConditionContext differentiates from other interfaces.
+	IsConditionContext()
 }
 
-type SourceAccountContext struct {
+type ConditionContext struct {
 	*antlr.BaseParserRuleContext
-	parser    antlr.Parser
-	account   IExpressionContext
-	overdraft ISourceAccountOverdraftContext
+	parser antlr.Parser
+	lhs    ICondOperandContext
+	op     antlr.Token
+	rhs    ICondOperandContext
 }
 
-func NewEmptySourceAccountContext() *SourceAccountContext {
-	var p = new(SourceAccountContext)
+func NewEmptyConditionContext() *ConditionContext {
+	var p = new(ConditionContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceAccount
+	p.RuleIndex = NumScriptParserRULE_condition
 	return p
 }
 
-func (*SourceAccountContext) IsSourceAccountContext() {}
+func (*ConditionContext) IsConditionContext() {}
 
-func NewSourceAccountContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAccountContext {
-	var p = new(SourceAccountContext)
+func NewConditionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ConditionContext {
+	var p = new(ConditionContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceAccount
+	p.RuleIndex = NumScriptParserRULE_condition
 
 	return p
 }
 
-func (s *SourceAccountContext) GetParser() antlr.Parser { return s.parser }
+func (s *ConditionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceAccountContext) GetAccount() IExpressionContext { return s.account }
+func (s *ConditionContext) GetOp() antlr.Token { return s.op }
 
-func (s *SourceAccountContext) GetOverdraft() ISourceAccountOverdraftContext { return s.overdraft }
+func (s *ConditionContext) SetOp(v antlr.Token) { s.op = v }
 
-func (s *SourceAccountContext) SetAccount(v IExpressionContext) { s.account = v }
+func (s *ConditionContext) GetLhs() ICondOperandContext { return s.lhs }
 
-func (s *SourceAccountContext) SetOverdraft(v ISourceAccountOverdraftContext) { s.overdraft = v }
+func (s *ConditionContext) GetRhs() ICondOperandContext { return s.rhs }
 
-func (s *SourceAccountContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+func (s *ConditionContext) SetLhs(v ICondOperandContext) { s.lhs = v }
+
+func (s *ConditionContext) SetRhs(v ICondOperandContext) { s.rhs = v }
+
+func (s *ConditionContext) AllCondOperand() []ICondOperandContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ICondOperandContext); ok {
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
+	tst := make([]ICondOperandContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ICondOperandContext); ok {
+			tst[i] = t.(ICondOperandContext)
+			i++
+		}
 	}
 
-	return t.(IExpressionContext)
+	return tst
 }
 
-func (s *SourceAccountContext) SourceAccountOverdraft() ISourceAccountOverdraftContext {
+func (s *ConditionContext) CondOperand(i int) ICondOperandContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceAccountOverdraftContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(ICondOperandContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -2946,35 +3601,59 @@ func (s *SourceAccountContext) SourceAccountOverdraft() ISourceAccountOverdraftC
 		return nil
 	}
 
-	return t.(ISourceAccountOverdraftContext)
+	return t.(ICondOperandContext)
 }
 
-func (s *SourceAccountContext) GetRuleContext() antlr.RuleContext {
+func (s *ConditionContext) OP_EQ() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_EQ, 0)
+}
+
+func (s *ConditionContext) OP_NEQ() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_NEQ, 0)
+}
+
+func (s *ConditionContext) OP_LT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_LT, 0)
+}
+
+func (s *ConditionContext) OP_LTE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_LTE, 0)
+}
+
+func (s *ConditionContext) OP_GT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_GT, 0)
+}
+
+func (s *ConditionContext) OP_GTE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserOP_GTE, 0)
+}
+
+func (s *ConditionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceAccountContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ConditionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SourceAccountContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ConditionContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceAccount(s)
+		listenerT.EnterCondition(s)
 	}
 }
 
-func (s *SourceAccountContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ConditionContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceAccount(s)
+		listenerT.ExitCondition(s)
 	}
 }
 
-func (p *NumScriptParser) SourceAccount() (localctx ISourceAccountContext) {
+func (p *NumScriptParser) Condition() (localctx IConditionContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceAccountContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, NumScriptParserRULE_sourceAccount)
+	localctx = NewConditionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, NumScriptParserRULE_condition)
 	var _la int
 
 	defer func() {
@@ -2995,137 +3674,120 @@ func (p *NumScriptParser) SourceAccount() (localctx ISourceAccountContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(127)
+		p.SetState(157)
 
-		var _x = p.expression(0)
+		var _x = p.CondOperand()
 
-		localctx.(*SourceAccountContext).account = _x
+		localctx.(*ConditionContext).lhs = _x
 	}
-	p.SetState(129)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+	{
+		p.SetState(158)
 
-	if _la == NumScriptParserT__1 || _la == NumScriptParserT__2 {
-		{
-			p.SetState(128)
+		var _lt = p.GetTokenStream().LT(1)
 
-			var _x = p.SourceAccountOverdraft()
+		localctx.(*ConditionContext).op = _lt
 
-			localctx.(*SourceAccountContext).overdraft = _x
+		_la = p.GetTokenStream().LA(1)
+
+		if !(((_la-35)&-(0x1f+1)) == 0 && ((1<<uint((_la-35)))&((1<<(NumScriptParserOP_EQ-35))|(1<<(NumScriptParserOP_NEQ-35))|(1<<(NumScriptParserOP_LTE-35))|(1<<(NumScriptParserOP_GTE-35))|(1<<(NumScriptParserOP_LT-35))|(1<<(NumScriptParserOP_GT-35)))) != 0) {
+			var _ri = p.GetErrorHandler().RecoverInline(p)
+
+			localctx.(*ConditionContext).op = _ri
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
+	}
+	{
+		p.SetState(159)
 
+		var _x = p.CondOperand()
+
+		localctx.(*ConditionContext).rhs = _x
 	}
 
 	return localctx
 }
 
-// ISourceInOrderContext is an interface to support dynamic dispatch.
-type ISourceInOrderContext interface {
+// ISourceAccountOverdraftContext is an interface to support dynamic dispatch.
+type ISourceAccountOverdraftContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_source returns the _source rule contexts.
-	Get_source() ISourceContext
-
-	// Set_source sets the _source rule contexts.
-	Set_source(ISourceContext)
-
-	// GetSources returns the sources rule context list.
-	GetSources() []ISourceContext
-
-	// SetSources sets the sources rule context list.
-	SetSources([]ISourceContext)
-
-	// IsSourceInOrderContext differentiates from other interfaces.
-	IsSourceInOrderContext()
+	// IsSourceAccountOverdraftContext differentiates from other interfaces.
+	IsSourceAccountOverdraftContext()
 }
 
-type SourceInOrderContext struct {
+type SourceAccountOverdraftContext struct {
 	*antlr.BaseParserRuleContext
-	parser  antlr.Parser
-	_source ISourceContext
-	sources []ISourceContext
+	parser antlr.Parser
 }
 
-func NewEmptySourceInOrderContext() *SourceInOrderContext {
-	var p = new(SourceInOrderContext)
+func NewEmptySourceAccountOverdraftContext() *SourceAccountOverdraftContext {
+	var p = new(SourceAccountOverdraftContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceInOrder
+	p.RuleIndex = NumScriptParserRULE_sourceAccountOverdraft
 	return p
 }
 
-func (*SourceInOrderContext) IsSourceInOrderContext() {}
+func (*SourceAccountOverdraftContext) IsSourceAccountOverdraftContext() {}
 
-func NewSourceInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceInOrderContext {
-	var p = new(SourceInOrderContext)
+func NewSourceAccountOverdraftContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAccountOverdraftContext {
+	var p = new(SourceAccountOverdraftContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceInOrder
+	p.RuleIndex = NumScriptParserRULE_sourceAccountOverdraft
 
 	return p
 }
 
-func (s *SourceInOrderContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SourceInOrderContext) Get_source() ISourceContext { return s._source }
-
-func (s *SourceInOrderContext) Set_source(v ISourceContext) { s._source = v }
-
-func (s *SourceInOrderContext) GetSources() []ISourceContext { return s.sources }
-
-func (s *SourceInOrderContext) SetSources(v []ISourceContext) { s.sources = v }
+func (s *SourceAccountOverdraftContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceInOrderContext) LBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLBRACE, 0)
+func (s *SourceAccountOverdraftContext) CopyFrom(ctx *SourceAccountOverdraftContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *SourceInOrderContext) AllNEWLINE() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserNEWLINE)
+func (s *SourceAccountOverdraftContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *SourceInOrderContext) NEWLINE(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserNEWLINE, i)
+func (s *SourceAccountOverdraftContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SourceInOrderContext) RBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRBRACE, 0)
+type SrcAccountOverdraftSpecificContext struct {
+	*SourceAccountOverdraftContext
+	specific IExpressionContext
 }
 
-func (s *SourceInOrderContext) AllSource() []ISourceContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(ISourceContext); ok {
-			len++
-		}
-	}
+func NewSrcAccountOverdraftSpecificContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountOverdraftSpecificContext {
+	var p = new(SrcAccountOverdraftSpecificContext)
 
-	tst := make([]ISourceContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(ISourceContext); ok {
-			tst[i] = t.(ISourceContext)
-			i++
-		}
-	}
+	p.SourceAccountOverdraftContext = NewEmptySourceAccountOverdraftContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*SourceAccountOverdraftContext))
 
-	return tst
+	return p
 }
 
-func (s *SourceInOrderContext) Source(i int) ISourceContext {
+func (s *SrcAccountOverdraftSpecificContext) GetSpecific() IExpressionContext { return s.specific }
+
+func (s *SrcAccountOverdraftSpecificContext) SetSpecific(v IExpressionContext) { s.specific = v }
+
+func (s *SrcAccountOverdraftSpecificContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SrcAccountOverdraftSpecificContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -3133,36 +3795,57 @@ func (s *SourceInOrderContext) Source(i int) ISourceContext {
 		return nil
 	}
 
-	return t.(ISourceContext)
+	return t.(IExpressionContext)
 }
 
-func (s *SourceInOrderContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SrcAccountOverdraftSpecificContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSrcAccountOverdraftSpecific(s)
+	}
 }
 
-func (s *SourceInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *SrcAccountOverdraftSpecificContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSrcAccountOverdraftSpecific(s)
+	}
 }
 
-func (s *SourceInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+type SrcAccountOverdraftUnboundedContext struct {
+	*SourceAccountOverdraftContext
+}
+
+func NewSrcAccountOverdraftUnboundedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountOverdraftUnboundedContext {
+	var p = new(SrcAccountOverdraftUnboundedContext)
+
+	p.SourceAccountOverdraftContext = NewEmptySourceAccountOverdraftContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*SourceAccountOverdraftContext))
+
+	return p
+}
+
+func (s *SrcAccountOverdraftUnboundedContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SrcAccountOverdraftUnboundedContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceInOrder(s)
+		listenerT.EnterSrcAccountOverdraftUnbounded(s)
 	}
 }
 
-func (s *SourceInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountOverdraftUnboundedContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceInOrder(s)
+		listenerT.ExitSrcAccountOverdraftUnbounded(s)
 	}
 }
 
-func (p *NumScriptParser) SourceInOrder() (localctx ISourceInOrderContext) {
+func (p *NumScriptParser) SourceAccountOverdraft() (localctx ISourceAccountOverdraftContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceInOrderContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, NumScriptParserRULE_sourceInOrder)
-	var _la int
+	localctx = NewSourceAccountOverdraftContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, NumScriptParserRULE_sourceAccountOverdraft)
 
 	defer func() {
 		p.ExitRule()
@@ -3180,114 +3863,101 @@ func (p *NumScriptParser) SourceInOrder() (localctx ISourceInOrderContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(131)
-		p.Match(NumScriptParserLBRACE)
-	}
-	{
-		p.SetState(132)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	p.SetState(136)
+	p.SetState(164)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la-18)&-(0x1f+1)) == 0 && ((1<<uint((_la-18)))&((1<<(NumScriptParserMAX-18))|(1<<(NumScriptParserLBRACK-18))|(1<<(NumScriptParserLBRACE-18))|(1<<(NumScriptParserSTRING-18))|(1<<(NumScriptParserPORTION-18))|(1<<(NumScriptParserNUMBER-18))|(1<<(NumScriptParserVARIABLE_NAME-18))|(1<<(NumScriptParserACCOUNT-18))|(1<<(NumScriptParserASSET-18)))) != 0) {
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserT__1:
+		localctx = NewSrcAccountOverdraftSpecificContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(161)
+			p.Match(NumScriptParserT__1)
+		}
 		{
-			p.SetState(133)
+			p.SetState(162)
 
-			var _x = p.Source()
+			var _x = p.expression(0)
 
-			localctx.(*SourceInOrderContext)._source = _x
+			localctx.(*SrcAccountOverdraftSpecificContext).specific = _x
 		}
-		localctx.(*SourceInOrderContext).sources = append(localctx.(*SourceInOrderContext).sources, localctx.(*SourceInOrderContext)._source)
+
+	case NumScriptParserT__2:
+		localctx = NewSrcAccountOverdraftUnboundedContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(134)
-			p.Match(NumScriptParserNEWLINE)
+			p.SetState(163)
+			p.Match(NumScriptParserT__2)
 		}
 
-		p.SetState(138)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
-	}
-	{
-		p.SetState(140)
-		p.Match(NumScriptParserRBRACE)
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// ISourceMaxedContext is an interface to support dynamic dispatch.
-type ISourceMaxedContext interface {
+// ISourceAccountContext is an interface to support dynamic dispatch.
+type ISourceAccountContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// GetMax returns the max rule contexts.
-	GetMax() IExpressionContext
+	// GetAccount returns the account rule contexts.
+	GetAccount() IExpressionContext
 
-	// GetSrc returns the src rule contexts.
-	GetSrc() ISourceContext
+	// GetOverdraft returns the overdraft rule contexts.
+	GetOverdraft() ISourceAccountOverdraftContext
 
-	// SetMax sets the max rule contexts.
-	SetMax(IExpressionContext)
+	// SetAccount sets the account rule contexts.
+	SetAccount(IExpressionContext)
 
-	// SetSrc sets the src rule contexts.
-	SetSrc(ISourceContext)
+	// SetOverdraft sets the overdraft rule contexts.
+	SetOverdraft(ISourceAccountOverdraftContext)
 
-	// IsSourceMaxedContext differentiates from other interfaces.
-	IsSourceMaxedContext()
+	// IsSourceAccountContext differentiates from other interfaces.
+	IsSourceAccountContext()
 }
 
-type SourceMaxedContext struct {
+type SourceAccountContext struct {
 	*antlr.BaseParserRuleContext
-	parser antlr.Parser
-	max    IExpressionContext
-	src    ISourceContext
+	parser    antlr.Parser
+	account   IExpressionContext
+	overdraft ISourceAccountOverdraftContext
 }
 
-func NewEmptySourceMaxedContext() *SourceMaxedContext {
-	var p = new(SourceMaxedContext)
+func NewEmptySourceAccountContext() *SourceAccountContext {
+	var p = new(SourceAccountContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceMaxed
+	p.RuleIndex = NumScriptParserRULE_sourceAccount
 	return p
 }
 
-func (*SourceMaxedContext) IsSourceMaxedContext() {}
+func (*SourceAccountContext) IsSourceAccountContext() {}
 
-func NewSourceMaxedContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceMaxedContext {
-	var p = new(SourceMaxedContext)
+func NewSourceAccountContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAccountContext {
+	var p = new(SourceAccountContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceMaxed
+	p.RuleIndex = NumScriptParserRULE_sourceAccount
 
 	return p
 }
 
-func (s *SourceMaxedContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SourceMaxedContext) GetMax() IExpressionContext { return s.max }
-
-func (s *SourceMaxedContext) GetSrc() ISourceContext { return s.src }
+func (s *SourceAccountContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceMaxedContext) SetMax(v IExpressionContext) { s.max = v }
+func (s *SourceAccountContext) GetAccount() IExpressionContext { return s.account }
 
-func (s *SourceMaxedContext) SetSrc(v ISourceContext) { s.src = v }
+func (s *SourceAccountContext) GetOverdraft() ISourceAccountOverdraftContext { return s.overdraft }
 
-func (s *SourceMaxedContext) MAX() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserMAX, 0)
-}
+func (s *SourceAccountContext) SetAccount(v IExpressionContext) { s.account = v }
 
-func (s *SourceMaxedContext) FROM() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserFROM, 0)
-}
+func (s *SourceAccountContext) SetOverdraft(v ISourceAccountOverdraftContext) { s.overdraft = v }
 
-func (s *SourceMaxedContext) Expression() IExpressionContext {
+func (s *SourceAccountContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -3303,10 +3973,10 @@ func (s *SourceMaxedContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *SourceMaxedContext) Source() ISourceContext {
+func (s *SourceAccountContext) SourceAccountOverdraft() ISourceAccountOverdraftContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceContext); ok {
+		if _, ok := ctx.(ISourceAccountOverdraftContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -3316,35 +3986,36 @@ func (s *SourceMaxedContext) Source() ISourceContext {
 		return nil
 	}
 
-	return t.(ISourceContext)
+	return t.(ISourceAccountOverdraftContext)
 }
 
-func (s *SourceMaxedContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceAccountContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceMaxedContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceAccountContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SourceMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceAccountContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceMaxed(s)
+		listenerT.EnterSourceAccount(s)
 	}
 }
 
-func (s *SourceMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceAccountContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceMaxed(s)
+		listenerT.ExitSourceAccount(s)
 	}
 }
 
-func (p *NumScriptParser) SourceMaxed() (localctx ISourceMaxedContext) {
+func (p *NumScriptParser) SourceAccount() (localctx ISourceAccountContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceMaxedContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, NumScriptParserRULE_sourceMaxed)
+	localctx = NewSourceAccountContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, NumScriptParserRULE_sourceAccount)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -3364,151 +4035,137 @@ func (p *NumScriptParser) SourceMaxed() (localctx ISourceMaxedContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(142)
-		p.Match(NumScriptParserMAX)
-	}
-	{
-		p.SetState(143)
+		p.SetState(166)
 
 		var _x = p.expression(0)
 
-		localctx.(*SourceMaxedContext).max = _x
-	}
-	{
-		p.SetState(144)
-		p.Match(NumScriptParserFROM)
+		localctx.(*SourceAccountContext).account = _x
 	}
-	{
-		p.SetState(145)
+	p.SetState(168)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-		var _x = p.Source()
+	if _la == NumScriptParserT__1 || _la == NumScriptParserT__2 {
+		{
+			p.SetState(167)
+
+			var _x = p.SourceAccountOverdraft()
+
+			localctx.(*SourceAccountContext).overdraft = _x
+		}
 
-		localctx.(*SourceMaxedContext).src = _x
 	}
 
 	return localctx
 }
 
-// ISourceContext is an interface to support dynamic dispatch.
-type ISourceContext interface {
+// ISourceInOrderContext is an interface to support dynamic dispatch.
+type ISourceInOrderContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSourceContext differentiates from other interfaces.
-	IsSourceContext()
+	// Get_source returns the _source rule contexts.
+	Get_source() ISourceContext
+
+	// Set_source sets the _source rule contexts.
+	Set_source(ISourceContext)
+
+	// GetSources returns the sources rule context list.
+	GetSources() []ISourceContext
+
+	// SetSources sets the sources rule context list.
+	SetSources([]ISourceContext)
+
+	// IsSourceInOrderContext differentiates from other interfaces.
+	IsSourceInOrderContext()
 }
 
-type SourceContext struct {
+type SourceInOrderContext struct {
 	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+	parser  antlr.Parser
+	_source ISourceContext
+	sources []ISourceContext
 }
 
-func NewEmptySourceContext() *SourceContext {
-	var p = new(SourceContext)
+func NewEmptySourceInOrderContext() *SourceInOrderContext {
+	var p = new(SourceInOrderContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_source
+	p.RuleIndex = NumScriptParserRULE_sourceInOrder
 	return p
 }
 
-func (*SourceContext) IsSourceContext() {}
+func (*SourceInOrderContext) IsSourceInOrderContext() {}
 
-func NewSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceContext {
-	var p = new(SourceContext)
+func NewSourceInOrderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceInOrderContext {
+	var p = new(SourceInOrderContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_source
+	p.RuleIndex = NumScriptParserRULE_sourceInOrder
 
 	return p
 }
 
-func (s *SourceContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SourceContext) CopyFrom(ctx *SourceContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
-}
-
-func (s *SourceContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *SourceInOrderContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
+func (s *SourceInOrderContext) Get_source() ISourceContext { return s._source }
 
-type SrcAccountContext struct {
-	*SourceContext
-}
+func (s *SourceInOrderContext) Set_source(v ISourceContext) { s._source = v }
 
-func NewSrcAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountContext {
-	var p = new(SrcAccountContext)
+func (s *SourceInOrderContext) GetSources() []ISourceContext { return s.sources }
 
-	p.SourceContext = NewEmptySourceContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*SourceContext))
+func (s *SourceInOrderContext) SetSources(v []ISourceContext) { s.sources = v }
 
-	return p
+func (s *SourceInOrderContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *SrcAccountContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SourceInOrderContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
 }
 
-func (s *SrcAccountContext) SourceAccount() ISourceAccountContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceAccountContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ISourceAccountContext)
+func (s *SourceInOrderContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
 }
 
-func (s *SrcAccountContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcAccount(s)
-	}
+func (s *SourceInOrderContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *SrcAccountContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcAccount(s)
+func (s *SourceInOrderContext) AllSource() []ISourceContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISourceContext); ok {
+			len++
+		}
 	}
-}
-
-type SrcMaxedContext struct {
-	*SourceContext
-}
-
-func NewSrcMaxedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcMaxedContext {
-	var p = new(SrcMaxedContext)
 
-	p.SourceContext = NewEmptySourceContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*SourceContext))
-
-	return p
-}
+	tst := make([]ISourceContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISourceContext); ok {
+			tst[i] = t.(ISourceContext)
+			i++
+		}
+	}
 
-func (s *SrcMaxedContext) GetRuleContext() antlr.RuleContext {
-	return s
+	return tst
 }
 
-func (s *SrcMaxedContext) SourceMaxed() ISourceMaxedContext {
+func (s *SourceInOrderContext) Source(i int) ISourceContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceMaxedContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(ISourceContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -3516,73 +4173,36 @@ func (s *SrcMaxedContext) SourceMaxed() ISourceMaxedContext {
 		return nil
 	}
 
-	return t.(ISourceMaxedContext)
-}
-
-func (s *SrcMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcMaxed(s)
-	}
-}
-
-func (s *SrcMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcMaxed(s)
-	}
-}
-
-type SrcInOrderContext struct {
-	*SourceContext
-}
-
-func NewSrcInOrderContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcInOrderContext {
-	var p = new(SrcInOrderContext)
-
-	p.SourceContext = NewEmptySourceContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*SourceContext))
-
-	return p
+	return t.(ISourceContext)
 }
 
-func (s *SrcInOrderContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceInOrderContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcInOrderContext) SourceInOrder() ISourceInOrderContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceInOrderContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(ISourceInOrderContext)
+func (s *SourceInOrderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SrcInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcInOrder(s)
+		listenerT.EnterSourceInOrder(s)
 	}
 }
 
-func (s *SrcInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcInOrder(s)
+		listenerT.ExitSourceInOrder(s)
 	}
 }
 
-func (p *NumScriptParser) Source() (localctx ISourceContext) {
+func (p *NumScriptParser) SourceInOrder() (localctx ISourceInOrderContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, NumScriptParserRULE_source)
+	localctx = NewSourceInOrderContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, NumScriptParserRULE_sourceInOrder)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -3600,182 +4220,119 @@ func (p *NumScriptParser) Source() (localctx ISourceContext) {
 		}
 	}()
 
-	p.SetState(150)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(170)
+		p.Match(NumScriptParserLBRACE)
+	}
+	{
+		p.SetState(171)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	p.SetState(175)
 	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserLBRACK, NumScriptParserSTRING, NumScriptParserPORTION, NumScriptParserNUMBER, NumScriptParserVARIABLE_NAME, NumScriptParserACCOUNT, NumScriptParserASSET:
-		localctx = NewSrcAccountContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	for ok := true; ok; ok = (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<NumScriptParserMAX)|(1<<NumScriptParserMIN)|(1<<NumScriptParserFLOOR)|(1<<NumScriptParserCEIL)|(1<<NumScriptParserROUND))) != 0) || (((_la-41)&-(0x1f+1)) == 0 && ((1<<uint((_la-41)))&((1<<(NumScriptParserLPAREN-41))|(1<<(NumScriptParserLBRACK-41))|(1<<(NumScriptParserLBRACE-41))|(1<<(NumScriptParserSTRING-41))|(1<<(NumScriptParserPORTION-41))|(1<<(NumScriptParserNUMBER-41))|(1<<(NumScriptParserVARIABLE_NAME-41))|(1<<(NumScriptParserACCOUNT-41))|(1<<(NumScriptParserASSET-41)))) != 0) {
 		{
-			p.SetState(147)
-			p.SourceAccount()
-		}
+			p.SetState(172)
 
-	case NumScriptParserMAX:
-		localctx = NewSrcMaxedContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(148)
-			p.SourceMaxed()
-		}
+			var _x = p.Source()
 
-	case NumScriptParserLBRACE:
-		localctx = NewSrcInOrderContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
+			localctx.(*SourceInOrderContext)._source = _x
+		}
+		localctx.(*SourceInOrderContext).sources = append(localctx.(*SourceInOrderContext).sources, localctx.(*SourceInOrderContext)._source)
 		{
-			p.SetState(149)
-			p.SourceInOrder()
+			p.SetState(173)
+			p.Match(NumScriptParserNEWLINE)
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		p.SetState(177)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(179)
+		p.Match(NumScriptParserRBRACE)
 	}
 
 	return localctx
 }
 
-// ISourceAllotmentContext is an interface to support dynamic dispatch.
-type ISourceAllotmentContext interface {
+// ISourceMaxedContext is an interface to support dynamic dispatch.
+type ISourceMaxedContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
-	Get_allotmentPortion() IAllotmentPortionContext
-
-	// Get_source returns the _source rule contexts.
-	Get_source() ISourceContext
-
-	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
-	Set_allotmentPortion(IAllotmentPortionContext)
-
-	// Set_source sets the _source rule contexts.
-	Set_source(ISourceContext)
-
-	// GetPortions returns the portions rule context list.
-	GetPortions() []IAllotmentPortionContext
+	// GetMax returns the max rule contexts.
+	GetMax() IExpressionContext
 
-	// GetSources returns the sources rule context list.
-	GetSources() []ISourceContext
+	// GetSrc returns the src rule contexts.
+	GetSrc() ISourceContext
 
-	// SetPortions sets the portions rule context list.
-	SetPortions([]IAllotmentPortionContext)
+	// SetMax sets the max rule contexts.
+	SetMax(IExpressionContext)
 
-	// SetSources sets the sources rule context list.
-	SetSources([]ISourceContext)
+	// SetSrc sets the src rule contexts.
+	SetSrc(ISourceContext)
 
-	// IsSourceAllotmentContext differentiates from other interfaces.
-	IsSourceAllotmentContext()
+	// IsSourceMaxedContext differentiates from other interfaces.
+	IsSourceMaxedContext()
 }
 
-type SourceAllotmentContext struct {
+type SourceMaxedContext struct {
 	*antlr.BaseParserRuleContext
-	parser            antlr.Parser
-	_allotmentPortion IAllotmentPortionContext
-	portions          []IAllotmentPortionContext
-	_source           ISourceContext
-	sources           []ISourceContext
+	parser antlr.Parser
+	max    IExpressionContext
+	src    ISourceContext
 }
 
-func NewEmptySourceAllotmentContext() *SourceAllotmentContext {
-	var p = new(SourceAllotmentContext)
+func NewEmptySourceMaxedContext() *SourceMaxedContext {
+	var p = new(SourceMaxedContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_sourceAllotment
+	p.RuleIndex = NumScriptParserRULE_sourceMaxed
 	return p
 }
 
-func (*SourceAllotmentContext) IsSourceAllotmentContext() {}
+func (*SourceMaxedContext) IsSourceMaxedContext() {}
 
-func NewSourceAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAllotmentContext {
-	var p = new(SourceAllotmentContext)
+func NewSourceMaxedContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceMaxedContext {
+	var p = new(SourceMaxedContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_sourceAllotment
+	p.RuleIndex = NumScriptParserRULE_sourceMaxed
 
 	return p
 }
 
-func (s *SourceAllotmentContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *SourceAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
-	return s._allotmentPortion
-}
-
-func (s *SourceAllotmentContext) Get_source() ISourceContext { return s._source }
-
-func (s *SourceAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
-	s._allotmentPortion = v
-}
-
-func (s *SourceAllotmentContext) Set_source(v ISourceContext) { s._source = v }
-
-func (s *SourceAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
-
-func (s *SourceAllotmentContext) GetSources() []ISourceContext { return s.sources }
-
-func (s *SourceAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
-
-func (s *SourceAllotmentContext) SetSources(v []ISourceContext) { s.sources = v }
-
-func (s *SourceAllotmentContext) LBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLBRACE, 0)
-}
+func (s *SourceMaxedContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SourceAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserNEWLINE)
-}
+func (s *SourceMaxedContext) GetMax() IExpressionContext { return s.max }
 
-func (s *SourceAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserNEWLINE, i)
-}
+func (s *SourceMaxedContext) GetSrc() ISourceContext { return s.src }
 
-func (s *SourceAllotmentContext) RBRACE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRBRACE, 0)
-}
+func (s *SourceMaxedContext) SetMax(v IExpressionContext) { s.max = v }
 
-func (s *SourceAllotmentContext) AllFROM() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserFROM)
-}
+func (s *SourceMaxedContext) SetSrc(v ISourceContext) { s.src = v }
 
-func (s *SourceAllotmentContext) FROM(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserFROM, i)
+func (s *SourceMaxedContext) MAX() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserMAX, 0)
 }
 
-func (s *SourceAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
-			len++
-		}
-	}
-
-	tst := make([]IAllotmentPortionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IAllotmentPortionContext); ok {
-			tst[i] = t.(IAllotmentPortionContext)
-			i++
-		}
-	}
-
-	return tst
+func (s *SourceMaxedContext) FROM() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFROM, 0)
 }
 
-func (s *SourceAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
+func (s *SourceMaxedContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAllotmentPortionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -3783,40 +4340,15 @@ func (s *SourceAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContex
 		return nil
 	}
 
-	return t.(IAllotmentPortionContext)
-}
-
-func (s *SourceAllotmentContext) AllSource() []ISourceContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(ISourceContext); ok {
-			len++
-		}
-	}
-
-	tst := make([]ISourceContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(ISourceContext); ok {
-			tst[i] = t.(ISourceContext)
-			i++
-		}
-	}
-
-	return tst
+	return t.(IExpressionContext)
 }
 
-func (s *SourceAllotmentContext) Source(i int) ISourceContext {
+func (s *SourceMaxedContext) Source() ISourceContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(ISourceContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -3827,33 +4359,32 @@ func (s *SourceAllotmentContext) Source(i int) ISourceContext {
 	return t.(ISourceContext)
 }
 
-func (s *SourceAllotmentContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceMaxedContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SourceAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceMaxedContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SourceAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSourceAllotment(s)
+		listenerT.EnterSourceMaxed(s)
 	}
 }
 
-func (s *SourceAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSourceAllotment(s)
+		listenerT.ExitSourceMaxed(s)
 	}
 }
 
-func (p *NumScriptParser) SourceAllotment() (localctx ISourceAllotmentContext) {
+func (p *NumScriptParser) SourceMaxed() (localctx ISourceMaxedContext) {
 	this := p
 	_ = this
 
-	localctx = NewSourceAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, NumScriptParserRULE_sourceAllotment)
-	var _la int
+	localctx = NewSourceMaxedContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, NumScriptParserRULE_sourceMaxed)
 
 	defer func() {
 		p.ExitRule()
@@ -3873,127 +4404,103 @@ func (p *NumScriptParser) SourceAllotment() (localctx ISourceAllotmentContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(152)
-		p.Match(NumScriptParserLBRACE)
+		p.SetState(181)
+		p.Match(NumScriptParserMAX)
 	}
 	{
-		p.SetState(153)
-		p.Match(NumScriptParserNEWLINE)
-	}
-	p.SetState(159)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	for ok := true; ok; ok = (((_la-38)&-(0x1f+1)) == 0 && ((1<<uint((_la-38)))&((1<<(NumScriptParserPORTION-38))|(1<<(NumScriptParserREMAINING-38))|(1<<(NumScriptParserVARIABLE_NAME-38)))) != 0) {
-		{
-			p.SetState(154)
-
-			var _x = p.AllotmentPortion()
-
-			localctx.(*SourceAllotmentContext)._allotmentPortion = _x
-		}
-		localctx.(*SourceAllotmentContext).portions = append(localctx.(*SourceAllotmentContext).portions, localctx.(*SourceAllotmentContext)._allotmentPortion)
-		{
-			p.SetState(155)
-			p.Match(NumScriptParserFROM)
-		}
-		{
-			p.SetState(156)
+		p.SetState(182)
 
-			var _x = p.Source()
-
-			localctx.(*SourceAllotmentContext)._source = _x
-		}
-		localctx.(*SourceAllotmentContext).sources = append(localctx.(*SourceAllotmentContext).sources, localctx.(*SourceAllotmentContext)._source)
-		{
-			p.SetState(157)
-			p.Match(NumScriptParserNEWLINE)
-		}
+		var _x = p.expression(0)
 
-		p.SetState(161)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+		localctx.(*SourceMaxedContext).max = _x
 	}
 	{
-		p.SetState(163)
-		p.Match(NumScriptParserRBRACE)
+		p.SetState(183)
+		p.Match(NumScriptParserFROM)
+	}
+	{
+		p.SetState(184)
+
+		var _x = p.Source()
+
+		localctx.(*SourceMaxedContext).src = _x
 	}
 
 	return localctx
 }
 
-// IValueAwareSourceContext is an interface to support dynamic dispatch.
-type IValueAwareSourceContext interface {
+// ISourceContext is an interface to support dynamic dispatch.
+type ISourceContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsValueAwareSourceContext differentiates from other interfaces.
-	IsValueAwareSourceContext()
+	// IsSourceContext differentiates from other interfaces.
+	IsSourceContext()
 }
 
-type ValueAwareSourceContext struct {
+type SourceContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyValueAwareSourceContext() *ValueAwareSourceContext {
-	var p = new(ValueAwareSourceContext)
+func NewEmptySourceContext() *SourceContext {
+	var p = new(SourceContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_valueAwareSource
+	p.RuleIndex = NumScriptParserRULE_source
 	return p
 }
 
-func (*ValueAwareSourceContext) IsValueAwareSourceContext() {}
+func (*SourceContext) IsSourceContext() {}
 
-func NewValueAwareSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueAwareSourceContext {
-	var p = new(ValueAwareSourceContext)
+func NewSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceContext {
+	var p = new(SourceContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_valueAwareSource
+	p.RuleIndex = NumScriptParserRULE_source
 
 	return p
 }
 
-func (s *ValueAwareSourceContext) GetParser() antlr.Parser { return s.parser }
+func (s *SourceContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ValueAwareSourceContext) CopyFrom(ctx *ValueAwareSourceContext) {
+func (s *SourceContext) CopyFrom(ctx *SourceContext) {
 	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
 }
 
-func (s *ValueAwareSourceContext) GetRuleContext() antlr.RuleContext {
+func (s *SourceContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ValueAwareSourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type SrcContext struct {
-	*ValueAwareSourceContext
+type SrcAccountContext struct {
+	*SourceContext
 }
 
-func NewSrcContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcContext {
-	var p = new(SrcContext)
+func NewSrcAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAccountContext {
+	var p = new(SrcAccountContext)
 
-	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
+	p.SourceContext = NewEmptySourceContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*ValueAwareSourceContext))
+	p.CopyFrom(ctx.(*SourceContext))
 
 	return p
 }
 
-func (s *SrcContext) GetRuleContext() antlr.RuleContext {
+func (s *SrcAccountContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcContext) Source() ISourceContext {
+func (s *SrcAccountContext) SourceAccount() ISourceAccountContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceContext); ok {
+		if _, ok := ctx.(ISourceAccountContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -4003,43 +4510,43 @@ func (s *SrcContext) Source() ISourceContext {
 		return nil
 	}
 
-	return t.(ISourceContext)
+	return t.(ISourceAccountContext)
 }
 
-func (s *SrcContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrc(s)
+		listenerT.EnterSrcAccount(s)
 	}
 }
 
-func (s *SrcContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcAccountContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrc(s)
+		listenerT.ExitSrcAccount(s)
 	}
 }
 
-type SrcAllotmentContext struct {
-	*ValueAwareSourceContext
+type SrcMaxedContext struct {
+	*SourceContext
 }
 
-func NewSrcAllotmentContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAllotmentContext {
-	var p = new(SrcAllotmentContext)
+func NewSrcMaxedContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcMaxedContext {
+	var p = new(SrcMaxedContext)
 
-	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
+	p.SourceContext = NewEmptySourceContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*ValueAwareSourceContext))
+	p.CopyFrom(ctx.(*SourceContext))
 
 	return p
 }
 
-func (s *SrcAllotmentContext) GetRuleContext() antlr.RuleContext {
+func (s *SrcMaxedContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SrcAllotmentContext) SourceAllotment() ISourceAllotmentContext {
+func (s *SrcMaxedContext) SourceMaxed() ISourceMaxedContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ISourceAllotmentContext); ok {
+		if _, ok := ctx.(ISourceMaxedContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -4049,27 +4556,73 @@ func (s *SrcAllotmentContext) SourceAllotment() ISourceAllotmentContext {
 		return nil
 	}
 
-	return t.(ISourceAllotmentContext)
+	return t.(ISourceMaxedContext)
 }
 
-func (s *SrcAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcMaxedContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSrcAllotment(s)
+		listenerT.EnterSrcMaxed(s)
 	}
 }
 
-func (s *SrcAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcMaxedContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSrcAllotment(s)
+		listenerT.ExitSrcMaxed(s)
 	}
 }
 
-func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext) {
+type SrcInOrderContext struct {
+	*SourceContext
+}
+
+func NewSrcInOrderContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcInOrderContext {
+	var p = new(SrcInOrderContext)
+
+	p.SourceContext = NewEmptySourceContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*SourceContext))
+
+	return p
+}
+
+func (s *SrcInOrderContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SrcInOrderContext) SourceInOrder() ISourceInOrderContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISourceInOrderContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISourceInOrderContext)
+}
+
+func (s *SrcInOrderContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSrcInOrder(s)
+	}
+}
+
+func (s *SrcInOrderContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSrcInOrder(s)
+	}
+}
+
+func (p *NumScriptParser) Source() (localctx ISourceContext) {
 	this := p
 	_ = this
 
-	localctx = NewValueAwareSourceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, NumScriptParserRULE_valueAwareSource)
+	localctx = NewSourceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, NumScriptParserRULE_source)
 
 	defer func() {
 		p.ExitRule()
@@ -4087,23 +4640,31 @@ func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext)
 		}
 	}()
 
-	p.SetState(167)
+	p.SetState(189)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 13, p.GetParserRuleContext()) {
 	case 1:
-		localctx = NewSrcContext(p, localctx)
+		localctx = NewSrcAccountContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(165)
-			p.Source()
+			p.SetState(186)
+			p.SourceAccount()
 		}
 
 	case 2:
-		localctx = NewSrcAllotmentContext(p, localctx)
+		localctx = NewSrcMaxedContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(166)
-			p.SourceAllotment()
+			p.SetState(187)
+			p.SourceMaxed()
+		}
+
+	case 3:
+		localctx = NewSrcInOrderContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(188)
+			p.SourceInOrder()
 		}
 
 	}
@@ -4111,166 +4672,130 @@ func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext)
 	return localctx
 }
 
-// IStatementContext is an interface to support dynamic dispatch.
-type IStatementContext interface {
+// ISourceAllotmentContext is an interface to support dynamic dispatch.
+type ISourceAllotmentContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsStatementContext differentiates from other interfaces.
-	IsStatementContext()
-}
-
-type StatementContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
-}
+	// Get_allotmentPortion returns the _allotmentPortion rule contexts.
+	Get_allotmentPortion() IAllotmentPortionContext
 
-func NewEmptyStatementContext() *StatementContext {
-	var p = new(StatementContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = NumScriptParserRULE_statement
-	return p
-}
+	// Get_source returns the _source rule contexts.
+	Get_source() ISourceContext
 
-func (*StatementContext) IsStatementContext() {}
+	// Set_allotmentPortion sets the _allotmentPortion rule contexts.
+	Set_allotmentPortion(IAllotmentPortionContext)
 
-func NewStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StatementContext {
-	var p = new(StatementContext)
+	// Set_source sets the _source rule contexts.
+	Set_source(ISourceContext)
 
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+	// GetPortions returns the portions rule context list.
+	GetPortions() []IAllotmentPortionContext
 
-	p.parser = parser
-	p.RuleIndex = NumScriptParserRULE_statement
+	// GetSources returns the sources rule context list.
+	GetSources() []ISourceContext
 
-	return p
-}
+	// SetPortions sets the portions rule context list.
+	SetPortions([]IAllotmentPortionContext)
 
-func (s *StatementContext) GetParser() antlr.Parser { return s.parser }
+	// SetSources sets the sources rule context list.
+	SetSources([]ISourceContext)
 
-func (s *StatementContext) CopyFrom(ctx *StatementContext) {
-	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+	// IsSourceAllotmentContext differentiates from other interfaces.
+	IsSourceAllotmentContext()
 }
 
-func (s *StatementContext) GetRuleContext() antlr.RuleContext {
-	return s
+type SourceAllotmentContext struct {
+	*antlr.BaseParserRuleContext
+	parser            antlr.Parser
+	_allotmentPortion IAllotmentPortionContext
+	portions          []IAllotmentPortionContext
+	_source           ISourceContext
+	sources           []ISourceContext
 }
 
-func (s *StatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func NewEmptySourceAllotmentContext() *SourceAllotmentContext {
+	var p = new(SourceAllotmentContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_sourceAllotment
+	return p
 }
 
-type PrintContext struct {
-	*StatementContext
-	expr IExpressionContext
-}
+func (*SourceAllotmentContext) IsSourceAllotmentContext() {}
 
-func NewPrintContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *PrintContext {
-	var p = new(PrintContext)
+func NewSourceAllotmentContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SourceAllotmentContext {
+	var p = new(SourceAllotmentContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.StatementContext = NewEmptyStatementContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*StatementContext))
+	p.RuleIndex = NumScriptParserRULE_sourceAllotment
 
 	return p
 }
 
-func (s *PrintContext) GetExpr() IExpressionContext { return s.expr }
-
-func (s *PrintContext) SetExpr(v IExpressionContext) { s.expr = v }
-
-func (s *PrintContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *SourceAllotmentContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *PrintContext) PRINT() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserPRINT, 0)
+func (s *SourceAllotmentContext) Get_allotmentPortion() IAllotmentPortionContext {
+	return s._allotmentPortion
 }
 
-func (s *PrintContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
+func (s *SourceAllotmentContext) Get_source() ISourceContext { return s._source }
 
-	return t.(IExpressionContext)
+func (s *SourceAllotmentContext) Set_allotmentPortion(v IAllotmentPortionContext) {
+	s._allotmentPortion = v
 }
 
-func (s *PrintContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterPrint(s)
-	}
-}
+func (s *SourceAllotmentContext) Set_source(v ISourceContext) { s._source = v }
 
-func (s *PrintContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitPrint(s)
-	}
-}
+func (s *SourceAllotmentContext) GetPortions() []IAllotmentPortionContext { return s.portions }
 
-type SaveFromAccountContext struct {
-	*StatementContext
-	mon    IExpressionContext
-	monAll IMonetaryAllContext
-	acc    IExpressionContext
-}
+func (s *SourceAllotmentContext) GetSources() []ISourceContext { return s.sources }
 
-func NewSaveFromAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SaveFromAccountContext {
-	var p = new(SaveFromAccountContext)
+func (s *SourceAllotmentContext) SetPortions(v []IAllotmentPortionContext) { s.portions = v }
 
-	p.StatementContext = NewEmptyStatementContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*StatementContext))
+func (s *SourceAllotmentContext) SetSources(v []ISourceContext) { s.sources = v }
 
-	return p
+func (s *SourceAllotmentContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *SaveFromAccountContext) GetMon() IExpressionContext { return s.mon }
-
-func (s *SaveFromAccountContext) GetMonAll() IMonetaryAllContext { return s.monAll }
-
-func (s *SaveFromAccountContext) GetAcc() IExpressionContext { return s.acc }
-
-func (s *SaveFromAccountContext) SetMon(v IExpressionContext) { s.mon = v }
-
-func (s *SaveFromAccountContext) SetMonAll(v IMonetaryAllContext) { s.monAll = v }
+func (s *SourceAllotmentContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
+}
 
-func (s *SaveFromAccountContext) SetAcc(v IExpressionContext) { s.acc = v }
+func (s *SourceAllotmentContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
+}
 
-func (s *SaveFromAccountContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SourceAllotmentContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *SaveFromAccountContext) SAVE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSAVE, 0)
+func (s *SourceAllotmentContext) AllFROM() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserFROM)
 }
 
-func (s *SaveFromAccountContext) FROM() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserFROM, 0)
+func (s *SourceAllotmentContext) FROM(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFROM, i)
 }
 
-func (s *SaveFromAccountContext) AllExpression() []IExpressionContext {
+func (s *SourceAllotmentContext) AllAllotmentPortion() []IAllotmentPortionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
+	tst := make([]IAllotmentPortionContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
+		if t, ok := ctx.(IAllotmentPortionContext); ok {
+			tst[i] = t.(IAllotmentPortionContext)
 			i++
 		}
 	}
@@ -4278,11 +4803,11 @@ func (s *SaveFromAccountContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *SaveFromAccountContext) Expression(i int) IExpressionContext {
+func (s *SourceAllotmentContext) AllotmentPortion(i int) IAllotmentPortionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IAllotmentPortionContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -4295,15 +4820,40 @@ func (s *SaveFromAccountContext) Expression(i int) IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IAllotmentPortionContext)
 }
 
-func (s *SaveFromAccountContext) MonetaryAll() IMonetaryAllContext {
-	var t antlr.RuleContext
+func (s *SourceAllotmentContext) AllSource() []ISourceContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISourceContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISourceContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISourceContext); ok {
+			tst[i] = t.(ISourceContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *SourceAllotmentContext) Source(i int) ISourceContext {
+	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IMonetaryAllContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(ISourceContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -4311,69 +4861,176 @@ func (s *SaveFromAccountContext) MonetaryAll() IMonetaryAllContext {
 		return nil
 	}
 
-	return t.(IMonetaryAllContext)
+	return t.(ISourceContext)
 }
 
-func (s *SaveFromAccountContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SourceAllotmentContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SourceAllotmentContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *SourceAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSaveFromAccount(s)
+		listenerT.EnterSourceAllotment(s)
 	}
 }
 
-func (s *SaveFromAccountContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SourceAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSaveFromAccount(s)
+		listenerT.ExitSourceAllotment(s)
 	}
 }
 
-type SetTxMetaContext struct {
-	*StatementContext
-	key   antlr.Token
-	value IExpressionContext
+func (p *NumScriptParser) SourceAllotment() (localctx ISourceAllotmentContext) {
+	this := p
+	_ = this
+
+	localctx = NewSourceAllotmentContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, NumScriptParserRULE_sourceAllotment)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(191)
+		p.Match(NumScriptParserLBRACE)
+	}
+	{
+		p.SetState(192)
+		p.Match(NumScriptParserNEWLINE)
+	}
+	p.SetState(198)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = (((_la-56)&-(0x1f+1)) == 0 && ((1<<uint((_la-56)))&((1<<(NumScriptParserPORTION-56))|(1<<(NumScriptParserREMAINING-56))|(1<<(NumScriptParserVARIABLE_NAME-56)))) != 0) {
+		{
+			p.SetState(193)
+
+			var _x = p.AllotmentPortion()
+
+			localctx.(*SourceAllotmentContext)._allotmentPortion = _x
+		}
+		localctx.(*SourceAllotmentContext).portions = append(localctx.(*SourceAllotmentContext).portions, localctx.(*SourceAllotmentContext)._allotmentPortion)
+		{
+			p.SetState(194)
+			p.Match(NumScriptParserFROM)
+		}
+		{
+			p.SetState(195)
+
+			var _x = p.Source()
+
+			localctx.(*SourceAllotmentContext)._source = _x
+		}
+		localctx.(*SourceAllotmentContext).sources = append(localctx.(*SourceAllotmentContext).sources, localctx.(*SourceAllotmentContext)._source)
+		{
+			p.SetState(196)
+			p.Match(NumScriptParserNEWLINE)
+		}
+
+		p.SetState(200)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(202)
+		p.Match(NumScriptParserRBRACE)
+	}
+
+	return localctx
 }
 
-func NewSetTxMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetTxMetaContext {
-	var p = new(SetTxMetaContext)
+// IValueAwareSourceContext is an interface to support dynamic dispatch.
+type IValueAwareSourceContext interface {
+	antlr.ParserRuleContext
 
-	p.StatementContext = NewEmptyStatementContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*StatementContext))
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsValueAwareSourceContext differentiates from other interfaces.
+	IsValueAwareSourceContext()
+}
+
+type ValueAwareSourceContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
 
+func NewEmptyValueAwareSourceContext() *ValueAwareSourceContext {
+	var p = new(ValueAwareSourceContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_valueAwareSource
 	return p
 }
 
-func (s *SetTxMetaContext) GetKey() antlr.Token { return s.key }
+func (*ValueAwareSourceContext) IsValueAwareSourceContext() {}
 
-func (s *SetTxMetaContext) SetKey(v antlr.Token) { s.key = v }
+func NewValueAwareSourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueAwareSourceContext {
+	var p = new(ValueAwareSourceContext)
 
-func (s *SetTxMetaContext) GetValue() IExpressionContext { return s.value }
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-func (s *SetTxMetaContext) SetValue(v IExpressionContext) { s.value = v }
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_valueAwareSource
 
-func (s *SetTxMetaContext) GetRuleContext() antlr.RuleContext {
+	return p
+}
+
+func (s *ValueAwareSourceContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ValueAwareSourceContext) CopyFrom(ctx *ValueAwareSourceContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *ValueAwareSourceContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SetTxMetaContext) SET_TX_META() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSET_TX_META, 0)
+func (s *ValueAwareSourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SetTxMetaContext) LPAREN() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLPAREN, 0)
+type SrcContext struct {
+	*ValueAwareSourceContext
 }
 
-func (s *SetTxMetaContext) RPAREN() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRPAREN, 0)
+func NewSrcContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcContext {
+	var p = new(SrcContext)
+
+	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*ValueAwareSourceContext))
+
+	return p
 }
 
-func (s *SetTxMetaContext) STRING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSTRING, 0)
+func (s *SrcContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *SetTxMetaContext) Expression() IExpressionContext {
+func (s *SrcContext) Source() ISourceContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(ISourceContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -4383,269 +5040,1604 @@ func (s *SetTxMetaContext) Expression() IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(ISourceContext)
 }
 
-func (s *SetTxMetaContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SrcContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSetTxMeta(s)
+		listenerT.EnterSrc(s)
 	}
 }
 
-func (s *SetTxMetaContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SrcContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSetTxMeta(s)
+		listenerT.ExitSrc(s)
 	}
 }
 
-type SetAccountMetaContext struct {
-	*StatementContext
-	acc   IExpressionContext
-	key   antlr.Token
-	value IExpressionContext
+type SrcAllotmentContext struct {
+	*ValueAwareSourceContext
 }
 
-func NewSetAccountMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetAccountMetaContext {
-	var p = new(SetAccountMetaContext)
+func NewSrcAllotmentContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SrcAllotmentContext {
+	var p = new(SrcAllotmentContext)
 
-	p.StatementContext = NewEmptyStatementContext()
+	p.ValueAwareSourceContext = NewEmptyValueAwareSourceContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*StatementContext))
+	p.CopyFrom(ctx.(*ValueAwareSourceContext))
 
 	return p
 }
 
-func (s *SetAccountMetaContext) GetKey() antlr.Token { return s.key }
-
-func (s *SetAccountMetaContext) SetKey(v antlr.Token) { s.key = v }
-
-func (s *SetAccountMetaContext) GetAcc() IExpressionContext { return s.acc }
+func (s *SrcAllotmentContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-func (s *SetAccountMetaContext) GetValue() IExpressionContext { return s.value }
+func (s *SrcAllotmentContext) SourceAllotment() ISourceAllotmentContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISourceAllotmentContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
 
-func (s *SetAccountMetaContext) SetAcc(v IExpressionContext) { s.acc = v }
+	if t == nil {
+		return nil
+	}
 
-func (s *SetAccountMetaContext) SetValue(v IExpressionContext) { s.value = v }
+	return t.(ISourceAllotmentContext)
+}
 
-func (s *SetAccountMetaContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SrcAllotmentContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSrcAllotment(s)
+	}
 }
 
-func (s *SetAccountMetaContext) SET_ACCOUNT_META() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSET_ACCOUNT_META, 0)
+func (s *SrcAllotmentContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSrcAllotment(s)
+	}
 }
 
-func (s *SetAccountMetaContext) LPAREN() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLPAREN, 0)
-}
+func (p *NumScriptParser) ValueAwareSource() (localctx IValueAwareSourceContext) {
+	this := p
+	_ = this
+
+	localctx = NewValueAwareSourceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, NumScriptParserRULE_valueAwareSource)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(206)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 15, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewSrcContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(204)
+			p.Source()
+		}
+
+	case 2:
+		localctx = NewSrcAllotmentContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(205)
+			p.SourceAllotment()
+		}
+
+	}
+
+	return localctx
+}
+
+// IStatementContext is an interface to support dynamic dispatch.
+type IStatementContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsStatementContext differentiates from other interfaces.
+	IsStatementContext()
+}
+
+type StatementContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyStatementContext() *StatementContext {
+	var p = new(StatementContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_statement
+	return p
+}
+
+func (*StatementContext) IsStatementContext() {}
+
+func NewStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StatementContext {
+	var p = new(StatementContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = NumScriptParserRULE_statement
+
+	return p
+}
+
+func (s *StatementContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *StatementContext) CopyFrom(ctx *StatementContext) {
+	s.BaseParserRuleContext.CopyFrom(ctx.BaseParserRuleContext)
+}
+
+func (s *StatementContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *StatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type PrintContext struct {
+	*StatementContext
+	expr IExpressionContext
+}
+
+func NewPrintContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *PrintContext {
+	var p = new(PrintContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *PrintContext) GetExpr() IExpressionContext { return s.expr }
+
+func (s *PrintContext) SetExpr(v IExpressionContext) { s.expr = v }
+
+func (s *PrintContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PrintContext) PRINT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserPRINT, 0)
+}
+
+func (s *PrintContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *PrintContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterPrint(s)
+	}
+}
+
+func (s *PrintContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitPrint(s)
+	}
+}
+
+type AssertContext struct {
+	*StatementContext
+	cond IConditionContext
+}
+
+func NewAssertContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AssertContext {
+	var p = new(AssertContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *AssertContext) GetCond() IConditionContext { return s.cond }
+
+func (s *AssertContext) SetCond(v IConditionContext) { s.cond = v }
+
+func (s *AssertContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AssertContext) ASSERT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserASSERT, 0)
+}
+
+func (s *AssertContext) Condition() IConditionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IConditionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IConditionContext)
+}
+
+func (s *AssertContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterAssert(s)
+	}
+}
+
+func (s *AssertContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitAssert(s)
+	}
+}
+
+type ConvertContext struct {
+	*StatementContext
+	mon   IExpressionContext
+	asset IExpressionContext
+	rate  IExpressionContext
+	src   ISourceContext
+	via   IExpressionContext
+	dest  IDestinationContext
+}
+
+func NewConvertContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ConvertContext {
+	var p = new(ConvertContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *ConvertContext) GetMon() IExpressionContext { return s.mon }
+
+func (s *ConvertContext) GetAsset() IExpressionContext { return s.asset }
+
+func (s *ConvertContext) GetRate() IExpressionContext { return s.rate }
+
+func (s *ConvertContext) GetSrc() ISourceContext { return s.src }
+
+func (s *ConvertContext) GetVia() IExpressionContext { return s.via }
+
+func (s *ConvertContext) GetDest() IDestinationContext { return s.dest }
+
+func (s *ConvertContext) SetMon(v IExpressionContext) { s.mon = v }
+
+func (s *ConvertContext) SetAsset(v IExpressionContext) { s.asset = v }
+
+func (s *ConvertContext) SetRate(v IExpressionContext) { s.rate = v }
+
+func (s *ConvertContext) SetSrc(v ISourceContext) { s.src = v }
+
+func (s *ConvertContext) SetVia(v IExpressionContext) { s.via = v }
+
+func (s *ConvertContext) SetDest(v IDestinationContext) { s.dest = v }
+
+func (s *ConvertContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ConvertContext) CONVERT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserCONVERT, 0)
+}
+
+func (s *ConvertContext) TO() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTO, 0)
+}
+
+func (s *ConvertContext) AT() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserAT, 0)
+}
+
+func (s *ConvertContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *ConvertContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
+}
+
+func (s *ConvertContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
+}
+
+func (s *ConvertContext) SOURCE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSOURCE, 0)
+}
+
+func (s *ConvertContext) AllEQ() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserEQ)
+}
+
+func (s *ConvertContext) EQ(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserEQ, i)
+}
+
+func (s *ConvertContext) VIA() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserVIA, 0)
+}
+
+func (s *ConvertContext) DESTINATION() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserDESTINATION, 0)
+}
+
+func (s *ConvertContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *ConvertContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ConvertContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ConvertContext) Source() ISourceContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ISourceContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISourceContext)
+}
+
+func (s *ConvertContext) Destination() IDestinationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDestinationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDestinationContext)
+}
+
+func (s *ConvertContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterConvert(s)
+	}
+}
+
+func (s *ConvertContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitConvert(s)
+	}
+}
+
+type SaveFromAccountContext struct {
+	*StatementContext
+	mon    IExpressionContext
+	monAll IMonetaryAllContext
+	acc    IExpressionContext
+}
+
+func NewSaveFromAccountContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SaveFromAccountContext {
+	var p = new(SaveFromAccountContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *SaveFromAccountContext) GetMon() IExpressionContext { return s.mon }
+
+func (s *SaveFromAccountContext) GetMonAll() IMonetaryAllContext { return s.monAll }
+
+func (s *SaveFromAccountContext) GetAcc() IExpressionContext { return s.acc }
+
+func (s *SaveFromAccountContext) SetMon(v IExpressionContext) { s.mon = v }
+
+func (s *SaveFromAccountContext) SetMonAll(v IMonetaryAllContext) { s.monAll = v }
+
+func (s *SaveFromAccountContext) SetAcc(v IExpressionContext) { s.acc = v }
+
+func (s *SaveFromAccountContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SaveFromAccountContext) SAVE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSAVE, 0)
+}
+
+func (s *SaveFromAccountContext) FROM() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFROM, 0)
+}
+
+func (s *SaveFromAccountContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *SaveFromAccountContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SaveFromAccountContext) MonetaryAll() IMonetaryAllContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMonetaryAllContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMonetaryAllContext)
+}
+
+func (s *SaveFromAccountContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSaveFromAccount(s)
+	}
+}
+
+func (s *SaveFromAccountContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSaveFromAccount(s)
+	}
+}
+
+type SetTxMetaContext struct {
+	*StatementContext
+	key   antlr.Token
+	value IExpressionContext
+}
+
+func NewSetTxMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetTxMetaContext {
+	var p = new(SetTxMetaContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *SetTxMetaContext) GetKey() antlr.Token { return s.key }
+
+func (s *SetTxMetaContext) SetKey(v antlr.Token) { s.key = v }
+
+func (s *SetTxMetaContext) GetValue() IExpressionContext { return s.value }
+
+func (s *SetTxMetaContext) SetValue(v IExpressionContext) { s.value = v }
+
+func (s *SetTxMetaContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SetTxMetaContext) SET_TX_META() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSET_TX_META, 0)
+}
+
+func (s *SetTxMetaContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *SetTxMetaContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *SetTxMetaContext) STRING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSTRING, 0)
+}
+
+func (s *SetTxMetaContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SetTxMetaContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSetTxMeta(s)
+	}
+}
+
+func (s *SetTxMetaContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSetTxMeta(s)
+	}
+}
+
+type IfContext struct {
+	*StatementContext
+	cond      IConditionContext
+	thenBlock IBlockContext
+	elseBlock IBlockContext
+}
+
+func NewIfContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IfContext {
+	var p = new(IfContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *IfContext) GetCond() IConditionContext { return s.cond }
+
+func (s *IfContext) GetThenBlock() IBlockContext { return s.thenBlock }
+
+func (s *IfContext) GetElseBlock() IBlockContext { return s.elseBlock }
+
+func (s *IfContext) SetCond(v IConditionContext) { s.cond = v }
+
+func (s *IfContext) SetThenBlock(v IBlockContext) { s.thenBlock = v }
+
+func (s *IfContext) SetElseBlock(v IBlockContext) { s.elseBlock = v }
+
+func (s *IfContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IfContext) IF() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserIF, 0)
+}
+
+func (s *IfContext) Condition() IConditionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IConditionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IConditionContext)
+}
+
+func (s *IfContext) AllBlock() []IBlockContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IBlockContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IBlockContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IBlockContext); ok {
+			tst[i] = t.(IBlockContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *IfContext) Block(i int) IBlockContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IBlockContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBlockContext)
+}
+
+func (s *IfContext) ELSE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserELSE, 0)
+}
+
+func (s *IfContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterIf(s)
+	}
+}
+
+func (s *IfContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitIf(s)
+	}
+}
+
+type SetAccountMetaContext struct {
+	*StatementContext
+	acc   IExpressionContext
+	key   antlr.Token
+	value IExpressionContext
+}
+
+func NewSetAccountMetaContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetAccountMetaContext {
+	var p = new(SetAccountMetaContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *SetAccountMetaContext) GetKey() antlr.Token { return s.key }
+
+func (s *SetAccountMetaContext) SetKey(v antlr.Token) { s.key = v }
+
+func (s *SetAccountMetaContext) GetAcc() IExpressionContext { return s.acc }
+
+func (s *SetAccountMetaContext) GetValue() IExpressionContext { return s.value }
+
+func (s *SetAccountMetaContext) SetAcc(v IExpressionContext) { s.acc = v }
+
+func (s *SetAccountMetaContext) SetValue(v IExpressionContext) { s.value = v }
+
+func (s *SetAccountMetaContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SetAccountMetaContext) SET_ACCOUNT_META() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSET_ACCOUNT_META, 0)
+}
+
+func (s *SetAccountMetaContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *SetAccountMetaContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *SetAccountMetaContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *SetAccountMetaContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SetAccountMetaContext) STRING() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSTRING, 0)
+}
+
+func (s *SetAccountMetaContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSetAccountMeta(s)
+	}
+}
+
+func (s *SetAccountMetaContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSetAccountMeta(s)
+	}
+}
+
+type FailContext struct {
+	*StatementContext
+}
+
+func NewFailContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FailContext {
+	var p = new(FailContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *FailContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FailContext) FAIL() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserFAIL, 0)
+}
+
+func (s *FailContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterFail(s)
+	}
+}
+
+func (s *FailContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitFail(s)
+	}
+}
+
+type SendContext struct {
+	*StatementContext
+	mon    IExpressionContext
+	monAll IMonetaryAllContext
+	src    IValueAwareSourceContext
+	dest   IDestinationContext
+}
+
+func NewSendContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SendContext {
+	var p = new(SendContext)
+
+	p.StatementContext = NewEmptyStatementContext()
+	p.parser = parser
+	p.CopyFrom(ctx.(*StatementContext))
+
+	return p
+}
+
+func (s *SendContext) GetMon() IExpressionContext { return s.mon }
+
+func (s *SendContext) GetMonAll() IMonetaryAllContext { return s.monAll }
+
+func (s *SendContext) GetSrc() IValueAwareSourceContext { return s.src }
+
+func (s *SendContext) GetDest() IDestinationContext { return s.dest }
+
+func (s *SendContext) SetMon(v IExpressionContext) { s.mon = v }
+
+func (s *SendContext) SetMonAll(v IMonetaryAllContext) { s.monAll = v }
+
+func (s *SendContext) SetSrc(v IValueAwareSourceContext) { s.src = v }
+
+func (s *SendContext) SetDest(v IDestinationContext) { s.dest = v }
+
+func (s *SendContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SendContext) SEND() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSEND, 0)
+}
+
+func (s *SendContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLPAREN, 0)
+}
+
+func (s *SendContext) AllNEWLINE() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserNEWLINE)
+}
+
+func (s *SendContext) NEWLINE(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserNEWLINE, i)
+}
+
+func (s *SendContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRPAREN, 0)
+}
+
+func (s *SendContext) SOURCE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserSOURCE, 0)
+}
+
+func (s *SendContext) AllEQ() []antlr.TerminalNode {
+	return s.GetTokens(NumScriptParserEQ)
+}
+
+func (s *SendContext) EQ(i int) antlr.TerminalNode {
+	return s.GetToken(NumScriptParserEQ, i)
+}
+
+func (s *SendContext) DESTINATION() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserDESTINATION, 0)
+}
+
+func (s *SendContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *SendContext) MonetaryAll() IMonetaryAllContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMonetaryAllContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMonetaryAllContext)
+}
+
+func (s *SendContext) ValueAwareSource() IValueAwareSourceContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IValueAwareSourceContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValueAwareSourceContext)
+}
+
+func (s *SendContext) Destination() IDestinationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDestinationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDestinationContext)
+}
+
+func (s *SendContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.EnterSend(s)
+	}
+}
+
+func (s *SendContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(NumScriptListener); ok {
+		listenerT.ExitSend(s)
+	}
+}
+
+func (p *NumScriptParser) Statement() (localctx IStatementContext) {
+	this := p
+	_ = this
+
+	localctx = NewStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, NumScriptParserRULE_statement)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(294)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case NumScriptParserPRINT:
+		localctx = NewPrintContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(208)
+			p.Match(NumScriptParserPRINT)
+		}
+		{
+			p.SetState(209)
+
+			var _x = p.expression(0)
+
+			localctx.(*PrintContext).expr = _x
+		}
+
+	case NumScriptParserSAVE:
+		localctx = NewSaveFromAccountContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(210)
+			p.Match(NumScriptParserSAVE)
+		}
+		p.SetState(213)
+		p.GetErrorHandler().Sync(p)
+		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 16, p.GetParserRuleContext()) {
+		case 1:
+			{
+				p.SetState(211)
+
+				var _x = p.expression(0)
+
+				localctx.(*SaveFromAccountContext).mon = _x
+			}
+
+		case 2:
+			{
+				p.SetState(212)
+
+				var _x = p.MonetaryAll()
+
+				localctx.(*SaveFromAccountContext).monAll = _x
+			}
+
+		}
+		{
+			p.SetState(215)
+			p.Match(NumScriptParserFROM)
+		}
+		{
+			p.SetState(216)
+
+			var _x = p.expression(0)
+
+			localctx.(*SaveFromAccountContext).acc = _x
+		}
+
+	case NumScriptParserSET_TX_META:
+		localctx = NewSetTxMetaContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(218)
+			p.Match(NumScriptParserSET_TX_META)
+		}
+		{
+			p.SetState(219)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(220)
+
+			var _m = p.Match(NumScriptParserSTRING)
+
+			localctx.(*SetTxMetaContext).key = _m
+		}
+		{
+			p.SetState(221)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(222)
+
+			var _x = p.expression(0)
+
+			localctx.(*SetTxMetaContext).value = _x
+		}
+		{
+			p.SetState(223)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserSET_ACCOUNT_META:
+		localctx = NewSetAccountMetaContext(p, localctx)
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(225)
+			p.Match(NumScriptParserSET_ACCOUNT_META)
+		}
+		{
+			p.SetState(226)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(227)
+
+			var _x = p.expression(0)
+
+			localctx.(*SetAccountMetaContext).acc = _x
+		}
+		{
+			p.SetState(228)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(229)
+
+			var _m = p.Match(NumScriptParserSTRING)
+
+			localctx.(*SetAccountMetaContext).key = _m
+		}
+		{
+			p.SetState(230)
+			p.Match(NumScriptParserT__0)
+		}
+		{
+			p.SetState(231)
+
+			var _x = p.expression(0)
+
+			localctx.(*SetAccountMetaContext).value = _x
+		}
+		{
+			p.SetState(232)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserFAIL:
+		localctx = NewFailContext(p, localctx)
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(234)
+			p.Match(NumScriptParserFAIL)
+		}
+
+	case NumScriptParserASSERT:
+		localctx = NewAssertContext(p, localctx)
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(235)
+			p.Match(NumScriptParserASSERT)
+		}
+		{
+			p.SetState(236)
+
+			var _x = p.Condition()
+
+			localctx.(*AssertContext).cond = _x
+		}
+
+	case NumScriptParserIF:
+		localctx = NewIfContext(p, localctx)
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(237)
+			p.Match(NumScriptParserIF)
+		}
+		{
+			p.SetState(238)
+
+			var _x = p.Condition()
+
+			localctx.(*IfContext).cond = _x
+		}
+		{
+			p.SetState(239)
+
+			var _x = p.Block()
+
+			localctx.(*IfContext).thenBlock = _x
+		}
+		p.SetState(242)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == NumScriptParserELSE {
+			{
+				p.SetState(240)
+				p.Match(NumScriptParserELSE)
+			}
+			{
+				p.SetState(241)
+
+				var _x = p.Block()
+
+				localctx.(*IfContext).elseBlock = _x
+			}
+
+		}
+
+	case NumScriptParserSEND:
+		localctx = NewSendContext(p, localctx)
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(244)
+			p.Match(NumScriptParserSEND)
+		}
+		p.SetState(247)
+		p.GetErrorHandler().Sync(p)
+		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 18, p.GetParserRuleContext()) {
+		case 1:
+			{
+				p.SetState(245)
+
+				var _x = p.expression(0)
+
+				localctx.(*SendContext).mon = _x
+			}
+
+		case 2:
+			{
+				p.SetState(246)
+
+				var _x = p.MonetaryAll()
+
+				localctx.(*SendContext).monAll = _x
+			}
+
+		}
+		{
+			p.SetState(249)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(250)
+			p.Match(NumScriptParserNEWLINE)
+		}
+		p.SetState(267)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case NumScriptParserSOURCE:
+			{
+				p.SetState(251)
+				p.Match(NumScriptParserSOURCE)
+			}
+			{
+				p.SetState(252)
+				p.Match(NumScriptParserEQ)
+			}
+			{
+				p.SetState(253)
+
+				var _x = p.ValueAwareSource()
+
+				localctx.(*SendContext).src = _x
+			}
+			{
+				p.SetState(254)
+				p.Match(NumScriptParserNEWLINE)
+			}
+			{
+				p.SetState(255)
+				p.Match(NumScriptParserDESTINATION)
+			}
+			{
+				p.SetState(256)
+				p.Match(NumScriptParserEQ)
+			}
+			{
+				p.SetState(257)
+
+				var _x = p.Destination()
+
+				localctx.(*SendContext).dest = _x
+			}
+
+		case NumScriptParserDESTINATION:
+			{
+				p.SetState(259)
+				p.Match(NumScriptParserDESTINATION)
+			}
+			{
+				p.SetState(260)
+				p.Match(NumScriptParserEQ)
+			}
+			{
+				p.SetState(261)
+
+				var _x = p.Destination()
+
+				localctx.(*SendContext).dest = _x
+			}
+			{
+				p.SetState(262)
+				p.Match(NumScriptParserNEWLINE)
+			}
+			{
+				p.SetState(263)
+				p.Match(NumScriptParserSOURCE)
+			}
+			{
+				p.SetState(264)
+				p.Match(NumScriptParserEQ)
+			}
+			{
+				p.SetState(265)
+
+				var _x = p.ValueAwareSource()
+
+				localctx.(*SendContext).src = _x
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+		{
+			p.SetState(269)
+			p.Match(NumScriptParserNEWLINE)
+		}
+		{
+			p.SetState(270)
+			p.Match(NumScriptParserRPAREN)
+		}
+
+	case NumScriptParserCONVERT:
+		localctx = NewConvertContext(p, localctx)
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(272)
+			p.Match(NumScriptParserCONVERT)
+		}
+		{
+			p.SetState(273)
+
+			var _x = p.expression(0)
+
+			localctx.(*ConvertContext).mon = _x
+		}
+		{
+			p.SetState(274)
+			p.Match(NumScriptParserTO)
+		}
+		{
+			p.SetState(275)
+
+			var _x = p.expression(0)
+
+			localctx.(*ConvertContext).asset = _x
+		}
+		{
+			p.SetState(276)
+			p.Match(NumScriptParserAT)
+		}
+		{
+			p.SetState(277)
+
+			var _x = p.expression(0)
 
-func (s *SetAccountMetaContext) RPAREN() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRPAREN, 0)
-}
+			localctx.(*ConvertContext).rate = _x
+		}
+		{
+			p.SetState(278)
+			p.Match(NumScriptParserLPAREN)
+		}
+		{
+			p.SetState(279)
+			p.Match(NumScriptParserNEWLINE)
+		}
+		{
+			p.SetState(280)
+			p.Match(NumScriptParserSOURCE)
+		}
+		{
+			p.SetState(281)
+			p.Match(NumScriptParserEQ)
+		}
+		{
+			p.SetState(282)
 
-func (s *SetAccountMetaContext) AllExpression() []IExpressionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
-			len++
+			var _x = p.Source()
+
+			localctx.(*ConvertContext).src = _x
 		}
-	}
+		{
+			p.SetState(283)
+			p.Match(NumScriptParserNEWLINE)
+		}
+		{
+			p.SetState(284)
+			p.Match(NumScriptParserVIA)
+		}
+		{
+			p.SetState(285)
+			p.Match(NumScriptParserEQ)
+		}
+		{
+			p.SetState(286)
 
-	tst := make([]IExpressionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
-			i++
+			var _x = p.expression(0)
+
+			localctx.(*ConvertContext).via = _x
 		}
-	}
+		{
+			p.SetState(287)
+			p.Match(NumScriptParserNEWLINE)
+		}
+		{
+			p.SetState(288)
+			p.Match(NumScriptParserDESTINATION)
+		}
+		{
+			p.SetState(289)
+			p.Match(NumScriptParserEQ)
+		}
+		{
+			p.SetState(290)
 
-	return tst
-}
+			var _x = p.Destination()
 
-func (s *SetAccountMetaContext) Expression(i int) IExpressionContext {
-	var t antlr.RuleContext
-	j := 0
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+			localctx.(*ConvertContext).dest = _x
+		}
+		{
+			p.SetState(291)
+			p.Match(NumScriptParserNEWLINE)
+		}
+		{
+			p.SetState(292)
+			p.Match(NumScriptParserRPAREN)
 		}
-	}
 
-	if t == nil {
-		return nil
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
-	return t.(IExpressionContext)
-}
-
-func (s *SetAccountMetaContext) STRING() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSTRING, 0)
+	return localctx
 }
 
-func (s *SetAccountMetaContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSetAccountMeta(s)
-	}
-}
+// IBlockContext is an interface to support dynamic dispatch.
+type IBlockContext interface {
+	antlr.ParserRuleContext
 
-func (s *SetAccountMetaContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSetAccountMeta(s)
-	}
-}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-type FailContext struct {
-	*StatementContext
-}
+	// Get_statement returns the _statement rule contexts.
+	Get_statement() IStatementContext
 
-func NewFailContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FailContext {
-	var p = new(FailContext)
+	// Set_statement sets the _statement rule contexts.
+	Set_statement(IStatementContext)
 
-	p.StatementContext = NewEmptyStatementContext()
-	p.parser = parser
-	p.CopyFrom(ctx.(*StatementContext))
+	// GetStmts returns the stmts rule context list.
+	GetStmts() []IStatementContext
 
-	return p
-}
+	// SetStmts sets the stmts rule context list.
+	SetStmts([]IStatementContext)
 
-func (s *FailContext) GetRuleContext() antlr.RuleContext {
-	return s
+	// IsBlockContext differentiates from other interfaces.
+	IsBlockContext()
 }
 
-func (s *FailContext) FAIL() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserFAIL, 0)
+type BlockContext struct {
+	*antlr.BaseParserRuleContext
+	parser     antlr.Parser
+	_statement IStatementContext
+	stmts      []IStatementContext
 }
 
-func (s *FailContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterFail(s)
-	}
+func NewEmptyBlockContext() *BlockContext {
+	var p = new(BlockContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = NumScriptParserRULE_block
+	return p
 }
 
-func (s *FailContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitFail(s)
-	}
-}
+func (*BlockContext) IsBlockContext() {}
 
-type SendContext struct {
-	*StatementContext
-	mon    IExpressionContext
-	monAll IMonetaryAllContext
-	src    IValueAwareSourceContext
-	dest   IDestinationContext
-}
+func NewBlockContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *BlockContext {
+	var p = new(BlockContext)
 
-func NewSendContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SendContext {
-	var p = new(SendContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-	p.StatementContext = NewEmptyStatementContext()
 	p.parser = parser
-	p.CopyFrom(ctx.(*StatementContext))
+	p.RuleIndex = NumScriptParserRULE_block
 
 	return p
 }
 
-func (s *SendContext) GetMon() IExpressionContext { return s.mon }
-
-func (s *SendContext) GetMonAll() IMonetaryAllContext { return s.monAll }
-
-func (s *SendContext) GetSrc() IValueAwareSourceContext { return s.src }
-
-func (s *SendContext) GetDest() IDestinationContext { return s.dest }
-
-func (s *SendContext) SetMon(v IExpressionContext) { s.mon = v }
+func (s *BlockContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SendContext) SetMonAll(v IMonetaryAllContext) { s.monAll = v }
+func (s *BlockContext) Get_statement() IStatementContext { return s._statement }
 
-func (s *SendContext) SetSrc(v IValueAwareSourceContext) { s.src = v }
+func (s *BlockContext) Set_statement(v IStatementContext) { s._statement = v }
 
-func (s *SendContext) SetDest(v IDestinationContext) { s.dest = v }
+func (s *BlockContext) GetStmts() []IStatementContext { return s.stmts }
 
-func (s *SendContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *BlockContext) SetStmts(v []IStatementContext) { s.stmts = v }
 
-func (s *SendContext) SEND() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSEND, 0)
+func (s *BlockContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserLBRACE, 0)
 }
 
-func (s *SendContext) LPAREN() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserLPAREN, 0)
+func (s *BlockContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserRBRACE, 0)
 }
 
-func (s *SendContext) AllNEWLINE() []antlr.TerminalNode {
+func (s *BlockContext) AllNEWLINE() []antlr.TerminalNode {
 	return s.GetTokens(NumScriptParserNEWLINE)
 }
 
-func (s *SendContext) NEWLINE(i int) antlr.TerminalNode {
+func (s *BlockContext) NEWLINE(i int) antlr.TerminalNode {
 	return s.GetToken(NumScriptParserNEWLINE, i)
 }
 
-func (s *SendContext) RPAREN() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserRPAREN, 0)
-}
-
-func (s *SendContext) SOURCE() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserSOURCE, 0)
-}
-
-func (s *SendContext) AllEQ() []antlr.TerminalNode {
-	return s.GetTokens(NumScriptParserEQ)
-}
-
-func (s *SendContext) EQ(i int) antlr.TerminalNode {
-	return s.GetToken(NumScriptParserEQ, i)
-}
-
-func (s *SendContext) DESTINATION() antlr.TerminalNode {
-	return s.GetToken(NumScriptParserDESTINATION, 0)
-}
-
-func (s *SendContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+func (s *BlockContext) AllStatement() []IStatementContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IStatementContext); ok {
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
-	}
-
-	return t.(IExpressionContext)
-}
-
-func (s *SendContext) MonetaryAll() IMonetaryAllContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IMonetaryAllContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+	tst := make([]IStatementContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IStatementContext); ok {
+			tst[i] = t.(IStatementContext)
+			i++
 		}
 	}
 
-	if t == nil {
-		return nil
-	}
-
-	return t.(IMonetaryAllContext)
+	return tst
 }
 
-func (s *SendContext) ValueAwareSource() IValueAwareSourceContext {
+func (s *BlockContext) Statement(i int) IStatementContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IValueAwareSourceContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IStatementContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -4653,43 +6645,36 @@ func (s *SendContext) ValueAwareSource() IValueAwareSourceContext {
 		return nil
 	}
 
-	return t.(IValueAwareSourceContext)
+	return t.(IStatementContext)
 }
 
-func (s *SendContext) Destination() IDestinationContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IDestinationContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
+func (s *BlockContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
 
-	return t.(IDestinationContext)
+func (s *BlockContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SendContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *BlockContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.EnterSend(s)
+		listenerT.EnterBlock(s)
 	}
 }
 
-func (s *SendContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *BlockContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(NumScriptListener); ok {
-		listenerT.ExitSend(s)
+		listenerT.ExitBlock(s)
 	}
 }
 
-func (p *NumScriptParser) Statement() (localctx IStatementContext) {
+func (p *NumScriptParser) Block() (localctx IBlockContext) {
 	this := p
 	_ = this
 
-	localctx = NewStatementContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, NumScriptParserRULE_statement)
+	localctx = NewBlockContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, NumScriptParserRULE_block)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -4707,280 +6692,60 @@ func (p *NumScriptParser) Statement() (localctx IStatementContext) {
 		}
 	}()
 
-	p.SetState(224)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(296)
+		p.Match(NumScriptParserLBRACE)
+	}
+	p.SetState(298)
 	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	switch p.GetTokenStream().LA(1) {
-	case NumScriptParserPRINT:
-		localctx = NewPrintContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	for ok := true; ok; ok = _la == NumScriptParserNEWLINE {
 		{
-			p.SetState(169)
-			p.Match(NumScriptParserPRINT)
-		}
-		{
-			p.SetState(170)
-
-			var _x = p.expression(0)
-
-			localctx.(*PrintContext).expr = _x
+			p.SetState(297)
+			p.Match(NumScriptParserNEWLINE)
 		}
 
-	case NumScriptParserSAVE:
-		localctx = NewSaveFromAccountContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(171)
-			p.Match(NumScriptParserSAVE)
-		}
-		p.SetState(174)
+		p.SetState(300)
 		p.GetErrorHandler().Sync(p)
-		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 14, p.GetParserRuleContext()) {
-		case 1:
-			{
-				p.SetState(172)
-
-				var _x = p.expression(0)
-
-				localctx.(*SaveFromAccountContext).mon = _x
-			}
-
-		case 2:
-			{
-				p.SetState(173)
-
-				var _x = p.MonetaryAll()
-
-				localctx.(*SaveFromAccountContext).monAll = _x
-			}
-
-		}
-		{
-			p.SetState(176)
-			p.Match(NumScriptParserFROM)
-		}
-		{
-			p.SetState(177)
-
-			var _x = p.expression(0)
-
-			localctx.(*SaveFromAccountContext).acc = _x
-		}
-
-	case NumScriptParserSET_TX_META:
-		localctx = NewSetTxMetaContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
-		{
-			p.SetState(179)
-			p.Match(NumScriptParserSET_TX_META)
-		}
-		{
-			p.SetState(180)
-			p.Match(NumScriptParserLPAREN)
-		}
-		{
-			p.SetState(181)
-
-			var _m = p.Match(NumScriptParserSTRING)
-
-			localctx.(*SetTxMetaContext).key = _m
-		}
-		{
-			p.SetState(182)
-			p.Match(NumScriptParserT__3)
-		}
-		{
-			p.SetState(183)
-
-			var _x = p.expression(0)
-
-			localctx.(*SetTxMetaContext).value = _x
-		}
-		{
-			p.SetState(184)
-			p.Match(NumScriptParserRPAREN)
-		}
-
-	case NumScriptParserSET_ACCOUNT_META:
-		localctx = NewSetAccountMetaContext(p, localctx)
-		p.EnterOuterAlt(localctx, 4)
-		{
-			p.SetState(186)
-			p.Match(NumScriptParserSET_ACCOUNT_META)
-		}
-		{
-			p.SetState(187)
-			p.Match(NumScriptParserLPAREN)
-		}
-		{
-			p.SetState(188)
-
-			var _x = p.expression(0)
-
-			localctx.(*SetAccountMetaContext).acc = _x
-		}
-		{
-			p.SetState(189)
-			p.Match(NumScriptParserT__3)
-		}
-		{
-			p.SetState(190)
-
-			var _m = p.Match(NumScriptParserSTRING)
-
-			localctx.(*SetAccountMetaContext).key = _m
-		}
-		{
-			p.SetState(191)
-			p.Match(NumScriptParserT__3)
-		}
-		{
-			p.SetState(192)
-
-			var _x = p.expression(0)
-
-			localctx.(*SetAccountMetaContext).value = _x
-		}
-		{
-			p.SetState(193)
-			p.Match(NumScriptParserRPAREN)
-		}
-
-	case NumScriptParserFAIL:
-		localctx = NewFailContext(p, localctx)
-		p.EnterOuterAlt(localctx, 5)
-		{
-			p.SetState(195)
-			p.Match(NumScriptParserFAIL)
-		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	p.SetState(308)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
 
-	case NumScriptParserSEND:
-		localctx = NewSendContext(p, localctx)
-		p.EnterOuterAlt(localctx, 6)
+	for ok := true; ok; ok = (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<NumScriptParserSET_TX_META)|(1<<NumScriptParserSET_ACCOUNT_META)|(1<<NumScriptParserPRINT)|(1<<NumScriptParserFAIL)|(1<<NumScriptParserASSERT)|(1<<NumScriptParserIF)|(1<<NumScriptParserSEND)|(1<<NumScriptParserCONVERT))) != 0) || _la == NumScriptParserSAVE {
 		{
-			p.SetState(196)
-			p.Match(NumScriptParserSEND)
-		}
-		p.SetState(199)
-		p.GetErrorHandler().Sync(p)
-		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 15, p.GetParserRuleContext()) {
-		case 1:
-			{
-				p.SetState(197)
-
-				var _x = p.expression(0)
-
-				localctx.(*SendContext).mon = _x
-			}
-
-		case 2:
-			{
-				p.SetState(198)
-
-				var _x = p.MonetaryAll()
+			p.SetState(302)
 
-				localctx.(*SendContext).monAll = _x
-			}
+			var _x = p.Statement()
 
+			localctx.(*BlockContext)._statement = _x
 		}
-		{
-			p.SetState(201)
-			p.Match(NumScriptParserLPAREN)
-		}
-		{
-			p.SetState(202)
-			p.Match(NumScriptParserNEWLINE)
-		}
-		p.SetState(219)
+		localctx.(*BlockContext).stmts = append(localctx.(*BlockContext).stmts, localctx.(*BlockContext)._statement)
+		p.SetState(304)
 		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
-		switch p.GetTokenStream().LA(1) {
-		case NumScriptParserSOURCE:
-			{
-				p.SetState(203)
-				p.Match(NumScriptParserSOURCE)
-			}
-			{
-				p.SetState(204)
-				p.Match(NumScriptParserEQ)
-			}
-			{
-				p.SetState(205)
-
-				var _x = p.ValueAwareSource()
-
-				localctx.(*SendContext).src = _x
-			}
-			{
-				p.SetState(206)
-				p.Match(NumScriptParserNEWLINE)
-			}
-			{
-				p.SetState(207)
-				p.Match(NumScriptParserDESTINATION)
-			}
-			{
-				p.SetState(208)
-				p.Match(NumScriptParserEQ)
-			}
-			{
-				p.SetState(209)
-
-				var _x = p.Destination()
-
-				localctx.(*SendContext).dest = _x
-			}
-
-		case NumScriptParserDESTINATION:
-			{
-				p.SetState(211)
-				p.Match(NumScriptParserDESTINATION)
-			}
-			{
-				p.SetState(212)
-				p.Match(NumScriptParserEQ)
-			}
-			{
-				p.SetState(213)
-
-				var _x = p.Destination()
-
-				localctx.(*SendContext).dest = _x
-			}
+		for ok := true; ok; ok = _la == NumScriptParserNEWLINE {
 			{
-				p.SetState(214)
+				p.SetState(303)
 				p.Match(NumScriptParserNEWLINE)
 			}
-			{
-				p.SetState(215)
-				p.Match(NumScriptParserSOURCE)
-			}
-			{
-				p.SetState(216)
-				p.Match(NumScriptParserEQ)
-			}
-			{
-				p.SetState(217)
-
-				var _x = p.ValueAwareSource()
-
-				localctx.(*SendContext).src = _x
-			}
 
-		default:
-			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-		}
-		{
-			p.SetState(221)
-			p.Match(NumScriptParserNEWLINE)
-		}
-		{
-			p.SetState(222)
-			p.Match(NumScriptParserRPAREN)
+			p.SetState(306)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		p.SetState(310)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(312)
+		p.Match(NumScriptParserRBRACE)
 	}
 
 	return localctx
@@ -5048,6 +6813,10 @@ func (s *Type_Context) TY_PORTION() antlr.TerminalNode {
 	return s.GetToken(NumScriptParserTY_PORTION, 0)
 }
 
+func (s *Type_Context) TY_RATE() antlr.TerminalNode {
+	return s.GetToken(NumScriptParserTY_RATE, 0)
+}
+
 func (s *Type_Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -5073,7 +6842,7 @@ func (p *NumScriptParser) Type_() (localctx IType_Context) {
 	_ = this
 
 	localctx = NewType_Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 36, NumScriptParserRULE_type_)
+	p.EnterRule(localctx, 42, NumScriptParserRULE_type_)
 	var _la int
 
 	defer func() {
@@ -5094,10 +6863,10 @@ func (p *NumScriptParser) Type_() (localctx IType_Context) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(226)
+		p.SetState(314)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((_la-31)&-(0x1f+1)) == 0 && ((1<<uint((_la-31)))&((1<<(NumScriptParserTY_ACCOUNT-31))|(1<<(NumScriptParserTY_ASSET-31))|(1<<(NumScriptParserTY_NUMBER-31))|(1<<(NumScriptParserTY_MONETARY-31))|(1<<(NumScriptParserTY_PORTION-31))|(1<<(NumScriptParserTY_STRING-31)))) != 0) {
+		if !(((_la-48)&-(0x1f+1)) == 0 && ((1<<uint((_la-48)))&((1<<(NumScriptParserTY_ACCOUNT-48))|(1<<(NumScriptParserTY_ASSET-48))|(1<<(NumScriptParserTY_NUMBER-48))|(1<<(NumScriptParserTY_MONETARY-48))|(1<<(NumScriptParserTY_PORTION-48))|(1<<(NumScriptParserTY_STRING-48))|(1<<(NumScriptParserTY_RATE-48)))) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -5328,7 +7097,7 @@ func (p *NumScriptParser) Origin() (localctx IOriginContext) {
 	_ = this
 
 	localctx = NewOriginContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 38, NumScriptParserRULE_origin)
+	p.EnterRule(localctx, 44, NumScriptParserRULE_origin)
 
 	defer func() {
 		p.ExitRule()
@@ -5346,7 +7115,7 @@ func (p *NumScriptParser) Origin() (localctx IOriginContext) {
 		}
 	}()
 
-	p.SetState(242)
+	p.SetState(330)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
@@ -5354,33 +7123,33 @@ func (p *NumScriptParser) Origin() (localctx IOriginContext) {
 		localctx = NewOriginAccountMetaContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(228)
+			p.SetState(316)
 			p.Match(NumScriptParserMETA)
 		}
 		{
-			p.SetState(229)
+			p.SetState(317)
 			p.Match(NumScriptParserLPAREN)
 		}
 		{
-			p.SetState(230)
+			p.SetState(318)
 
 			var _x = p.expression(0)
 
 			localctx.(*OriginAccountMetaContext).account = _x
 		}
 		{
-			p.SetState(231)
-			p.Match(NumScriptParserT__3)
+			p.SetState(319)
+			p.Match(NumScriptParserT__0)
 		}
 		{
-			p.SetState(232)
+			p.SetState(320)
 
 			var _m = p.Match(NumScriptParserSTRING)
 
 			localctx.(*OriginAccountMetaContext).key = _m
 		}
 		{
-			p.SetState(233)
+			p.SetState(321)
 			p.Match(NumScriptParserRPAREN)
 		}
 
@@ -5388,33 +7157,33 @@ func (p *NumScriptParser) Origin() (localctx IOriginContext) {
 		localctx = NewOriginAccountBalanceContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(235)
+			p.SetState(323)
 			p.Match(NumScriptParserBALANCE)
 		}
 		{
-			p.SetState(236)
+			p.SetState(324)
 			p.Match(NumScriptParserLPAREN)
 		}
 		{
-			p.SetState(237)
+			p.SetState(325)
 
 			var _x = p.expression(0)
 
 			localctx.(*OriginAccountBalanceContext).account = _x
 		}
 		{
-			p.SetState(238)
-			p.Match(NumScriptParserT__3)
+			p.SetState(326)
+			p.Match(NumScriptParserT__0)
 		}
 		{
-			p.SetState(239)
+			p.SetState(327)
 
 			var _x = p.expression(0)
 
 			localctx.(*OriginAccountBalanceContext).asset = _x
 		}
 		{
-			p.SetState(240)
+			p.SetState(328)
 			p.Match(NumScriptParserRPAREN)
 		}
 
@@ -5573,7 +7342,7 @@ func (p *NumScriptParser) VarDecl() (localctx IVarDeclContext) {
 	_ = this
 
 	localctx = NewVarDeclContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, NumScriptParserRULE_varDecl)
+	p.EnterRule(localctx, 46, NumScriptParserRULE_varDecl)
 	var _la int
 
 	defer func() {
@@ -5594,30 +7363,30 @@ func (p *NumScriptParser) VarDecl() (localctx IVarDeclContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(244)
+		p.SetState(332)
 
 		var _x = p.Type_()
 
 		localctx.(*VarDeclContext).ty = _x
 	}
 	{
-		p.SetState(245)
+		p.SetState(333)
 
 		var _x = p.Variable()
 
 		localctx.(*VarDeclContext).name = _x
 	}
-	p.SetState(248)
+	p.SetState(336)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == NumScriptParserEQ {
 		{
-			p.SetState(246)
+			p.SetState(334)
 			p.Match(NumScriptParserEQ)
 		}
 		{
-			p.SetState(247)
+			p.SetState(335)
 
 			var _x = p.Origin()
 
@@ -5775,7 +7544,7 @@ func (p *NumScriptParser) VarListDecl() (localctx IVarListDeclContext) {
 	_ = this
 
 	localctx = NewVarListDeclContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 42, NumScriptParserRULE_varListDecl)
+	p.EnterRule(localctx, 48, NumScriptParserRULE_varListDecl)
 	var _la int
 
 	defer func() {
@@ -5796,55 +7565,55 @@ func (p *NumScriptParser) VarListDecl() (localctx IVarListDeclContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(250)
+		p.SetState(338)
 		p.Match(NumScriptParserVARS)
 	}
 	{
-		p.SetState(251)
+		p.SetState(339)
 		p.Match(NumScriptParserLBRACE)
 	}
 	{
-		p.SetState(252)
+		p.SetState(340)
 		p.Match(NumScriptParserNEWLINE)
 	}
-	p.SetState(259)
+	p.SetState(347)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = (((_la-31)&-(0x1f+1)) == 0 && ((1<<uint((_la-31)))&((1<<(NumScriptParserTY_ACCOUNT-31))|(1<<(NumScriptParserTY_ASSET-31))|(1<<(NumScriptParserTY_NUMBER-31))|(1<<(NumScriptParserTY_MONETARY-31))|(1<<(NumScriptParserTY_PORTION-31))|(1<<(NumScriptParserTY_STRING-31)))) != 0) {
+	for ok := true; ok; ok = (((_la-48)&-(0x1f+1)) == 0 && ((1<<uint((_la-48)))&((1<<(NumScriptParserTY_ACCOUNT-48))|(1<<(NumScriptParserTY_ASSET-48))|(1<<(NumScriptParserTY_NUMBER-48))|(1<<(NumScriptParserTY_MONETARY-48))|(1<<(NumScriptParserTY_PORTION-48))|(1<<(NumScriptParserTY_STRING-48))|(1<<(NumScriptParserTY_RATE-48)))) != 0) {
 		{
-			p.SetState(253)
+			p.SetState(341)
 
 			var _x = p.VarDecl()
 
 			localctx.(*VarListDeclContext)._varDecl = _x
 		}
 		localctx.(*VarListDeclContext).v = append(localctx.(*VarListDeclContext).v, localctx.(*VarListDeclContext)._varDecl)
-		p.SetState(255)
+		p.SetState(343)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 		for ok := true; ok; ok = _la == NumScriptParserNEWLINE {
 			{
-				p.SetState(254)
+				p.SetState(342)
 				p.Match(NumScriptParserNEWLINE)
 			}
 
-			p.SetState(257)
+			p.SetState(345)
 			p.GetErrorHandler().Sync(p)
 			_la = p.GetTokenStream().LA(1)
 		}
 
-		p.SetState(261)
+		p.SetState(349)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(263)
+		p.SetState(351)
 		p.Match(NumScriptParserRBRACE)
 	}
 	{
-		p.SetState(264)
+		p.SetState(352)
 		p.Match(NumScriptParserNEWLINE)
 	}
 
@@ -6016,7 +7785,7 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 	_ = this
 
 	localctx = NewScriptContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 44, NumScriptParserRULE_script)
+	p.EnterRule(localctx, 50, NumScriptParserRULE_script)
 	var _la int
 
 	defer func() {
@@ -6038,27 +7807,27 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(269)
+	p.SetState(357)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == NumScriptParserNEWLINE {
 		{
-			p.SetState(266)
+			p.SetState(354)
 			p.Match(NumScriptParserNEWLINE)
 		}
 
-		p.SetState(271)
+		p.SetState(359)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
-	p.SetState(273)
+	p.SetState(361)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == NumScriptParserVARS {
 		{
-			p.SetState(272)
+			p.SetState(360)
 
 			var _x = p.VarListDecl()
 
@@ -6067,25 +7836,25 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 
 	}
 	{
-		p.SetState(275)
+		p.SetState(363)
 
 		var _x = p.Statement()
 
 		localctx.(*ScriptContext)._statement = _x
 	}
 	localctx.(*ScriptContext).stmts = append(localctx.(*ScriptContext).stmts, localctx.(*ScriptContext)._statement)
-	p.SetState(280)
+	p.SetState(368)
 	p.GetErrorHandler().Sync(p)
-	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 24, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 30, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
 			{
-				p.SetState(276)
+				p.SetState(364)
 				p.Match(NumScriptParserNEWLINE)
 			}
 			{
-				p.SetState(277)
+				p.SetState(365)
 
 				var _x = p.Statement()
 
@@ -6094,26 +7863,26 @@ func (p *NumScriptParser) Script() (localctx IScriptContext) {
 			localctx.(*ScriptContext).stmts = append(localctx.(*ScriptContext).stmts, localctx.(*ScriptContext)._statement)
 
 		}
-		p.SetState(282)
+		p.SetState(370)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 24, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 30, p.GetParserRuleContext())
 	}
-	p.SetState(286)
+	p.SetState(374)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == NumScriptParserNEWLINE {
 		{
-			p.SetState(283)
+			p.SetState(371)
 			p.Match(NumScriptParserNEWLINE)
 		}
 
-		p.SetState(288)
+		p.SetState(376)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(289)
+		p.SetState(377)
 		p.Match(NumScriptParserEOF)
 	}
 
@@ -6140,7 +7909,10 @@ func (p *NumScriptParser) Expression_Sempred(localctx antlr.RuleContext, predInd
 
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 3)
+		return p.Precpred(p.GetParserRuleContext(), 7)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 6)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
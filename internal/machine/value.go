@@ -17,6 +17,7 @@ const (
 	TypeAllotment                  // list of portions
 	TypeAmount                     // either ALL or a SPECIFIC number
 	TypeFunding                    // (asset, []{amount, account})
+	TypeRate                       // strictly positive rational number
 )
 
 func (t Type) String() string {
@@ -37,6 +38,8 @@ func (t Type) String() string {
 		return "allotment"
 	case TypeAmount:
 		return "amount"
+	case TypeRate:
+		return "rate"
 	default:
 		return "invalid type"
 	}
@@ -76,6 +79,9 @@ func ValueEquals(lhs, rhs Value) bool {
 	} else if lhsp, ok := lhs.(Portion); ok {
 		rhsp := rhs.(Portion)
 		return lhsp.Equals(rhsp)
+	} else if lhsr, ok := lhs.(Rate); ok {
+		rhsr := rhs.(Rate)
+		return lhsr.Equals(rhsr)
 	} else if lhsf, ok := lhs.(Funding); ok {
 		rhsf := rhs.(Funding)
 		return lhsf.Equals(rhsf)
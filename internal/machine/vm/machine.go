@@ -40,6 +40,7 @@ type Machine struct {
 	Printer                    func(chan machine.Value)
 	printChan                  chan machine.Value
 	Debug                      bool
+	accountPolicies            ledger.AccountPolicies
 }
 
 type Posting struct {
@@ -184,6 +185,89 @@ func (m *Machine) repay(funding machine.Funding) {
 	}
 }
 
+func (m *Machine) balance(account machine.AccountAddress, asset machine.Asset) (*machine.MonetaryInt, error) {
+	if account == "world" {
+		return nil, errors.New("cannot read the balance of @world")
+	}
+	if accBalances, ok := m.Balances[account]; ok {
+		if balance, ok := accBalances[asset]; ok {
+			return balance, nil
+		}
+	}
+	return nil, fmt.Errorf("missing %v balance from %v", asset, account)
+}
+
+// compare returns -1, 0 or 1 depending on whether a is lower than, equal to or greater than b.
+// Both values must be numbers or monetaries of the same asset.
+func compare(a, b machine.Value) (int, error) {
+	switch a := a.(type) {
+	case machine.Number:
+		if b, ok := b.(machine.Number); ok {
+			return a.Cmp(b), nil
+		}
+	case machine.Monetary:
+		if b, ok := b.(machine.Monetary); ok {
+			if a.Asset != b.Asset {
+				return 0, machine.NewErrInvalidScript("cannot compare different assets: %v and %v", a.Asset, b.Asset)
+			}
+			return a.Amount.Cmp(b.Amount), nil
+		}
+	}
+	return 0, machine.NewErrInvalidScript("cannot compare %v and %v", a.GetType(), b.GetType())
+}
+
+// equals reports whether a and b hold the same value.
+// Monetaries must be of the same asset.
+func equals(a, b machine.Value) (bool, error) {
+	if a.GetType() != b.GetType() {
+		return false, machine.NewErrInvalidScript("cannot compare %v and %v", a.GetType(), b.GetType())
+	}
+	if a, ok := a.(machine.Monetary); ok {
+		cmp, err := compare(a, b)
+		if err != nil {
+			return false, err
+		}
+		return cmp == 0, nil
+	}
+	return machine.ValueEquals(a, b), nil
+}
+
+// ConversionRateMetadataKey is the transaction metadata key under which
+// the rate used to convert from one asset to another is recorded.
+func ConversionRateMetadataKey(from, to machine.Asset) string {
+	return fmt.Sprintf("conversion_rate:%s:%s", from, to)
+}
+
+// mul multiplies a number or the amount of a monetary by factor,
+// rounding the result to an integer with the given rounding mode.
+func mul(a machine.Value, factor *big.Rat, rounding machine.Rounding) (machine.Value, error) {
+	switch a := a.(type) {
+	case machine.Number:
+		res, err := machine.Round(new(big.Rat).Mul(new(big.Rat).SetInt((*big.Int)(a)), factor), rounding)
+		if err != nil {
+			return nil, machine.NewErrInvalidScript(err.Error())
+		}
+		return res, nil
+	case machine.Monetary:
+		amount, err := mul(a.Amount, factor, rounding)
+		if err != nil {
+			return nil, err
+		}
+		return machine.Monetary{
+			Asset:  a.Asset,
+			Amount: amount.(machine.Number),
+		}, nil
+	default:
+		return nil, machine.NewErrInvalidScript("wrong type for left-hand side operand of multiplication: %v", a.GetType())
+	}
+}
+
+// jumpTarget reads the instruction address following a jump opcode.
+func (m *Machine) jumpTarget() uint {
+	bytes := m.Program.Instructions[m.P+1 : m.P+3]
+	return uint(binary.LittleEndian.Uint16(bytes))
+}
+
 func (m *Machine) tick() (bool, error) {
 	op := m.Program.Instructions[m.P]
 
@@ -443,6 +527,143 @@ func (m *Machine) tick() (bool, error) {
 			panic(fmt.Errorf("invalid value type: %T", v))
 		}
 
+	case program.OP_BALANCE:
+		asset := pop[machine.Asset](m)
+		account := pop[machine.AccountAddress](m)
+		balance, err := m.balance(account, asset)
+		if err != nil {
+			return true, machine.NewErrInvalidScript(err.Error())
+		}
+		m.pushValue(machine.Monetary{
+			Asset:  asset,
+			Amount: balance,
+		})
+
+	case program.OP_EQ, program.OP_NEQ:
+		b := m.popValue()
+		a := m.popValue()
+		res, err := equals(a, b)
+		if err != nil {
+			return true, err
+		}
+		if op == program.OP_NEQ {
+			res = !res
+		}
+		if res {
+			m.pushValue(machine.NewNumber(1))
+		} else {
+			m.pushValue(machine.NewNumber(0))
+		}
+
+	case program.OP_LT, program.OP_LTE, program.OP_GT, program.OP_GTE:
+		b := m.popValue()
+		a := m.popValue()
+		cmp, err := compare(a, b)
+		if err != nil {
+			return true, err
+		}
+		var res bool
+		switch op {
+		case program.OP_LT:
+			res = cmp < 0
+		case program.OP_LTE:
+			res = cmp <= 0
+		case program.OP_GT:
+			res = cmp > 0
+		case program.OP_GTE:
+			res = cmp >= 0
+		}
+		if res {
+			m.pushValue(machine.NewNumber(1))
+		} else {
+			m.pushValue(machine.NewNumber(0))
+		}
+
+	case program.OP_ASSERT:
+		msg := pop[machine.String](m)
+		cond := pop[machine.Number](m)
+		if cond.Eq(machine.Zero) {
+			return true, machine.NewErrAssertionFailed("assertion failed: %s", string(msg))
+		}
+
+	case program.OP_JUMP:
+		m.P = m.jumpTarget()
+		return int(m.P) >= len(m.Program.Instructions), nil
+
+	case program.OP_JUMP_IF_FALSE:
+		cond := pop[machine.Number](m)
+		if cond.Eq(machine.Zero) {
+			m.P = m.jumpTarget()
+			return int(m.P) >= len(m.Program.Instructions), nil
+		}
+		m.P += 2
+
+	case program.OP_MUL, program.OP_DIV:
+		rounding := machine.Rounding(m.Program.Instructions[m.P+1])
+		b := m.popValue()
+		a := m.popValue()
+		var factor *big.Rat
+		switch b := b.(type) {
+		case machine.Number:
+			factor = new(big.Rat).SetInt((*big.Int)(b))
+		case machine.Portion:
+			if op == program.OP_MUL && !b.Remaining {
+				factor = b.Specific
+			}
+		}
+		if factor == nil {
+			return true, machine.NewErrInvalidScript("wrong type for right-hand side operand of %s: %v", program.OpcodeName(op), b.GetType())
+		}
+		if op == program.OP_DIV {
+			if factor.Sign() == 0 {
+				return true, machine.NewErrInvalidScript("division by zero")
+			}
+			factor = new(big.Rat).Inv(factor)
+		}
+		res, err := mul(a, factor, rounding)
+		if err != nil {
+			return true, err
+		}
+		m.pushValue(res)
+		m.P += 1
+
+	case program.OP_MIN, program.OP_MAX:
+		b := m.popValue()
+		a := m.popValue()
+		cmp, err := compare(a, b)
+		if err != nil {
+			return true, err
+		}
+		if (op == program.OP_MIN) == (cmp <= 0) {
+			m.pushValue(a)
+		} else {
+			m.pushValue(b)
+		}
+
+	case program.OP_CONVERT:
+		rounding := machine.Rounding(m.Program.Instructions[m.P+1])
+		rate := pop[machine.Rate](m)
+		asset := pop[machine.Asset](m)
+		mon := pop[machine.Monetary](m)
+		if asset == mon.Asset {
+			return true, machine.NewErrInvalidScript("cannot convert %s to itself", asset)
+		}
+		amount, err := mul(mon.Amount, rate.Value, rounding)
+		if err != nil {
+			return true, err
+		}
+		// The transaction records a single rate per pair of assets
+		key := ConversionRateMetadataKey(mon.Asset, asset)
+		if recorded, ok := m.TxMeta[key]; ok && !machine.ValueEquals(recorded, rate) {
+			return true, machine.NewErrInvalidScript("cannot convert %s to %s at several rates", mon.Asset, asset)
+		}
+		m.TxMeta[key] = rate
+		m.pushValue(machine.Monetary{
+			Asset:  asset,
+			Amount: amount.(machine.Number),
+		})
+		m.P += 1
+
 	default:
 		return true, machine.NewErrInvalidScript("invalid opcode: %v", op)
 	}
@@ -628,6 +849,13 @@ func (m *Machine) ResolveResources(ctx context.Context, store Store) ([]string,
 	for _, machineAddress := range m.Program.WriteLockAccounts {
 		writeLockAccounts = append(writeLockAccounts, involvedAccountsMap[machineAddress])
 	}
+	// The balances of the accounts constrained by a policy are checked after the execution,
+	// even when the program does not need them
+	for _, account := range m.policyLockAccounts(involvedAccountsMap) {
+		if !slices.Contains(writeLockAccounts, account) {
+			writeLockAccounts = append(writeLockAccounts, account)
+		}
+	}
 
 	slices.Sort(readLockAccounts)
 	slices.Sort(writeLockAccounts)
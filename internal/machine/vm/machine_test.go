@@ -2415,3 +2415,456 @@ func (s *mockStore) GetBalance(ctx context.Context, address, asset string) (*big
 func (s *mockStore) GetAccount(ctx context.Context, address string) (*ledger.Account, error) {
 	panic("not implemented")
 }
+
+func TestAssert(t *testing.T) {
+	script := `
+	vars {
+		monetary $min
+	}
+	send [COIN 40] (
+		source = @a
+		destination = @b
+	)
+	assert balance(@a, COIN) >= $min
+	assert balance(@b, COIN) == [COIN 40]`
+
+	t.Run("success", func(t *testing.T) {
+		tc := NewTestCase()
+		tc.compile(t, script)
+		tc.setVarsFromJSON(t, `{"min": "COIN 10"}`)
+		tc.setBalance("a", "COIN", 50)
+		tc.expected = CaseResult{
+			Printed: []machine.Value{},
+			Postings: []Posting{
+				{
+					Asset:       "COIN",
+					Amount:      machine.NewMonetaryInt(40),
+					Source:      "a",
+					Destination: "b",
+				},
+			},
+			Error: nil,
+		}
+		test(t, tc)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		tc := NewTestCase()
+		tc.compile(t, script)
+		tc.setVarsFromJSON(t, `{"min": "COIN 20"}`)
+		tc.setBalance("a", "COIN", 50)
+		tc.expected = CaseResult{
+			Error:         &machine.ErrAssertionFailed{},
+			ErrorContains: "assertion failed: balance(@a, COIN) >= $min",
+		}
+		test(t, tc)
+	})
+
+	t.Run("different assets", func(t *testing.T) {
+		tc := NewTestCase()
+		tc.compile(t, `assert balance(@a, COIN) > [EUR 0]`)
+		tc.setBalance("a", "COIN", 50)
+		tc.expected = CaseResult{
+			Error: &machine.ErrInvalidScript{},
+		}
+		test(t, tc)
+	})
+}
+
+func TestIf(t *testing.T) {
+	script := `
+	vars {
+		account $customer
+		string $tier = meta($customer, "tier")
+	}
+	if $tier == "premium" {
+		send [COIN 10] (
+			source = $customer
+			destination = @fees:premium
+		)
+	} else {
+		send [COIN 20] (
+			source = $customer
+			destination = @fees:standard
+		)
+		if balance($customer, COIN) < [COIN 50] {
+			set_tx_meta("low_balance", "yes")
+		}
+	}`
+
+	for _, tc := range []struct {
+		name             string
+		tier             string
+		balance          int64
+		expectedPostings []Posting
+		expectedMetadata map[string]machine.Value
+	}{
+		{
+			name:    "then branch",
+			tier:    "premium",
+			balance: 100,
+			expectedPostings: []Posting{{
+				Asset:       "COIN",
+				Amount:      machine.NewMonetaryInt(10),
+				Source:      "users:001",
+				Destination: "fees:premium",
+			}},
+		},
+		{
+			name:    "else branch",
+			tier:    "standard",
+			balance: 100,
+			expectedPostings: []Posting{{
+				Asset:       "COIN",
+				Amount:      machine.NewMonetaryInt(20),
+				Source:      "users:001",
+				Destination: "fees:standard",
+			}},
+		},
+		{
+			name:    "nested branch",
+			tier:    "standard",
+			balance: 60,
+			expectedPostings: []Posting{{
+				Asset:       "COIN",
+				Amount:      machine.NewMonetaryInt(20),
+				Source:      "users:001",
+				Destination: "fees:standard",
+			}},
+			expectedMetadata: map[string]machine.Value{
+				"low_balance": machine.String("yes"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewTestCase()
+			c.compile(t, script)
+			c.vars = map[string]string{
+				"customer": "users:001",
+			}
+			c.meta = map[string]metadata.Metadata{
+				"users:001": {
+					"tier": tc.tier,
+				},
+			}
+			c.setBalance("users:001", "COIN", tc.balance)
+			c.expected = CaseResult{
+				Printed:  []machine.Value{},
+				Postings: tc.expectedPostings,
+				Metadata: tc.expectedMetadata,
+			}
+			test(t, c)
+		})
+	}
+}
+
+func TestResolveResourcesIf(t *testing.T) {
+	p, err := compiler.Compile(`
+	if 1 == 2 {
+		send [COIN 10] (
+			source = @a
+			destination = @b
+		)
+	} else {
+		send [COIN 10] (
+			source = @c
+			destination = @d
+		)
+	}`)
+	require.NoError(t, err)
+
+	m := NewMachine(*p)
+	require.NoError(t, m.SetVarsFromJSON(map[string]string{}))
+	rlAccounts, wlAccounts, err := m.ResolveResources(context.Background(), EmptyStore)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, wlAccounts)
+	require.Equal(t, []string{"b", "d"}, rlAccounts)
+}
+
+func TestFee(t *testing.T) {
+	script := `
+	vars {
+		monetary $amount
+	}
+	send min(max(round($amount * 2.9%) + [USD/2 30], [USD/2 50]), [USD/2 1000]) (
+		source = @payments
+		destination = @fees
+	)`
+
+	for _, tc := range []struct {
+		amount   string
+		expected int64
+	}{
+		{amount: "USD/2 1000", expected: 59},
+		{amount: "USD/2 500", expected: 50},
+		{amount: "USD/2 1050", expected: 60},
+		{amount: "USD/2 1000000", expected: 1000},
+	} {
+		t.Run(tc.amount, func(t *testing.T) {
+			c := NewTestCase()
+			c.compile(t, script)
+			c.setVarsFromJSON(t, `{"amount": "`+tc.amount+`"}`)
+			c.setBalance("payments", "USD/2", 10000)
+			c.expected = CaseResult{
+				Printed: []machine.Value{},
+				Postings: []Posting{{
+					Asset:       "USD/2",
+					Amount:      machine.NewMonetaryInt(tc.expected),
+					Source:      "payments",
+					Destination: "fees",
+				}},
+			}
+			test(t, c)
+		})
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	for _, tc := range []struct {
+		script        string
+		expected      machine.Value
+		error         error
+		errorContains string
+	}{
+		{script: `print 7 * 3`, expected: machine.NewMonetaryInt(21)},
+		{script: `print floor(7 / (2))`, expected: machine.NewMonetaryInt(3)},
+		{script: `print ceil(7 / (2))`, expected: machine.NewMonetaryInt(4)},
+		{script: `print round(7 / (2))`, expected: machine.NewMonetaryInt(4)},
+		{script: `print round(5 / (2))`, expected: machine.NewMonetaryInt(2)},
+		{script: `print floor((0 - 7) / (2))`, expected: machine.NewMonetaryInt(-4)},
+		{script: `print floor([EUR/2 7] * 1/3)`, expected: machine.Monetary{
+			Asset:  "EUR/2",
+			Amount: machine.NewMonetaryInt(2),
+		}},
+		{script: `print floor([EUR/2 7] / 0)`, error: &machine.ErrInvalidScript{}, errorContains: "division by zero"},
+	} {
+		t.Run(tc.script, func(t *testing.T) {
+			c := NewTestCase()
+			c.compile(t, tc.script)
+			if tc.error != nil {
+				c.expected = CaseResult{
+					Printed:       []machine.Value{},
+					Error:         tc.error,
+					ErrorContains: tc.errorContains,
+				}
+			} else {
+				c.expected = CaseResult{
+					Printed:  []machine.Value{tc.expected},
+					Postings: []Posting{},
+				}
+			}
+			test(t, c)
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	script := `
+	vars {
+		rate $rate
+	}
+	convert [EUR/2 100] to USD/2 at $rate (
+		source = {
+			@alice
+			@world
+		}
+		via = @fx:eur_usd
+		destination = @bob
+	)`
+
+	c := NewTestCase()
+	c.compile(t, script)
+	c.setVarsFromJSON(t, `{"rate": "1.0825"}`)
+	c.setBalance("alice", "EUR/2", 60)
+	c.expected = CaseResult{
+		Printed: []machine.Value{},
+		Postings: []Posting{
+			{
+				Asset:       "EUR/2",
+				Amount:      machine.NewMonetaryInt(60),
+				Source:      "alice",
+				Destination: "fx:eur_usd",
+			},
+			{
+				Asset:       "EUR/2",
+				Amount:      machine.NewMonetaryInt(40),
+				Source:      "world",
+				Destination: "fx:eur_usd",
+			},
+			{
+				Asset:       "USD/2",
+				Amount:      machine.NewMonetaryInt(108),
+				Source:      "fx:eur_usd",
+				Destination: "bob",
+			},
+		},
+		Metadata: map[string]machine.Value{
+			"conversion_rate:EUR/2:USD/2": machine.Rate{Value: big.NewRat(433, 400)},
+		},
+	}
+	test(t, c)
+}
+
+func TestConvertRounding(t *testing.T) {
+	for _, tc := range []struct {
+		rate     string
+		expected int64
+	}{
+		{rate: "$rate", expected: 108},
+		{rate: "round($rate)", expected: 108},
+		{rate: "floor($rate)", expected: 108},
+		{rate: "ceil($rate)", expected: 109},
+	} {
+		t.Run(tc.rate, func(t *testing.T) {
+			c := NewTestCase()
+			c.compile(t, fmt.Sprintf(`vars {
+				rate $rate
+			}
+			convert [EUR/2 100] to USD/2 at %s (
+				source = @world
+				via = @fx
+				destination = @bob
+			)`, tc.rate))
+			c.setVarsFromJSON(t, `{"rate": "1.0825"}`)
+			c.expected = CaseResult{
+				Printed: []machine.Value{},
+				Postings: []Posting{
+					{
+						Asset:       "EUR/2",
+						Amount:      machine.NewMonetaryInt(100),
+						Source:      "world",
+						Destination: "fx",
+					},
+					{
+						Asset:       "USD/2",
+						Amount:      machine.NewMonetaryInt(tc.expected),
+						Source:      "fx",
+						Destination: "bob",
+					},
+				},
+				Metadata: map[string]machine.Value{
+					"conversion_rate:EUR/2:USD/2": machine.Rate{Value: big.NewRat(433, 400)},
+				},
+			}
+			test(t, c)
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	t.Run("same asset", func(t *testing.T) {
+		c := NewTestCase()
+		c.compile(t, `vars {
+			rate $rate
+		}
+		convert [EUR/2 100] to EUR/2 at $rate (
+			source = @world
+			via = @fx
+			destination = @bob
+		)`)
+		c.setVarsFromJSON(t, `{"rate": "2"}`)
+		c.expected = CaseResult{
+			Printed:       []machine.Value{},
+			Error:         &machine.ErrInvalidScript{},
+			ErrorContains: "cannot convert EUR/2 to itself",
+		}
+		test(t, c)
+	})
+
+	t.Run("several rates", func(t *testing.T) {
+		c := NewTestCase()
+		c.compile(t, `vars {
+			rate $rate
+			rate $other
+		}
+		convert [EUR/2 100] to USD/2 at $rate (
+			source = @world
+			via = @fx
+			destination = @bob
+		)
+		convert [EUR/2 100] to USD/2 at $other (
+			source = @world
+			via = @fx
+			destination = @bob
+		)`)
+		c.setVarsFromJSON(t, `{"rate": "2", "other": "3"}`)
+		c.expected = CaseResult{
+			Printed:       []machine.Value{},
+			Error:         &machine.ErrInvalidScript{},
+			ErrorContains: "cannot convert EUR/2 to USD/2 at several rates",
+		}
+		test(t, c)
+	})
+
+	t.Run("same rate twice", func(t *testing.T) {
+		c := NewTestCase()
+		c.compile(t, `vars {
+			rate $rate
+		}
+		convert [EUR/2 100] to USD/2 at $rate (
+			source = @world
+			via = @fx
+			destination = @bob
+		)
+		convert [EUR/2 50] to USD/2 at $rate (
+			source = @world
+			via = @fx
+			destination = @carol
+		)`)
+		c.setVarsFromJSON(t, `{"rate": "2"}`)
+		c.expected = CaseResult{
+			Printed: []machine.Value{},
+			Postings: []Posting{
+				{Asset: "EUR/2", Amount: machine.NewMonetaryInt(100), Source: "world", Destination: "fx"},
+				{Asset: "USD/2", Amount: machine.NewMonetaryInt(200), Source: "fx", Destination: "bob"},
+				{Asset: "EUR/2", Amount: machine.NewMonetaryInt(50), Source: "world", Destination: "fx"},
+				{Asset: "USD/2", Amount: machine.NewMonetaryInt(100), Source: "fx", Destination: "carol"},
+			},
+			Metadata: map[string]machine.Value{
+				"conversion_rate:EUR/2:USD/2": machine.Rate{Value: big.NewRat(2, 1)},
+			},
+		}
+		test(t, c)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		c := NewTestCase()
+		c.compile(t, `vars {
+			rate $rate
+		}
+		convert [EUR/2 100] to USD/2 at $rate (
+			source = @alice
+			via = @fx
+			destination = @bob
+		)`)
+		c.setVarsFromJSON(t, `{"rate": "2"}`)
+		c.setBalance("alice", "EUR/2", 99)
+		c.expected = CaseResult{
+			Printed: []machine.Value{},
+			Error:   &machine.ErrInsufficientFund{},
+		}
+		test(t, c)
+	})
+}
+
+func TestResolveResourcesConvert(t *testing.T) {
+	p, err := compiler.Compile(`
+	vars {
+		rate $rate
+	}
+	convert [EUR/2 100] to USD/2 at $rate (
+		source = @alice
+		via = @fx
+		destination = @bob
+	)`)
+	require.NoError(t, err)
+
+	m := NewMachine(*p)
+	require.NoError(t, m.SetVarsFromJSON(map[string]string{
+		"rate": "3/2",
+	}))
+	rlAccounts, wlAccounts, err := m.ResolveResources(context.Background(), EmptyStore)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice"}, wlAccounts)
+	require.Equal(t, []string{"bob", "fx"}, rlAccounts)
+}
@@ -0,0 +1,99 @@
+package vm
+
+import (
+	"context"
+	"math/big"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/pkg/errors"
+)
+
+// SetAccountPolicies sets the policies enforced on the accounts used by the program.
+// It must be called before resolving the resources, as the accounts constrained
+// by a minimum balance need to be write locked, even when used as unbounded sources.
+func (m *Machine) SetAccountPolicies(policies ledger.AccountPolicies) {
+	m.accountPolicies = policies
+}
+
+// CheckAccountPolicies checks the postings of an executed program against the policies of their accounts.
+// The balances are read from store, which must not include the postings yet.
+func (m *Machine) CheckAccountPolicies(ctx context.Context, store Store) error {
+	if len(m.accountPolicies) == 0 {
+		return nil
+	}
+
+	type key struct {
+		account string
+		asset   string
+	}
+	var (
+		debits = make(map[key]*big.Int)
+		order  = make([]key, 0)
+	)
+	move := func(account, asset string, amount *big.Int) error {
+		policy := m.accountPolicies.Find(account)
+		if policy == nil {
+			return nil
+		}
+		if policy.Frozen {
+			return machine.NewErrAccountPolicy("account %s is frozen by policy %s", account, policy.Pattern)
+		}
+		if !policy.IsAssetAllowed(asset) {
+			return machine.NewErrAccountPolicy("asset %s is not allowed on account %s by policy %s", asset, account, policy.Pattern)
+		}
+		if policy.MinimumBalance(asset) == nil {
+			return nil
+		}
+
+		k := key{account: account, asset: asset}
+		if _, ok := debits[k]; !ok {
+			debits[k] = new(big.Int)
+			order = append(order, k)
+		}
+		debits[k].Add(debits[k], amount)
+		return nil
+	}
+
+	for _, posting := range m.Postings {
+		amount := (*big.Int)(posting.Amount)
+		if err := move(posting.Source, posting.Asset, new(big.Int).Neg(amount)); err != nil {
+			return err
+		}
+		if err := move(posting.Destination, posting.Asset, amount); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range order {
+		// Credits are always accepted, even if they leave the account under its minimum balance
+		if debits[k].Sign() >= 0 {
+			continue
+		}
+
+		balance, err := store.GetBalance(ctx, k.account, k.asset)
+		if err != nil {
+			return errors.Wrapf(err, "could not get balance for account %q", k.account)
+		}
+
+		minimum := m.accountPolicies.Find(k.account).MinimumBalance(k.asset)
+		if after := new(big.Int).Add(balance, debits[k]); after.Cmp(minimum) < 0 {
+			return machine.NewErrAccountPolicy("account %s would have a balance of %s %s, under the minimum balance %s of policy %s",
+				k.account, after, k.asset, minimum, m.accountPolicies.Find(k.account).Pattern)
+		}
+	}
+
+	return nil
+}
+
+// policyLockAccounts returns the accounts of involvedAccounts whose balance is constrained by a policy.
+func (m *Machine) policyLockAccounts(involvedAccounts map[machine.Address]string) []string {
+	ret := make([]string, 0)
+	for _, account := range involvedAccounts {
+		policy := m.accountPolicies.Find(account)
+		if policy != nil && !policy.AllowUnboundedOverdraft {
+			ret = append(ret, account)
+		}
+	}
+	return ret
+}
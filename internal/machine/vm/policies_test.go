@@ -0,0 +1,136 @@
+package vm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/formancehq/ledger/internal/machine/script/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPolicies(t *testing.T) {
+	t.Parallel()
+
+	policies := ledger.AccountPolicies{
+		{
+			Pattern: "users:*:wallet",
+			MinimumBalances: map[string]*big.Int{
+				"USD": big.NewInt(-10),
+			},
+			AllowedAssets: []string{"USD", "EUR"},
+		},
+		{
+			Pattern:                 "users:vip:wallet",
+			AllowUnboundedOverdraft: true,
+		},
+		{
+			Pattern: "blocked:*",
+			Frozen:  true,
+		},
+	}
+	store := StaticStore{
+		"users:1:wallet": {
+			Account:  ledger.NewAccount("users:1:wallet"),
+			Balances: map[string]*big.Int{"USD": big.NewInt(100)},
+		},
+	}
+
+	type testCase struct {
+		name              string
+		script            string
+		expectError       bool
+		expectWriteLocked []string
+	}
+	testCases := []testCase{
+		{
+			name: "debit within the minimum balance",
+			script: `send [USD 110] (
+				source = @users:1:wallet allowing unbounded overdraft
+				destination = @bank
+			)`,
+			expectWriteLocked: []string{"users:1:wallet"},
+		},
+		{
+			name: "debit under the minimum balance",
+			script: `send [USD 111] (
+				source = @users:1:wallet allowing unbounded overdraft
+				destination = @bank
+			)`,
+			expectError: true,
+		},
+		{
+			name: "debit of an asset without minimum balance",
+			script: `send [EUR 1] (
+				source = @users:1:wallet allowing unbounded overdraft
+				destination = @bank
+			)`,
+			expectError: true,
+		},
+		{
+			name: "asset not allowed",
+			script: `send [GBP 1] (
+				source = @world
+				destination = @users:1:wallet
+			)`,
+			expectError: true,
+		},
+		{
+			name: "more specific policy",
+			script: `send [GBP 1000] (
+				source = @users:vip:wallet allowing unbounded overdraft
+				destination = @bank
+			)`,
+		},
+		{
+			name: "frozen account",
+			script: `send [USD 1] (
+				source = @world
+				destination = @blocked:1
+			)`,
+			expectError: true,
+		},
+		{
+			name: "account without policy",
+			script: `send [USD 1000] (
+				source = @bank allowing unbounded overdraft
+				destination = @users:2
+			)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			program, err := compiler.Compile(tc.script)
+			require.NoError(t, err)
+
+			m := NewMachine(*program)
+			m.Printer = func(c chan machine.Value) {
+				for range c {
+				}
+			}
+			m.SetAccountPolicies(policies)
+
+			ctx := context.Background()
+			_, writeLocked, err := m.ResolveResources(ctx, store)
+			require.NoError(t, err)
+			for _, account := range tc.expectWriteLocked {
+				require.Contains(t, writeLocked, account)
+			}
+			require.NoError(t, m.ResolveBalances(ctx, store))
+			require.NoError(t, m.Execute())
+
+			err = m.CheckAccountPolicies(ctx, store)
+			if tc.expectError {
+				require.True(t, machine.IsAccountPolicyError(err))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -26,6 +26,21 @@ const (
 	OP_TX_META          //
 	OP_ACCOUNT_META     //
 	OP_SAVE
+	OP_BALANCE       // <account> <asset> => <monetary> // current balance, including postings computed so far
+	OP_EQ            // <a: any> <b: any> => <number: 1 if true, 0 otherwise>
+	OP_NEQ           // <a: any> <b: any> => <number: 1 if true, 0 otherwise>
+	OP_LT            // <a: number | monetary> <b: number | monetary> => <number: 1 if true, 0 otherwise>
+	OP_LTE           // <a: number | monetary> <b: number | monetary> => <number: 1 if true, 0 otherwise>
+	OP_GT            // <a: number | monetary> <b: number | monetary> => <number: 1 if true, 0 otherwise>
+	OP_GTE           // <a: number | monetary> <b: number | monetary> => <number: 1 if true, 0 otherwise>
+	OP_ASSERT        // <condition: number> <message: string> // fails with ErrAssertionFailed if condition is 0
+	OP_JUMP          // followed by a 2 bytes instruction address to jump to
+	OP_JUMP_IF_FALSE // <condition: number> // followed by a 2 bytes instruction address to jump to if condition is 0
+	OP_MUL           // <a: number | monetary> <b: number | portion> => <a * b> // followed by a 1 byte rounding mode
+	OP_DIV           // <a: number | monetary> <b: number> => <a / b> // followed by a 1 byte rounding mode, fails on division by zero
+	OP_MIN           // <a: number | monetary> <b: number | monetary> => <min(a, b)>
+	OP_MAX           // <a: number | monetary> <b: number | monetary> => <max(a, b)>
+	OP_CONVERT       // <monetary> <target: asset> <rate> => <monetary> // followed by a 1 byte rounding mode, records the rate in the transaction metadata
 )
 
 func OpcodeName(op byte) string {
@@ -80,6 +95,36 @@ func OpcodeName(op byte) string {
 		return "OP_ACCOUNT_META"
 	case OP_SAVE:
 		return "OP_SAVE"
+	case OP_BALANCE:
+		return "OP_BALANCE"
+	case OP_EQ:
+		return "OP_EQ"
+	case OP_NEQ:
+		return "OP_NEQ"
+	case OP_LT:
+		return "OP_LT"
+	case OP_LTE:
+		return "OP_LTE"
+	case OP_GT:
+		return "OP_GT"
+	case OP_GTE:
+		return "OP_GTE"
+	case OP_ASSERT:
+		return "OP_ASSERT"
+	case OP_JUMP:
+		return "OP_JUMP"
+	case OP_JUMP_IF_FALSE:
+		return "OP_JUMP_IF_FALSE"
+	case OP_MUL:
+		return "OP_MUL"
+	case OP_DIV:
+		return "OP_DIV"
+	case OP_MIN:
+		return "OP_MIN"
+	case OP_MAX:
+		return "OP_MAX"
+	case OP_CONVERT:
+		return "OP_CONVERT"
 	default:
 		return "Unknown opcode"
 	}
@@ -3,6 +3,7 @@ package program
 import (
 	"encoding/binary"
 	"fmt"
+	"maps"
 
 	"github.com/formancehq/ledger/internal/machine"
 
@@ -28,6 +29,15 @@ func (p Program) String() string {
 			address := binary.LittleEndian.Uint16(p.Instructions[i+1 : i+3])
 			out += fmt.Sprintf("#%d\n", address)
 			i += 2
+		case OP_JUMP, OP_JUMP_IF_FALSE:
+			out += OpcodeName(p.Instructions[i]) + " "
+			target := binary.LittleEndian.Uint16(p.Instructions[i+1 : i+3])
+			out += fmt.Sprintf("%02d\n", target)
+			i += 2
+		case OP_MUL, OP_DIV, OP_CONVERT:
+			out += OpcodeName(p.Instructions[i]) + " "
+			out += fmt.Sprintf("%v\n", machine.Rounding(p.Instructions[i+1]))
+			i += 1
 		default:
 			out += OpcodeName(p.Instructions[i]) + "\n"
 		}
@@ -69,6 +79,11 @@ func (p *Program) ParseVariables(vars map[string]machine.Value) (map[string]mach
 						return nil, errors.Wrapf(err, "invalid variable $%s value '%s'",
 							variable.Name, val.(machine.Portion).String())
 					}
+				case machine.TypeRate:
+					if err := machine.ValidateRate(val.(machine.Rate)); err != nil {
+						return nil, errors.Wrapf(err, "invalid variable $%s value '%s'",
+							variable.Name, val.(machine.Rate).String())
+					}
 				case machine.TypeString:
 				case machine.TypeNumber:
 				default:
@@ -91,6 +106,8 @@ func (p *Program) ParseVariables(vars map[string]machine.Value) (map[string]mach
 }
 
 func (p *Program) ParseVariablesJSON(vars map[string]string) (map[string]machine.Value, error) {
+	// The variables of the script are left untouched, so it can be executed again
+	vars = maps.Clone(vars)
 	variables := make(map[string]machine.Value)
 	for _, res := range p.Resources {
 		if param, ok := res.(Variable); ok {
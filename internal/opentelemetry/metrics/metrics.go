@@ -9,6 +9,7 @@ type GlobalRegistry interface {
 	APILatencies() metric.Int64Histogram
 	StatusCodes() metric.Int64Counter
 	ActiveLedgers() metric.Int64UpDownCounter
+	OutboxLag() metric.Int64Histogram
 }
 
 type globalRegistry struct {
@@ -16,6 +17,7 @@ type globalRegistry struct {
 	apiLatencies  metric.Int64Histogram
 	statusCodes   metric.Int64Counter
 	activeLedgers metric.Int64UpDownCounter
+	outboxLag     metric.Int64Histogram
 }
 
 func RegisterGlobalRegistry(meterProvider metric.MeterProvider) (GlobalRegistry, error) {
@@ -48,10 +50,20 @@ func RegisterGlobalRegistry(meterProvider metric.MeterProvider) (GlobalRegistry,
 		return nil, err
 	}
 
+	outboxLag, err := meter.Int64Histogram(
+		"ledger.outbox.lag",
+		metric.WithUnit("ms"),
+		metric.WithDescription("Delay between the commit of a log and the publication of its event"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &globalRegistry{
 		apiLatencies:  apiLatencies,
 		statusCodes:   statusCodes,
 		activeLedgers: activeLedgers,
+		outboxLag:     outboxLag,
 	}, nil
 }
 
@@ -67,6 +79,10 @@ func (gm *globalRegistry) ActiveLedgers() metric.Int64UpDownCounter {
 	return gm.activeLedgers
 }
 
+func (gm *globalRegistry) OutboxLag() metric.Int64Histogram {
+	return gm.outboxLag
+}
+
 type noOpRegistry struct{}
 
 func NewNoOpRegistry() *noOpRegistry {
@@ -87,3 +103,8 @@ func (nm *noOpRegistry) ActiveLedgers() metric.Int64UpDownCounter {
 	counter, _ := noop.NewMeterProvider().Meter("ledger").Int64UpDownCounter("active_ledgers")
 	return counter
 }
+
+func (nm *noOpRegistry) OutboxLag() metric.Int64Histogram {
+	histogram, _ := noop.NewMeterProvider().Meter("ledger").Int64Histogram("outbox_lag")
+	return histogram
+}
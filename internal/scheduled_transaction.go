@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"math/big"
+
+	"github.com/formancehq/go-libs/time"
+	"github.com/google/uuid"
+)
+
+const (
+	ScheduledTransactionStatePending   = "PENDING"
+	ScheduledTransactionStateSucceeded = "SUCCEEDED"
+	ScheduledTransactionStateFailed    = "FAILED"
+)
+
+type ScheduledTransaction struct {
+	ID            string     `json:"id"`
+	Script        RunScript  `json:"script"`
+	ExecuteAt     time.Time  `json:"executeAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	State         string     `json:"state"`
+	ExecutedAt    *time.Time `json:"executedAt,omitempty"`
+	TransactionID *big.Int   `json:"transactionId,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// IdempotencyKey is the key used to create the transaction, so that a
+// scheduled transaction is never executed twice.
+func (s ScheduledTransaction) IdempotencyKey() string {
+	return "scheduled-transaction:" + s.ID
+}
+
+func (s ScheduledTransaction) WithSuccess(at time.Time, txID *big.Int) ScheduledTransaction {
+	s.State = ScheduledTransactionStateSucceeded
+	s.ExecutedAt = &at
+	s.TransactionID = txID
+	return s
+}
+
+func (s ScheduledTransaction) WithFailure(at time.Time, err error) ScheduledTransaction {
+	s.State = ScheduledTransactionStateFailed
+	s.ExecutedAt = &at
+	s.Error = err.Error()
+	return s
+}
+
+func NewScheduledTransaction(script RunScript, executeAt time.Time) ScheduledTransaction {
+	return ScheduledTransaction{
+		ID:        uuid.NewString(),
+		Script:    script,
+		ExecuteAt: executeAt,
+		CreatedAt: time.Now(),
+		State:     ScheduledTransactionStatePending,
+	}
+}
@@ -0,0 +1,37 @@
+package ledger
+
+import (
+	"math/big"
+
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+)
+
+const SnapshotVersion = 1
+
+// SnapshotHeader identifies the state of a ledger captured by a snapshot.
+// A ledger restored from the snapshot continues the hash chain from the last log.
+type SnapshotHeader struct {
+	Version           int       `json:"version"`
+	Ledger            string    `json:"ledger"`
+	Date              time.Time `json:"date"`
+	LastLogID         *big.Int  `json:"lastLogID"`
+	LastLogHash       []byte    `json:"lastLogHash"`
+	LastTransactionID *big.Int  `json:"lastTransactionID,omitempty"`
+}
+
+// LastLog returns the log the chain of a ledger restored from the snapshot continues from.
+// Only its id and hash are known.
+func (h SnapshotHeader) LastLog() *ChainedLog {
+	return &ChainedLog{
+		ID:   h.LastLogID,
+		Hash: h.LastLogHash,
+	}
+}
+
+type SnapshotAccount struct {
+	Address    string            `json:"address"`
+	Metadata   metadata.Metadata `json:"metadata"`
+	FirstUsage time.Time         `json:"firstUsage"`
+	Volumes    VolumesByAssets   `json:"volumes"`
+}
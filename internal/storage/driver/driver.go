@@ -151,6 +151,40 @@ func (f *Driver) CreateLedgerStore(ctx context.Context, name string, configurati
 	return store, errors.Wrap(tx.Commit(), "committing sql transaction")
 }
 
+// DeleteLedger archives the ledger, which keeps its rows in its bucket.
+// When purge is true, the rows are deleted instead and the ledger is unregistered,
+// so its name can be reused.
+func (d *Driver) DeleteLedger(ctx context.Context, name string, purge bool) error {
+	ledger, err := d.systemStore.GetLedger(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if !purge {
+		return errors.Wrap(
+			d.systemStore.UpdateLedgerState(ctx, name, systemstore.StateArchived),
+			"archiving ledger",
+		)
+	}
+
+	store, err := d.GetLedgerStore(ctx, name, LedgerState{
+		LedgerConfiguration: LedgerConfiguration{
+			Bucket:   ledger.Bucket,
+			Metadata: ledger.Metadata,
+		},
+		State: ledger.State,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := store.Purge(ctx); err != nil {
+		return errors.Wrap(err, "purging ledger")
+	}
+
+	return d.systemStore.DeleteLedger(ctx, name)
+}
+
 func (d *Driver) Initialize(ctx context.Context) error {
 	logging.FromContext(ctx).Debugf("Initialize driver")
 
@@ -9,6 +9,7 @@ import (
 	"github.com/formancehq/ledger/internal/storage/driver"
 
 	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
 
 	"github.com/formancehq/go-libs/logging"
 	"github.com/google/uuid"
@@ -103,3 +104,35 @@ func TestUpgradeAllBuckets(t *testing.T) {
 
 	require.NoError(t, d.UpgradeAllBuckets(ctx))
 }
+
+func TestDeleteLedger(t *testing.T) {
+	t.Parallel()
+
+	d := storagetesting.StorageDriver(t)
+	ctx := logging.TestingContext()
+
+	archived := uuid.NewString()
+	_, err := d.CreateLedgerStore(ctx, archived, driver.LedgerConfiguration{})
+	require.NoError(t, err)
+
+	require.NoError(t, d.DeleteLedger(ctx, archived, false))
+
+	ledger, err := d.GetSystemStore().GetLedger(ctx, archived)
+	require.NoError(t, err)
+	require.Equal(t, systemstore.StateArchived, ledger.State)
+
+	purged := uuid.NewString()
+	_, err = d.CreateLedgerStore(ctx, purged, driver.LedgerConfiguration{})
+	require.NoError(t, err)
+
+	require.NoError(t, d.DeleteLedger(ctx, purged, true))
+
+	_, err = d.GetSystemStore().GetLedger(ctx, purged)
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	// The name of a purged ledger can be reused
+	_, err = d.CreateLedgerStore(ctx, purged, driver.LedgerConfiguration{})
+	require.NoError(t, err)
+
+	require.True(t, sqlutils.IsNotFoundError(d.DeleteLedger(ctx, uuid.NewString(), false)))
+}
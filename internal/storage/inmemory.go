@@ -107,6 +107,9 @@ func (m *InMemoryStore) InsertLogs(ctx context.Context, logs ...*ledger.ChainedL
 				PreCommitVolumes:  nil,
 				PostCommitVolumes: nil,
 			})
+			for address, accountMetadata := range payload.AccountMetadata {
+				m.saveAccountMetadata(address, accountMetadata)
+			}
 		case ledger.RevertedTransactionLogPayload:
 			tx := collectionutils.Filter(m.transactions, func(transaction *ledger.ExpandedTransaction) bool {
 				return transaction.ID.Cmp(payload.RevertedTransactionID) == 0
@@ -119,12 +122,28 @@ func (m *InMemoryStore) InsertLogs(ctx context.Context, logs ...*ledger.ChainedL
 				PostCommitVolumes: nil,
 			})
 		case ledger.SetMetadataLogPayload:
+			if payload.TargetType == ledger.MetaTargetTypeAccount {
+				m.saveAccountMetadata(payload.TargetID.(string), payload.Metadata)
+			}
 		}
 	}
 
 	return nil
 }
 
+func (m *InMemoryStore) saveAccountMetadata(address string, accountMetadata metadata.Metadata) {
+	for _, account := range m.accounts {
+		if account.Address == address {
+			account.Metadata = account.Metadata.Merge(accountMetadata)
+			return
+		}
+	}
+	m.accounts = append(m.accounts, &ledger.Account{
+		Address:  address,
+		Metadata: metadata.Metadata{}.Merge(accountMetadata),
+	})
+}
+
 func (m *InMemoryStore) GetLastTransaction(ctx context.Context) (*ledger.ExpandedTransaction, error) {
 	if len(m.transactions) == 0 {
 		return nil, sqlutils.ErrNotFound
@@ -132,6 +151,10 @@ func (m *InMemoryStore) GetLastTransaction(ctx context.Context) (*ledger.Expande
 	return m.transactions[len(m.transactions)-1], nil
 }
 
+func (m *InMemoryStore) GetRestoredSnapshot(ctx context.Context) (*ledger.SnapshotHeader, error) {
+	return nil, sqlutils.ErrNotFound
+}
+
 func NewInMemoryStore() *InMemoryStore {
 	return &InMemoryStore{
 		logs: []*ledger.ChainedLog{},
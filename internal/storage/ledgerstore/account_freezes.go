@@ -0,0 +1,218 @@
+package ledgerstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/uptrace/bun"
+)
+
+type AccountFreeze struct {
+	bun.BaseModel `bun:"account_freezes,alias:account_freezes"`
+
+	Seq       int64     `bun:"seq,pk,autoincrement"`
+	Ledger    string    `bun:"ledger,type:varchar"`
+	Address   string    `bun:"address,type:varchar"`
+	Direction string    `bun:"direction,type:varchar"`
+	Reason    string    `bun:"reason,type:varchar,nullzero"`
+	FrozenAt  time.Time `bun:"frozen_at,type:timestamp without time zone"`
+}
+
+func (f *AccountFreeze) toCore() ledger.AccountFreeze {
+	return ledger.AccountFreeze{
+		Address:   f.Address,
+		Direction: f.Direction,
+		Reason:    f.Reason,
+		FrozenAt:  f.FrozenAt,
+	}
+}
+
+type AccountFreezeEvent struct {
+	bun.BaseModel `bun:"account_freeze_events,alias:account_freeze_events"`
+
+	Seq       int64     `bun:"seq,pk,autoincrement"`
+	Ledger    string    `bun:"ledger,type:varchar"`
+	ID        string    `bun:"id,type:varchar"`
+	Type      string    `bun:"type,type:varchar"`
+	Address   string    `bun:"address,type:varchar"`
+	Direction string    `bun:"direction,type:varchar,nullzero"`
+	Reason    string    `bun:"reason,type:varchar,nullzero"`
+	Date      time.Time `bun:"date,type:timestamp without time zone"`
+}
+
+func (e *AccountFreezeEvent) toCore() ledger.AccountFreezeEvent {
+	return ledger.AccountFreezeEvent{
+		ID:        e.ID,
+		Type:      e.Type,
+		Address:   e.Address,
+		Direction: e.Direction,
+		Reason:    e.Reason,
+		Date:      e.Date,
+	}
+}
+
+func (store *Store) newAccountFreezeEvent(from ledger.AccountFreezeEvent) *AccountFreezeEvent {
+	return &AccountFreezeEvent{
+		Ledger:    store.name,
+		ID:        from.ID,
+		Type:      from.Type,
+		Address:   from.Address,
+		Direction: from.Direction,
+		Reason:    from.Reason,
+		Date:      from.Date,
+	}
+}
+
+// FreezeAccount creates the freeze of an account, or replaces its current freeze, and records the event.
+func (store *Store) FreezeAccount(ctx context.Context, event ledger.AccountFreezeEvent) error {
+	freeze := event.Freeze()
+	return sqlutils.PostgresError(store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().
+			Model(&AccountFreeze{
+				Ledger:    store.name,
+				Address:   freeze.Address,
+				Direction: freeze.Direction,
+				Reason:    freeze.Reason,
+				FrozenAt:  freeze.FrozenAt,
+			}).
+			On("conflict (ledger, address) do update").
+			Set("direction = excluded.direction").
+			Set("reason = excluded.reason").
+			Set("frozen_at = excluded.frozen_at").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().
+			Model(store.newAccountFreezeEvent(event)).
+			Exec(ctx)
+		return err
+	}))
+}
+
+// UnfreezeAccount deletes the freeze of an account and records the event.
+func (store *Store) UnfreezeAccount(ctx context.Context, event ledger.AccountFreezeEvent) error {
+	return sqlutils.PostgresError(store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		ret, err := tx.NewDelete().
+			Model((*AccountFreeze)(nil)).
+			Where("ledger = ?", store.name).
+			Where("address = ?", event.Address).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		affected, err := ret.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sqlutils.ErrNotFound
+		}
+
+		_, err = tx.NewInsert().
+			Model(store.newAccountFreezeEvent(event)).
+			Exec(ctx)
+		return err
+	}))
+}
+
+func (store *Store) GetAccountFreeze(ctx context.Context, address string) (*ledger.AccountFreeze, error) {
+	ret, err := fetch[*AccountFreeze](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("ledger = ?", store.name).
+				Where("address = ?", address).
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	freeze := ret.toCore()
+	return &freeze, nil
+}
+
+func (store *Store) GetAccountFreezes(ctx context.Context, q GetAccountFreezesQuery) (*bunpaginate.Cursor[ledger.AccountFreeze], error) {
+	freezes, err := paginateWithColumn[PaginatedQueryOptions[any], AccountFreeze](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.Where("ledger = ?", store.name)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(freezes, func(from AccountFreeze) ledger.AccountFreeze {
+		return from.toCore()
+	}), nil
+}
+
+// GetAllAccountFreezes returns all the freezes of the ledger, to be enforced by the commander.
+func (store *Store) GetAllAccountFreezes(ctx context.Context) (ledger.AccountFreezes, error) {
+	rows := make([]AccountFreeze, 0)
+	err := store.bucket.db.NewSelect().
+		Model(&rows).
+		Where("ledger = ?", store.name).
+		Scan(ctx)
+	if err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	ret := make(ledger.AccountFreezes, len(rows))
+	for _, row := range rows {
+		ret[row.Address] = row.toCore()
+	}
+	return ret, nil
+}
+
+func (store *Store) GetAccountFreezeEvents(ctx context.Context, q GetAccountFreezeEventsQuery) (*bunpaginate.Cursor[ledger.AccountFreezeEvent], error) {
+	events, err := paginateWithColumn[PaginatedQueryOptions[AccountFreezeEventsFilter], AccountFreezeEvent](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[AccountFreezeEventsFilter]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			query = query.Where("ledger = ?", store.name)
+			if q.Options.Options.Address != "" {
+				query = query.Where("address = ?", q.Options.Options.Address)
+			}
+			return query
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(events, func(from AccountFreezeEvent) ledger.AccountFreezeEvent {
+		return from.toCore()
+	}), nil
+}
+
+type GetAccountFreezesQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]]
+
+func NewGetAccountFreezesQuery(options PaginatedQueryOptions[any]) GetAccountFreezesQuery {
+	return GetAccountFreezesQuery{
+		PageSize: options.PageSize,
+		Column:   "seq",
+		Order:    bunpaginate.OrderDesc,
+		Options:  options,
+	}
+}
+
+type AccountFreezeEventsFilter struct {
+	Address string `json:"address,omitempty"`
+}
+
+type GetAccountFreezeEventsQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[AccountFreezeEventsFilter]]
+
+func NewGetAccountFreezeEventsQuery(options PaginatedQueryOptions[AccountFreezeEventsFilter]) GetAccountFreezeEventsQuery {
+	return GetAccountFreezeEventsQuery{
+		PageSize: options.PageSize,
+		Column:   "seq",
+		Order:    bunpaginate.OrderDesc,
+		Options:  options,
+	}
+}
@@ -0,0 +1,57 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountFreezes(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	freezes, err := store.GetAllAccountFreezes(ctx)
+	require.NoError(t, err)
+	require.Empty(t, freezes)
+
+	require.NoError(t, store.FreezeAccount(ctx, ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionDebit, "fraud")))
+	require.NoError(t, store.FreezeAccount(ctx, ledger.NewAccountFreezeEvent("users:2", ledger.FreezeDirectionBoth, "")))
+	require.NoError(t, store.FreezeAccount(ctx, ledger.NewAccountFreezeEvent("users:1", ledger.FreezeDirectionCredit, "dispute")))
+
+	freeze, err := store.GetAccountFreeze(ctx, "users:1")
+	require.NoError(t, err)
+	require.Equal(t, ledger.FreezeDirectionCredit, freeze.Direction)
+	require.Equal(t, "dispute", freeze.Reason)
+
+	freezes, err = store.GetAllAccountFreezes(ctx)
+	require.NoError(t, err)
+	require.Len(t, freezes, 2)
+
+	cursor, err := store.GetAccountFreezes(ctx, NewGetAccountFreezesQuery(NewPaginatedQueryOptions[any](nil)))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 2)
+
+	require.NoError(t, store.UnfreezeAccount(ctx, ledger.NewAccountUnfreezeEvent("users:2", "")))
+	require.True(t, sqlutils.IsNotFoundError(store.UnfreezeAccount(ctx, ledger.NewAccountUnfreezeEvent("users:2", ""))))
+
+	_, err = store.GetAccountFreeze(ctx, "users:2")
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	events, err := store.GetAccountFreezeEvents(ctx, NewGetAccountFreezeEventsQuery(NewPaginatedQueryOptions(AccountFreezeEventsFilter{
+		Address: "users:1",
+	})))
+	require.NoError(t, err)
+	require.Len(t, events.Data, 2)
+	require.Equal(t, ledger.FreezeDirectionCredit, events.Data[0].Direction)
+
+	events, err = store.GetAccountFreezeEvents(ctx, NewGetAccountFreezeEventsQuery(NewPaginatedQueryOptions(AccountFreezeEventsFilter{})))
+	require.NoError(t, err)
+	require.Len(t, events.Data, 4)
+	require.Equal(t, ledger.AccountFreezeEventTypeUnfreeze, events.Data[0].Type)
+}
@@ -0,0 +1,176 @@
+package ledgerstore
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/uptrace/bun"
+)
+
+type AccountHistoryEntry struct {
+	bun.BaseModel `bun:"history,alias:history"`
+
+	ID               *bunpaginate.BigInt `bun:"id,type:numeric"`
+	Type             string              `bun:"type"`
+	Date             time.Time           `bun:"date,type:timestamp without time zone"`
+	TransactionID    *bunpaginate.BigInt `bun:"transaction_id,type:numeric"`
+	Asset            string              `bun:"asset"`
+	Amount           *bunpaginate.BigInt `bun:"amount,type:numeric"`
+	IsSource         bool                `bun:"is_source"`
+	EffectiveDate    time.Time           `bun:"effective_date,type:timestamp without time zone"`
+	PostCommitInput  *bunpaginate.BigInt `bun:"post_commit_input,type:numeric"`
+	PostCommitOutput *bunpaginate.BigInt `bun:"post_commit_output,type:numeric"`
+	Reverts          *bunpaginate.BigInt `bun:"reverts,type:numeric"`
+	Reverted         bool                `bun:"reverted"`
+	Metadata         metadata.Metadata   `bun:"metadata,type:jsonb"`
+	Revision         *bunpaginate.BigInt `bun:"revision,type:numeric"`
+}
+
+func (e AccountHistoryEntry) toCore() ledger.AccountHistoryEntry {
+	ret := ledger.AccountHistoryEntry{
+		ID:   (*big.Int)(e.ID),
+		Type: e.Type,
+		Date: e.Date,
+	}
+
+	switch e.Type {
+	case ledger.AccountHistoryEntryTypeMove:
+		amount := (*big.Int)(e.Amount)
+		postCommitVolumes := ledger.Volumes{
+			Input:  (*big.Int)(e.PostCommitInput),
+			Output: (*big.Int)(e.PostCommitOutput),
+		}
+		preCommitVolumes := postCommitVolumes.CopyWithZerosIfNeeded()
+		if e.IsSource {
+			preCommitVolumes.Output = new(big.Int).Sub(preCommitVolumes.Output, amount)
+		} else {
+			preCommitVolumes.Input = new(big.Int).Sub(preCommitVolumes.Input, amount)
+		}
+
+		ret.Move = &ledger.AccountMove{
+			TransactionID:     (*big.Int)(e.TransactionID),
+			Asset:             e.Asset,
+			Amount:            amount,
+			IsSource:          e.IsSource,
+			EffectiveDate:     e.EffectiveDate,
+			PreCommitVolumes:  *preCommitVolumes,
+			PostCommitVolumes: postCommitVolumes,
+			Reverts:           (*big.Int)(e.Reverts),
+			Reverted:          e.Reverted,
+		}
+	case ledger.AccountHistoryEntryTypeMetadata:
+		ret.Metadata = &ledger.AccountMetadataRevision{
+			Revision: (*big.Int)(e.Revision),
+			Metadata: e.Metadata,
+		}
+	}
+
+	return ret
+}
+
+func (store *Store) buildAccountHistoryQuery(filters AccountHistoryFilters, query *bun.SelectQuery) *bun.SelectQuery {
+	moves := store.GetDB().NewSelect().
+		TableExpr("moves").
+		ColumnExpr("? as type", ledger.AccountHistoryEntryTypeMove).
+		ColumnExpr("moves.insertion_date as date").
+		ColumnExpr("moves.history_seq as id").
+		ColumnExpr("transactions.id as transaction_id").
+		ColumnExpr("moves.asset").
+		ColumnExpr("moves.amount").
+		ColumnExpr("moves.is_source").
+		ColumnExpr("moves.effective_date").
+		ColumnExpr("(moves.post_commit_volumes).inputs as post_commit_input").
+		ColumnExpr("(moves.post_commit_volumes).outputs as post_commit_output").
+		ColumnExpr("(transactions.metadata ->> ?)::numeric as reverts", ledger.RevertMetadataSpecKey()).
+		ColumnExpr("transactions.reverted_at is not null as reverted").
+		ColumnExpr("null::jsonb as metadata").
+		ColumnExpr("null::numeric as revision").
+		// The opening moves of a ledger restored from a snapshot belong to no transaction
+		Join("left join transactions on transactions.seq = moves.transactions_seq").
+		Where("moves.ledger = ?", store.name).
+		Where("moves.account_address = ?", filters.Address).
+		Apply(filterOOT(filters.StartTime, "moves.insertion_date")).
+		Apply(filterPIT(filters.EndTime, "moves.insertion_date"))
+	if filters.Asset != "" {
+		moves = moves.Where("moves.asset = ?", filters.Asset)
+	}
+
+	// The accounts are updated without changing their metadata, only the revisions changing them are kept
+	revisions := store.GetDB().NewSelect().
+		TableExpr("accounts_metadata").
+		Column("history_seq", "date", "metadata", "revision").
+		ColumnExpr("lag(metadata) over (order by revision) as previous_metadata").
+		Where("accounts_metadata.ledger = ?", store.name).
+		Where("accounts_metadata.accounts_seq = (select seq from accounts where ledger = ? and address = ?)", store.name, filters.Address)
+
+	metadataRevisions := store.GetDB().NewSelect().
+		TableExpr("(?) revisions", revisions).
+		ColumnExpr("? as type", ledger.AccountHistoryEntryTypeMetadata).
+		ColumnExpr("revisions.date").
+		ColumnExpr("revisions.history_seq as id").
+		ColumnExpr("null::numeric as transaction_id").
+		ColumnExpr("null::varchar as asset").
+		ColumnExpr("null::numeric as amount").
+		ColumnExpr("null::boolean as is_source").
+		ColumnExpr("null::timestamp as effective_date").
+		ColumnExpr("null::numeric as post_commit_input").
+		ColumnExpr("null::numeric as post_commit_output").
+		ColumnExpr("null::numeric as reverts").
+		ColumnExpr("false as reverted").
+		ColumnExpr("revisions.metadata").
+		ColumnExpr("revisions.revision").
+		Where("revisions.date is not null").
+		Where("revisions.metadata != coalesce(revisions.previous_metadata, '{}'::jsonb)").
+		Apply(filterOOT(filters.StartTime, "revisions.date")).
+		Apply(filterPIT(filters.EndTime, "revisions.date"))
+
+	return query.
+		With("history", moves.UnionAll(metadataRevisions)).
+		ModelTableExpr("history")
+}
+
+// GetAccountHistory returns the moves and the metadata changes of an account, ordered as they have been committed.
+// The asset filter only applies to the moves, the metadata changes being shared by all the assets.
+func (store *Store) GetAccountHistory(ctx context.Context, q GetAccountHistoryQuery) (*bunpaginate.Cursor[ledger.AccountHistoryEntry], error) {
+	entries, err := paginateWithColumn[PaginatedQueryOptions[AccountHistoryFilters], AccountHistoryEntry](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[AccountHistoryFilters]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return store.buildAccountHistoryQuery(q.Options.Options, query)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(entries, func(from AccountHistoryEntry) ledger.AccountHistoryEntry {
+		return from.toCore()
+	}), nil
+}
+
+type AccountHistoryFilters struct {
+	Address string `json:"address"`
+	Asset   string `json:"asset,omitempty"`
+	// StartTime and EndTime are inclusive
+	StartTime *time.Time `json:"startTime,omitempty"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+type GetAccountHistoryQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[AccountHistoryFilters]]
+
+func (q GetAccountHistoryQuery) WithOrder(order bunpaginate.Order) GetAccountHistoryQuery {
+	q.Order = order
+	return q
+}
+
+func NewGetAccountHistoryQuery(options PaginatedQueryOptions[AccountHistoryFilters]) GetAccountHistoryQuery {
+	return GetAccountHistoryQuery{
+		PageSize: options.PageSize,
+		Column:   "id",
+		Order:    bunpaginate.OrderDesc,
+		Options:  options,
+	}
+}
@@ -0,0 +1,111 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/pointer"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountHistory(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	tx0 := ledger.NewTransaction().WithPostings(
+		ledger.NewPosting("world", "bank", "USD", big.NewInt(100)),
+	).WithDate(now)
+	tx1 := ledger.NewTransaction().WithPostings(
+		ledger.NewPosting("world", "bank", "EUR", big.NewInt(50)),
+	).WithIDUint64(1).WithDate(now.Add(time.Minute))
+	revert := ledger.NewTransaction().WithPostings(
+		ledger.NewPosting("bank", "world", "USD", big.NewInt(100)),
+	).WithIDUint64(2).WithMetadata(ledger.RevertMetadata(big.NewInt(0))).WithDate(now.Add(3 * time.Minute))
+
+	require.NoError(t, store.InsertLogs(ctx,
+		ledger.ChainLogs(
+			ledger.NewTransactionLogWithDate(tx0, map[string]metadata.Metadata{}, now),
+			ledger.NewTransactionLogWithDate(tx1, map[string]metadata.Metadata{}, now.Add(time.Minute)),
+			ledger.NewSetMetadataOnAccountLog(now.Add(2*time.Minute), "bank", metadata.Metadata{"category": "gold"}),
+			ledger.NewRevertedTransactionLog(now.Add(3*time.Minute), big.NewInt(0), revert),
+		)...,
+	))
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		cursor, err := store.GetAccountHistory(ctx, NewGetAccountHistoryQuery(NewPaginatedQueryOptions(AccountHistoryFilters{
+			Address: "bank",
+		})))
+		require.NoError(t, err)
+		require.Len(t, cursor.Data, 4)
+
+		require.Equal(t, ledger.AccountHistoryEntryTypeMove, cursor.Data[0].Type)
+		require.Equal(t, big.NewInt(2), cursor.Data[0].Move.TransactionID)
+		require.True(t, cursor.Data[0].Move.IsSource)
+		require.Equal(t, big.NewInt(0), cursor.Data[0].Move.Reverts)
+		require.Equal(t, ledger.Volumes{Input: big.NewInt(100), Output: big.NewInt(0)}, cursor.Data[0].Move.PreCommitVolumes)
+		require.Equal(t, ledger.Volumes{Input: big.NewInt(100), Output: big.NewInt(100)}, cursor.Data[0].Move.PostCommitVolumes)
+
+		require.Equal(t, ledger.AccountHistoryEntryTypeMetadata, cursor.Data[1].Type)
+		require.Equal(t, metadata.Metadata{"category": "gold"}, cursor.Data[1].Metadata.Metadata)
+
+		require.Equal(t, ledger.AccountHistoryEntryTypeMove, cursor.Data[2].Type)
+		require.Equal(t, "EUR", cursor.Data[2].Move.Asset)
+
+		require.Equal(t, ledger.AccountHistoryEntryTypeMove, cursor.Data[3].Type)
+		require.Equal(t, big.NewInt(0), cursor.Data[3].Move.TransactionID)
+		require.False(t, cursor.Data[3].Move.IsSource)
+		require.True(t, cursor.Data[3].Move.Reverted)
+		require.Equal(t, ledger.Volumes{Input: big.NewInt(0), Output: big.NewInt(0)}, cursor.Data[3].Move.PreCommitVolumes)
+		require.Equal(t, ledger.Volumes{Input: big.NewInt(100), Output: big.NewInt(0)}, cursor.Data[3].Move.PostCommitVolumes)
+	})
+	t.Run("filter on asset", func(t *testing.T) {
+		t.Parallel()
+		cursor, err := store.GetAccountHistory(ctx, NewGetAccountHistoryQuery(NewPaginatedQueryOptions(AccountHistoryFilters{
+			Address: "bank",
+			Asset:   "USD",
+		})))
+		require.NoError(t, err)
+		require.Len(t, cursor.Data, 3)
+		require.Equal(t, ledger.AccountHistoryEntryTypeMetadata, cursor.Data[1].Type)
+	})
+	t.Run("filter on dates", func(t *testing.T) {
+		t.Parallel()
+		cursor, err := store.GetAccountHistory(ctx, NewGetAccountHistoryQuery(NewPaginatedQueryOptions(AccountHistoryFilters{
+			Address:   "bank",
+			StartTime: pointer.For(now.Add(time.Minute)),
+			EndTime:   pointer.For(now.Add(2 * time.Minute)),
+		})))
+		require.NoError(t, err)
+		require.Len(t, cursor.Data, 2)
+		require.Equal(t, ledger.AccountHistoryEntryTypeMetadata, cursor.Data[0].Type)
+		require.Equal(t, ledger.AccountHistoryEntryTypeMove, cursor.Data[1].Type)
+	})
+	t.Run("paginate", func(t *testing.T) {
+		t.Parallel()
+		cursor, err := store.GetAccountHistory(ctx, NewGetAccountHistoryQuery(NewPaginatedQueryOptions(AccountHistoryFilters{
+			Address: "bank",
+		}).WithPageSize(3)).WithOrder(bunpaginate.OrderAsc))
+		require.NoError(t, err)
+		require.Len(t, cursor.Data, 3)
+		require.True(t, cursor.HasMore)
+		require.Equal(t, big.NewInt(0), cursor.Data[0].Move.TransactionID)
+
+		q := GetAccountHistoryQuery{}
+		require.NoError(t, bunpaginate.UnmarshalCursor(cursor.Next, &q))
+		cursor, err = store.GetAccountHistory(ctx, q)
+		require.NoError(t, err)
+		require.Len(t, cursor.Data, 1)
+		require.False(t, cursor.HasMore)
+		require.Equal(t, big.NewInt(2), cursor.Data[0].Move.TransactionID)
+	})
+}
@@ -0,0 +1,142 @@
+package ledgerstore
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/uptrace/bun"
+)
+
+type AccountPolicy struct {
+	bun.BaseModel `bun:"account_policies,alias:account_policies"`
+
+	Seq                     int64               `bun:"seq,pk,autoincrement"`
+	Ledger                  string              `bun:"ledger,type:varchar"`
+	Pattern                 string              `bun:"pattern,type:varchar"`
+	MinimumBalances         map[string]*big.Int `bun:"minimum_balances,type:jsonb"`
+	AllowUnboundedOverdraft bool                `bun:"allow_unbounded_overdraft"`
+	AllowedAssets           []string            `bun:"allowed_assets,type:jsonb"`
+	Frozen                  bool                `bun:"frozen"`
+	UpdatedAt               time.Time           `bun:"updated_at,type:timestamp without time zone"`
+}
+
+func (p *AccountPolicy) toCore() ledger.AccountPolicy {
+	return ledger.AccountPolicy{
+		Pattern:                 p.Pattern,
+		MinimumBalances:         p.MinimumBalances,
+		AllowUnboundedOverdraft: p.AllowUnboundedOverdraft,
+		AllowedAssets:           p.AllowedAssets,
+		Frozen:                  p.Frozen,
+		UpdatedAt:               p.UpdatedAt,
+	}
+}
+
+// SaveAccountPolicy creates the policy, or replaces the policy having the same pattern.
+func (store *Store) SaveAccountPolicy(ctx context.Context, policy ledger.AccountPolicy) error {
+	_, err := store.bucket.db.
+		NewInsert().
+		Model(&AccountPolicy{
+			Ledger:                  store.name,
+			Pattern:                 policy.Pattern,
+			MinimumBalances:         policy.MinimumBalances,
+			AllowUnboundedOverdraft: policy.AllowUnboundedOverdraft,
+			AllowedAssets:           policy.AllowedAssets,
+			Frozen:                  policy.Frozen,
+			UpdatedAt:               policy.UpdatedAt,
+		}).
+		On("conflict (ledger, pattern) do update").
+		Set("minimum_balances = excluded.minimum_balances").
+		Set("allow_unbounded_overdraft = excluded.allow_unbounded_overdraft").
+		Set("allowed_assets = excluded.allowed_assets").
+		Set("frozen = excluded.frozen").
+		Set("updated_at = excluded.updated_at").
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+func (store *Store) DeleteAccountPolicy(ctx context.Context, pattern string) error {
+	ret, err := store.bucket.db.
+		NewDelete().
+		Model((*AccountPolicy)(nil)).
+		Where("ledger = ?", store.name).
+		Where("pattern = ?", pattern).
+		Exec(ctx)
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+
+	affected, err := ret.RowsAffected()
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+	if affected == 0 {
+		return sqlutils.ErrNotFound
+	}
+	return nil
+}
+
+func (store *Store) GetAccountPolicy(ctx context.Context, pattern string) (*ledger.AccountPolicy, error) {
+	ret, err := fetch[*AccountPolicy](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("ledger = ?", store.name).
+				Where("pattern = ?", pattern).
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	policy := ret.toCore()
+	return &policy, nil
+}
+
+func (store *Store) GetAccountPolicies(ctx context.Context, q GetAccountPoliciesQuery) (*bunpaginate.Cursor[ledger.AccountPolicy], error) {
+	policies, err := paginateWithColumn[PaginatedQueryOptions[any], AccountPolicy](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.Where("ledger = ?", store.name)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(policies, func(from AccountPolicy) ledger.AccountPolicy {
+		return from.toCore()
+	}), nil
+}
+
+// GetAllAccountPolicies returns all the policies of the ledger, to be enforced by the commander.
+func (store *Store) GetAllAccountPolicies(ctx context.Context) (ledger.AccountPolicies, error) {
+	rows := make([]AccountPolicy, 0)
+	err := store.bucket.db.NewSelect().
+		Model(&rows).
+		Where("ledger = ?", store.name).
+		Order("seq").
+		Scan(ctx)
+	if err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	ret := make(ledger.AccountPolicies, 0, len(rows))
+	for _, row := range rows {
+		ret = append(ret, row.toCore())
+	}
+	return ret, nil
+}
+
+type GetAccountPoliciesQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]]
+
+func NewGetAccountPoliciesQuery(options PaginatedQueryOptions[any]) GetAccountPoliciesQuery {
+	return GetAccountPoliciesQuery{
+		PageSize: options.PageSize,
+		Column:   "seq",
+		Order:    bunpaginate.OrderAsc,
+		Options:  options,
+	}
+}
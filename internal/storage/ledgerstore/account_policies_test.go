@@ -0,0 +1,57 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPolicies(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	policies, err := store.GetAllAccountPolicies(ctx)
+	require.NoError(t, err)
+	require.Empty(t, policies)
+
+	users := ledger.NewAccountPolicy("users:*:wallet")
+	users.MinimumBalances = map[string]*big.Int{"USD": big.NewInt(-100)}
+	users.AllowedAssets = []string{"USD"}
+	blocked := ledger.NewAccountPolicy("blocked:*")
+	blocked.Frozen = true
+	for _, policy := range []ledger.AccountPolicy{users, blocked} {
+		require.NoError(t, store.SaveAccountPolicy(ctx, policy))
+	}
+
+	policy, err := store.GetAccountPolicy(ctx, users.Pattern)
+	require.NoError(t, err)
+	require.Equal(t, users.MinimumBalances, policy.MinimumBalances)
+	require.Equal(t, users.AllowedAssets, policy.AllowedAssets)
+
+	users.AllowUnboundedOverdraft = true
+	require.NoError(t, store.SaveAccountPolicy(ctx, users))
+
+	policies, err = store.GetAllAccountPolicies(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	require.Equal(t, users.Pattern, policies[0].Pattern)
+	require.True(t, policies[0].AllowUnboundedOverdraft)
+	require.True(t, policies[1].Frozen)
+
+	cursor, err := store.GetAccountPolicies(ctx, NewGetAccountPoliciesQuery(NewPaginatedQueryOptions[any](nil)))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 2)
+
+	require.NoError(t, store.DeleteAccountPolicy(ctx, blocked.Pattern))
+	require.True(t, sqlutils.IsNotFoundError(store.DeleteAccountPolicy(ctx, blocked.Pattern)))
+
+	_, err = store.GetAccountPolicy(ctx, blocked.Pattern)
+	require.True(t, sqlutils.IsNotFoundError(err))
+}
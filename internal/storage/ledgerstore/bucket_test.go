@@ -9,6 +9,7 @@ import (
 	"github.com/formancehq/go-libs/logging"
 	"github.com/formancehq/go-libs/metadata"
 	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -70,4 +71,18 @@ func TestBuckets(t *testing.T) {
 	count, err = ledger1Store.CountTransactions(ctx, NewGetTransactionsQuery(PaginatedQueryOptions[PITFilterWithVolumes]{}))
 	require.NoError(t, err)
 	require.Equal(t, count, 1)
+
+	// Purging a ledger does not affect the other ledgers of the bucket
+	require.NoError(t, ledger0Store.Purge(ctx))
+
+	count, err = ledger0Store.CountTransactions(ctx, NewGetTransactionsQuery(PaginatedQueryOptions[PITFilterWithVolumes]{}))
+	require.NoError(t, err)
+	require.Equal(t, count, 0)
+
+	_, err = ledger0Store.GetLastLog(ctx)
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	count, err = ledger1Store.CountTransactions(ctx, NewGetTransactionsQuery(PaginatedQueryOptions[PITFilterWithVolumes]{}))
+	require.NoError(t, err)
+	require.Equal(t, count, 1)
 }
@@ -0,0 +1,85 @@
+package ledgerstore
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/uptrace/bun"
+)
+
+type Chart struct {
+	bun.BaseModel `bun:"charts,alias:charts"`
+
+	Seq       int64                 `bun:"seq,pk,autoincrement"`
+	Ledger    string                `bun:"ledger,type:varchar"`
+	Mode      string                `bun:"mode,type:varchar"`
+	Accounts  []ledger.ChartAccount `bun:"accounts,type:jsonb"`
+	UpdatedAt time.Time             `bun:"updated_at,type:timestamp without time zone"`
+}
+
+func (c *Chart) toCore() ledger.Chart {
+	return ledger.Chart{
+		Mode:      c.Mode,
+		Accounts:  c.Accounts,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// SaveChart creates the chart of accounts of the ledger, or replaces it.
+func (store *Store) SaveChart(ctx context.Context, chart ledger.Chart) error {
+	accounts := chart.Accounts
+	if accounts == nil {
+		accounts = []ledger.ChartAccount{}
+	}
+	_, err := store.bucket.db.
+		NewInsert().
+		Model(&Chart{
+			Ledger:    store.name,
+			Mode:      chart.Mode,
+			Accounts:  accounts,
+			UpdatedAt: chart.UpdatedAt,
+		}).
+		On("conflict (ledger) do update").
+		Set("mode = excluded.mode").
+		Set("accounts = excluded.accounts").
+		Set("updated_at = excluded.updated_at").
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+func (store *Store) DeleteChart(ctx context.Context) error {
+	ret, err := store.bucket.db.
+		NewDelete().
+		Model((*Chart)(nil)).
+		Where("ledger = ?", store.name).
+		Exec(ctx)
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+
+	affected, err := ret.RowsAffected()
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+	if affected == 0 {
+		return sqlutils.ErrNotFound
+	}
+	return nil
+}
+
+func (store *Store) GetChart(ctx context.Context) (*ledger.Chart, error) {
+	ret, err := fetch[*Chart](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("ledger = ?", store.name).
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := ret.toCore()
+	return &chart, nil
+}
@@ -0,0 +1,45 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharts(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	_, err := store.GetChart(ctx)
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	chart := ledger.NewChart(ledger.ChartModeStrict, ledger.ChartAccount{
+		Template:        "users:{id}:wallet",
+		Type:            ledger.AccountTypeLiability,
+		AllowedAssets:   []string{"USD/2"},
+		DefaultMetadata: metadata.Metadata{"kind": "wallet"},
+	})
+	require.NoError(t, store.SaveChart(ctx, chart))
+
+	saved, err := store.GetChart(ctx)
+	require.NoError(t, err)
+	require.Equal(t, chart.Mode, saved.Mode)
+	require.Equal(t, chart.Accounts, saved.Accounts)
+
+	chart.Mode = ledger.ChartModeWarn
+	require.NoError(t, store.SaveChart(ctx, chart))
+
+	saved, err = store.GetChart(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ledger.ChartModeWarn, saved.Mode)
+
+	require.NoError(t, store.DeleteChart(ctx))
+	require.True(t, sqlutils.IsNotFoundError(store.DeleteChart(ctx)))
+}
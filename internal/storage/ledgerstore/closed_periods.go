@@ -0,0 +1,147 @@
+package ledgerstore
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/uptrace/bun"
+)
+
+type ClosedPeriod struct {
+	bun.BaseModel `bun:"closed_periods,alias:closed_periods"`
+
+	Seq         int64                             `bun:"seq,pk,autoincrement"`
+	Ledger      string                            `bun:"ledger,type:varchar"`
+	ID          string                            `bun:"id,type:varchar"`
+	ClosingDate time.Time                         `bun:"closing_date,type:timestamp without time zone"`
+	ClosedAt    time.Time                         `bun:"closed_at,type:timestamp without time zone"`
+	Balances    ledger.BalancesByAssetsByAccounts `bun:"balances,type:jsonb"`
+}
+
+func (p *ClosedPeriod) toCore() ledger.ClosedPeriod {
+	return ledger.ClosedPeriod{
+		ID:          p.ID,
+		ClosingDate: p.ClosingDate,
+		ClosedAt:    p.ClosedAt,
+		Balances:    p.Balances,
+	}
+}
+
+// closedPeriodColumns are the columns of the closed periods, without their balances which may be large
+var closedPeriodColumns = []string{"seq", "ledger", "id", "closing_date", "closed_at"}
+
+func (store *Store) InsertClosedPeriod(ctx context.Context, period ledger.ClosedPeriod) error {
+	_, err := store.bucket.db.
+		NewInsert().
+		Model(&ClosedPeriod{
+			Ledger:      store.name,
+			ID:          period.ID,
+			ClosingDate: period.ClosingDate,
+			ClosedAt:    period.ClosedAt,
+			Balances:    period.Balances,
+		}).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+// GetLastClosedPeriod returns the period having the latest closing date, without its balances.
+func (store *Store) GetLastClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	ret, err := fetch[*ClosedPeriod](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Column(closedPeriodColumns...).
+				Where("ledger = ?", store.name).
+				Order("closing_date desc").
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	period := ret.toCore()
+	return &period, nil
+}
+
+func (store *Store) GetClosedPeriod(ctx context.Context, id string) (*ledger.ClosedPeriod, error) {
+	ret, err := fetch[*ClosedPeriod](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("ledger = ?", store.name).
+				Where("id = ?", id).
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	period := ret.toCore()
+	return &period, nil
+}
+
+// GetClosedPeriods lists the closed periods, without their balances.
+func (store *Store) GetClosedPeriods(ctx context.Context, q GetClosedPeriodsQuery) (*bunpaginate.Cursor[ledger.ClosedPeriod], error) {
+	periods, err := paginateWithColumn[PaginatedQueryOptions[any], ClosedPeriod](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Column(closedPeriodColumns...).
+				Where("ledger = ?", store.name)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(periods, func(from ClosedPeriod) ledger.ClosedPeriod {
+		return from.toCore()
+	}), nil
+}
+
+// GetBalancesAt returns the balances of all the accounts, using the moves effective strictly before date.
+func (store *Store) GetBalancesAt(ctx context.Context, date time.Time) (ledger.BalancesByAssetsByAccounts, error) {
+	type row struct {
+		Account string              `bun:"account_address"`
+		Asset   string              `bun:"asset"`
+		Balance *bunpaginate.BigInt `bun:"balance"`
+	}
+	rows := make([]row, 0)
+	err := store.bucket.db.NewSelect().
+		TableExpr("moves").
+		DistinctOn("account_address, asset").
+		ColumnExpr("account_address").
+		ColumnExpr("asset").
+		ColumnExpr("balance_from_volumes(post_commit_effective_volumes) as balance").
+		Where("ledger = ?", store.name).
+		Where("effective_date < ?", date).
+		Order("account_address", "asset").
+		OrderExpr("effective_date desc, seq desc").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	ret := ledger.BalancesByAssetsByAccounts{}
+	for _, row := range rows {
+		if _, ok := ret[row.Account]; !ok {
+			ret[row.Account] = ledger.BalancesByAssets{}
+		}
+		ret[row.Account][row.Asset] = (*big.Int)(row.Balance)
+	}
+
+	return ret, nil
+}
+
+type GetClosedPeriodsQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]]
+
+func NewGetClosedPeriodsQuery(options PaginatedQueryOptions[any]) GetClosedPeriodsQuery {
+	return GetClosedPeriodsQuery{
+		PageSize: options.PageSize,
+		Column:   "seq",
+		Order:    bunpaginate.OrderDesc,
+		Options:  options,
+	}
+}
@@ -0,0 +1,77 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClosedPeriods(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	_, err := store.GetLastClosedPeriod(ctx)
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	require.NoError(t, store.InsertLogs(ctx, ledger.ChainLogs(
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))).
+				WithDate(now.Add(-2*time.Hour)),
+			map[string]metadata.Metadata{},
+		),
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("bank", "users:1", "USD", big.NewInt(10))).
+				WithIDUint64(1).
+				WithDate(now),
+			map[string]metadata.Metadata{},
+		),
+	)...))
+
+	balances, err := store.GetBalancesAt(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, ledger.BalancesByAssetsByAccounts{
+		"world": {
+			"USD": big.NewInt(-100),
+		},
+		"bank": {
+			"USD": big.NewInt(100),
+		},
+	}, balances)
+
+	period1 := ledger.NewClosedPeriod(now.Add(-time.Hour)).WithBalances(balances)
+	period2 := ledger.NewClosedPeriod(now)
+	for _, period := range []ledger.ClosedPeriod{period1, period2} {
+		require.NoError(t, store.InsertClosedPeriod(ctx, period))
+	}
+
+	last, err := store.GetLastClosedPeriod(ctx)
+	require.NoError(t, err)
+	require.Equal(t, period2.ID, last.ID)
+	require.Equal(t, period2.ClosingDate, last.ClosingDate)
+
+	_, err = store.GetClosedPeriod(ctx, "unknown")
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	period, err := store.GetClosedPeriod(ctx, period1.ID)
+	require.NoError(t, err)
+	require.Equal(t, balances, period.Balances)
+
+	cursor, err := store.GetClosedPeriods(ctx, NewGetClosedPeriodsQuery(NewPaginatedQueryOptions[any](nil)))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 2)
+	require.Equal(t, period2.ID, cursor.Data[0].ID)
+	require.Equal(t, period1.ID, cursor.Data[1].ID)
+	require.Nil(t, cursor.Data[1].Balances)
+}
@@ -0,0 +1,122 @@
+package ledgerstore
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/uptrace/bun"
+)
+
+type ImportJob struct {
+	bun.BaseModel `bun:"import_jobs,alias:import_jobs"`
+
+	Seq          int64               `bun:"seq,pk,autoincrement"`
+	Ledger       string              `bun:"ledger,type:varchar"`
+	ID           string              `bun:"id,type:varchar"`
+	State        string              `bun:"state,type:varchar"`
+	CreatedAt    time.Time           `bun:"created_at,type:timestamp without time zone"`
+	UpdatedAt    time.Time           `bun:"updated_at,type:timestamp without time zone"`
+	TerminatedAt *time.Time          `bun:"terminated_at,type:timestamp without time zone"`
+	ImportedLogs int                 `bun:"imported_logs,type:bigint"`
+	SkippedLogs  int                 `bun:"skipped_logs,type:bigint"`
+	LastLogID    *bunpaginate.BigInt `bun:"last_log_id,type:numeric"`
+	ErrorCode    string              `bun:"error_code,type:varchar,nullzero"`
+	Error        string              `bun:"error,type:varchar,nullzero"`
+}
+
+func (j *ImportJob) toCore() ledger.ImportJob {
+	return ledger.ImportJob{
+		ID:           j.ID,
+		State:        j.State,
+		CreatedAt:    j.CreatedAt,
+		UpdatedAt:    j.UpdatedAt,
+		TerminatedAt: j.TerminatedAt,
+		ImportedLogs: j.ImportedLogs,
+		SkippedLogs:  j.SkippedLogs,
+		LastLogID:    (*big.Int)(j.LastLogID),
+		ErrorCode:    j.ErrorCode,
+		Error:        j.Error,
+	}
+}
+
+func (store *Store) newImportJob(from ledger.ImportJob) *ImportJob {
+	return &ImportJob{
+		Ledger:       store.name,
+		ID:           from.ID,
+		State:        from.State,
+		CreatedAt:    from.CreatedAt,
+		UpdatedAt:    from.UpdatedAt,
+		TerminatedAt: from.TerminatedAt,
+		ImportedLogs: from.ImportedLogs,
+		SkippedLogs:  from.SkippedLogs,
+		LastLogID:    (*bunpaginate.BigInt)(from.LastLogID),
+		ErrorCode:    from.ErrorCode,
+		Error:        from.Error,
+	}
+}
+
+func (store *Store) InsertImportJob(ctx context.Context, job ledger.ImportJob) error {
+	_, err := store.bucket.db.
+		NewInsert().
+		Model(store.newImportJob(job)).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+func (store *Store) UpdateImportJob(ctx context.Context, job ledger.ImportJob) error {
+	_, err := store.bucket.db.
+		NewUpdate().
+		Model(store.newImportJob(job)).
+		Column("state", "updated_at", "terminated_at", "imported_logs", "skipped_logs", "last_log_id", "error_code", "error").
+		Where("ledger = ?", store.name).
+		Where("id = ?", job.ID).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+func (store *Store) GetImportJob(ctx context.Context, id string) (*ledger.ImportJob, error) {
+	ret, err := fetch[*ImportJob](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("ledger = ?", store.name).
+				Where("id = ?", id).
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	job := ret.toCore()
+	return &job, nil
+}
+
+func (store *Store) GetImportJobs(ctx context.Context, q GetImportJobsQuery) (*bunpaginate.Cursor[ledger.ImportJob], error) {
+	jobs, err := paginateWithColumn[PaginatedQueryOptions[any], ImportJob](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.Where("ledger = ?", store.name)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(jobs, func(from ImportJob) ledger.ImportJob {
+		return from.toCore()
+	}), nil
+}
+
+type GetImportJobsQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]]
+
+func NewGetImportJobsQuery(options PaginatedQueryOptions[any]) GetImportJobsQuery {
+	return GetImportJobsQuery{
+		PageSize: options.PageSize,
+		Column:   "seq",
+		Order:    bunpaginate.OrderDesc,
+		Options:  options,
+	}
+}
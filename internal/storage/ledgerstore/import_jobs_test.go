@@ -0,0 +1,58 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportJobs(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	job1 := ledger.NewImportJob()
+	job2 := ledger.NewImportJob()
+	for _, job := range []ledger.ImportJob{job1, job2} {
+		require.NoError(t, store.InsertImportJob(ctx, job))
+	}
+
+	_, err := store.GetImportJob(ctx, "unknown")
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	job, err := store.GetImportJob(ctx, job1.ID)
+	require.NoError(t, err)
+	require.Equal(t, job1.ID, job.ID)
+	require.Equal(t, ledger.ImportJobStateRunning, job.State)
+	require.Nil(t, job.LastLogID)
+
+	job1 = job1.WithProgress(now, 100, 10, big.NewInt(109))
+	require.NoError(t, store.UpdateImportJob(ctx, job1))
+	require.NoError(t, store.UpdateImportJob(ctx, job1.WithSuccess(now)))
+	require.NoError(t, store.UpdateImportJob(ctx, job2.WithFailure(now, "IMPORT", errors.New("invalid hash"))))
+
+	job, err = store.GetImportJob(ctx, job1.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.ImportJobStateSucceeded, job.State)
+	require.Equal(t, 100, job.ImportedLogs)
+	require.Equal(t, 10, job.SkippedLogs)
+	require.Equal(t, big.NewInt(109), job.LastLogID)
+	require.NotNil(t, job.TerminatedAt)
+
+	cursor, err := store.GetImportJobs(ctx, NewGetImportJobsQuery(NewPaginatedQueryOptions[any](nil)))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 2)
+	require.Equal(t, job2.ID, cursor.Data[0].ID)
+	require.Equal(t, ledger.ImportJobStateFailed, cursor.Data[0].State)
+	require.Equal(t, "IMPORT", cursor.Data[0].ErrorCode)
+	require.Equal(t, "invalid hash", cursor.Data[0].Error)
+}
@@ -0,0 +1,149 @@
+package ledgerstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+var ErrWriterLockLost = errors.New("writer lock lost")
+
+// writerLockPollInterval is the delay between two attempts to take the writer lock of a ledger held by another instance
+const writerLockPollInterval = 20 * time.Millisecond
+
+func (store *Store) writerLockKey() string {
+	return fmt.Sprintf("ledger:%s", store.name)
+}
+
+// WriterLock is the writer lock of a ledger held by the instance.
+// It is a session level advisory lock held by a dedicated connection,
+// so PostgreSQL releases it if the instance holding it dies, or if the connection is lost.
+// Each acquisition starts a new term of the ledger, which fences the logs inserted by the previous writers.
+type WriterLock struct {
+	conn   bun.Conn
+	key    string
+	ledger string
+	term   int64
+	lost   atomic.Bool
+}
+
+// discard closes the connection instead of returning it to the pool,
+// as the session may still hold the lock.
+func (lock *WriterLock) discard() {
+	_ = lock.conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	_ = lock.conn.Close()
+}
+
+// Check returns ErrWriterLockLost if the connection holding the lock has been lost,
+// or if another instance has started a new term, in which case another instance may have taken the lock.
+func (lock *WriterLock) Check(ctx context.Context) error {
+	if lock.lost.Load() {
+		return ErrWriterLockLost
+	}
+	if err := lock.conn.PingContext(ctx); err != nil {
+		return errors.Wrap(ErrWriterLockLost, err.Error())
+	}
+	return nil
+}
+
+type writerLockContextKey struct{}
+
+// Fence returns a context whose logs are only inserted if the term of the lock is still the current one.
+func (lock *WriterLock) Fence(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writerLockContextKey{}, lock)
+}
+
+// checkWriterTerm returns ErrWriterLockLost if the writer lock fencing ctx is not the one of the current term.
+// The term is read with a share lock, so the next writer cannot start its term before tx is done.
+func checkWriterTerm(ctx context.Context, tx bun.Tx) error {
+	lock, ok := ctx.Value(writerLockContextKey{}).(*WriterLock)
+	if !ok {
+		return nil
+	}
+
+	term := int64(0)
+	if err := tx.QueryRowContext(ctx, "select term from writer_terms where ledger = ? for share", lock.ledger).
+		Scan(&term); err != nil {
+		return sqlutils.PostgresError(err)
+	}
+	if term != lock.term {
+		lock.lost.Store(true)
+		return errors.Wrapf(ErrWriterLockLost, "term %d started", term)
+	}
+	return nil
+}
+
+// Release releases the lock and closes its connection.
+func (lock *WriterLock) Release(ctx context.Context) error {
+	released := false
+	if err := lock.conn.QueryRowContext(ctx, "select pg_advisory_unlock(hashtextextended(?, 0))", lock.key).
+		Scan(&released); err != nil {
+		lock.discard()
+		return sqlutils.PostgresError(err)
+	}
+	_ = lock.conn.Close()
+
+	if !released {
+		return ErrWriterLockLost
+	}
+	return nil
+}
+
+// startTerm increments the term of the ledger once the lock is acquired.
+func (lock *WriterLock) startTerm(ctx context.Context) error {
+	if err := lock.conn.QueryRowContext(ctx, `insert into writer_terms (ledger, term) values (?, 1)
+		on conflict (ledger) do update set term = writer_terms.term + 1
+		returning term`, lock.ledger).Scan(&lock.term); err != nil {
+		lock.discard()
+		return sqlutils.PostgresError(err)
+	}
+	return nil
+}
+
+// AcquireWriterLock waits for the writer lock of the ledger, which ensures only one instance writes on it at a time.
+// The lock is polled until ctx is done, so a waiting instance never holds a pending lock request.
+func (store *Store) AcquireWriterLock(ctx context.Context) (*WriterLock, error) {
+	conn, err := store.bucket.db.Conn(ctx)
+	if err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+	lock := &WriterLock{
+		conn:   conn,
+		key:    store.writerLockKey(),
+		ledger: store.name,
+	}
+
+	for {
+		acquired := false
+		if err := conn.QueryRowContext(ctx, "select pg_try_advisory_lock(hashtextextended(?, 0))", lock.key).
+			Scan(&acquired); err != nil {
+			// The query may have been canceled after the lock was granted
+			lock.discard()
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, sqlutils.PostgresError(err)
+		}
+		if acquired {
+			if err := lock.startTerm(ctx); err != nil {
+				return nil, err
+			}
+			return lock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+			return nil, ctx.Err()
+		case <-time.After(writerLockPollInterval):
+		}
+	}
+}
@@ -0,0 +1,65 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterLock(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	lock, err := store.AcquireWriterLock(ctx)
+	require.NoError(t, err)
+	require.NoError(t, lock.Check(ctx))
+
+	// Another instance must wait for the lock to be released
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = store.AcquireWriterLock(timeoutCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, lock.Release(ctx))
+
+	lock, err = store.AcquireWriterLock(ctx)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(ctx))
+}
+
+func TestWriterLockFencesLogs(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	previous, err := store.AcquireWriterLock(ctx)
+	require.NoError(t, err)
+
+	log0 := ledger.NewSetMetadataOnAccountLog(time.Now(), "bank", metadata.Metadata{"foo": "bar"}).ChainLog(nil)
+	require.NoError(t, store.InsertLogs(previous.Fence(ctx), log0))
+
+	// Another writer starts a new term, once the previous one has lost the lock
+	require.NoError(t, previous.Release(ctx))
+	lock, err := store.AcquireWriterLock(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, lock.Release(ctx))
+	}()
+
+	// The logs of the previous writer are rejected
+	log1 := ledger.NewSetMetadataOnAccountLog(time.Now(), "bank", metadata.Metadata{"foo": "baz"}).ChainLog(log0)
+	require.ErrorIs(t, store.InsertLogs(previous.Fence(ctx), log1), ErrWriterLockLost)
+	require.ErrorIs(t, store.ImportLogs(previous.Fence(ctx), log1), ErrWriterLockLost)
+	require.ErrorIs(t, previous.Check(ctx), ErrWriterLockLost)
+
+	require.NoError(t, store.InsertLogs(lock.Fence(ctx), log1))
+	require.NoError(t, lock.Check(ctx))
+}
@@ -2,6 +2,7 @@ package ledgerstore
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/formancehq/go-libs/query"
 	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
 	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
 )
@@ -23,13 +25,14 @@ import (
 type Logs struct {
 	bun.BaseModel `bun:"table:logs,alias:logs"`
 
-	Ledger         string              `bun:"ledger,type:varchar"`
-	ID             *bunpaginate.BigInt `bun:"id,unique,type:numeric"`
-	Type           string              `bun:"type,type:log_type"`
-	Hash           []byte              `bun:"hash,type:bytea"`
-	Date           time.Time           `bun:"date,type:timestamptz"`
-	Data           RawMessage          `bun:"data,type:jsonb"`
-	IdempotencyKey *string             `bun:"idempotency_key,type:varchar(256),unique"`
+	Ledger          string              `bun:"ledger,type:varchar"`
+	ID              *bunpaginate.BigInt `bun:"id,unique,type:numeric"`
+	Type            string              `bun:"type,type:log_type"`
+	Hash            []byte              `bun:"hash,type:bytea"`
+	Date            time.Time           `bun:"date,type:timestamptz"`
+	Data            RawMessage          `bun:"data,type:jsonb"`
+	IdempotencyKey  *string             `bun:"idempotency_key,type:varchar(256),unique"`
+	IdempotencyHash *string             `bun:"idempotency_hash,type:varchar"`
 }
 
 func (log *Logs) ToCore() *ledger.ChainedLog {
@@ -50,6 +53,12 @@ func (log *Logs) ToCore() *ledger.ChainedLog {
 				}
 				return ""
 			}(),
+			IdempotencyHash: func() string {
+				if log.IdempotencyHash != nil {
+					return *log.IdempotencyHash
+				}
+				return ""
+			}(),
 		},
 		ID:   (*big.Int)(log.ID),
 		Hash: log.Hash,
@@ -65,31 +74,80 @@ func (j RawMessage) Value() (driver.Value, error) {
 	return string(j), nil
 }
 
-func (store *Store) logsQueryBuilder(q PaginatedQueryOptions[any]) func(*bun.SelectQuery) *bun.SelectQuery {
+func (store *Store) logsQueryContext(qb query.Builder) (string, []any, error) {
+	return qb.Build(query.ContextFn(func(key, operator string, value any) (string, []any, error) {
+		switch {
+		case key == "date" || key == "id":
+			return fmt.Sprintf("%s %s ?", key, query.DefaultComparisonOperatorsMapping[operator]), []any{value}, nil
+		case key == "type":
+			if operator != "$match" {
+				return "", nil, newErrInvalidQuery("'type' column can only be used with $match")
+			}
+			logType, ok := value.(string)
+			if !ok || !isValidLogType(logType) {
+				return "", nil, newErrInvalidQuery("invalid log type %v", value)
+			}
+			return "type = ?", []any{logType}, nil
+		default:
+			return "", nil, newErrInvalidQuery("unknown key '%s' when building query", key)
+		}
+	}))
+}
+
+func isValidLogType(logType string) bool {
+	for _, t := range []ledger.LogType{
+		ledger.SetMetadataLogType,
+		ledger.NewTransactionLogType,
+		ledger.RevertedTransactionLogType,
+		ledger.DeleteMetadataLogType,
+	} {
+		if t.String() == logType {
+			return true
+		}
+	}
+	return false
+}
+
+func (store *Store) logsQueryBuilder(where string, args []any) func(*bun.SelectQuery) *bun.SelectQuery {
 	return func(selectQuery *bun.SelectQuery) *bun.SelectQuery {
 
 		selectQuery = selectQuery.Where("ledger = ?", store.name)
-		if q.QueryBuilder != nil {
-			subQuery, args, err := q.QueryBuilder.Build(query.ContextFn(func(key, operator string, value any) (string, []any, error) {
-				switch {
-				case key == "date":
-					return fmt.Sprintf("%s %s ?", key, query.DefaultComparisonOperatorsMapping[operator]), []any{value}, nil
-				default:
-					return "", nil, fmt.Errorf("unknown key '%s' when building query", key)
-				}
-			}))
-			if err != nil {
-				panic(err)
-			}
-			selectQuery = selectQuery.Where(subQuery, args...)
+		if where != "" {
+			selectQuery = selectQuery.Where(where, args...)
 		}
 
 		return selectQuery
 	}
 }
 
+// InsertLogs inserts the logs, and records them in the outbox of the ledger in the same transaction,
+// so that their events are published even if the instance stops right after.
+// If ctx is fenced by a writer lock, the logs are only inserted if it is still the current writer of the ledger.
 func (store *Store) InsertLogs(ctx context.Context, activeLogs ...*ledger.ChainedLog) error {
-	_, err := store.bucket.db.
+	return store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkWriterTerm(ctx, tx); err != nil {
+			return err
+		}
+		if err := store.insertLogs(ctx, tx, activeLogs); err != nil {
+			return err
+		}
+		return store.insertOutboxEntries(ctx, tx, activeLogs)
+	})
+}
+
+// ImportLogs inserts logs imported from another ledger, whose events are not published.
+// Like InsertLogs, the logs are fenced by the writer lock of ctx, if any.
+func (store *Store) ImportLogs(ctx context.Context, logs ...*ledger.ChainedLog) error {
+	return store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkWriterTerm(ctx, tx); err != nil {
+			return err
+		}
+		return store.insertLogs(ctx, tx, logs)
+	})
+}
+
+func (store *Store) insertLogs(ctx context.Context, db bun.IDB, activeLogs []*ledger.ChainedLog) error {
+	_, err := db.
 		NewInsert().
 		Model(pointer.For(collectionutils.Map(activeLogs, func(from *ledger.ChainedLog) Logs {
 			data, err := json.Marshal(from.Data)
@@ -110,6 +168,13 @@ func (store *Store) InsertLogs(ctx context.Context, activeLogs ...*ledger.Chaine
 					}
 					return nil
 				}(),
+				// The hash is set, even empty, as long as the idempotency key is active
+				IdempotencyHash: func() *string {
+					if from.IdempotencyKey != "" {
+						return &from.IdempotencyHash
+					}
+					return nil
+				}(),
 			}
 		}))).
 		Exec(ctx)
@@ -132,9 +197,21 @@ func (store *Store) GetLastLog(ctx context.Context) (*ledger.ChainedLog, error)
 }
 
 func (store *Store) GetLogs(ctx context.Context, q GetLogsQuery) (*bunpaginate.Cursor[ledger.ChainedLog], error) {
+	var (
+		where string
+		args  []any
+		err   error
+	)
+	if q.Options.QueryBuilder != nil {
+		where, args, err = store.logsQueryContext(q.Options.QueryBuilder)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	logs, err := paginateWithColumn[PaginatedQueryOptions[any], Logs](store, ctx,
 		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]])(&q),
-		store.logsQueryBuilder(q.Options),
+		store.logsQueryBuilder(where, args),
 	)
 	if err != nil {
 		return nil, err
@@ -152,6 +229,7 @@ func (store *Store) ReadLogWithIdempotencyKey(ctx context.Context, key string) (
 				OrderExpr("id desc").
 				Limit(1).
 				Where("idempotency_key = ?", key).
+				Where("idempotency_hash is not null").
 				Where("ledger = ?", store.name)
 		})
 	if err != nil {
@@ -161,6 +239,24 @@ func (store *Store) ReadLogWithIdempotencyKey(ctx context.Context, key string) (
 	return ret.ToCore(), nil
 }
 
+// PurgeIdempotencyKeys expires the idempotency keys of the logs inserted before the given date,
+// which allows to reuse them. The keys are kept on the logs, as they are part of their hash.
+func (store *Store) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	ret, err := store.bucket.db.
+		NewUpdate().
+		Table("logs").
+		Set("idempotency_hash = null").
+		Where("ledger = ?", store.name).
+		Where("idempotency_hash is not null").
+		Where("inserted_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, sqlutils.PostgresError(err)
+	}
+
+	return ret.RowsAffected()
+}
+
 type GetLogsQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]]
 
 func (q GetLogsQuery) WithOrder(order bunpaginate.Order) GetLogsQuery {
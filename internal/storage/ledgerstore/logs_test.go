@@ -108,6 +108,49 @@ func TestReadLogWithIdempotencyKey(t *testing.T) {
 	require.Equal(t, *ret, *lastLog)
 }
 
+func TestPurgeIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	newLog := func(previous *ledger.ChainedLog) *ledger.ChainedLog {
+		return ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(
+					ledger.NewPosting("world", "bank", "USD", big.NewInt(100)),
+				),
+			map[string]metadata.Metadata{},
+		).
+			WithIdempotencyKey("test").
+			WithIdempotencyHash("hash").
+			ChainLog(previous)
+	}
+
+	first := appendLog(t, store, newLog(nil))
+
+	log, err := store.ReadLogWithIdempotencyKey(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "hash", log.IdempotencyHash)
+
+	purged, err := store.PurgeIdempotencyKeys(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Zero(t, purged)
+
+	purged, err = store.PurgeIdempotencyKeys(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, purged)
+
+	_, err = store.ReadLogWithIdempotencyKey(ctx, "test")
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	// The expired key can be reused
+	second := appendLog(t, store, newLog(first))
+
+	log, err = store.ReadLogWithIdempotencyKey(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, second.ID, log.ID)
+}
+
 func TestGetLogs(t *testing.T) {
 	t.Parallel()
 	store := newLedgerStore(t)
@@ -289,6 +332,22 @@ func TestGetLogs(t *testing.T) {
 	// Should get only the second log, as StartTime is inclusive and EndTime exclusive.
 	require.Len(t, cursor.Data, 1)
 	require.Equal(t, big.NewInt(1), cursor.Data[0].ID)
+
+	cursor, err = store.GetLogs(context.Background(), NewGetLogsQuery(NewPaginatedQueryOptions[any](nil).
+		WithQueryBuilder(query.And(
+			query.Gt("id", 0),
+			query.Match("type", ledger.NewTransactionLogType.String()),
+		)),
+	))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 2)
+	require.Equal(t, big.NewInt(2), cursor.Data[0].ID)
+	require.Equal(t, big.NewInt(1), cursor.Data[1].ID)
+
+	_, err = store.GetLogs(context.Background(), NewGetLogsQuery(NewPaginatedQueryOptions[any](nil).
+		WithQueryBuilder(query.Match("type", "UNKNOWN")),
+	))
+	require.True(t, IsErrInvalidQuery(err))
 }
 
 func TestGetBalance(t *testing.T) {
@@ -0,0 +1,16 @@
+create table scheduled_transactions
+(
+    seq            bigserial primary key,
+    ledger         varchar   not null,
+    id             varchar   not null,
+    script         jsonb     not null,
+    execute_at     timestamp not null,
+    created_at     timestamp not null,
+    state          varchar   not null,
+    executed_at    timestamp,
+    transaction_id numeric,
+    error          varchar
+);
+
+create unique index scheduled_transactions_ledger on scheduled_transactions (ledger, id);
+create index scheduled_transactions_pending on scheduled_transactions (ledger, execute_at) where state = 'PENDING';
@@ -0,0 +1,15 @@
+alter table logs
+add column idempotency_hash varchar,
+add column inserted_at timestamp not null default (now() at time zone 'utc');
+
+-- the payload of the requests which created the existing logs is unknown,
+-- an empty hash keeps their idempotency key active without checking the payload
+update logs
+set idempotency_hash = ''
+where idempotency_key is not null;
+
+drop index logs_idempotency_key;
+
+-- expired idempotency keys have no hash anymore, and can be reused
+create unique index logs_idempotency_key on logs (ledger, idempotency_key) where idempotency_hash is not null;
+create index logs_idempotency_inserted_at on logs (ledger, inserted_at) where idempotency_hash is not null;
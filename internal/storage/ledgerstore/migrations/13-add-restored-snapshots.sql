@@ -0,0 +1,14 @@
+create table restored_snapshots
+(
+    ledger              varchar   not null primary key,
+    source_ledger       varchar   not null,
+    last_log_id         numeric   not null,
+    last_log_hash       bytea     not null,
+    last_transaction_id numeric,
+    date                timestamp not null,
+    restored_at         timestamp not null default (now() at time zone 'utc')
+);
+
+-- the volumes of a ledger restored from a snapshot are carried by opening moves, which belong to no transaction
+alter table moves
+alter column transactions_seq drop not null;
@@ -0,0 +1,17 @@
+create table import_jobs
+(
+    seq           bigserial primary key,
+    ledger        varchar   not null,
+    id            varchar   not null,
+    state         varchar   not null,
+    created_at    timestamp not null,
+    updated_at    timestamp not null,
+    terminated_at timestamp,
+    imported_logs bigint    not null default 0,
+    skipped_logs  bigint    not null default 0,
+    last_log_id   numeric,
+    error_code    varchar,
+    error         varchar
+);
+
+create unique index import_jobs_ledger on import_jobs (ledger, id);
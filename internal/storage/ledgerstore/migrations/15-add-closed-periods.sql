@@ -0,0 +1,12 @@
+create table closed_periods
+(
+    seq          bigserial primary key,
+    ledger       varchar   not null,
+    id           varchar   not null,
+    closing_date timestamp not null,
+    closed_at    timestamp not null,
+    balances     jsonb
+);
+
+create unique index closed_periods_ledger on closed_periods (ledger, id);
+create index closed_periods_closing_date on closed_periods (ledger, closing_date desc);
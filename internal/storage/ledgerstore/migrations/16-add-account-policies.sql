@@ -0,0 +1,13 @@
+create table account_policies
+(
+    seq                       bigserial primary key,
+    ledger                    varchar   not null,
+    pattern                   varchar   not null,
+    minimum_balances          jsonb,
+    allow_unbounded_overdraft bool      not null default false,
+    allowed_assets            jsonb,
+    frozen                    bool      not null default false,
+    updated_at                timestamp not null
+);
+
+create unique index account_policies_ledger on account_policies (ledger, pattern);
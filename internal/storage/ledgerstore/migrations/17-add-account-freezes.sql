@@ -0,0 +1,26 @@
+create table account_freezes
+(
+    seq       bigserial primary key,
+    ledger    varchar   not null,
+    address   varchar   not null,
+    direction varchar   not null,
+    reason    varchar,
+    frozen_at timestamp not null
+);
+
+create unique index account_freezes_ledger on account_freezes (ledger, address);
+
+create table account_freeze_events
+(
+    seq       bigserial primary key,
+    ledger    varchar   not null,
+    id        varchar   not null,
+    type      varchar   not null,
+    address   varchar   not null,
+    direction varchar,
+    reason    varchar,
+    date      timestamp not null
+);
+
+create unique index account_freeze_events_ledger on account_freeze_events (ledger, id);
+create index account_freeze_events_address on account_freeze_events (ledger, address, seq desc);
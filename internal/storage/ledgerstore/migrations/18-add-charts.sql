@@ -0,0 +1,10 @@
+create table charts
+(
+    seq        bigserial primary key,
+    ledger     varchar   not null,
+    mode       varchar   not null,
+    accounts   jsonb     not null,
+    updated_at timestamp not null
+);
+
+create unique index charts_ledger on charts (ledger);
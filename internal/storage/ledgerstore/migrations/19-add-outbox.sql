@@ -0,0 +1,9 @@
+create table outbox
+(
+    seq        bigserial primary key,
+    ledger     varchar   not null,
+    log_id     numeric   not null,
+    created_at timestamp not null
+);
+
+create index outbox_ledger on outbox (ledger, seq);
@@ -0,0 +1,56 @@
+-- the entries of the history of the accounts, moves and metadata revisions, share a sequence
+-- so they are ordered as they are committed by the writer of the ledger
+create sequence account_history_seq;
+
+alter table moves
+add column history_seq bigint;
+
+alter table accounts_metadata
+add column history_seq bigint;
+
+-- the history is backfilled ledger by ledger, each one ordered by date
+do
+$$
+declare
+    _ledger       varchar;
+    _history_seq  bigint = 0;
+begin
+    for _ledger in
+        select ledger from moves
+        union
+        select ledger from accounts_metadata
+    loop
+        create temporary table account_history_entries as
+        select kind, seq, _history_seq + row_number() over (order by date, kind, seq) as history_seq
+        from (
+            select 0 as kind, seq, insertion_date as date from moves where ledger = _ledger
+            union all
+            select 1 as kind, seq, date from accounts_metadata where ledger = _ledger
+        ) entries;
+
+        update moves
+        set history_seq = account_history_entries.history_seq
+        from account_history_entries
+        where account_history_entries.kind = 0 and account_history_entries.seq = moves.seq;
+
+        update accounts_metadata
+        set history_seq = account_history_entries.history_seq
+        from account_history_entries
+        where account_history_entries.kind = 1 and account_history_entries.seq = accounts_metadata.seq;
+
+        select coalesce(max(history_seq), _history_seq) from account_history_entries into _history_seq;
+
+        drop table account_history_entries;
+    end loop;
+
+    perform setval('account_history_seq', _history_seq + 1, false);
+end
+$$;
+
+alter table moves
+alter column history_seq set default nextval('account_history_seq');
+
+alter table accounts_metadata
+alter column history_seq set default nextval('account_history_seq');
+
+create index moves_account_history on moves (ledger, account_address, history_seq);
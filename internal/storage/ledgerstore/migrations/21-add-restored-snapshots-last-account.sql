@@ -0,0 +1,3 @@
+-- the accounts restored from a snapshot are the base of the point-in-time snapshots of the ledger
+alter table restored_snapshots
+add column last_account_seq bigint;
@@ -0,0 +1,7 @@
+-- the term of a ledger is incremented each time an instance takes its writer lock,
+-- the logs are only inserted if the term of their writer is still the current one
+create table writer_terms
+(
+    ledger varchar primary key,
+    term   bigint  not null
+);
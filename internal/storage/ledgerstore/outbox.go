@@ -0,0 +1,138 @@
+package ledgerstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/collectionutils"
+	"github.com/formancehq/go-libs/pointer"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+type Outbox struct {
+	bun.BaseModel `bun:"outbox,alias:outbox"`
+
+	Seq       int64               `bun:"seq,pk,autoincrement"`
+	Ledger    string              `bun:"ledger,type:varchar"`
+	LogID     *bunpaginate.BigInt `bun:"log_id,type:numeric"`
+	CreatedAt time.Time           `bun:"created_at,type:timestamp without time zone"`
+}
+
+// OutboxEntry is a log whose events are still to be published.
+type OutboxEntry struct {
+	Seq       int64
+	CreatedAt time.Time
+	Log       *ledger.ChainedLog
+}
+
+func (store *Store) outboxLockKey() string {
+	return fmt.Sprintf("outbox:%s", store.name)
+}
+
+func (store *Store) insertOutboxEntries(ctx context.Context, db bun.IDB, logs []*ledger.ChainedLog) error {
+	now := time.Now()
+	_, err := db.
+		NewInsert().
+		Model(pointer.For(collectionutils.Map(logs, func(from *ledger.ChainedLog) Outbox {
+			return Outbox{
+				Ledger:    store.name,
+				LogID:     (*bunpaginate.BigInt)(from.ID),
+				CreatedAt: now,
+			}
+		}))).
+		Exec(ctx)
+	return err
+}
+
+// RelayOutbox calls relayFn with the oldest entries of the outbox, up to limit, and deletes the entries relayFn reports as relayed.
+// It runs in a transaction holding the outbox lock of the ledger, so that the entries are relayed in order,
+// by one instance at a time. It returns the number of entries relayed, which is zero if another instance holds the lock.
+// The entries relayed before relayFn fails are deleted.
+func (store *Store) RelayOutbox(ctx context.Context, limit int, relayFn func(ctx context.Context, entries []OutboxEntry) (int, error)) (int, error) {
+	relayed := 0
+	var relayErr error
+	err := store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		locked := false
+		if err := tx.QueryRowContext(ctx, "select pg_try_advisory_xact_lock(hashtextextended(?, 0))", store.outboxLockKey()).
+			Scan(&locked); err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		rows := make([]Outbox, 0)
+		if err := tx.NewSelect().
+			Model(&rows).
+			Where("ledger = ?", store.name).
+			Order("seq").
+			Limit(limit).
+			Scan(ctx); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		logs := make([]Logs, 0, len(rows))
+		if err := tx.NewSelect().
+			Model(&logs).
+			Where("ledger = ?", store.name).
+			Where("id in (?)", bun.In(collectionutils.Map(rows, func(from Outbox) *bunpaginate.BigInt {
+				return from.LogID
+			}))).
+			Scan(ctx); err != nil {
+			return err
+		}
+		logsByID := make(map[string]*ledger.ChainedLog, len(logs))
+		for _, log := range logs {
+			logsByID[(*big.Int)(log.ID).String()] = log.ToCore()
+		}
+
+		entries := make([]OutboxEntry, 0, len(rows))
+		for _, row := range rows {
+			log, ok := logsByID[(*big.Int)(row.LogID).String()]
+			if !ok {
+				return errors.Errorf("log %s of outbox entry %d not found", (*big.Int)(row.LogID), row.Seq)
+			}
+			entries = append(entries, OutboxEntry{
+				Seq:       row.Seq,
+				CreatedAt: row.CreatedAt,
+				Log:       log,
+			})
+		}
+
+		relayed, relayErr = relayFn(ctx, entries)
+		if relayed == 0 {
+			return nil
+		}
+
+		_, err := tx.NewDelete().
+			Model((*Outbox)(nil)).
+			Where("ledger = ?", store.name).
+			Where("seq <= ?", entries[relayed-1].Seq).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return 0, sqlutils.PostgresError(err)
+	}
+
+	return relayed, relayErr
+}
+
+// CountOutboxEntries returns the number of logs whose events are still to be published.
+func (store *Store) CountOutboxEntries(ctx context.Context) (int, error) {
+	count, err := store.bucket.db.NewSelect().
+		Model((*Outbox)(nil)).
+		Where("ledger = ?", store.name).
+		Count(ctx)
+	return count, sqlutils.PostgresError(err)
+}
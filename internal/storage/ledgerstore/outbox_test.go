@@ -0,0 +1,63 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	log1 := ledger.NewTransactionLog(
+		ledger.NewTransaction().WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))),
+		map[string]metadata.Metadata{},
+	).ChainLog(nil)
+	log2 := ledger.NewSetMetadataOnAccountLog(log1.Date, "bank", metadata.Metadata{"foo": "bar"}).ChainLog(log1)
+	log3 := ledger.NewTransactionLog(
+		ledger.NewTransaction().WithID(big.NewInt(1)).WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))),
+		map[string]metadata.Metadata{},
+	).ChainLog(log2)
+	require.NoError(t, store.InsertLogs(ctx, log1, log2))
+	require.NoError(t, store.InsertLogs(ctx, log3))
+
+	// Imported logs are not recorded in the outbox
+	require.NoError(t, store.ImportLogs(ctx, ledger.NewSetMetadataOnAccountLog(log1.Date, "bank", metadata.Metadata{"foo": "baz"}).ChainLog(log3)))
+
+	count, err := store.CountOutboxEntries(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	// The entries relayed before a failure are removed
+	relayed, err := store.RelayOutbox(ctx, 2, func(ctx context.Context, entries []OutboxEntry) (int, error) {
+		require.Len(t, entries, 2)
+		require.Equal(t, log1.ID, entries[0].Log.ID)
+		require.Equal(t, ledger.SetMetadataLogType, entries[1].Log.Type)
+		return 1, errors.New("broker unavailable")
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, relayed)
+
+	relayed, err = store.RelayOutbox(ctx, 10, func(ctx context.Context, entries []OutboxEntry) (int, error) {
+		require.Len(t, entries, 2)
+		require.Equal(t, log2.ID, entries[0].Log.ID)
+		require.Equal(t, log3.ID, entries[1].Log.ID)
+		return len(entries), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, relayed)
+
+	count, err = store.CountOutboxEntries(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
@@ -0,0 +1,178 @@
+package ledgerstore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/query"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/uptrace/bun"
+)
+
+type ScheduledTransaction struct {
+	bun.BaseModel `bun:"scheduled_transactions,alias:scheduled_transactions"`
+
+	Seq           int64               `bun:"seq,pk,autoincrement"`
+	Ledger        string              `bun:"ledger,type:varchar"`
+	ID            string              `bun:"id,type:varchar"`
+	Script        ledger.RunScript    `bun:"script,type:jsonb"`
+	ExecuteAt     time.Time           `bun:"execute_at,type:timestamp without time zone"`
+	CreatedAt     time.Time           `bun:"created_at,type:timestamp without time zone"`
+	State         string              `bun:"state,type:varchar"`
+	ExecutedAt    *time.Time          `bun:"executed_at,type:timestamp without time zone"`
+	TransactionID *bunpaginate.BigInt `bun:"transaction_id,type:numeric"`
+	Error         string              `bun:"error,type:varchar,nullzero"`
+}
+
+func (s *ScheduledTransaction) toCore() ledger.ScheduledTransaction {
+	return ledger.ScheduledTransaction{
+		ID:            s.ID,
+		Script:        s.Script,
+		ExecuteAt:     s.ExecuteAt,
+		CreatedAt:     s.CreatedAt,
+		State:         s.State,
+		ExecutedAt:    s.ExecutedAt,
+		TransactionID: (*big.Int)(s.TransactionID),
+		Error:         s.Error,
+	}
+}
+
+func (store *Store) newScheduledTransaction(from ledger.ScheduledTransaction) *ScheduledTransaction {
+	return &ScheduledTransaction{
+		Ledger:        store.name,
+		ID:            from.ID,
+		Script:        from.Script,
+		ExecuteAt:     from.ExecuteAt,
+		CreatedAt:     from.CreatedAt,
+		State:         from.State,
+		ExecutedAt:    from.ExecutedAt,
+		TransactionID: (*bunpaginate.BigInt)(from.TransactionID),
+		Error:         from.Error,
+	}
+}
+
+func (store *Store) InsertScheduledTransaction(ctx context.Context, scheduled ledger.ScheduledTransaction) error {
+	_, err := store.bucket.db.
+		NewInsert().
+		Model(store.newScheduledTransaction(scheduled)).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+// UpdateScheduledTransaction records the outcome of a scheduled transaction.
+// Only pending scheduled transactions can be updated.
+func (store *Store) UpdateScheduledTransaction(ctx context.Context, scheduled ledger.ScheduledTransaction) error {
+	_, err := store.bucket.db.
+		NewUpdate().
+		Model(store.newScheduledTransaction(scheduled)).
+		Column("state", "executed_at", "transaction_id", "error").
+		Where("ledger = ?", store.name).
+		Where("id = ?", scheduled.ID).
+		Where("state = ?", ledger.ScheduledTransactionStatePending).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+func (store *Store) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	ret, err := fetch[*ScheduledTransaction](store, true, ctx,
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("ledger = ?", store.name).
+				Where("id = ?", id).
+				Limit(1)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := ret.toCore()
+	return &scheduled, nil
+}
+
+// GetDueScheduledTransactions returns, oldest first, at most limit pending
+// scheduled transactions whose execution time is before date, skipping the first offset ones.
+func (store *Store) GetDueScheduledTransactions(ctx context.Context, date time.Time, offset, limit int) ([]ledger.ScheduledTransaction, error) {
+	ret := make([]ScheduledTransaction, 0)
+	err := store.bucket.db.NewSelect().
+		Model(&ret).
+		Where("ledger = ?", store.name).
+		Where("state = ?", ledger.ScheduledTransactionStatePending).
+		Where("execute_at <= ?", date).
+		Order("execute_at asc", "seq asc").
+		Offset(offset).
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	scheduled := make([]ledger.ScheduledTransaction, len(ret))
+	for i := range ret {
+		scheduled[i] = ret[i].toCore()
+	}
+	return scheduled, nil
+}
+
+func (store *Store) scheduledTransactionQueryContext(qb query.Builder) (string, []any, error) {
+	return qb.Build(query.ContextFn(func(key, operator string, value any) (string, []any, error) {
+		switch {
+		case key == "state":
+			if operator != "$match" {
+				return "", nil, newErrInvalidQuery("'state' column can only be used with $match")
+			}
+			return "state = ?", []any{value}, nil
+		case key == "executeAt":
+			return fmt.Sprintf("execute_at %s ?", query.DefaultComparisonOperatorsMapping[operator]), []any{value}, nil
+		default:
+			return "", nil, newErrInvalidQuery("unknown key '%s' when building query", key)
+		}
+	}))
+}
+
+func (store *Store) GetScheduledTransactions(ctx context.Context, q GetScheduledTransactionsQuery) (*bunpaginate.Cursor[ledger.ScheduledTransaction], error) {
+
+	var (
+		where string
+		args  []any
+		err   error
+	)
+	if q.Options.QueryBuilder != nil {
+		where, args, err = store.scheduledTransactionQueryContext(q.Options.QueryBuilder)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scheduled, err := paginateWithColumn[PaginatedQueryOptions[any], ScheduledTransaction](store, ctx,
+		(*bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]])(&q),
+		func(query *bun.SelectQuery) *bun.SelectQuery {
+			query = query.Where("ledger = ?", store.name)
+			if where != "" {
+				query = query.Where(where, args...)
+			}
+			return query
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return bunpaginate.MapCursor(scheduled, func(from ScheduledTransaction) ledger.ScheduledTransaction {
+		return from.toCore()
+	}), nil
+}
+
+type GetScheduledTransactionsQuery bunpaginate.ColumnPaginatedQuery[PaginatedQueryOptions[any]]
+
+func NewGetScheduledTransactionsQuery(options PaginatedQueryOptions[any]) GetScheduledTransactionsQuery {
+	return GetScheduledTransactionsQuery{
+		PageSize: options.PageSize,
+		Column:   "seq",
+		Order:    bunpaginate.OrderDesc,
+		Options:  options,
+	}
+}
@@ -0,0 +1,82 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/query"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledTransactions(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	script := ledger.RunScript{
+		Script: ledger.Script{
+			Plain: "send [USD 100] (source = @world destination = @bank)",
+			Vars:  map[string]string{},
+		},
+	}
+
+	st1 := ledger.NewScheduledTransaction(script, now.Add(-time.Minute))
+	st2 := ledger.NewScheduledTransaction(script, now.Add(-2*time.Minute))
+	st3 := ledger.NewScheduledTransaction(script, now.Add(time.Hour))
+	for _, st := range []ledger.ScheduledTransaction{st1, st2, st3} {
+		require.NoError(t, store.InsertScheduledTransaction(ctx, st))
+	}
+
+	_, err := store.GetScheduledTransaction(ctx, "unknown")
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	scheduled, err := store.GetScheduledTransaction(ctx, st1.ID)
+	require.NoError(t, err)
+	require.Equal(t, st1.ID, scheduled.ID)
+	require.Equal(t, script, scheduled.Script)
+	require.Equal(t, ledger.ScheduledTransactionStatePending, scheduled.State)
+
+	due, err := store.GetDueScheduledTransactions(ctx, now, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, st2.ID, due[0].ID)
+	require.Equal(t, st1.ID, due[1].ID)
+
+	due, err = store.GetDueScheduledTransactions(ctx, now, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, st1.ID, due[0].ID)
+
+	require.NoError(t, store.UpdateScheduledTransaction(ctx, st2.WithSuccess(now, big.NewInt(0))))
+	require.NoError(t, store.UpdateScheduledTransaction(ctx, st1.WithFailure(now, errors.New("insufficient funds"))))
+
+	due, err = store.GetDueScheduledTransactions(context.Background(), now, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	scheduled, err = store.GetScheduledTransaction(ctx, st2.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.ScheduledTransactionStateSucceeded, scheduled.State)
+	require.Equal(t, big.NewInt(0), scheduled.TransactionID)
+
+	cursor, err := store.GetScheduledTransactions(ctx, NewGetScheduledTransactionsQuery(NewPaginatedQueryOptions[any](nil)))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 3)
+	require.Equal(t, st3.ID, cursor.Data[0].ID)
+
+	cursor, err = store.GetScheduledTransactions(ctx, NewGetScheduledTransactionsQuery(NewPaginatedQueryOptions[any](nil).
+		WithQueryBuilder(query.Match("state", ledger.ScheduledTransactionStateFailed))))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, st1.ID, cursor.Data[0].ID)
+	require.Equal(t, "insufficient funds", cursor.Data[0].Error)
+}
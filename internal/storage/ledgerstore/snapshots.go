@@ -0,0 +1,511 @@
+package ledgerstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/collectionutils"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/pointer"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrNothingToSnapshot   = errors.New("ledger has no logs")
+	ErrLedgerNotEmpty      = errors.New("ledger is not empty")
+	ErrSnapshotLogNotFound = errors.New("log not found")
+	// ErrSnapshotBeforeRestore is returned for a snapshot of a restored ledger at a log preceding the restored snapshot,
+	// or when the accounts restored from the snapshot are unknown
+	ErrSnapshotBeforeRestore = errors.New("log is not after the snapshot the ledger has been restored from")
+)
+
+const snapshotPageSize = 100
+
+type RestoredSnapshot struct {
+	bun.BaseModel `bun:"table:restored_snapshots,alias:restored_snapshots"`
+
+	Ledger            string              `bun:"ledger,type:varchar"`
+	SourceLedger      string              `bun:"source_ledger,type:varchar"`
+	LastLogID         *bunpaginate.BigInt `bun:"last_log_id,type:numeric"`
+	LastLogHash       []byte              `bun:"last_log_hash,type:bytea"`
+	LastTransactionID *bunpaginate.BigInt `bun:"last_transaction_id,type:numeric"`
+	Date              time.Time           `bun:"date,type:timestamp without time zone"`
+	// LastAccountSeq is the seq of the last account restored from the snapshot
+	LastAccountSeq *int64 `bun:"last_account_seq,type:bigint"`
+}
+
+func (s *RestoredSnapshot) toCore() *ledger.SnapshotHeader {
+	return &ledger.SnapshotHeader{
+		Version:           ledger.SnapshotVersion,
+		Ledger:            s.SourceLedger,
+		Date:              s.Date,
+		LastLogID:         (*big.Int)(s.LastLogID),
+		LastLogHash:       s.LastLogHash,
+		LastTransactionID: (*big.Int)(s.LastTransactionID),
+	}
+}
+
+type snapshotAccount struct {
+	bun.BaseModel `bun:"table:accounts,alias:accounts"`
+
+	Seq           int64                  `bun:"seq,scanonly"`
+	Ledger        string                 `bun:"ledger,type:varchar"`
+	Address       string                 `bun:"address,type:varchar"`
+	AddressArray  []string               `bun:"address_array,type:jsonb"`
+	InsertionDate time.Time              `bun:"insertion_date,type:timestamp without time zone"`
+	UpdatedAt     time.Time              `bun:"updated_at,type:timestamp without time zone"`
+	FirstUsage    time.Time              `bun:"first_usage,type:timestamp without time zone"`
+	Metadata      metadata.Metadata      `bun:"metadata,type:jsonb"`
+	Volumes       ledger.VolumesByAssets `bun:"volumes,type:jsonb,scanonly"`
+}
+
+func (store *Store) getRestoredSnapshot(ctx context.Context, db bun.IDB) (*ledger.SnapshotHeader, error) {
+	ret := &RestoredSnapshot{}
+	if err := db.NewSelect().
+		Model(ret).
+		Where("ledger = ?", store.name).
+		Scan(ctx); err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	return ret.toCore(), nil
+}
+
+// GetRestoredSnapshot returns the header of the snapshot the ledger has been restored from, if any.
+func (store *Store) GetRestoredSnapshot(ctx context.Context) (*ledger.SnapshotHeader, error) {
+	return store.getRestoredSnapshot(ctx, store.bucket.db)
+}
+
+// TakeSnapshot reads the accounts of the ledger, with their metadata and volumes, as of the log logID,
+// or as of its last log if logID is nil.
+// All the reads happen in a single repeatable read transaction, so the snapshot is consistent
+// even if the ledger is written in the meantime.
+func (store *Store) TakeSnapshot(ctx context.Context, logID *big.Int,
+	headerFn func(header ledger.SnapshotHeader) error,
+	accountFn func(account ledger.SnapshotAccount) error,
+) error {
+	return sqlutils.PostgresError(store.bucket.db.RunInTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	}, func(ctx context.Context, tx bun.Tx) error {
+		if logID != nil {
+			return store.takeSnapshotAt(ctx, tx, logID, headerFn, accountFn)
+		}
+
+		header, err := store.snapshotHeader(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := headerFn(*header); err != nil {
+			return err
+		}
+
+		lastSeq := int64(0)
+		for {
+			accounts := make([]snapshotAccount, 0)
+			if err := tx.NewSelect().
+				Model(&accounts).
+				Column("seq", "address", "metadata").
+				ColumnExpr("coalesce(first_usage, insertion_date) as first_usage").
+				ColumnExpr("coalesce(get_account_aggregated_volumes(?, address), '{}'::jsonb) as volumes", store.name).
+				Where("ledger = ?", store.name).
+				Where("seq > ?", lastSeq).
+				Order("seq").
+				Limit(snapshotPageSize).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			for _, account := range accounts {
+				if err := accountFn(ledger.SnapshotAccount{
+					Address:    account.Address,
+					Metadata:   account.Metadata,
+					FirstUsage: account.FirstUsage,
+					Volumes:    account.Volumes,
+				}); err != nil {
+					return err
+				}
+			}
+
+			if len(accounts) < snapshotPageSize {
+				return nil
+			}
+			lastSeq = accounts[len(accounts)-1].Seq
+		}
+	}))
+}
+
+func (store *Store) snapshotHeader(ctx context.Context, tx bun.Tx) (*ledger.SnapshotHeader, error) {
+	// A restored ledger, on which nothing has been written yet, has the state of the snapshot it has been restored from
+	origin, err := store.getRestoredSnapshot(ctx, tx)
+	if err != nil && !sqlutils.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	header := &ledger.SnapshotHeader{
+		Version: ledger.SnapshotVersion,
+		Ledger:  store.name,
+		Date:    time.Now(),
+	}
+
+	lastLog := &Logs{}
+	err = tx.NewSelect().
+		Model(lastLog).
+		Column("id", "hash").
+		Where("ledger = ?", store.name).
+		OrderExpr("id desc").
+		Limit(1).
+		Scan(ctx)
+	switch {
+	case err == nil:
+		header.LastLogID = (*big.Int)(lastLog.ID)
+		header.LastLogHash = lastLog.Hash
+	case errors.Is(err, sql.ErrNoRows) && origin != nil:
+		header.LastLogID = origin.LastLogID
+		header.LastLogHash = origin.LastLogHash
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrNothingToSnapshot
+	default:
+		return nil, err
+	}
+
+	lastTransactionID := &bunpaginate.BigInt{}
+	err = tx.NewSelect().
+		TableExpr("transactions").
+		ColumnExpr("id").
+		Where("ledger = ?", store.name).
+		OrderExpr("id desc").
+		Limit(1).
+		Scan(ctx, lastTransactionID)
+	switch {
+	case err == nil:
+		header.LastTransactionID = (*big.Int)(lastTransactionID)
+	case errors.Is(err, sql.ErrNoRows) && origin != nil:
+		header.LastTransactionID = origin.LastTransactionID
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// snapshotAccountsAtQuery reads the accounts of a ledger as of a log.
+// The moves being inserted in the order of the logs, the volumes are the ones of the last moves of the transactions
+// created up to the log, along with the opening moves of a restored ledger. The metadata are folded from the logs,
+// on top of the metadata of the accounts restored from a snapshot.
+// Arguments: ledger, log id, seq of the last transaction created up to the log, seq of the last restored account.
+const snapshotAccountsAtQuery = `
+	with entries as (
+		select accounts.address, metadata.key, metadata.value, accounts.insertion_date as date, -1::numeric as log_id
+		from accounts
+		join accounts_metadata on accounts_metadata.accounts_seq = accounts.seq and accounts_metadata.revision = 1,
+		     jsonb_each(accounts_metadata.metadata) metadata
+		where accounts.ledger = ?0 and accounts.seq <= ?3
+		union all
+		select account.key, metadata.key, metadata.value, (logs.data -> 'transaction' ->> 'timestamp')::timestamp, logs.id
+		from logs,
+		     jsonb_each(logs.data -> 'accountMetadata') account,
+		     jsonb_each(account.value) metadata
+		where logs.ledger = ?0 and logs.id <= ?1 and logs.type = 'NEW_TRANSACTION'
+		union all
+		select logs.data ->> 'targetId', metadata.key, metadata.value, logs.date, logs.id
+		from logs,
+		     jsonb_each(logs.data -> 'metadata') metadata
+		where logs.ledger = ?0 and logs.id <= ?1 and logs.type = 'SET_METADATA' and logs.data ->> 'targetType' = 'ACCOUNT'
+		union all
+		select logs.data ->> 'targetId', logs.data ->> 'key', null::jsonb, logs.date, logs.id
+		from logs
+		where logs.ledger = ?0 and logs.id <= ?1 and logs.type = 'DELETE_METADATA' and logs.data ->> 'targetType' = 'ACCOUNT'
+	), metadata as (
+		select address, coalesce(jsonb_object_agg(key, value) filter (where value is not null), '{}'::jsonb) as metadata
+		from (
+			select distinct on (address, key) address, key, value
+			from entries
+			order by address, key, log_id desc
+		) latest
+		group by address
+	), metadata_usage as (
+		select address, min(date) as first_usage
+		from entries
+		where value is not null
+		group by address
+	), restored as (
+		select address, insertion_date as first_usage
+		from accounts
+		where ledger = ?0 and seq <= ?3
+	), volumes as (
+		select account_address as address,
+		       jsonb_object_agg(asset, jsonb_build_object(
+		           'input', (post_commit_volumes).inputs,
+		           'output', (post_commit_volumes).outputs
+		       )) as volumes
+		from (
+			select distinct on (account_address, asset) account_address, asset, post_commit_volumes
+			from moves
+			where ledger = ?0 and (transactions_seq is null or transactions_seq <= ?2)
+			order by account_address, asset, seq desc
+		) latest
+		group by account_address
+	), moves_usage as (
+		select account_address as address, min(effective_date) as first_usage
+		from moves
+		where ledger = ?0 and transactions_seq <= ?2
+		group by account_address
+	), addresses as (
+		select address from restored
+		union
+		select address from metadata_usage
+		union
+		select address from volumes
+	)
+	select addresses.address,
+	       coalesce(metadata.metadata, '{}'::jsonb) as metadata,
+	       least(restored.first_usage, metadata_usage.first_usage, moves_usage.first_usage) as first_usage,
+	       coalesce(volumes.volumes, '{}'::jsonb) as volumes
+	from addresses
+	left join metadata on metadata.address = addresses.address
+	left join metadata_usage on metadata_usage.address = addresses.address
+	left join restored on restored.address = addresses.address
+	left join volumes on volumes.address = addresses.address
+	left join moves_usage on moves_usage.address = addresses.address
+	order by addresses.address
+`
+
+// takeSnapshotAt reads the accounts of the ledger as of the log logID, which must be in the ledger,
+// or be the last log of the snapshot the ledger has been restored from.
+func (store *Store) takeSnapshotAt(ctx context.Context, tx bun.Tx, logID *big.Int,
+	headerFn func(header ledger.SnapshotHeader) error,
+	accountFn func(account ledger.SnapshotAccount) error,
+) error {
+	origin := &RestoredSnapshot{}
+	err := tx.NewSelect().
+		Model(origin).
+		Where("ledger = ?", store.name).
+		Scan(ctx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		origin = nil
+	case err != nil:
+		return err
+	case origin.LastAccountSeq == nil || logID.Cmp((*big.Int)(origin.LastLogID)) < 0:
+		return ErrSnapshotBeforeRestore
+	}
+
+	header := &ledger.SnapshotHeader{
+		Version: ledger.SnapshotVersion,
+		Ledger:  store.name,
+	}
+
+	log := &Logs{}
+	err = tx.NewSelect().
+		Model(log).
+		Column("id", "hash", "date").
+		Where("ledger = ?", store.name).
+		Where("id = ?", (*bunpaginate.BigInt)(logID)).
+		Scan(ctx)
+	switch {
+	case err == nil:
+		header.LastLogID = (*big.Int)(log.ID)
+		header.LastLogHash = log.Hash
+		header.Date = log.Date
+	case errors.Is(err, sql.ErrNoRows) && origin != nil && logID.Cmp((*big.Int)(origin.LastLogID)) == 0:
+		header.LastLogID = (*big.Int)(origin.LastLogID)
+		header.LastLogHash = origin.LastLogHash
+		header.Date = origin.Date
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrSnapshotLogNotFound
+	default:
+		return err
+	}
+
+	// The last transaction created up to the log bounds the moves of the snapshot
+	lastTransaction := struct {
+		ID  *bunpaginate.BigInt `bun:"id"`
+		Seq int64               `bun:"seq"`
+	}{}
+	err = tx.NewSelect().
+		TableExpr("transactions").
+		Column("id", "seq").
+		Where("ledger = ?", store.name).
+		Where(`id = (
+			select (data -> 'transaction' ->> 'id')::numeric
+			from logs
+			where ledger = ? and id <= ? and type in ('NEW_TRANSACTION', 'REVERTED_TRANSACTION')
+			order by id desc
+			limit 1
+		)`, store.name, (*bunpaginate.BigInt)(logID)).
+		Scan(ctx, &lastTransaction)
+	switch {
+	case err == nil:
+		header.LastTransactionID = (*big.Int)(lastTransaction.ID)
+	case errors.Is(err, sql.ErrNoRows) && origin != nil:
+		header.LastTransactionID = (*big.Int)(origin.LastTransactionID)
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	if err := headerFn(*header); err != nil {
+		return err
+	}
+
+	lastAccountSeq := int64(0)
+	if origin != nil {
+		lastAccountSeq = *origin.LastAccountSeq
+	}
+
+	rows, err := tx.QueryContext(ctx, snapshotAccountsAtQuery,
+		store.name, (*bunpaginate.BigInt)(logID), lastTransaction.Seq, lastAccountSeq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		account := snapshotAccount{}
+		if err := store.bucket.db.ScanRow(ctx, rows, &account); err != nil {
+			return err
+		}
+		if err := accountFn(ledger.SnapshotAccount{
+			Address:    account.Address,
+			Metadata:   account.Metadata,
+			FirstUsage: account.FirstUsage,
+			Volumes:    account.Volumes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// RestoreSnapshot initializes an empty ledger from a snapshot.
+// next is called until it returns io.EOF to read the accounts of the snapshot.
+// The volumes of the accounts are restored as opening moves, dated at the date of the snapshot.
+func (store *Store) RestoreSnapshot(ctx context.Context, header ledger.SnapshotHeader, next func() (*ledger.SnapshotAccount, error)) error {
+	return sqlutils.PostgresError(store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		exists, err := tx.NewSelect().
+			TableExpr("logs").
+			Where("ledger = ?", store.name).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrLedgerNotEmpty
+		}
+
+		if _, err := tx.NewInsert().
+			Model(&RestoredSnapshot{
+				Ledger:            store.name,
+				SourceLedger:      header.Ledger,
+				LastLogID:         (*bunpaginate.BigInt)(header.LastLogID),
+				LastLogHash:       header.LastLogHash,
+				LastTransactionID: (*bunpaginate.BigInt)(header.LastTransactionID),
+				Date:              header.Date,
+			}).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		batch := make([]ledger.SnapshotAccount, 0, snapshotPageSize)
+		for {
+			account, err := next()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+
+			batch = append(batch, *account)
+			if len(batch) == snapshotPageSize {
+				if err := store.insertSnapshotAccounts(ctx, tx, header.Date, batch); err != nil {
+					return err
+				}
+				batch = make([]ledger.SnapshotAccount, 0, snapshotPageSize)
+			}
+		}
+		if len(batch) > 0 {
+			if err := store.insertSnapshotAccounts(ctx, tx, header.Date, batch); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*RestoredSnapshot)(nil)).
+			Set("last_account_seq = (select coalesce(max(seq), 0) from accounts where ledger = ?)", store.name).
+			Where("ledger = ?", store.name).
+			Exec(ctx)
+		return err
+	}))
+}
+
+func (store *Store) insertSnapshotAccounts(ctx context.Context, tx bun.Tx, date time.Time, accounts []ledger.SnapshotAccount) error {
+	if _, err := tx.NewInsert().
+		Model(pointer.For(collectionutils.Map(accounts, func(from ledger.SnapshotAccount) snapshotAccount {
+			return snapshotAccount{
+				Ledger:        store.name,
+				Address:       from.Address,
+				AddressArray:  strings.Split(from.Address, ":"),
+				InsertionDate: from.FirstUsage,
+				UpdatedAt:     date,
+				FirstUsage:    from.FirstUsage,
+				Metadata: func() metadata.Metadata {
+					if from.Metadata == nil {
+						return metadata.Metadata{}
+					}
+					return from.Metadata
+				}(),
+			}
+		}))).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	type openingVolumes struct {
+		Address string   `json:"address"`
+		Asset   string   `json:"asset"`
+		Input   *big.Int `json:"input"`
+		Output  *big.Int `json:"output"`
+	}
+	volumes := make([]openingVolumes, 0)
+	for _, account := range accounts {
+		for asset, v := range account.Volumes {
+			volumes = append(volumes, openingVolumes{
+				Address: account.Address,
+				Asset:   asset,
+				Input:   v.Input,
+				Output:  v.Output,
+			})
+		}
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(volumes)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewRaw(`
+		insert into moves (ledger, accounts_seq, account_address, account_address_array, asset, amount, is_source,
+		                   insertion_date, effective_date, post_commit_volumes, post_commit_effective_volumes)
+		select accounts.ledger, accounts.seq, accounts.address, accounts.address_array, v.asset, 0, false,
+		       ?0, ?0, (v.input, v.output)::volumes, (v.input, v.output)::volumes
+		from jsonb_to_recordset(?1::jsonb) v(address varchar, asset varchar, input numeric, output numeric)
+		join accounts on accounts.ledger = ?2 and accounts.address = v.address
+	`, date, string(data), store.name).Exec(ctx)
+	return err
+}
@@ -0,0 +1,237 @@
+//go:build it
+
+package ledgerstore
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/metadata"
+	"github.com/formancehq/go-libs/time"
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	err := store.TakeSnapshot(ctx, nil, func(header ledger.SnapshotHeader) error {
+		return nil
+	}, func(account ledger.SnapshotAccount) error {
+		return nil
+	})
+	require.ErrorIs(t, err, ErrNothingToSnapshot)
+
+	logs := ledger.ChainLogs(
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))).
+				WithDate(now),
+			map[string]metadata.Metadata{
+				"bank": {
+					"category": "1",
+				},
+			},
+		).WithDate(now),
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("bank", "users:1", "USD", big.NewInt(10))).
+				WithIDUint64(1).
+				WithDate(now),
+			map[string]metadata.Metadata{},
+		).WithDate(now),
+	)
+	require.NoError(t, store.InsertLogs(ctx, logs...))
+
+	var header ledger.SnapshotHeader
+	accounts := make([]ledger.SnapshotAccount, 0)
+	require.NoError(t, store.TakeSnapshot(ctx, nil, func(h ledger.SnapshotHeader) error {
+		header = h
+		return nil
+	}, func(account ledger.SnapshotAccount) error {
+		accounts = append(accounts, account)
+		return nil
+	}))
+	require.Equal(t, logs[1].ID, header.LastLogID)
+	require.Equal(t, logs[1].Hash, header.LastLogHash)
+	require.Equal(t, big.NewInt(1), header.LastTransactionID)
+	require.Len(t, accounts, 3)
+
+	restored, err := store.bucket.CreateLedgerStore(uuid.NewString())
+	require.NoError(t, err)
+
+	_, err = restored.GetRestoredSnapshot(ctx)
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	i := 0
+	require.NoError(t, restored.RestoreSnapshot(ctx, header, func() (*ledger.SnapshotAccount, error) {
+		if i == len(accounts) {
+			return nil, io.EOF
+		}
+		i++
+		return &accounts[i-1], nil
+	}))
+
+	origin, err := restored.GetRestoredSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, header.LastLogID, origin.LastLogID)
+	require.Equal(t, header.LastLogHash, origin.LastLogHash)
+	require.Equal(t, header.LastTransactionID, origin.LastTransactionID)
+
+	bank, err := restored.GetAccountWithVolumes(ctx, NewGetAccountQuery("bank").WithExpandVolumes())
+	require.NoError(t, err)
+	require.Equal(t, metadata.Metadata{"category": "1"}, bank.Metadata)
+	require.Equal(t, ledger.VolumesByAssets{
+		"USD": ledger.NewVolumesInt64(100, 10),
+	}, bank.Volumes)
+
+	// New transactions are applied on top of the restored volumes
+	require.NoError(t, restored.InsertLogs(ctx,
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("bank", "users:1", "USD", big.NewInt(20))).
+				WithIDUint64(2).
+				WithDate(now.Add(time.Minute)),
+			map[string]metadata.Metadata{},
+		).ChainLog(origin.LastLog()),
+	))
+
+	user, err := restored.GetAccountWithVolumes(ctx, NewGetAccountQuery("users:1").WithExpandVolumes())
+	require.NoError(t, err)
+	require.Equal(t, ledger.VolumesByAssets{
+		"USD": ledger.NewVolumesInt64(30, 0),
+	}, user.Volumes)
+
+	// The opening moves are part of the history of the accounts
+	history, err := restored.GetAccountHistory(ctx, NewGetAccountHistoryQuery(NewPaginatedQueryOptions(AccountHistoryFilters{
+		Address: "users:1",
+	})))
+	require.NoError(t, err)
+	require.Len(t, history.Data, 2)
+	require.Equal(t, big.NewInt(2), history.Data[0].Move.TransactionID)
+	require.Nil(t, history.Data[1].Move.TransactionID)
+	require.Equal(t, ledger.NewVolumesInt64(10, 0), &history.Data[1].Move.PostCommitVolumes)
+
+	// The restored ledger can be snapshotted again
+	require.NoError(t, restored.TakeSnapshot(ctx, nil, func(h ledger.SnapshotHeader) error {
+		require.Equal(t, big.NewInt(2), h.LastTransactionID)
+		require.Equal(t, big.NewInt(2), h.LastLogID)
+		return nil
+	}, func(account ledger.SnapshotAccount) error {
+		return nil
+	}))
+
+	// A ledger can only be restored once
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, header, func() (*ledger.SnapshotAccount, error) {
+		return nil, io.EOF
+	}), ErrLedgerNotEmpty)
+}
+
+func TestSnapshotAtLog(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	logs := ledger.ChainLogs(
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))).
+				WithDate(now),
+			map[string]metadata.Metadata{
+				"bank": {
+					"category": "1",
+				},
+			},
+		).WithDate(now),
+		ledger.NewSetMetadataOnAccountLog(now, "bank", metadata.Metadata{"tier": "gold"}),
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("bank", "users:1", "USD", big.NewInt(10))).
+				WithIDUint64(1).
+				WithDate(now.Add(time.Minute)),
+			map[string]metadata.Metadata{},
+		).WithDate(now.Add(time.Minute)),
+		ledger.NewDeleteMetadataLog(now.Add(time.Minute), ledger.DeleteMetadataLogPayload{
+			TargetType: ledger.MetaTargetTypeAccount,
+			TargetID:   "bank",
+			Key:        "category",
+		}),
+		ledger.NewTransactionLog(
+			ledger.NewTransaction().
+				WithPostings(ledger.NewPosting("world", "users:2", "USD", big.NewInt(5))).
+				WithIDUint64(2).
+				WithDate(now.Add(2*time.Minute)),
+			map[string]metadata.Metadata{},
+		).WithDate(now.Add(2*time.Minute)),
+	)
+	require.NoError(t, store.InsertLogs(ctx, logs...))
+
+	takeSnapshot := func(store *Store, logID *big.Int) (ledger.SnapshotHeader, []ledger.SnapshotAccount, error) {
+		var header ledger.SnapshotHeader
+		accounts := make([]ledger.SnapshotAccount, 0)
+		err := store.TakeSnapshot(ctx, logID, func(h ledger.SnapshotHeader) error {
+			header = h
+			return nil
+		}, func(account ledger.SnapshotAccount) error {
+			accounts = append(accounts, account)
+			return nil
+		})
+		return header, accounts, err
+	}
+
+	header, accounts, err := takeSnapshot(store, big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, logs[2].ID, header.LastLogID)
+	require.Equal(t, logs[2].Hash, header.LastLogHash)
+	require.Equal(t, big.NewInt(1), header.LastTransactionID)
+	require.Len(t, accounts, 3)
+	require.Equal(t, "bank", accounts[0].Address)
+	require.Equal(t, metadata.Metadata{"category": "1", "tier": "gold"}, accounts[0].Metadata)
+	require.Equal(t, ledger.VolumesByAssets{"USD": ledger.NewVolumesInt64(100, 10)}, accounts[0].Volumes)
+	require.Equal(t, "users:1", accounts[1].Address)
+	require.Equal(t, ledger.VolumesByAssets{"USD": ledger.NewVolumesInt64(10, 0)}, accounts[1].Volumes)
+	require.Equal(t, "world", accounts[2].Address)
+	require.Equal(t, ledger.VolumesByAssets{"USD": ledger.NewVolumesInt64(0, 100)}, accounts[2].Volumes)
+
+	_, accounts, err = takeSnapshot(store, big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, metadata.Metadata{"tier": "gold"}, accounts[0].Metadata)
+
+	_, _, err = takeSnapshot(store, big.NewInt(10))
+	require.ErrorIs(t, err, ErrSnapshotLogNotFound)
+
+	// A ledger restored from the snapshot can be snapshotted from the restored log
+	header, accounts, err = takeSnapshot(store, big.NewInt(2))
+	require.NoError(t, err)
+
+	restored, err := store.bucket.CreateLedgerStore(uuid.NewString())
+	require.NoError(t, err)
+
+	i := 0
+	require.NoError(t, restored.RestoreSnapshot(ctx, header, func() (*ledger.SnapshotAccount, error) {
+		if i == len(accounts) {
+			return nil, io.EOF
+		}
+		i++
+		return &accounts[i-1], nil
+	}))
+
+	restoredHeader, restoredAccounts, err := takeSnapshot(restored, big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, header.LastLogHash, restoredHeader.LastLogHash)
+	require.Equal(t, header.LastTransactionID, restoredHeader.LastTransactionID)
+	require.Len(t, restoredAccounts, 3)
+	require.Equal(t, accounts[0].Metadata, restoredAccounts[0].Metadata)
+	require.Equal(t, accounts[0].Volumes, restoredAccounts[0].Volumes)
+
+	_, _, err = takeSnapshot(restored, big.NewInt(1))
+	require.ErrorIs(t, err, ErrSnapshotBeforeRestore)
+}
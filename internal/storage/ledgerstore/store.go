@@ -2,13 +2,35 @@ package ledgerstore
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/formancehq/go-libs/migrations"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/uptrace/bun"
 )
 
+// ledgerTables lists the tables of the bucket holding rows of the ledgers,
+// ordered so that rows are deleted before the rows they reference.
+var ledgerTables = []string{
+	"moves",
+	"transactions_metadata",
+	"accounts_metadata",
+	"transactions",
+	"accounts",
+	"logs",
+	"scheduled_transactions",
+	"restored_snapshots",
+	"import_jobs",
+	"closed_periods",
+	"account_policies",
+	"account_freezes",
+	"account_freeze_events",
+	"charts",
+	"outbox",
+}
+
 type Store struct {
 	bucket *Bucket
 
@@ -31,6 +53,21 @@ func (store *Store) GetMigrationsInfo(ctx context.Context) ([]migrations.Info, e
 	return store.bucket.GetMigrationsInfo(ctx)
 }
 
+// Purge deletes all the rows of the ledger from the bucket.
+func (store *Store) Purge(ctx context.Context) error {
+	return sqlutils.PostgresError(store.bucket.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		for _, table := range ledgerTables {
+			if _, err := tx.NewDelete().
+				TableExpr(table).
+				Where("ledger = ?", store.name).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
 func New(
 	bucket *Bucket,
 	name string,
@@ -16,6 +16,10 @@ import (
 const (
 	StateInitializing = "initializing"
 	StateInUse        = "in-use"
+	// StateReadOnly ledgers are served but reject writes
+	StateReadOnly = "read-only"
+	// StateArchived ledgers keep their rows in their bucket but are not served anymore
+	StateArchived = "archived"
 )
 
 type Ledger struct {
@@ -25,7 +29,7 @@ type Ledger struct {
 	AddedAt  time.Time         `bun:"addedat,type:timestamp" json:"addedAt"`
 	Bucket   string            `bun:"bucket,type:varchar(255)" json:"bucket"`
 	Metadata map[string]string `bun:"metadata,type:jsonb" json:"metadata"`
-	State    string            `bun:"state,type:varchar(255)" json:"-"`
+	State    string            `bun:"state,type:varchar(255)" json:"state"`
 }
 
 type PaginatedQueryOptions struct {
@@ -53,11 +57,19 @@ func (s *Store) ListLedgers(ctx context.Context, q ListLedgersQuery) (*bunpagina
 	return bunpaginate.UsingOffset[PaginatedQueryOptions, Ledger](ctx, query, bunpaginate.OffsetPaginatedQuery[PaginatedQueryOptions](q))
 }
 
+// DeleteLedger unregisters the ledger, along with its webhooks.
 func (s *Store) DeleteLedger(ctx context.Context, name string) error {
-	_, err := s.db.NewDelete().
-		Model((*Ledger)(nil)).
-		Where("ledger = ?", name).
-		Exec(ctx)
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, model := range []any{(*WebhookDeadLetter)(nil), (*Webhook)(nil), (*Ledger)(nil)} {
+			if _, err := tx.NewDelete().
+				Model(model).
+				Where("ledger = ?", name).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 
 	return errors.Wrap(sqlutils.PostgresError(err), "delete ledger from system store")
 }
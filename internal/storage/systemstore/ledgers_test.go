@@ -14,6 +14,7 @@ import (
 	"github.com/formancehq/go-libs/bun/bunpaginate"
 
 	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -119,3 +120,33 @@ func TestDeleteLedgerMetadata(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, map[string]string{}, ledgerFromDB.Metadata)
 }
+
+func TestDeleteLedger(t *testing.T) {
+	ctx := logging.TestingContext()
+	store := newSystemStore(t)
+
+	ledger := &Ledger{
+		Name:    uuid.NewString(),
+		AddedAt: time.Now(),
+	}
+	_, err := store.RegisterLedger(ctx, ledger)
+	require.NoError(t, err)
+
+	webhook := &Webhook{
+		ID:        uuid.NewString(),
+		Ledger:    ledger.Name,
+		Endpoint:  "http://localhost",
+		Secret:    "secret",
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, store.InsertWebhook(ctx, webhook))
+
+	require.NoError(t, store.DeleteLedger(ctx, ledger.Name))
+
+	_, err = store.GetLedger(ctx, ledger.Name)
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	_, err = store.GetWebhook(ctx, ledger.Name, webhook.ID)
+	require.True(t, sqlutils.IsNotFoundError(err))
+}
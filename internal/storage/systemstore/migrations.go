@@ -122,6 +122,62 @@ func Migrate(ctx context.Context, db bun.IDB) error {
 				return nil
 			},
 		},
+		migrations.Migration{
+			Name: "Add webhooks",
+			UpWithContext: func(ctx context.Context, tx bun.Tx) error {
+				_, err := tx.ExecContext(ctx, `
+					create table if not exists webhooks (
+						id varchar primary key,
+						ledger varchar(63) not null,
+						endpoint varchar not null,
+						secret varchar not null,
+						event_types varchar[] not null default '{}',
+						active boolean not null default true,
+						created_at timestamp without time zone not null
+					);
+
+					create index if not exists webhooks_ledger on webhooks (ledger);
+
+					create table if not exists webhook_attempts (
+						id varchar primary key,
+						webhook_id varchar not null references webhooks (id) on delete cascade,
+						ledger varchar(63) not null,
+						event_id varchar not null,
+						event_type varchar not null,
+						payload jsonb not null,
+						status varchar not null,
+						attempts integer not null default 0,
+						next_retry_at timestamp without time zone not null,
+						last_status_code integer,
+						last_error varchar,
+						created_at timestamp without time zone not null,
+						updated_at timestamp without time zone not null
+					);
+
+					create index if not exists webhook_attempts_pending on webhook_attempts (next_retry_at) where status = 'pending';
+					create index if not exists webhook_attempts_webhook on webhook_attempts (webhook_id, created_at);
+
+					create table if not exists webhook_dead_letters (
+						id varchar primary key,
+						webhook_id varchar not null,
+						ledger varchar(63) not null,
+						event_id varchar not null,
+						event_type varchar not null,
+						payload jsonb not null,
+						attempts integer not null,
+						last_status_code integer,
+						last_error varchar,
+						created_at timestamp without time zone not null
+					);
+
+					create index if not exists webhook_dead_letters_webhook on webhook_dead_letters (webhook_id, created_at);
+				`)
+				if err != nil {
+					return err
+				}
+				return nil
+			},
+		},
 	)
 	return migrator.Up(ctx, db)
 }
@@ -0,0 +1,270 @@
+package systemstore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/formancehq/go-libs/bun/bunpaginate"
+	"github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+const (
+	WebhookAttemptStatusPending   = "pending"
+	WebhookAttemptStatusSucceeded = "succeeded"
+	WebhookAttemptStatusFailed    = "failed"
+)
+
+type Webhook struct {
+	bun.BaseModel `bun:"_system.webhooks,alias:webhooks"`
+
+	ID         string    `bun:"id,type:varchar,pk" json:"id"`
+	Ledger     string    `bun:"ledger,type:varchar(63)" json:"ledger"`
+	Endpoint   string    `bun:"endpoint,type:varchar" json:"endpoint"`
+	Secret     string    `bun:"secret,type:varchar" json:"-"`
+	EventTypes []string  `bun:"event_types,type:varchar[],array" json:"eventTypes"`
+	Active     bool      `bun:"active,type:boolean" json:"active"`
+	CreatedAt  time.Time `bun:"created_at,type:timestamp without time zone" json:"createdAt"`
+}
+
+// Accepts returns true if the webhook is subscribed to the event type.
+// A webhook without event types is subscribed to all events.
+func (w Webhook) Accepts(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookAttempt tracks the delivery of an event to a webhook, across retries.
+type WebhookAttempt struct {
+	bun.BaseModel `bun:"_system.webhook_attempts,alias:webhook_attempts"`
+
+	ID             string          `bun:"id,type:varchar,pk" json:"id"`
+	WebhookID      string          `bun:"webhook_id,type:varchar" json:"webhookId"`
+	Ledger         string          `bun:"ledger,type:varchar(63)" json:"ledger"`
+	EventID        string          `bun:"event_id,type:varchar" json:"eventId"`
+	EventType      string          `bun:"event_type,type:varchar" json:"eventType"`
+	Payload        json.RawMessage `bun:"payload,type:jsonb" json:"payload"`
+	Status         string          `bun:"status,type:varchar" json:"status"`
+	Attempts       int             `bun:"attempts,type:integer" json:"attempts"`
+	NextRetryAt    time.Time       `bun:"next_retry_at,type:timestamp without time zone" json:"nextRetryAt"`
+	LastStatusCode int             `bun:"last_status_code,type:integer,nullzero" json:"lastStatusCode,omitempty"`
+	LastError      string          `bun:"last_error,type:varchar,nullzero" json:"lastError,omitempty"`
+	CreatedAt      time.Time       `bun:"created_at,type:timestamp without time zone" json:"createdAt"`
+	UpdatedAt      time.Time       `bun:"updated_at,type:timestamp without time zone" json:"updatedAt"`
+}
+
+// WebhookDeadLetter is a delivery which exhausted its retries.
+type WebhookDeadLetter struct {
+	bun.BaseModel `bun:"_system.webhook_dead_letters,alias:webhook_dead_letters"`
+
+	ID             string          `bun:"id,type:varchar,pk" json:"id"`
+	WebhookID      string          `bun:"webhook_id,type:varchar" json:"webhookId"`
+	Ledger         string          `bun:"ledger,type:varchar(63)" json:"ledger"`
+	EventID        string          `bun:"event_id,type:varchar" json:"eventId"`
+	EventType      string          `bun:"event_type,type:varchar" json:"eventType"`
+	Payload        json.RawMessage `bun:"payload,type:jsonb" json:"payload"`
+	Attempts       int             `bun:"attempts,type:integer" json:"attempts"`
+	LastStatusCode int             `bun:"last_status_code,type:integer,nullzero" json:"lastStatusCode,omitempty"`
+	LastError      string          `bun:"last_error,type:varchar,nullzero" json:"lastError,omitempty"`
+	CreatedAt      time.Time       `bun:"created_at,type:timestamp without time zone" json:"createdAt"`
+}
+
+type ListWebhooksQuery bunpaginate.OffsetPaginatedQuery[PaginatedQueryOptions]
+
+func NewListWebhooksQuery(pageSize uint64) ListWebhooksQuery {
+	return ListWebhooksQuery{
+		PageSize: pageSize,
+	}
+}
+
+type ListWebhookAttemptsQuery bunpaginate.OffsetPaginatedQuery[PaginatedQueryOptions]
+
+func NewListWebhookAttemptsQuery(pageSize uint64) ListWebhookAttemptsQuery {
+	return ListWebhookAttemptsQuery{
+		PageSize: pageSize,
+	}
+}
+
+func (s *Store) InsertWebhook(ctx context.Context, webhook *Webhook) error {
+	if webhook.EventTypes == nil {
+		webhook.EventTypes = []string{}
+	}
+	_, err := s.db.NewInsert().
+		Model(webhook).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+func (s *Store) UpdateWebhook(ctx context.Context, webhook *Webhook) error {
+	if webhook.EventTypes == nil {
+		webhook.EventTypes = []string{}
+	}
+	ret, err := s.db.NewUpdate().
+		Model(webhook).
+		Column("endpoint", "secret", "event_types", "active").
+		Where("ledger = ?", webhook.Ledger).
+		Where("id = ?", webhook.ID).
+		Exec(ctx)
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+
+	affected, err := ret.RowsAffected()
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+	if affected == 0 {
+		return sqlutils.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, ledger, id string) error {
+	ret, err := s.db.NewDelete().
+		Model((*Webhook)(nil)).
+		Where("ledger = ?", ledger).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return errors.Wrap(sqlutils.PostgresError(err), "delete webhook from system store")
+	}
+
+	affected, err := ret.RowsAffected()
+	if err != nil {
+		return sqlutils.PostgresError(err)
+	}
+	if affected == 0 {
+		return sqlutils.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) GetWebhook(ctx context.Context, ledger, id string) (*Webhook, error) {
+	ret := &Webhook{}
+	if err := s.db.NewSelect().
+		Model(ret).
+		Where("ledger = ?", ledger).
+		Where("id = ?", id).
+		Scan(ctx); err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Store) ListWebhooks(ctx context.Context, ledger string, q ListWebhooksQuery) (*bunpaginate.Cursor[Webhook], error) {
+	query := s.db.NewSelect().
+		Where("ledger = ?", ledger).
+		Order("created_at asc", "id asc")
+
+	return bunpaginate.UsingOffset[PaginatedQueryOptions, Webhook](ctx, query, bunpaginate.OffsetPaginatedQuery[PaginatedQueryOptions](q))
+}
+
+// GetWebhooksForEvent returns the active webhooks of the ledger subscribed to the event type.
+func (s *Store) GetWebhooksForEvent(ctx context.Context, ledger, eventType string) ([]Webhook, error) {
+	ret := make([]Webhook, 0)
+	if err := s.db.NewSelect().
+		Model(&ret).
+		Where("ledger = ?", ledger).
+		Where("active").
+		Where("(cardinality(event_types) = 0 or ? = any(event_types))", eventType).
+		Scan(ctx); err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Store) InsertWebhookAttempts(ctx context.Context, attempts ...WebhookAttempt) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+	_, err := s.db.NewInsert().
+		Model(&attempts).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+// ClaimWebhookAttempts returns, oldest first, at most limit pending attempts due before date.
+// The claimed attempts are postponed by lease, so they are not claimed again,
+// by this instance or another one, while being delivered.
+func (s *Store) ClaimWebhookAttempts(ctx context.Context, date time.Time, lease time.Duration, limit int) ([]WebhookAttempt, error) {
+	ret := make([]WebhookAttempt, 0)
+	if err := s.db.NewRaw(`
+		update _system.webhook_attempts
+		set next_retry_at = ?
+		where id in (
+			select id
+			from _system.webhook_attempts
+			where status = ? and next_retry_at <= ?
+			order by next_retry_at asc
+			limit ?
+			for update skip locked
+		)
+		returning *`,
+		date.Add(lease), WebhookAttemptStatusPending, date, limit,
+	).Scan(ctx, &ret); err != nil {
+		return nil, sqlutils.PostgresError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Store) UpdateWebhookAttempt(ctx context.Context, attempt WebhookAttempt) error {
+	_, err := s.db.NewUpdate().
+		Model(&attempt).
+		Column("status", "attempts", "next_retry_at", "last_status_code", "last_error", "updated_at").
+		Where("id = ?", attempt.ID).
+		Exec(ctx)
+	return sqlutils.PostgresError(err)
+}
+
+// DeadLetterWebhookAttempt marks the attempt as failed and copies it to the dead letters.
+func (s *Store) DeadLetterWebhookAttempt(ctx context.Context, attempt WebhookAttempt) error {
+	attempt.Status = WebhookAttemptStatusFailed
+	return sqlutils.PostgresError(s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(&attempt).
+			Column("status", "attempts", "last_status_code", "last_error", "updated_at").
+			Where("id = ?", attempt.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().
+			Model(&WebhookDeadLetter{
+				ID:             attempt.ID,
+				WebhookID:      attempt.WebhookID,
+				Ledger:         attempt.Ledger,
+				EventID:        attempt.EventID,
+				EventType:      attempt.EventType,
+				Payload:        attempt.Payload,
+				Attempts:       attempt.Attempts,
+				LastStatusCode: attempt.LastStatusCode,
+				LastError:      attempt.LastError,
+				CreatedAt:      attempt.UpdatedAt,
+			}).
+			On("conflict (id) do nothing").
+			Exec(ctx)
+		return err
+	}))
+}
+
+func (s *Store) ListWebhookAttempts(ctx context.Context, ledger, webhookID string, q ListWebhookAttemptsQuery) (*bunpaginate.Cursor[WebhookAttempt], error) {
+	query := s.db.NewSelect().
+		Where("ledger = ?", ledger).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at desc", "id desc")
+
+	return bunpaginate.UsingOffset[PaginatedQueryOptions, WebhookAttempt](ctx, query, bunpaginate.OffsetPaginatedQuery[PaginatedQueryOptions](q))
+}
@@ -0,0 +1,158 @@
+//go:build it
+
+package systemstore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	ctx := logging.TestingContext()
+	store := newSystemStore(t)
+	now := time.Now()
+
+	all := Webhook{
+		ID:        "all",
+		Ledger:    "ledger0",
+		Endpoint:  "https://example.com/all",
+		Secret:    "secret",
+		Active:    true,
+		CreatedAt: now,
+	}
+	require.NoError(t, store.InsertWebhook(ctx, &all))
+
+	reverts := Webhook{
+		ID:         "reverts",
+		Ledger:     "ledger0",
+		Endpoint:   "https://example.com/reverts",
+		Secret:     "secret",
+		EventTypes: []string{"REVERTED_TRANSACTION"},
+		Active:     true,
+		CreatedAt:  now.Add(time.Second),
+	}
+	require.NoError(t, store.InsertWebhook(ctx, &reverts))
+
+	other := Webhook{
+		ID:        "other",
+		Ledger:    "ledger1",
+		Endpoint:  "https://example.com/other",
+		Secret:    "secret",
+		Active:    true,
+		CreatedAt: now,
+	}
+	require.NoError(t, store.InsertWebhook(ctx, &other))
+
+	webhook, err := store.GetWebhook(ctx, "ledger0", "reverts")
+	require.NoError(t, err)
+	require.Equal(t, reverts, *webhook)
+
+	_, err = store.GetWebhook(ctx, "ledger1", "reverts")
+	require.True(t, sqlutils.IsNotFoundError(err))
+
+	cursor, err := store.ListWebhooks(ctx, "ledger0", NewListWebhooksQuery(10))
+	require.NoError(t, err)
+	require.Equal(t, []Webhook{all, reverts}, cursor.Data)
+
+	webhooks, err := store.GetWebhooksForEvent(ctx, "ledger0", "COMMITTED_TRANSACTIONS")
+	require.NoError(t, err)
+	require.Equal(t, []Webhook{all}, webhooks)
+
+	webhooks, err = store.GetWebhooksForEvent(ctx, "ledger0", "REVERTED_TRANSACTION")
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+
+	all.Active = false
+	require.NoError(t, store.UpdateWebhook(ctx, &all))
+	webhooks, err = store.GetWebhooksForEvent(ctx, "ledger0", "COMMITTED_TRANSACTIONS")
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+
+	require.NoError(t, store.DeleteWebhook(ctx, "ledger1", "other"))
+	require.True(t, sqlutils.IsNotFoundError(store.DeleteWebhook(ctx, "ledger1", "other")))
+}
+
+func TestWebhookAttempts(t *testing.T) {
+	ctx := logging.TestingContext()
+	store := newSystemStore(t)
+	now := time.Now()
+
+	webhook := Webhook{
+		ID:        "webhook",
+		Ledger:    "ledger0",
+		Endpoint:  "https://example.com",
+		Secret:    "secret",
+		Active:    true,
+		CreatedAt: now,
+	}
+	require.NoError(t, store.InsertWebhook(ctx, &webhook))
+
+	newAttempt := func(id string, nextRetryAt time.Time) WebhookAttempt {
+		return WebhookAttempt{
+			ID:          id,
+			WebhookID:   webhook.ID,
+			Ledger:      webhook.Ledger,
+			EventID:     id,
+			EventType:   "COMMITTED_TRANSACTIONS",
+			Payload:     json.RawMessage(`{"type": "COMMITTED_TRANSACTIONS"}`),
+			Status:      WebhookAttemptStatusPending,
+			NextRetryAt: nextRetryAt,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	}
+	require.NoError(t, store.InsertWebhookAttempts(ctx,
+		newAttempt("due", now.Add(-time.Minute)),
+		newAttempt("later", now.Add(time.Minute)),
+	))
+
+	claimed, err := store.ClaimWebhookAttempts(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "due", claimed[0].ID)
+
+	// The claimed attempt is leased and cannot be claimed again
+	claimed, err = store.ClaimWebhookAttempts(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	attempt := newAttempt("due", now)
+	attempt.Status = WebhookAttemptStatusSucceeded
+	attempt.Attempts = 1
+	attempt.LastStatusCode = 200
+	require.NoError(t, store.UpdateWebhookAttempt(ctx, attempt))
+
+	attempt = newAttempt("later", now)
+	attempt.Attempts = 10
+	attempt.LastStatusCode = 500
+	attempt.LastError = "unexpected status code 500"
+	require.NoError(t, store.DeadLetterWebhookAttempt(ctx, attempt))
+
+	claimed, err = store.ClaimWebhookAttempts(ctx, now.Add(time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	cursor, err := store.ListWebhookAttempts(ctx, webhook.Ledger, webhook.ID, NewListWebhookAttemptsQuery(10))
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 2)
+
+	statuses := map[string]string{}
+	for _, attempt := range cursor.Data {
+		statuses[attempt.ID] = attempt.Status
+	}
+	require.Equal(t, map[string]string{
+		"due":   WebhookAttemptStatusSucceeded,
+		"later": WebhookAttemptStatusFailed,
+	}, statuses)
+
+	deadLetters := make([]WebhookDeadLetter, 0)
+	require.NoError(t, store.DB().NewSelect().Model(&deadLetters).Scan(ctx))
+	require.Len(t, deadLetters, 1)
+	require.Equal(t, "later", deadLetters[0].ID)
+	require.Equal(t, 10, deadLetters[0].Attempts)
+}
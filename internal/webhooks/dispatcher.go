@@ -0,0 +1,242 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/logging"
+	libtime "github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	attemptsBatchSize = 20
+	// maxErrorLength bounds the part of the response body recorded on failed attempts.
+	maxErrorLength = 512
+)
+
+type Configuration struct {
+	PollInterval time.Duration
+	MaxAttempts  int
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+}
+
+var DefaultConfiguration = Configuration{
+	PollInterval: time.Second,
+	MaxAttempts:  10,
+	MinBackoff:   time.Second,
+	MaxBackoff:   time.Hour,
+	Timeout:      10 * time.Second,
+}
+
+// Backoff returns the delay before retrying a delivery which failed attempts times.
+func (c Configuration) Backoff(attempts int) time.Duration {
+	delay := c.MinBackoff
+	for i := 1; i < attempts && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	return delay
+}
+
+// event holds the fields of the published events needed to route them to webhooks.
+type event struct {
+	Type    string `json:"type"`
+	Payload struct {
+		Ledger string `json:"ledger"`
+	} `json:"payload"`
+}
+
+// Dispatcher records the events to deliver to each subscribed webhook,
+// then delivers them in background, retrying failed deliveries with an exponential backoff.
+// Deliveries exhausting their attempts are moved to the dead letters.
+type Dispatcher struct {
+	store         Store
+	configuration Configuration
+	httpClient    *http.Client
+	wakeUp        chan struct{}
+	stopChan      chan chan struct{}
+}
+
+// Enqueue records a delivery of the message for each webhook subscribed to it.
+func (d *Dispatcher) Enqueue(ctx context.Context, msg *message.Message) error {
+	ev := event{}
+	if err := json.Unmarshal(msg.Payload, &ev); err != nil {
+		return errors.Wrap(err, "decoding event")
+	}
+	if ev.Payload.Ledger == "" {
+		return nil
+	}
+
+	webhooks, err := d.store.GetWebhooksForEvent(ctx, ev.Payload.Ledger, ev.Type)
+	if err != nil {
+		return errors.Wrap(err, "listing webhooks")
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := libtime.Now()
+	attempts := make([]systemstore.WebhookAttempt, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		attempts = append(attempts, systemstore.WebhookAttempt{
+			ID:          uuid.NewString(),
+			WebhookID:   webhook.ID,
+			Ledger:      webhook.Ledger,
+			EventID:     msg.UUID,
+			EventType:   ev.Type,
+			Payload:     json.RawMessage(msg.Payload),
+			Status:      systemstore.WebhookAttemptStatusPending,
+			NextRetryAt: now,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+	if err := d.store.InsertWebhookAttempts(ctx, attempts...); err != nil {
+		return errors.Wrap(err, "inserting webhook attempts")
+	}
+
+	select {
+	case d.wakeUp <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.configuration.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case done := <-d.stopChan:
+			close(done)
+			return
+		case <-ticker.C:
+		case <-d.wakeUp:
+		}
+		if err := d.deliverDueAttempts(ctx); err != nil {
+			logging.FromContext(ctx).Errorf("delivering webhooks: %s", err)
+		}
+	}
+}
+
+func (d *Dispatcher) Close() {
+	done := make(chan struct{})
+	d.stopChan <- done
+	<-done
+}
+
+func (d *Dispatcher) deliverDueAttempts(ctx context.Context) error {
+	for {
+		// The lease prevents the attempts from being claimed again while delivered,
+		// it must cover the deliveries of the whole batch.
+		lease := d.configuration.Timeout*attemptsBatchSize + d.configuration.MinBackoff
+		attempts, err := d.store.ClaimWebhookAttempts(ctx, libtime.Now(), lease, attemptsBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "claiming webhook attempts")
+		}
+
+		for _, attempt := range attempts {
+			if err := d.deliver(ctx, attempt); err != nil {
+				return err
+			}
+		}
+
+		if len(attempts) < attemptsBatchSize {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, attempt systemstore.WebhookAttempt) error {
+	attempt.Attempts++
+	attempt.UpdatedAt = libtime.Now()
+
+	webhook, err := d.store.GetWebhook(ctx, attempt.Ledger, attempt.WebhookID)
+	switch {
+	case sqlutils.IsNotFoundError(err):
+		// The webhook has been deleted concurrently, its attempts are deleted with it
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "getting webhook")
+	case !webhook.Active:
+		attempt.LastStatusCode = 0
+		attempt.LastError = "webhook disabled"
+		return errors.Wrap(d.store.DeadLetterWebhookAttempt(ctx, attempt), "dead lettering webhook attempt")
+	}
+
+	attempt.LastStatusCode, err = d.send(ctx, *webhook, attempt)
+	if err == nil {
+		attempt.Status = systemstore.WebhookAttemptStatusSucceeded
+		attempt.LastError = ""
+		return errors.Wrap(d.store.UpdateWebhookAttempt(ctx, attempt), "updating webhook attempt")
+	}
+	attempt.LastError = err.Error()
+
+	if attempt.Attempts >= d.configuration.MaxAttempts {
+		logging.FromContext(ctx).Errorf("webhook %s: delivery of event %s failed %d times, moving to dead letters",
+			webhook.ID, attempt.EventID, attempt.Attempts)
+		return errors.Wrap(d.store.DeadLetterWebhookAttempt(ctx, attempt), "dead lettering webhook attempt")
+	}
+
+	attempt.NextRetryAt = attempt.UpdatedAt.Add(d.configuration.Backoff(attempt.Attempts))
+	return errors.Wrap(d.store.UpdateWebhookAttempt(ctx, attempt), "updating webhook attempt")
+}
+
+// send posts the payload to the webhook endpoint, signed with the webhook secret.
+// Any non 2xx status code is an error.
+func (d *Dispatcher) send(ctx context.Context, webhook systemstore.Webhook, attempt systemstore.WebhookAttempt) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.configuration.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Endpoint, bytes.NewReader(attempt.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	now := libtime.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, attempt.ID)
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(now.Unix()))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, attempt.ID, now, attempt.Payload))
+
+	rsp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(rsp.Body, maxErrorLength))
+		return rsp.StatusCode, fmt.Errorf("unexpected status code %d: %s", rsp.StatusCode, body)
+	}
+
+	return rsp.StatusCode, nil
+}
+
+func NewDispatcher(store Store, httpClient *http.Client, configuration Configuration) *Dispatcher {
+	return &Dispatcher{
+		store:         store,
+		configuration: configuration,
+		httpClient:    httpClient,
+		wakeUp:        make(chan struct{}, 1),
+		stopChan:      make(chan chan struct{}),
+	}
+}
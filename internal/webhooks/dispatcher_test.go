@@ -0,0 +1,267 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/publish"
+	libtime "github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/sqlutils"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"github.com/formancehq/ledger/pkg/events"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemoryStore struct {
+	mu          sync.Mutex
+	webhooks    []systemstore.Webhook
+	attempts    []systemstore.WebhookAttempt
+	deadLetters []systemstore.WebhookAttempt
+}
+
+func (s *inMemoryStore) GetWebhook(_ context.Context, ledger, id string) (*systemstore.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, webhook := range s.webhooks {
+		if webhook.Ledger == ledger && webhook.ID == id {
+			return &webhook, nil
+		}
+	}
+	return nil, sqlutils.ErrNotFound
+}
+
+func (s *inMemoryStore) GetWebhooksForEvent(_ context.Context, ledger, eventType string) ([]systemstore.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]systemstore.Webhook, 0)
+	for _, webhook := range s.webhooks {
+		if webhook.Ledger == ledger && webhook.Active && webhook.Accepts(eventType) {
+			ret = append(ret, webhook)
+		}
+	}
+	return ret, nil
+}
+
+func (s *inMemoryStore) InsertWebhookAttempts(_ context.Context, attempts ...systemstore.WebhookAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts = append(s.attempts, attempts...)
+	return nil
+}
+
+func (s *inMemoryStore) ClaimWebhookAttempts(_ context.Context, date libtime.Time, lease time.Duration, limit int) ([]systemstore.WebhookAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := make([]systemstore.WebhookAttempt, 0)
+	for i, attempt := range s.attempts {
+		if len(ret) == limit {
+			break
+		}
+		if attempt.Status == systemstore.WebhookAttemptStatusPending && !attempt.NextRetryAt.After(date) {
+			s.attempts[i].NextRetryAt = date.Add(lease)
+			ret = append(ret, s.attempts[i])
+		}
+	}
+	return ret, nil
+}
+
+func (s *inMemoryStore) UpdateWebhookAttempt(_ context.Context, attempt systemstore.WebhookAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.attempts {
+		if s.attempts[i].ID == attempt.ID {
+			s.attempts[i] = attempt
+		}
+	}
+	return nil
+}
+
+func (s *inMemoryStore) DeadLetterWebhookAttempt(ctx context.Context, attempt systemstore.WebhookAttempt) error {
+	attempt.Status = systemstore.WebhookAttemptStatusFailed
+	if err := s.UpdateWebhookAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters = append(s.deadLetters, attempt)
+	return nil
+}
+
+var _ Store = (*inMemoryStore)(nil)
+
+func newEventMessage(ledger, eventType string) *message.Message {
+	return publish.NewMessage(context.Background(), publish.EventMessage{
+		Date:    time.Now(),
+		App:     events.EventApp,
+		Version: events.EventVersion,
+		Type:    eventType,
+		Payload: map[string]any{
+			"ledger": ledger,
+		},
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	configuration := Configuration{
+		MinBackoff: time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+	require.Equal(t, time.Second, configuration.Backoff(1))
+	require.Equal(t, 2*time.Second, configuration.Backoff(2))
+	require.Equal(t, 8*time.Second, configuration.Backoff(4))
+	require.Equal(t, 10*time.Second, configuration.Backoff(5))
+	require.Equal(t, 10*time.Second, configuration.Backoff(100))
+}
+
+func TestSignature(t *testing.T) {
+	t.Parallel()
+
+	now := libtime.Now()
+	signature := Sign("secret", "id", now, []byte("body"))
+	require.True(t, Verify("secret", "id", now, []byte("body"), signature))
+	require.False(t, Verify("other", "id", now, []byte("body"), signature))
+	require.False(t, Verify("secret", "id", now, []byte("altered"), signature))
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Parallel()
+
+	store := &inMemoryStore{
+		webhooks: []systemstore.Webhook{
+			{ID: "all", Ledger: "ledger0", Active: true},
+			{ID: "reverts", Ledger: "ledger0", Active: true, EventTypes: []string{events.EventTypeRevertedTransaction}},
+			{ID: "disabled", Ledger: "ledger0"},
+			{ID: "other", Ledger: "ledger1", Active: true},
+		},
+	}
+	dispatcher := NewDispatcher(store, http.DefaultClient, DefaultConfiguration)
+
+	msg := newEventMessage("ledger0", events.EventTypeCommittedTransactions)
+	require.NoError(t, dispatcher.Enqueue(context.Background(), msg))
+
+	require.Len(t, store.attempts, 1)
+	require.Equal(t, "all", store.attempts[0].WebhookID)
+	require.Equal(t, msg.UUID, store.attempts[0].EventID)
+	require.Equal(t, events.EventTypeCommittedTransactions, store.attempts[0].EventType)
+	require.Equal(t, systemstore.WebhookAttemptStatusPending, store.attempts[0].Status)
+	require.JSONEq(t, string(msg.Payload), string(store.attempts[0].Payload))
+}
+
+func TestDeliver(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		headers http.Header
+		body    []byte
+	}
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{
+			headers: r.Header,
+			body:    body,
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	store := &inMemoryStore{
+		webhooks: []systemstore.Webhook{{
+			ID:       "webhook",
+			Ledger:   "ledger0",
+			Endpoint: srv.URL,
+			Secret:   "secret",
+			Active:   true,
+		}},
+	}
+	dispatcher := NewDispatcher(store, srv.Client(), DefaultConfiguration)
+	go dispatcher.Run(logging.TestingContext())
+	t.Cleanup(dispatcher.Close)
+
+	msg := newEventMessage("ledger0", events.EventTypeCommittedTransactions)
+	require.NoError(t, dispatcher.Enqueue(context.Background(), msg))
+
+	select {
+	case req := <-requests:
+		require.JSONEq(t, string(msg.Payload), string(req.body))
+
+		timestamp, err := strconv.ParseInt(req.headers.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.True(t, Verify("secret", req.headers.Get(HeaderID), libtime.New(time.Unix(timestamp, 0)),
+			req.body, req.headers.Get(HeaderSignature)))
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		return store.attempts[0].Status == systemstore.WebhookAttemptStatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, store.attempts[0].Attempts)
+	require.Equal(t, http.StatusOK, store.attempts[0].LastStatusCode)
+}
+
+func TestDeliverWithRetries(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode("unavailable")
+	}))
+	t.Cleanup(srv.Close)
+
+	store := &inMemoryStore{
+		webhooks: []systemstore.Webhook{{
+			ID:       "webhook",
+			Ledger:   "ledger0",
+			Endpoint: srv.URL,
+			Secret:   "secret",
+			Active:   true,
+		}},
+	}
+	dispatcher := NewDispatcher(store, srv.Client(), Configuration{
+		PollInterval: 10 * time.Millisecond,
+		MaxAttempts:  3,
+		MinBackoff:   time.Millisecond,
+		MaxBackoff:   5 * time.Millisecond,
+		Timeout:      time.Second,
+	})
+	go dispatcher.Run(logging.TestingContext())
+	t.Cleanup(dispatcher.Close)
+
+	require.NoError(t, dispatcher.Enqueue(context.Background(), newEventMessage("ledger0", events.EventTypeCommittedTransactions)))
+
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		return len(store.deadLetters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	require.Equal(t, systemstore.WebhookAttemptStatusFailed, store.attempts[0].Status)
+	require.Equal(t, 3, store.attempts[0].Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, store.attempts[0].LastStatusCode)
+	require.Contains(t, store.attempts[0].LastError, "unavailable")
+}
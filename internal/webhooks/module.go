@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/formancehq/go-libs/logging"
+	"github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+	"go.uber.org/fx"
+)
+
+func Module(configuration Configuration) fx.Option {
+	return fx.Options(
+		fx.Provide(func(driver *driver.Driver) *Dispatcher {
+			return NewDispatcher(driverStore{driver: driver}, http.DefaultClient, configuration)
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, dispatcher *Dispatcher, logger logging.Logger) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go dispatcher.Run(logging.ContextWithLogger(context.Background(), logger.WithField("component", "webhooks")))
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					dispatcher.Close()
+					return nil
+				},
+			})
+		}),
+	)
+}
+
+// driverStore defers the access to the system store, which is only available
+// once the driver is initialized.
+type driverStore struct {
+	driver *driver.Driver
+}
+
+func (s driverStore) GetWebhook(ctx context.Context, ledger, id string) (*systemstore.Webhook, error) {
+	return s.driver.GetSystemStore().GetWebhook(ctx, ledger, id)
+}
+
+func (s driverStore) GetWebhooksForEvent(ctx context.Context, ledger, eventType string) ([]systemstore.Webhook, error) {
+	return s.driver.GetSystemStore().GetWebhooksForEvent(ctx, ledger, eventType)
+}
+
+func (s driverStore) InsertWebhookAttempts(ctx context.Context, attempts ...systemstore.WebhookAttempt) error {
+	return s.driver.GetSystemStore().InsertWebhookAttempts(ctx, attempts...)
+}
+
+func (s driverStore) ClaimWebhookAttempts(ctx context.Context, date time.Time, lease time.Duration, limit int) ([]systemstore.WebhookAttempt, error) {
+	return s.driver.GetSystemStore().ClaimWebhookAttempts(ctx, date, lease, limit)
+}
+
+func (s driverStore) UpdateWebhookAttempt(ctx context.Context, attempt systemstore.WebhookAttempt) error {
+	return s.driver.GetSystemStore().UpdateWebhookAttempt(ctx, attempt)
+}
+
+func (s driverStore) DeadLetterWebhookAttempt(ctx context.Context, attempt systemstore.WebhookAttempt) error {
+	return s.driver.GetSystemStore().DeadLetterWebhookAttempt(ctx, attempt)
+}
+
+var _ Store = driverStore{}
@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/formancehq/go-libs/logging"
+)
+
+// Publisher decorates the events publisher to also route the events to the webhooks.
+type Publisher struct {
+	message.Publisher
+	dispatcher *Dispatcher
+}
+
+func (p *Publisher) Publish(topic string, messages ...*message.Message) error {
+	for _, msg := range messages {
+		if err := p.dispatcher.Enqueue(msg.Context(), msg); err != nil {
+			logging.FromContext(msg.Context()).Errorf("enqueuing webhooks for message %s: %s", msg.UUID, err)
+		}
+	}
+
+	return p.Publisher.Publish(topic, messages...)
+}
+
+var _ message.Publisher = (*Publisher)(nil)
+
+func NewPublisher(publisher message.Publisher, dispatcher *Dispatcher) *Publisher {
+	return &Publisher{
+		Publisher:  publisher,
+		dispatcher: dispatcher,
+	}
+}
@@ -0,0 +1,55 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/formancehq/go-libs/time"
+	"github.com/formancehq/ledger/internal/storage/systemstore"
+)
+
+const (
+	HeaderID        = "formance-webhook-id"
+	HeaderTimestamp = "formance-webhook-timestamp"
+	HeaderSignature = "formance-webhook-signature"
+
+	// SignatureVersion prefixes the signatures, allowing to change the scheme later.
+	SignatureVersion = "v1"
+)
+
+type Store interface {
+	GetWebhook(ctx context.Context, ledger, id string) (*systemstore.Webhook, error)
+	GetWebhooksForEvent(ctx context.Context, ledger, eventType string) ([]systemstore.Webhook, error)
+	InsertWebhookAttempts(ctx context.Context, attempts ...systemstore.WebhookAttempt) error
+	ClaimWebhookAttempts(ctx context.Context, date time.Time, lease time.Duration, limit int) ([]systemstore.WebhookAttempt, error)
+	UpdateWebhookAttempt(ctx context.Context, attempt systemstore.WebhookAttempt) error
+	DeadLetterWebhookAttempt(ctx context.Context, attempt systemstore.WebhookAttempt) error
+}
+
+// Sign computes the signature of a delivery.
+// The signed content is the id, the unix timestamp and the body, separated by dots.
+func Sign(secret, id string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%s.%d.", id, timestamp.Unix())
+	_, _ = mac.Write(body)
+
+	return fmt.Sprintf("%s,%s", SignatureVersion, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+// Verify checks a signature computed by Sign.
+func Verify(secret, id string, timestamp time.Time, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, id, timestamp, body)), []byte(signature))
+}
+
+// NewSecret generates a random secret for webhooks created without one.
+func NewSecret() string {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(secret)
+}
@@ -1,11 +1,45 @@
 package events
 
 const (
-	EventVersion = "v2"
+	EventVersionV2 = "v2"
+	// EventVersionV3 adds the post-commit volumes of the accounts touched by the transactions
+	// to the COMMITTED_TRANSACTIONS and REVERTED_TRANSACTION events, the other events are unchanged
+	EventVersionV3 = "v3"
+	// EventVersion is the version of the events published by default
+	EventVersion = EventVersionV2
 	EventApp     = "ledger"
 
 	EventTypeCommittedTransactions = "COMMITTED_TRANSACTIONS"
 	EventTypeSavedMetadata         = "SAVED_METADATA"
 	EventTypeRevertedTransaction   = "REVERTED_TRANSACTION"
 	EventTypeDeletedMetadata       = "DELETED_METADATA"
+
+	EventTypeExecutedScheduledTransaction = "EXECUTED_SCHEDULED_TRANSACTION"
+
+	EventTypeCreatedHold   = "CREATED_HOLD"
+	EventTypeConfirmedHold = "CONFIRMED_HOLD"
+	EventTypeVoidedHold    = "VOIDED_HOLD"
+
+	EventTypeFrozenAccount   = "FROZEN_ACCOUNT"
+	EventTypeUnfrozenAccount = "UNFROZEN_ACCOUNT"
 )
+
+// EventTypes lists all the event types published by the ledger.
+var EventTypes = []string{
+	EventTypeCommittedTransactions,
+	EventTypeSavedMetadata,
+	EventTypeRevertedTransaction,
+	EventTypeDeletedMetadata,
+	EventTypeExecutedScheduledTransaction,
+	EventTypeCreatedHold,
+	EventTypeConfirmedHold,
+	EventTypeVoidedHold,
+	EventTypeFrozenAccount,
+	EventTypeUnfrozenAccount,
+}
+
+// EventVersions lists the versions of the events which can be published by the ledger.
+var EventVersions = []string{
+	EventVersionV2,
+	EventVersionV3,
+}